@@ -199,6 +199,9 @@ func (*awsProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResu
 	if msg.ContinuationToken != "" {
 		params.ContinuationToken = aws.String(msg.ContinuationToken)
 	}
+	if msg.IsFlagSet(apc.LsNoRecursion) {
+		params.Delimiter = aws.String(cos.PathSeparator)
+	}
 
 	versioning = bck.Props != nil && bck.Props.Versioning.Enabled && msg.WantProp(apc.GetPropsVersion)
 	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
@@ -241,6 +244,12 @@ func (*awsProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResu
 		}
 	}
 	lst.Entries = lst.Entries[:l]
+	if len(resp.CommonPrefixes) > 0 {
+		for _, cp := range resp.CommonPrefixes {
+			lst.Entries = append(lst.Entries, newDirEntry(*cp.Prefix))
+		}
+		cmn.SortLso(lst.Entries) // (merge objects and "directories" - see newDirEntry)
+	}
 
 	if *resp.IsTruncated {
 		lst.ContinuationToken = *resp.NextContinuationToken
@@ -261,6 +270,9 @@ func (*awsProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResu
 		num       int
 	)
 	for _, entry := range lst.Entries {
+		if entry.IsDir() {
+			continue
+		}
 		verParams.Prefix = aws.String(entry.Name)
 		verResp, err := svc.ListObjectVersions(context.Background(), verParams)
 		if err != nil {
@@ -263,7 +263,10 @@ func (ap *azureProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.Ls
 		cloudBck = bck.RemoteBck()
 		cntURL   = ap.u + "/" + cloudBck.Name
 		num      = int32(msg.PageSize)
-		opts     = container.ListBlobsFlatOptions{Prefix: apc.Ptr(msg.Prefix), MaxResults: &num}
+		marker   *string
+		blobs    []*container.BlobItem
+		dirs     []*container.BlobPrefix
+		next     *string
 	)
 	client, err := container.NewClientWithSharedKeyCredential(cntURL, ap.creds, nil)
 	if err != nil {
@@ -273,23 +276,35 @@ func (ap *azureProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.Ls
 		nlog.Infof("list_objects %s", cloudBck.Name)
 	}
 	if msg.ContinuationToken != "" {
-		opts.Marker = apc.Ptr(msg.ContinuationToken)
+		marker = apc.Ptr(msg.ContinuationToken)
 	}
 
-	pager := client.NewListBlobsFlatPager(&opts)
-	resp, err := pager.NextPage(context.Background())
-	if err != nil {
-		return azureErrorToAISError(err, cloudBck, "")
+	if msg.IsFlagSet(apc.LsNoRecursion) {
+		opts := container.ListBlobsHierarchyOptions{Prefix: apc.Ptr(msg.Prefix), MaxResults: &num, Marker: marker}
+		pager := client.NewListBlobsHierarchyPager(cos.PathSeparator, &opts)
+		resp, err := pager.NextPage(context.Background())
+		if err != nil {
+			return azureErrorToAISError(err, cloudBck, "")
+		}
+		blobs, dirs, next = resp.Segment.BlobItems, resp.Segment.BlobPrefixes, resp.NextMarker
+	} else {
+		opts := container.ListBlobsFlatOptions{Prefix: apc.Ptr(msg.Prefix), MaxResults: &num, Marker: marker}
+		pager := client.NewListBlobsFlatPager(&opts)
+		resp, err := pager.NextPage(context.Background())
+		if err != nil {
+			return azureErrorToAISError(err, cloudBck, "")
+		}
+		blobs, next = resp.Segment.BlobItems, resp.NextMarker
 	}
 
-	l := len(resp.Segment.BlobItems)
+	l := len(blobs)
 	for i := len(lst.Entries); i < l; i++ {
 		lst.Entries = append(lst.Entries, &cmn.LsoEntry{}) // add missing empty
 	}
-	for idx := range resp.Segment.BlobItems {
+	for idx := range blobs {
 		var (
 			custom = cos.StrKVs{}
-			blob   = resp.Segment.BlobItems[idx]
+			blob   = blobs[idx]
 			entry  = lst.Entries[idx]
 		)
 		entry.Name = *blob.Name
@@ -319,9 +334,15 @@ func (ap *azureProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.Ls
 		}
 	}
 	lst.Entries = lst.Entries[:l]
+	if len(dirs) > 0 {
+		for _, dir := range dirs {
+			lst.Entries = append(lst.Entries, newDirEntry(*dir.Name))
+		}
+		cmn.SortLso(lst.Entries) // (merge objects and "directories" - see newDirEntry)
+	}
 
-	if resp.NextMarker != nil {
-		lst.ContinuationToken = *resp.NextMarker
+	if next != nil {
+		lst.ContinuationToken = *next
 	}
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
 		nlog.Infof("[list_objects] count %d(marker: %s)", len(lst.Entries), lst.ContinuationToken)
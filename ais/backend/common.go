@@ -6,9 +6,12 @@ package backend
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/fs"
@@ -26,6 +29,13 @@ func calcPageSize(pageSize, maxPageSize uint) uint {
 	return min(pageSize, maxPageSize)
 }
 
+// non-recursive listing (apc.LsNoRecursion): remote "directory" (aka common prefix)
+// becomes an entry flagged as such - with no trailing delimiter, same as when listing ais buckets;
+// the resulting page must then be sorted (cmn.SortLso) as backends list directories separately
+func newDirEntry(prefix string) *cmn.LsoEntry {
+	return &cmn.LsoEntry{Name: strings.TrimSuffix(prefix, cos.PathSeparator), Flags: apc.EntryIsDir}
+}
+
 func newErrInventory(provider string) error {
 	return cmn.NewErrNotImpl("list "+provider+" backend objects via", "bucket inventory")
 }
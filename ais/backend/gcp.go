@@ -167,6 +167,9 @@ func (*gcpProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResu
 	if msg.Prefix != "" {
 		query = &storage.Query{Prefix: msg.Prefix}
 	}
+	if msg.IsFlagSet(apc.LsNoRecursion) {
+		query = &storage.Query{Prefix: msg.Prefix, Delimiter: cos.PathSeparator}
+	}
 	var (
 		it    = gcpClient.Bucket(cloudBck.Name).Objects(gctx, query)
 		pager = iterator.NewPager(it, int(msg.PageSize), msg.ContinuationToken)
@@ -192,6 +195,10 @@ func (*gcpProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResu
 	}
 	for i, attrs := range objs {
 		entry := lst.Entries[i]
+		if attrs.Prefix != "" { // (synthetic "directory" - see newDirEntry)
+			*entry = *newDirEntry(attrs.Prefix)
+			continue
+		}
 		entry.Name, entry.Size = attrs.Name, attrs.Size
 		if msg.IsFlagSet(apc.LsNameOnly) || msg.IsFlagSet(apc.LsNameSize) {
 			continue
@@ -211,6 +218,9 @@ func (*gcpProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResu
 		}
 	}
 	lst.Entries = lst.Entries[:l]
+	if msg.IsFlagSet(apc.LsNoRecursion) {
+		cmn.SortLso(lst.Entries) // (objects and "directories" - see newDirEntry)
+	}
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
 		nlog.Infof("[list_objects] count %d", len(lst.Entries))
 	}
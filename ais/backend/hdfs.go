@@ -132,8 +132,9 @@ func (hp *hdfsProvider) ListObjectsInv(*meta.Bck, *apc.LsoMsg, *cmn.LsoResult, *
 
 func (hp *hdfsProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoResult) (int, error) {
 	var (
-		h   = cmn.BackendHelpers.HDFS
-		idx int
+		h           = cmn.BackendHelpers.HDFS
+		idx         int
+		noRecursion = msg.IsFlagSet(apc.LsNoRecursion)
 	)
 	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
 
@@ -157,6 +158,12 @@ func (hp *hdfsProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.Lso
 				return skipDir(fi)
 			}
 		}
+		if noRecursion && cmn.ObjHasPrefix(objName, msg.Prefix) {
+			// nested deeper than requested (see also xs.LsoXact.validateCb)
+			if strings.Contains(strings.TrimPrefix(objName, msg.Prefix), cos.PathSeparator) {
+				return skipDir(fi)
+			}
+		}
 		if msg.ContinuationToken != "" && objName <= msg.ContinuationToken {
 			return nil
 		}
@@ -164,17 +171,24 @@ func (hp *hdfsProvider) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.Lso
 			return nil
 		}
 		if fi.IsDir() {
-			return nil
+			if !noRecursion || objName == "" || !cmn.ObjHasPrefix(objName, msg.Prefix) {
+				return nil
+			}
 		}
 
 		var entry *cmn.LsoEntry
 		if idx < len(lst.Entries) {
 			entry = lst.Entries[idx]
+			entry.Name = objName
 		} else {
 			entry = &cmn.LsoEntry{Name: objName}
 			lst.Entries = append(lst.Entries, entry)
 		}
 		idx++
+		if fi.IsDir() {
+			entry.Flags = apc.EntryIsDir
+			return filepath.SkipDir
+		}
 		entry.Size = fi.Size()
 		if msg.WantProp(apc.GetPropsChecksum) {
 			fr, err := hp.c.Open(path)
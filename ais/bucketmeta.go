@@ -144,6 +144,32 @@ func (m *bucketMD) add(bck *meta.Bck, p *cmn.Bprops) bool {
 	return true
 }
 
+// add virtual (read-only) snapshot bucket that inherits its origin's props
+// and shares the origin's BID (see cmn/snap.go)
+func (m *bucketMD) addSnap(bck, snap *meta.Bck) bool {
+	debug.Assert(snap.IsSnap())
+	bprops, present := m.Get(bck)
+	if !present {
+		return false
+	}
+	if _, present := m.Get(snap); present {
+		return false
+	}
+	p := bprops.Clone()
+	p.Access &= apc.AccessRO
+	p.Mirror.Enabled, p.EC.Enabled, p.LRU.Enabled = false, false, false
+	p.Pack.Enabled = false // (metadata is stored separately - see core/lsnap.go)
+	p.WritePolicy.MD = apc.WriteImmediate
+	p.Renamed = ""
+	p.Created = time.Now().UnixNano()
+	snap.Props = p
+
+	m.Add(snap)
+	m.Version++
+
+	return true
+}
+
 func (m *bucketMD) del(bck *meta.Bck) (deleted bool) {
 	if !m.Del(bck) {
 		return
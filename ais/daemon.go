@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -202,6 +203,11 @@ func initDaemon(version, buildTime string) cos.Runner {
 	// K8s
 	k8s.Init()
 
+	// encryption at rest: master key provider
+	if err := crypt.Init(); err != nil {
+		cos.ExitLog(err)
+	}
+
 	xreg.Init()
 
 	// fork (proxy | target)
@@ -12,6 +12,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/ext/dsort"
@@ -176,6 +177,7 @@ func (g *fsprungroup) postDD(rmi *fs.Mountpath, action string, xres *xs.Resilver
 		return
 	}
 	fspathsConfigAddDel(rmi.Path, false /*add*/)
+	g.degradedStats()
 	nlog.Infof("%s: %s %q %s done", g.t, rmi, action, xres)
 
 	// 3. the case of multiple overlapping detach _or_ disable operations
@@ -201,6 +203,45 @@ func (g *fsprungroup) postDD(rmi *fs.Mountpath, action string, xres *xs.Resilver
 	}
 }
 
+//
+// degrade | undegrade (see fs/health)
+//
+
+//   - objects on the degraded mountpath become misplaced: the persistent resilver marker
+//     makes GET (and HEAD) look them up on the other mountpaths (see restoreFromAny);
+//   - resilvering is postponed until the mountpath recovers (not to load the slow disk)
+func (g *fsprungroup) degradeMpath(mpath string) error {
+	mi, err := fs.Degrade(mpath)
+	if err != nil || mi == nil {
+		return err
+	}
+	if fatalErr, writeErr := fs.PersistMarker(fname.ResilverMarker); fatalErr != nil || writeErr != nil {
+		nlog.Errorf("%s: failed to persist resilver marker: %v, %v", mi, fatalErr, writeErr)
+	}
+	core.UncacheMountpath(mi)
+	g.t.statsT.Inc(stats.MpathDegradeCount)
+	g.degradedStats()
+	return nil
+}
+
+func (g *fsprungroup) undegradeMpath(mpath string) error {
+	mi, err := fs.Undegrade(mpath)
+	if err != nil || mi == nil {
+		return err
+	}
+	g.degradedStats()
+	if cmn.GCO.Get().Resilver.Enabled {
+		go g.t.runResilver(res.Args{}, nil /*wg*/)
+	}
+	return nil
+}
+
+// (gauge) the number of currently degraded mountpaths
+func (g *fsprungroup) degradedStats() {
+	n := int64(fs.NumDegraded())
+	g.t.statsT.Add(stats.MpathDegradedCount, n-g.t.statsT.Get(stats.MpathDegradedCount))
+}
+
 // store updated fspaths locally as part of the 'OverrideConfigFname'
 // and commit new version of the config
 func fspathsConfigAddDel(mpath string, add bool) {
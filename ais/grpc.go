@@ -0,0 +1,484 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/rpc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xact"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC API (optional, see `port_grpc` in the local config)
+// - every gRPC call is converted into the corresponding HTTP request and dispatched
+//   via public-network muxers, to be served by the same handlers (and with the same
+//   routing, validation, and access control) as HTTP;
+// - object content never passes through proxies: HTTP redirect becomes gRPC redirect
+//   (to the owning target's gRPC endpoint - see rpc.MdLocation) that the client follows;
+// - same as HTTP, target serves object PUT and DELETE only when redirected by a proxy,
+//   and redirects calls for objects it does not own;
+// - list-objects and xactions are handled by proxies only (see api/rpc for the service)
+
+type (
+	grpcServer struct {
+		h   *htrun
+		mux http.Handler // public-network muxers
+		s   *grpc.Server
+		sync.Mutex
+	}
+	// http.ResponseWriter => gRPC response (or stream)
+	grpcWriter struct {
+		hdr    http.Header
+		send   func(*rpc.ObjChunk) error // streaming GET (nil otherwise)
+		buf    bytes.Buffer
+		status int
+		sent   bool // (streaming) response header sent
+	}
+	// PUT stream => request body
+	grpcReader struct {
+		stream rpc.PutServer
+		data   []byte
+		eof    bool
+	}
+)
+
+// interface guards
+var (
+	_ rpc.Server          = (*grpcServer)(nil)
+	_ http.ResponseWriter = (*grpcWriter)(nil)
+	_ io.Reader           = (*grpcReader)(nil)
+)
+
+func (gs *grpcServer) listen(addr string, tlsConf *tls.Config, config *cmn.Config) {
+	var opts []grpc.ServerOption
+	if config.Net.HTTP.UseHTTPS {
+		cert, err := tls.LoadX509KeyPair(config.Net.HTTP.Certificate, config.Net.HTTP.CertKey)
+		if err != nil {
+			nlog.Errorln("gRPC:", err)
+			return
+		}
+		c := tlsConf.Clone()
+		c.Certificates = []tls.Certificate{cert}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(c)))
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		nlog.Errorln("gRPC:", err)
+		return
+	}
+	gs.Lock()
+	gs.s = grpc.NewServer(opts...)
+	rpc.Register(gs.s, gs)
+	gs.Unlock()
+
+	nlog.Infoln(gs.h.si.String(), "gRPC: listening on", addr)
+	if err := gs.s.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		nlog.Errorln("gRPC terminated with error:", err)
+	}
+}
+
+func (gs *grpcServer) shutdown(config *cmn.Config) {
+	gs.Lock()
+	defer gs.Unlock()
+	if gs.s == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		gs.s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(config.Timeout.MaxHostBusy.D()):
+		gs.s.Stop()
+	}
+}
+
+//
+// rpc.Server
+//
+
+func (gs *grpcServer) Get(req *rpc.ObjReq, stream rpc.GetServer) error {
+	ctx := stream.Context()
+	r, err := gs.objReq(ctx, http.MethodGet, req, nil)
+	if err != nil {
+		return err
+	}
+	w := &grpcWriter{hdr: make(http.Header), send: stream.Send}
+	if err := gs.do(r, w); err != nil {
+		return err
+	}
+	if err := w.err(ctx); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+func (gs *grpcServer) Put(stream rpc.PutServer) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.Req == nil {
+		return status.Error(codes.InvalidArgument, "PUT: the first message must carry the request")
+	}
+	body := &grpcReader{stream: stream, data: first.Data}
+	r, err := gs.objReq(ctx, http.MethodPut, first.Req, body)
+	if err != nil {
+		return err
+	}
+	r.ContentLength = -1 // (unknown)
+	if first.Req.Size > 0 {
+		r.ContentLength = first.Req.Size
+	}
+	if gs.h.si.IsTarget() {
+		// ready to receive; proxy, on the other hand, redirects without reading (see rpc.MdContinue)
+		if err := stream.SendHeader(metadata.Pairs(rpc.MdContinue, "true")); err != nil {
+			return err
+		}
+	}
+	w := &grpcWriter{hdr: make(http.Header)}
+	if err := gs.do(r, w); err != nil {
+		return err
+	}
+	if err := w.err(ctx); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&rpc.ObjResp{Header: w.hdr})
+}
+
+func (gs *grpcServer) Head(ctx context.Context, req *rpc.ObjReq) (*rpc.ObjResp, error) {
+	return gs.objUnary(ctx, http.MethodHead, req)
+}
+
+func (gs *grpcServer) Delete(ctx context.Context, req *rpc.ObjReq) (*rpc.ObjResp, error) {
+	return gs.objUnary(ctx, http.MethodDelete, req)
+}
+
+func (gs *grpcServer) ListObjects(ctx context.Context, req *rpc.LsoReq) (*cmn.LsoResult, error) {
+	if gs.h.si.IsTarget() {
+		return nil, status.Error(codes.Unimplemented, "list-objects: not supported by targets (use proxy)")
+	}
+	lsmsg := req.Msg
+	if lsmsg == nil {
+		lsmsg = &apc.LsoMsg{}
+	}
+	body := cos.MustMarshal(apc.ActMsg{Action: apc.ActList, Value: lsmsg})
+	r, err := gs.newReq(ctx, http.MethodGet, apc.URLPathBuckets.Join(req.Bck.Name), req.Bck.NewQuery(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	lst := &cmn.LsoResult{}
+	if err := gs.doJSON(ctx, r, lst); err != nil {
+		return nil, err
+	}
+	return lst, nil
+}
+
+func (gs *grpcServer) StartXaction(ctx context.Context, req *rpc.XactReq) (*rpc.XactResp, error) {
+	if gs.h.si.IsTarget() {
+		return nil, status.Error(codes.Unimplemented, "xactions: not supported by targets (use proxy)")
+	}
+	args := req.Args
+	if args == nil {
+		return nil, status.Error(codes.InvalidArgument, "start xaction: missing xaction kind")
+	}
+	if !xact.Table[args.Kind].Startable {
+		return nil, status.Errorf(codes.InvalidArgument, "xaction %q is not startable", args.Kind)
+	}
+	q := args.Bck.NewQuery()
+	if args.Force {
+		q.Set(apc.QparamForce, "true")
+	}
+	body := cos.MustMarshal(apc.ActMsg{Action: apc.ActXactStart, Value: args, Name: req.Extra})
+	r, err := gs.newReq(ctx, http.MethodPut, apc.URLPathClu.S, q, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	w := &grpcWriter{hdr: make(http.Header)}
+	if err := gs.do(r, w); err != nil {
+		return nil, err
+	}
+	if err := w.err(ctx); err != nil {
+		return nil, err
+	}
+	return &rpc.XactResp{ID: w.buf.String()}, nil
+}
+
+func (gs *grpcServer) XactionStatus(ctx context.Context, req *rpc.XactReq) (*nl.Status, error) {
+	if gs.h.si.IsTarget() {
+		return nil, status.Error(codes.Unimplemented, "xactions: not supported by targets (use proxy)")
+	}
+	args := req.Args
+	if args == nil {
+		return nil, status.Error(codes.InvalidArgument, "xaction status: missing xaction (ID, kind, and/or bucket)")
+	}
+	msg := xact.QueryMsg{ID: args.ID, Kind: args.Kind, Bck: args.Bck}
+	if args.OnlyRunning {
+		msg.OnlyRunning = apc.Ptr(true)
+	}
+	q := url.Values{apc.QparamWhat: []string{apc.WhatOneXactStatus}}
+	r, err := gs.newReq(ctx, http.MethodGet, apc.URLPathClu.S, q, bytes.NewReader(cos.MustMarshal(msg)))
+	if err != nil {
+		return nil, err
+	}
+	xs := &nl.Status{}
+	if err := gs.doJSON(ctx, r, xs); err != nil {
+		return nil, err
+	}
+	return xs, nil
+}
+
+//
+// gRPC => HTTP
+//
+
+func (*grpcServer) newReq(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := url.URL{Path: path, RawQuery: query.Encode()}
+	r, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r.RequestURI = u.RequestURI()
+	if body == nil {
+		r.Body = http.NoBody // (same as net/http server)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.RemoteAddr = p.Addr.String()
+	}
+	if body != nil && method != http.MethodPut {
+		r.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(apc.HdrAuthorization); len(v) > 0 {
+			r.Header.Set(apc.HdrAuthorization, v[0])
+		}
+		if v := md.Get(cos.HdrUserAgent); len(v) > 0 {
+			r.Header.Set(cos.HdrUserAgent, v[0])
+		}
+	}
+	return r, nil
+}
+
+// returns HTTP request; target redirects the calls for objects it does not own
+func (gs *grpcServer) objReq(ctx context.Context, method string, req *rpc.ObjReq, body io.Reader) (*http.Request, error) {
+	if req == nil || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, method+": missing object name")
+	}
+	q := req.Bck.NewQuery()
+	for k, v := range req.Query {
+		q.Set(k, v)
+	}
+	if gs.h.si.IsTarget() {
+		smap := gs.h.owner.smap.get()
+		tsi, err := smap.HrwName2T(req.Bck.HrwUname(req.Name))
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		if tsi.ID() != gs.h.si.ID() {
+			return nil, gs.redirect(ctx, tsi, q.Encode())
+		}
+		if method != http.MethodGet && method != http.MethodHead && isRedirect(q) == "" {
+			return nil, rpc.Error(ctx, http.StatusBadRequest,
+				gs.h.si.String()+": "+method+"(obj) is expected to be redirected (use proxy's gRPC endpoint)")
+		}
+	}
+	r, err := gs.newReq(ctx, method, apc.URLPathObjects.Join(req.Bck.Name, req.Name), q, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range req.Header {
+		for _, v := range vs {
+			r.Header.Add(k, v)
+		}
+	}
+	return r, nil
+}
+
+func (gs *grpcServer) objUnary(ctx context.Context, method string, req *rpc.ObjReq) (*rpc.ObjResp, error) {
+	r, err := gs.objReq(ctx, method, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	w := &grpcWriter{hdr: make(http.Header)}
+	if err := gs.do(r, w); err != nil {
+		return nil, err
+	}
+	if err := w.err(ctx); err != nil {
+		return nil, err
+	}
+	return &rpc.ObjResp{Header: w.hdr}, nil
+}
+
+// serve via public muxers - same handlers as HTTP - and convert HTTP redirect, if any
+func (gs *grpcServer) do(r *http.Request, w *grpcWriter) error {
+	gs.mux.ServeHTTP(w, r)
+	switch w.status {
+	case http.StatusMovedPermanently, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		loc := w.hdr.Get(cos.HdrLocation)
+		if loc == "" || w.sent {
+			return nil
+		}
+		u, err := url.Parse(loc)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		smap := gs.h.owner.smap.get()
+		si := hostport2Node(smap, u.Host)
+		if si == nil {
+			return rpc.Error(r.Context(), http.StatusServiceUnavailable, "redirect to "+u.Host+": node not found in "+smap.StringEx())
+		}
+		return gs.redirect(r.Context(), si, u.RawQuery)
+	}
+	return nil
+}
+
+func (*grpcServer) redirect(ctx context.Context, si *meta.Snode, query string) error {
+	ep := si.GRPCEndpoint()
+	if ep == "" {
+		return rpc.Error(ctx, http.StatusNotImplemented, si.StringEx()+": gRPC is not enabled (see port_grpc)")
+	}
+	return rpc.Redirect(ctx, ep, query)
+}
+
+// node by any of its (public, intra-cluster) network endpoints, as in: redirect URL
+func hostport2Node(smap *smapX, hostport string) *meta.Snode {
+	for _, mm := range []meta.NodeMap{smap.Tmap, smap.Pmap} {
+		for _, si := range mm {
+			if si.PubNet.TCPEndpoint() == hostport || si.DataNet.TCPEndpoint() == hostport ||
+				si.ControlNet.TCPEndpoint() == hostport {
+				return si
+			}
+			for i := range si.PubExtra {
+				if si.PubExtra[i].TCPEndpoint() == hostport {
+					return si
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (gs *grpcServer) doJSON(ctx context.Context, r *http.Request, out any) error {
+	w := &grpcWriter{hdr: make(http.Header)}
+	if err := gs.do(r, w); err != nil {
+		return err
+	}
+	if err := w.err(ctx); err != nil {
+		return err
+	}
+	if err := cos.JSON.Unmarshal(w.buf.Bytes(), out); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+////////////////
+// grpcWriter //
+////////////////
+
+func (w *grpcWriter) Header() http.Header { return w.hdr }
+
+func (w *grpcWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *grpcWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.send == nil || w.status >= http.StatusMultipleChoices {
+		return w.buf.Write(b)
+	}
+	if err := w.flush(); err != nil {
+		return 0, err
+	}
+	n := len(b)
+	for len(b) > 0 {
+		l := min(len(b), rpc.ChunkSize)
+		if err := w.send(&rpc.ObjChunk{Data: b[:l]}); err != nil {
+			return n - len(b), err
+		}
+		b = b[l:]
+	}
+	return n, nil
+}
+
+// (streaming) send response header unless already sent
+func (w *grpcWriter) flush() error {
+	if w.send == nil || w.sent {
+		return nil
+	}
+	w.sent = true
+	return w.send(&rpc.ObjChunk{Header: w.hdr})
+}
+
+// HTTP error => gRPC status (and trailer)
+func (w *grpcWriter) err(ctx context.Context) error {
+	if w.status < http.StatusMultipleChoices {
+		return nil
+	}
+	msg := w.buf.String()
+	if msg == "" {
+		msg = w.hdr.Get(apc.HdrError) // (HEAD)
+	}
+	if herr := cmn.Str2HTTPErr(msg); herr != nil {
+		msg = herr.Message
+	}
+	if msg == "" {
+		msg = http.StatusText(w.status)
+	}
+	return rpc.Error(ctx, w.status, msg)
+}
+
+////////////////
+// grpcReader //
+////////////////
+
+func (gr *grpcReader) Read(b []byte) (int, error) {
+	for len(gr.data) == 0 {
+		if gr.eof {
+			return 0, io.EOF
+		}
+		m, err := gr.stream.Recv()
+		if err == io.EOF {
+			gr.eof = true
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		gr.data = m.Data
+	}
+	n := copy(b, gr.data)
+	gr.data = gr.data[n:]
+	return n, nil
+}
@@ -0,0 +1,136 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/rpc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func grpcServe(t *testing.T, gs *grpcServer) string {
+	gs.s = grpc.NewServer()
+	rpc.Register(gs.s, gs)
+	ln, err := net.Listen("tcp", ":0")
+	tassert.CheckFatal(t, err)
+	go gs.s.Serve(ln)
+	t.Cleanup(gs.s.Stop)
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	return port
+}
+
+func grpcDial(t *testing.T, endpoint string) *grpc.ClientConn {
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	tassert.CheckFatal(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// gRPC => proxy (HTTP redirect => gRPC redirect) => client follows => target
+func TestGRPCRedirect(t *testing.T) {
+	var (
+		tgt  = core.T.(*target)
+		bck  = cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		size = 3*rpc.ChunkSize + 7
+		psi  = newSnode("primary", apc.Proxy, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})
+		nred atomic.Int32
+	)
+	// target: real handlers
+	tmux := newMuxers()
+	for _, v := range allHTTPverbs {
+		tmux[v].HandleFunc(apc.URLPathObjects.S+"/", tgt.objectHandler)
+	}
+	gt := &grpcServer{h: &tgt.htrun, mux: tmux}
+	pubNet := tgt.si.PubNet
+	tgt.si.PubNet.Init("http", "127.0.0.1", "8080")
+	tgt.si.PortGRPC = grpcServe(t, gt)
+	t.Cleanup(func() { tgt.si.PubNet, tgt.si.PortGRPC = pubNet, "" })
+
+	smap := newSmap()
+	smap.Tmap[tgt.SID()] = tgt.si
+	smap.Pmap[psi.ID()] = psi
+	smap.Primary = psi
+	smap.Version = 1
+	tgt.owner.smap.put(smap)
+
+	// proxy: redirects (to the target's public URL), same as p.redirectURL
+	pmux := newMuxers()
+	for _, v := range allHTTPverbs {
+		pmux[v].HandleFunc(apc.URLPathObjects.S+"/", func(w http.ResponseWriter, r *http.Request) {
+			nred.Inc()
+			q := url.Values{apc.QparamProxyID: []string{psi.ID()}, apc.QparamUnixTime: []string{cos.UnixNano2S(time.Now().UnixNano())}}
+			loc := tgt.si.URL(cmn.NetPublic) + r.URL.Path + "?" + r.URL.RawQuery + "&" + q.Encode()
+			http.Redirect(w, r, loc, http.StatusTemporaryRedirect)
+		})
+	}
+	gp := &grpcServer{h: &htrun{si: psi}, mux: pmux}
+	gp.h.owner.smap = tgt.owner.smap
+	pport := grpcServe(t, gp)
+
+	proxy := api.GRPCParams{Conn: grpcDial(t, "127.0.0.1:"+pport)}
+	direct := api.GRPCParams{Conn: grpcDial(t, tgt.si.GRPCEndpoint())}
+
+	// PUT via proxy
+	reader, err := readers.NewRand(int64(size), cos.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	_, err = api.GRPCPutObject(proxy, &api.PutArgs{Bck: bck, ObjName: "grpc-obj", Reader: reader, Size: uint64(size)})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, nred.Load() == 1, "PUT: expected proxy to redirect once, got %d", nred.Load())
+
+	lom := core.AllocLOM("grpc-obj")
+	tassert.CheckFatal(t, lom.InitBck(&bck))
+	tassert.CheckFatal(t, lom.Load(false, false))
+	tassert.Errorf(t, lom.SizeBytes() == int64(size), "PUT: expected size %d, got %d", size, lom.SizeBytes())
+	core.FreeLOM(lom)
+
+	// GET via proxy
+	buf, expected := &bytes.Buffer{}, &bytes.Buffer{}
+	_, err = reader.Seek(0, io.SeekStart)
+	tassert.CheckFatal(t, err)
+	_, err = expected.ReadFrom(reader)
+	tassert.CheckFatal(t, err)
+	oah, err := api.GRPCGetObject(proxy, bck, "grpc-obj", &api.GetArgs{Writer: buf})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(buf.Bytes(), expected.Bytes()), "GET: content mismatch (%d vs %d)", buf.Len(), expected.Len())
+	tassert.Errorf(t, oah.Size() == int64(size), "GET: expected size %d, got %d", size, oah.Size())
+
+	// HEAD via proxy
+	props, err := api.GRPCHeadObject(proxy, bck, "grpc-obj", apc.FltPresent, false)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, props.Size == int64(size), "HEAD: expected size %d, got %d", size, props.Size)
+
+	// PUT and DELETE: target expects redirect
+	reader, err = readers.NewRand(int64(size), cos.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	_, err = api.GRPCPutObject(direct, &api.PutArgs{Bck: bck, ObjName: "grpc-obj", Reader: reader, Size: uint64(size)})
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusBadRequest, "direct PUT: expected status 400, got %v", err)
+	err = api.GRPCDeleteObject(direct, bck, "grpc-obj")
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusBadRequest, "direct DELETE: expected status 400, got %v", err)
+
+	// DELETE via proxy
+	tassert.CheckFatal(t, api.GRPCDeleteObject(proxy, bck, "grpc-obj"))
+	_, err = api.GRPCHeadObject(proxy, bck, "grpc-obj", apc.FltPresent, true)
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusNotFound, "HEAD after DELETE: expected status 404, got %v", err)
+
+	tassert.Errorf(t, nred.Load() == 5, "expected proxy to redirect 5 times, got %d", nred.Load())
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -355,6 +356,9 @@ func (h *htrun) initSnode(config *cmn.Config) {
 		ControlNet: ctrlAddr,
 		DataNet:    dataAddr,
 	}
+	if config.HostNet.PortGRPC != 0 {
+		h.si.PortGRPC = strconv.Itoa(config.HostNet.PortGRPC)
+	}
 	if l := len(pubExtra); l > 0 {
 		h.si.PubExtra = make([]meta.NetInfo, l)
 		copy(h.si.PubExtra, pubExtra)
@@ -470,6 +474,15 @@ func (h *htrun) run(config *cmn.Config) error {
 		}()
 	}
 
+	if port := config.HostNet.PortGRPC; port != 0 {
+		addr := net.JoinHostPort(h.si.PubNet.Hostname, strconv.Itoa(port))
+		if h.pubAddrAny(config) {
+			addr = ":" + strconv.Itoa(port)
+		}
+		g.netServ.grpc = &grpcServer{h: h, mux: g.netServ.pub.muxers}
+		go g.netServ.grpc.listen(addr, tlsConf, config)
+	}
+
 	return g.netServ.pub.listen(ep, logger, tlsConf, config) // stay here
 }
 
@@ -17,6 +17,7 @@ type global struct {
 		control *netServer
 		data    *netServer
 		pub2    *netServer
+		grpc    *grpcServer // optional (see `port_grpc`)
 	}
 	client struct {
 		control *http.Client // http client for intra-cluster comm
@@ -103,4 +104,7 @@ func shuthttp() {
 	if config.HostNet.UseIntraData {
 		g.netServ.data.shutdown(config)
 	}
+	if g.netServ.grpc != nil {
+		g.netServ.grpc.shutdown(config)
+	}
 }
@@ -97,6 +97,9 @@ func (c *lstcx) do() (string, error) {
 		PageSize: 0, // i.e., backend.MaxPageSize()
 	}
 	c.lsmsg.SetFlag(apc.LsNameOnly)
+	if c.bckFrom.Props.Inventory.Enabled {
+		c.lsmsg.SetFlag(apc.LsInventory) // (see cmn.InvConf)
+	}
 	c.smap = c.p.owner.smap.get()
 	tsi, err := c.smap.HrwTargetTask(c.lsmsg.UUID)
 	if err != nil {
@@ -199,6 +199,7 @@ func (p *proxy) Run() error {
 		// pubnet handlers: cluster must be started
 		{r: apc.Buckets, h: p.bucketHandler, net: accessNetPublic},
 		{r: apc.Objects, h: p.objectHandler, net: accessNetPublic},
+		{r: apc.Batch, h: p.batchHandler, net: accessNetPublic},
 		{r: apc.Download, h: p.downloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
 		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
@@ -355,6 +356,34 @@ func (p *proxy) _parseReqTry(w http.ResponseWriter, r *http.Request, bckArgs *bc
 	return
 }
 
+// virtual directory rename in progress (see cmn.PrefixRename): readers see either
+// the old or the new names (but never both); writes under either prefix are not permitted
+func (p *proxy) renPrefixOK(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string, write bool) bool {
+	errCode, err := p.renPrefixErr(bck, objName, write)
+	if err == nil {
+		return true
+	}
+	if errCode == http.StatusNotFound {
+		p.writeErr(w, r, err, errCode, Silent)
+	} else {
+		p.writeErr(w, r, err, errCode)
+	}
+	return false
+}
+
+func (p *proxy) renPrefixErr(bck *meta.Bck, objName string, write bool) (int, error) {
+	rp := bck.Props.RenPrefix
+	switch {
+	case rp == nil:
+		return 0, nil
+	case write && rp.Busy(objName):
+		return http.StatusConflict, cmn.NewErrBusy("bucket", bck, apc.ActRenamePrefix+" "+rp.String())
+	case !write && rp.Hidden(objName):
+		return http.StatusNotFound, cos.NewErrNotFound(p, bck.Cname(objName))
+	}
+	return 0, nil
+}
+
 // verb /v1/buckets/
 func (p *proxy) bucketHandler(w http.ResponseWriter, r *http.Request) {
 	if !p.cluStartedWithRetry() {
@@ -601,6 +630,19 @@ func (p *proxy) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 		p.writeErrf(w, r, "bad list-objects request: invalid prefix %q", lsmsg.Prefix)
 		return
 	}
+	if lsmsg.Filter != "" {
+		flt, err := cmn.NewLsoFilter(lsmsg.Filter)
+		if err != nil {
+			p.writeErrf(w, r, "bad list-objects request: %v", err)
+			return
+		}
+		if flt != nil {
+			// remote entries get evaluated given their listed properties;
+			// (cached pages are not filtered)
+			lsmsg.AddProps(flt.Props()...)
+			lsmsg.ClearFlag(apc.UseListObjsCache)
+		}
+	}
 	bckArgs := bctx{p: p, w: w, r: r, msg: msg, perms: apc.AceObjLIST, bck: bck, dpq: dpq}
 	bckArgs.createAIS = false
 
@@ -649,10 +691,13 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 	if err != nil {
 		return
 	}
+	if !p.renPrefixOK(w, r, bck, objName, false) {
+		return
+	}
 
 	// 3. redirect
 	smap := p.owner.smap.get()
-	tsi, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
+	tsi, netPub, err := smap.HrwMultiHome(bck.HrwUname(objName))
 	if err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -716,8 +761,11 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		objName = apireq.items[1]
 		netPub  = cmn.NetPublic
 	)
+	if !p.renPrefixOK(w, r, bck, objName, true) {
+		return
+	}
 	if nodeID == "" {
-		tsi, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
+		tsi, netPub, err = smap.HrwMultiHome(bck.HrwUname(objName))
 		if err != nil {
 			p.writeErr(w, r, err)
 			return
@@ -767,8 +815,11 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if !p.renPrefixOK(w, r, bck, objName, true) {
+		return
+	}
 	smap := p.owner.smap.get()
-	tsi, err := smap.HrwName2T(bck.MakeUname(objName))
+	tsi, err := smap.HrwName2T(bck.HrwUname(objName))
 	if err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -1136,7 +1187,7 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 
 	// only the primary can do metasync
 	dtor := xact.Table[msg.Action]
-	if dtor.Metasync {
+	if dtor.Metasync || msg.Action == apc.ActDestroySnap {
 		if p.forwardCP(w, r, msg, bucket) {
 			return
 		}
@@ -1172,7 +1223,15 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErrf(w, r, "cannot rename bucket %q to itself (%q)", bckFrom, bckTo)
 			return
 		}
+		if bckFrom.IsSnap() || bckTo.IsSnap() {
+			p.writeErrf(w, r, "cannot rename bucket %q to %q: bucket snapshots cannot be renamed", bckFrom, bckTo)
+			return
+		}
 		bckFrom.Provider, bckTo.Provider = apc.AIS, apc.AIS
+		if snaps := p.owner.bmd.get().Snaps(bckFrom); len(snaps) > 0 {
+			p.writeErrf(w, r, "cannot rename bucket %q that has %d snapshot(s) - destroy the snapshots first", bckFrom, len(snaps))
+			return
+		}
 		if _, present := p.owner.bmd.get().Get(bckTo); present {
 			err := cmn.NewErrBckAlreadyExists(bckTo.Bucket())
 			p.writeErr(w, r, err)
@@ -1246,7 +1305,7 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			fltPresence, _ = strconv.Atoi(v)
 		}
 		debug.Assertf(fltPresence != apc.FltExistsOutside, "(flt %d=\"outside\") not implemented yet", fltPresence)
-		if !apc.IsFltPresent(fltPresence) && (bckFrom.IsCloud() || bckFrom.IsRemoteAIS()) {
+		if !apc.IsFltPresent(fltPresence) && (bckFrom.IsCloud() || bckFrom.IsRemoteAIS() || bckFrom.Props.Inventory.Enabled) {
 			lstcx := &lstcx{
 				p:       p,
 				bckFrom: bckFrom,
@@ -1328,6 +1387,27 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErr(w, r, err)
 			return
 		}
+	case apc.ActExportList:
+		expMsg := &cmn.ExportListMsg{}
+		if err := cos.MorphMarshal(msg.Value, expMsg); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := expMsg.Validate(bck.Bucket()); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		bckTo := meta.CloneBck(&expMsg.ToBck)
+		bckToArgs := bctx{p: p, w: w, r: r, bck: bckTo, msg: msg, perms: apc.AcePUT, query: query}
+		bckToArgs.createAIS = false
+		if _, err = bckToArgs.initAndTry(); err != nil {
+			return
+		}
+		msg.Value = expMsg // (with defaults)
+		if xid, err = p.listrange(r.Method, bucket, msg, query); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
 	case apc.ActInvalListCache:
 		p.qm.c.invalidate(bck.Bucket())
 		return
@@ -1341,6 +1421,56 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErr(w, r, err)
 			return
 		}
+	case apc.ActRenamePrefix:
+		rpmsg := &cmn.RenamePrefixMsg{}
+		if err := cos.MorphMarshal(msg.Value, rpmsg); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := rpmsg.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if !bck.IsAIS() || bck.Backend() != nil {
+			p.writeErrf(w, r, "can only rename virtual directories in AIS ('ais://') buckets (%q is not)", bck)
+			return
+		}
+		if bck.Props.EC.Enabled {
+			p.writeErrf(w, r, "cannot rename virtual directory in erasure-coded bucket %q", bck)
+			return
+		}
+		if err := p.checkAccess(w, r, bck, apc.AceObjMOVE); err != nil {
+			return
+		}
+		nlog.Infof("%s %s: %q => %q (abort: %t)", msg.Action, bck, rpmsg.From, rpmsg.To, rpmsg.Abort)
+		if xid, err = p.renamePrefix(bck, rpmsg); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+	case apc.ActCreateSnap:
+		if err := p.checkAccess(w, r, nil, apc.AceCreateBucket); err != nil {
+			return
+		}
+		if xid, err = p.createSnap(msg, bck); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+	case apc.ActRestoreSnap:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT|apc.AceObjDELETE); err != nil {
+			return
+		}
+		if xid, err = p.restoreSnap(msg, bck); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+	case apc.ActDestroySnap:
+		if err := p.checkAccess(w, r, bck, apc.AceDestroyBucket); err != nil {
+			return
+		}
+		if err := p.destroySnap(msg, bck); err != nil {
+			p.writeErr(w, r, err)
+		}
+		return // (no xaction)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 		return
@@ -1354,6 +1484,11 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 // init existing or create remote
 // not calling `initAndTry` - delegating ais:from// props cloning to the separate method
 func (p *proxy) initBckTo(w http.ResponseWriter, r *http.Request, query url.Values, bckTo *meta.Bck) (*meta.Bck, int, error) {
+	if bckTo.IsSnap() {
+		err := errSnapRO(bckTo, "write into")
+		p.writeErr(w, r, err)
+		return nil, 0, err
+	}
 	bckToArgs := bctx{p: p, w: w, r: r, bck: bckTo, perms: apc.AcePUT, query: query}
 	bckToArgs.createAIS = true
 
@@ -1386,6 +1521,10 @@ func (p *proxy) _bcr(w http.ResponseWriter, r *http.Request, query url.Values, m
 		p.writeErr(w, r, err)
 		return
 	}
+	if bck.IsSnap() {
+		p.writeErr(w, r, errSnapRO(bck, "create"))
+		return
+	}
 	if p.forwardCP(w, r, msg, bucket) {
 		return
 	}
@@ -1510,6 +1649,15 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 		}
 	}
 
+	if lsmsg.IsFlagSet(apc.LsNoRecursion) && lsmsg.IsFlagSet(apc.LsInventory) {
+		p.writeErrMsg(w, r, "cannot list bucket inventory non-recursively (flags 'LsInventory', 'LsNoRecursion')")
+		return
+	}
+	// remote bucket with configured inventory (manifest) - page through the latter
+	if bck.Props != nil && bck.Props.Inventory.Enabled && !lsmsg.IsFlagSet(apc.LsObjCached) && !lsmsg.IsFlagSet(apc.LsNoRecursion) {
+		lsmsg.SetFlag(apc.LsInventory)
+	}
+
 	// default props & flags => user-provided message
 	switch {
 	case lsmsg.Props == "":
@@ -1524,7 +1672,7 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	case lsmsg.Props == apc.GetPropsNameSize:
 		lsmsg.SetFlag(apc.LsNameSize)
 	}
-	if bck.IsHTTP() || lsmsg.IsFlagSet(apc.LsArchDir) {
+	if (bck.IsHTTP() && !lsmsg.IsFlagSet(apc.LsInventory)) || lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
 
@@ -1672,7 +1820,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 	if err != nil {
 		return
 	}
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActCompose {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1708,6 +1856,9 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		if !p.isValidObjname(w, r, objNameTo) {
 			return
 		}
+		if !p.renPrefixOK(w, r, bck, objName, true) || !p.renPrefixOK(w, r, bck, objNameTo, true) {
+			return
+		}
 		p.redirectObjAction(w, r, bck, apireq.items[1], msg)
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
@@ -1752,11 +1903,60 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		objName := msg.Name
 		p.redirectObjAction(w, r, bck, objName, msg)
+	case apc.ActCompose:
+		objName := apireq.items[1]
+		if !p.isValidObjname(w, r, objName) || !p.renPrefixOK(w, r, bck, objName, true) {
+			return
+		}
+		if err := p.validateCompose(w, r, bck, msg); err != nil {
+			return
+		}
+		p.redirectObjAction(w, r, bck, objName, msg)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
 }
 
+// compose: validate the sources, and check read access to their respective buckets
+func (p *proxy) validateCompose(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) error {
+	args := &cmn.ComposeMsg{}
+	if err := cos.MorphMarshal(msg.Value, args); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return err
+	}
+	if err := args.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return err
+	}
+	var (
+		checked = make(map[string]struct{}, 2)
+		selfsrc bool
+	)
+	for i := range args.Sources {
+		src := &args.Sources[i]
+		if src.Bck.IsEmpty() {
+			selfsrc = true
+			continue
+		}
+		uname := src.Bck.MakeUname("")
+		if _, ok := checked[uname]; ok {
+			continue
+		}
+		bckArgs := bctx{p: p, w: w, r: r, msg: msg, perms: apc.AceGET, bck: meta.CloneBck(&src.Bck)}
+		bckArgs.createAIS = false
+		bckArgs.dontHeadRemote = true
+		if _, err := bckArgs.initAndTry(); err != nil {
+			return err
+		}
+		checked[uname] = struct{}{}
+	}
+	if selfsrc {
+		// (destination bucket is also a source)
+		return p.checkAccess(w, r, bck, apc.AceGET)
+	}
+	return nil
+}
+
 // HEAD /v1/buckets/bucket-name
 func (p *proxy) httpbckhead(w http.ResponseWriter, r *http.Request, apireq *apiRequest) {
 	err := p.parseReq(w, r, apireq)
@@ -1886,6 +2086,10 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request, apireq *api
 		return
 	}
 	bck := apireq.bck
+	if bck.IsSnap() {
+		p.writeErr(w, r, errSnapRO(bck, "modify"))
+		return
+	}
 	if p.forwardCP(w, r, msg, "patch "+bck.String()) {
 		return
 	}
@@ -1924,6 +2128,28 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request, apireq *api
 			return
 		}
 	}
+	if nprops.Tier.Enabled {
+		// ditto (tier bucket)
+		tier := meta.CloneBck(&nprops.Tier.Bck)
+		tier.Props = nil
+
+		args := bctx{p: p, w: w, r: r, bck: tier, msg: msg, dpq: apireq.dpq, query: apireq.query}
+		args.createAIS = false
+		if _, err = args.initAndTry(); err != nil {
+			return
+		}
+	}
+	if nprops.Replication.Enabled {
+		// ditto (replication destination in the remote AIS cluster)
+		dst := meta.CloneBck(&nprops.Replication.Bck)
+		dst.Props = nil
+
+		args := bctx{p: p, w: w, r: r, bck: dst, msg: msg, dpq: apireq.dpq, query: apireq.query}
+		args.createAIS = false
+		if _, err = args.initAndTry(); err != nil {
+			return
+		}
+	}
 	if xid, err = p.setBprops(msg, bck, nprops); err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -1948,8 +2174,11 @@ func (p *proxy) httpobjhead(w http.ResponseWriter, r *http.Request, origURLBck .
 	if err != nil {
 		return
 	}
+	if !p.renPrefixOK(w, r, bck, objName, false) {
+		return
+	}
 	smap := p.owner.smap.get()
-	si, err := smap.HrwName2T(bck.MakeUname(objName))
+	si, err := smap.HrwName2T(bck.HrwUname(objName))
 	if err != nil {
 		p.writeErr(w, r, err, http.StatusInternalServerError)
 		return
@@ -1977,7 +2206,7 @@ func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	smap := p.owner.smap.get()
-	si, err := smap.HrwName2T(bck.MakeUname(objName))
+	si, err := smap.HrwName2T(bck.HrwUname(objName))
 	if err != nil {
 		p.writeErr(w, r, err, http.StatusInternalServerError)
 		return
@@ -2256,7 +2485,7 @@ func (p *proxy) lsObjsR(bck *meta.Bck, lsmsg *apc.LsoMsg, smap *smapX, tsi *meta
 func (p *proxy) redirectObjAction(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName string, msg *apc.ActMsg) {
 	started := time.Now()
 	smap := p.owner.smap.get()
-	si, err := smap.HrwName2T(bck.MakeUname(objName))
+	si, err := smap.HrwName2T(bck.HrwUname(objName))
 	if err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -0,0 +1,58 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// virtual directory rename in progress: depending on the phase, readers see either
+// the old or the new names; writes under either prefix are rejected
+func TestRenPrefixErr(t *testing.T) {
+	var (
+		p   = &proxy{htrun: htrun{si: newSnode("p1", apc.Proxy, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})}}
+		rp  = &cmn.PrefixRename{From: "tmp/run42/", To: "final/run42/"}
+		bck = meta.NewBck("renpfx", apc.AIS, cmn.NsGlobal, &cmn.Bprops{RenPrefix: rp})
+	)
+	tests := []struct {
+		phase   string
+		objName string
+		write   bool
+		code    int
+	}{
+		{cmn.RenPrefixCopy, "tmp/run42/a", false, 0},
+		{cmn.RenPrefixCopy, "final/run42/a", false, http.StatusNotFound},
+		{cmn.RenPrefixRollback, "tmp/run42/a", false, 0},
+		{cmn.RenPrefixRollback, "final/run42/a", false, http.StatusNotFound},
+		{cmn.RenPrefixCommit, "tmp/run42/a", false, http.StatusNotFound},
+		{cmn.RenPrefixCommit, "final/run42/a", false, 0},
+
+		{cmn.RenPrefixCopy, "tmp/run42/b", true, http.StatusConflict},
+		{cmn.RenPrefixCopy, "final/run42/b", true, http.StatusConflict},
+		{cmn.RenPrefixCommit, "tmp/run42/b", true, http.StatusConflict},
+
+		// unrelated
+		{cmn.RenPrefixCopy, "tmp/run43/a", false, 0},
+		{cmn.RenPrefixCommit, "tmp/run43/a", true, 0},
+		{cmn.RenPrefixCommit, "final/run4", true, 0},
+	}
+	for _, test := range tests {
+		rp.Phase = test.phase
+		code, err := p.renPrefixErr(bck, test.objName, test.write)
+		tassert.Errorf(t, code == test.code && (err == nil) == (test.code == 0),
+			"%s %q (write %t): expected %d, got %d (%v)", rp, test.objName, test.write, test.code, code, err)
+	}
+
+	// done
+	bck.Props.RenPrefix = nil
+	code, err := p.renPrefixErr(bck, "final/run42/a", true)
+	tassert.Errorf(t, code == 0 && err == nil, "expected no error, got %d (%v)", code, err)
+}
@@ -0,0 +1,105 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// GET /v1/batch/bucket-name (see cmn.GetBatchMsg and tgtbatch.go)
+func (p *proxy) batchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	apireq := apiReqAlloc(1, apc.URLPathBatch.L, false)
+	p.httpbatchget(w, r, apireq)
+	apiReqFree(apireq)
+}
+
+// designate the target that owns the most entries, and redirect
+func (p *proxy) httpbatchget(w http.ResponseWriter, r *http.Request, apireq *apiRequest) {
+	if err := p.parseReq(w, r, apireq); err != nil {
+		return
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if _, err := archive.Mime(msg.Mime, ""); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// buckets and access permissions
+	bckArgs := bctx{p: p, w: w, r: r, perms: apc.AceGET, bck: apireq.bck}
+	bck, err := bckArgs.initAndTry()
+	if err != nil {
+		return
+	}
+	bcks := make(map[cmn.Bck]*meta.Bck, 2)
+	for i := range msg.In {
+		in := &msg.In[i]
+		if in.Bck.IsEmpty() {
+			continue
+		}
+		if _, ok := bcks[in.Bck]; ok {
+			continue
+		}
+		bckArgs := bctx{p: p, w: w, r: r, perms: apc.AceGET, bck: meta.CloneBck(&in.Bck)}
+		b, err := bckArgs.initAndTry()
+		if err != nil {
+			return
+		}
+		bcks[in.Bck] = b
+	}
+
+	// the target that owns the most entries assembles and streams the archive
+	var (
+		smap   = p.owner.smap.get()
+		counts = make(map[string]int, smap.CountActiveTs())
+		tsi    *meta.Snode
+		most   int
+	)
+	for i := range msg.In {
+		in := &msg.In[i]
+		b := bck
+		if !in.Bck.IsEmpty() {
+			b = bcks[in.Bck]
+		}
+		si, err := smap.HrwName2T(b.HrwUname(in.ObjName))
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		n := counts[si.ID()] + 1
+		if n > most {
+			tsi, most = si, n
+		}
+		counts[si.ID()] = n
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
+		nlog.Infoln("GET batch", bck.Cname(""), len(msg.In), "entries =>", tsi.StringEx(), most)
+	}
+
+	// NOTE: 307 to redirect with the original JSON payload
+	redirectURL := p.redirectURL(r, tsi, time.Now() /*started*/, cmn.NetIntraData)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+
+	p.statsT.Inc(stats.GetCount)
+}
@@ -1173,6 +1173,16 @@ func (p *proxy) xstart(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 		}
 		args._selected(tsi)
 		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs})
+	case xargs.Kind == apc.ActReencrypt:
+		// rotate: new data key first (see core/lcrypt.go)
+		if err := p.rotateDataKey(&xargs); err != nil {
+			freeBcArgs(args)
+			p.writeErr(w, r, err)
+			return
+		}
+		args.to = core.Targets
+		xargs.ID = cos.GenUUID()
+		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs})
 	default:
 		// all targets, one common UUID for all
 		args.to = core.Targets
@@ -1228,6 +1238,24 @@ func (p *proxy) blobdl(smap *smapX, xargs *xact.ArgsMsg, msg *apc.ActMsg) (tsi *
 	return tsi, err
 }
 
+// re-encrypt: when encryption is enabled, generate new (current) data key
+// and then have all targets rewrite existing objects (see xs/reencrypt.go)
+func (p *proxy) rotateDataKey(xargs *xact.ArgsMsg) error {
+	bck := meta.CloneBck(&xargs.Bck)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		return err
+	}
+	if !bck.Props.Encrypt.Enabled {
+		return nil // (decrypting previously encrypted objects, if any)
+	}
+	nprops := bck.Props.Clone()
+	if err := nprops.Encrypt.NewKey(); err != nil {
+		return err
+	}
+	_, err := p.setBprops(&apc.ActMsg{Action: apc.ActSetBprops}, bck, nprops)
+	return err
+}
+
 func (p *proxy) xstop(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
 	var (
 		xargs = xact.ArgsMsg{}
@@ -486,6 +486,10 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if errCode, err := p.renPrefixErr(bck, objName, true); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
 	si, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -530,6 +534,10 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if errCode, err := p.renPrefixErr(bck, objName, false); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
 	si, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -610,6 +618,10 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if errCode, err := p.renPrefixErr(bck, objName, false); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -648,6 +660,10 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if errCode, err := p.renPrefixErr(bck, objName, true); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
 	si, err = smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -0,0 +1,183 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// Bucket snapshots: proxy side (see cmn/snap.go and xact/xs/snap.go)
+
+// the origin (bucket) must be a "pure" ais:// bucket (no remote backend, no erasure coding)
+func validateSnapSrc(bck *meta.Bck) error {
+	switch {
+	case bck.IsSnap():
+		return fmt.Errorf("%s is a snapshot (cannot snapshot a snapshot)", bck.Cname(""))
+	case !bck.IsAIS() || bck.Backend() != nil:
+		return cmn.NewErrUnsupp("snapshot", bck.Cname("")+" (snapshots are supported only for ais:// buckets without remote backend)")
+	case bck.Props.EC.Enabled:
+		return cmn.NewErrUnsupp("snapshot", "erasure-coded bucket "+bck.Cname(""))
+	}
+	return nil
+}
+
+// snapshots are read-only: reject all attempts to create, rename-to, or modify
+func errSnapRO(bck *meta.Bck, op string) error {
+	return fmt.Errorf("cannot %s %s: bucket snapshots are read-only (see 'ais bucket snapshot --help')",
+		op, bck.Cname(""))
+}
+
+func (p *proxy) initSnap(msg *apc.ActMsg, bck *meta.Bck) (*meta.Bck, error) {
+	if err := validateSnapSrc(bck); err != nil {
+		return nil, err
+	}
+	if err := cmn.ValidateSnapName(msg.Name); err != nil {
+		return nil, err
+	}
+	sbck := bck.Bucket().SnapBck(msg.Name)
+	return meta.CloneBck(&sbck), nil
+}
+
+// create-snapshot: { confirm non-existence -- begin -- add snapshot to BMD -- metasync -- commit }
+func (p *proxy) createSnap(msg *apc.ActMsg, bck *meta.Bck) (xid string, err error) {
+	snap, err := p.initSnap(msg, bck)
+	if err != nil {
+		return "", err
+	}
+	nlp := newBckNLP(bck)
+	if !nlp.TryLock(cmn.Rom.CplaneOperation() / 2) {
+		return "", cmn.NewErrBusy("bucket", bck, "")
+	}
+	defer nlp.Unlock()
+
+	// 1. confirm non-existence
+	bmd := p.owner.bmd.get()
+	if _, present := bmd.Get(snap); present {
+		return "", cmn.NewErrBckAlreadyExists(snap.Bucket())
+	}
+
+	// 2. begin
+	var (
+		waitmsync = true
+		c         = p.prepTxnClient(msg, bck, waitmsync)
+	)
+	if err = c.begin(bck); err != nil {
+		return
+	}
+
+	// 3. add snapshot to BMD & metasync
+	ctx := &bmdModifier{
+		pre:   bmodCreateSnap,
+		final: p.bmodSync,
+		wait:  waitmsync,
+		msg:   &c.msg.ActMsg,
+		txnID: c.uuid,
+		bcks:  []*meta.Bck{bck, snap},
+	}
+	bmd, err = p.owner.bmd.modify(ctx)
+	if err != nil {
+		c.bcastAbort(bck, err)
+		return "", err
+	}
+	c.msg.BMDVersion = bmd.version()
+
+	// 4. IC
+	nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
+	nl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+
+	// 5. commit
+	xid, _, err = c.commit(bck, c.cmtTout(waitmsync))
+	debug.Assertf(xid == "" || xid == c.uuid, "committed %q vs generated %q", xid, c.uuid)
+	if err != nil {
+		c.bcastAbort(bck, err) // cleanup
+		p.undoCreateBucket(msg, snap)
+	}
+	return xid, err
+}
+
+func bmodCreateSnap(ctx *bmdModifier, clone *bucketMD) error {
+	bck, snap := ctx.bcks[0], ctx.bcks[1]
+	if _, present := clone.Get(bck); !present {
+		return cmn.NewErrBckNotFound(bck.Bucket())
+	}
+	if !clone.addSnap(bck, snap) {
+		return cmn.NewErrBckAlreadyExists(snap.Bucket())
+	}
+	return nil
+}
+
+// restore-snapshot: { confirm existence -- begin -- commit }
+func (p *proxy) restoreSnap(msg *apc.ActMsg, bck *meta.Bck) (xid string, err error) {
+	snap, err := p.initSnap(msg, bck)
+	if err != nil {
+		return "", err
+	}
+
+	// 1. confirm existence
+	if _, present := p.owner.bmd.get().Get(snap); !present {
+		return "", cmn.NewErrBckNotFound(snap.Bucket())
+	}
+
+	// 2. begin
+	c := p.prepTxnClient(msg, bck, false /*waitmsync*/)
+	if err = c.begin(bck); err != nil {
+		return
+	}
+
+	// 3. IC
+	nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
+	nl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+
+	// 4. commit
+	xid, _, err = c.commit(bck, c.cmtTout(false /*waitmsync*/))
+	debug.Assertf(xid == "" || xid == c.uuid, "committed %q vs generated %q", xid, c.uuid)
+	if err != nil {
+		c.bcastAbort(bck, err) // cleanup
+	}
+	return xid, err
+}
+
+// destroy-snapshot: { confirm existence -- remove from BMD -- metasync }
+// (targets remove snapshot content upon receiving the updated BMD - see t._syncBMD)
+func (p *proxy) destroySnap(msg *apc.ActMsg, bck *meta.Bck) error {
+	if err := cmn.ValidateSnapName(msg.Name); err != nil {
+		return err
+	}
+	sbck := bck.Bucket().SnapBck(msg.Name)
+	snap := meta.CloneBck(&sbck)
+
+	nlp := newBckNLP(bck)
+	if !nlp.TryLock(cmn.Rom.CplaneOperation() / 2) {
+		return cmn.NewErrBusy("bucket", bck, "")
+	}
+	defer nlp.Unlock()
+
+	if _, present := p.owner.bmd.get().Get(snap); !present {
+		return cmn.NewErrBckNotFound(snap.Bucket())
+	}
+	ctx := &bmdModifier{
+		pre:   bmodRm,
+		final: p.bmodSync,
+		msg:   msg,
+		txnID: cos.GenUUID(),
+		wait:  true,
+		bcks:  []*meta.Bck{snap},
+	}
+	_, err := p.owner.bmd.modify(ctx)
+	if err == nil {
+		nlog.Infoln(p.String(), msg.Action, snap.Cname(""))
+	}
+	return err
+}
@@ -284,6 +284,12 @@ func bmodRm(ctx *bmdModifier, clone *bucketMD) error {
 	}
 	deleted := clone.del(bck)
 	cos.Assert(deleted)
+	if !bck.IsSnap() {
+		// snapshots do not outlive their origin
+		for _, snap := range clone.Snaps(bck) {
+			clone.del(snap)
+		}
+	}
 	return nil
 }
 
@@ -395,6 +401,8 @@ func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (s
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		// keep data keys: existing objects (and EC slices) may still be encrypted
+		nprops.Encrypt.Keys = bprops.Encrypt.Keys
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -457,6 +465,7 @@ func (p *proxy) bmodSetProps(ctx *bmdModifier, clone *bucketMD) (err error) {
 	if ctx.msg.Action == apc.ActSetBprops {
 		bck.Props = bprops
 	}
+	ctx.setProps.RenPrefix = bprops.RenPrefix // (is managed by rename-prefix - see _renpfx)
 	ctx.needReMirror = _reMirror(bprops, ctx.setProps)
 	targetCnt, ctx.needReEC = _reEC(bprops, ctx.setProps, bck, p.owner.smap.get())
 	debug.Assert(!ctx.needReEC || ctx.setProps.Validate(targetCnt) == nil)
@@ -472,10 +481,15 @@ func (p *proxy) renameBucket(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg) (xid str
 	}
 	// 1. confirm existence & non-existence
 	bmd := p.owner.bmd.get()
-	if _, present := bmd.Get(bckFrom); !present {
+	props, present := bmd.Get(bckFrom)
+	if !present {
 		err = cmn.NewErrBckNotFound(bckFrom.Bucket())
 		return
 	}
+	if props.RenPrefix != nil {
+		err = cmn.NewErrBusy("bucket", bckFrom, apc.ActRenamePrefix+" "+props.RenPrefix.String())
+		return
+	}
 	if _, present := bmd.Get(bckTo); present {
 		err = cmn.NewErrBckAlreadyExists(bckTo.Bucket())
 		return
@@ -788,6 +802,92 @@ func (p *proxy) ecEncode(bck *meta.Bck, msg *apc.ActMsg) (xid string, err error)
 	return xid, err
 }
 
+// rename virtual directory: { confirm phase -- begin -- update BMD (phase) -- IC -- commit }
+// The same sequence executes each phase of the rename (see cmn.PrefixRename). Upon completion
+// of the copy phase, the primary commits the rename with the BMD version that (cluster-wide)
+// makes the new names visible and the old ones hidden; the old names then get removed (see _renpfx.cb).
+// Interrupted rename can be resumed by re-issuing the same request, or aborted (rolled back).
+func (p *proxy) renamePrefix(bck *meta.Bck, rpmsg *cmn.RenamePrefixMsg) (string, error) {
+	var (
+		cur = bck.Props.RenPrefix
+		rp  = &cmn.PrefixRename{From: rpmsg.From, To: rpmsg.To, Phase: cmn.RenPrefixCopy}
+	)
+	switch {
+	case cur == nil:
+		if rpmsg.Abort {
+			return "", fmt.Errorf("%s: no %s %q => %q in progress", bck, apc.ActRenamePrefix, rpmsg.From, rpmsg.To)
+		}
+	case cur.From != rp.From || cur.To != rp.To:
+		return "", cmn.NewErrBusy("bucket", bck, apc.ActRenamePrefix+" "+cur.String())
+	case cur.Phase == cmn.RenPrefixCommit:
+		if rpmsg.Abort {
+			return "", fmt.Errorf("%s: cannot abort %s %s (hint: re-issue the request to complete it)", bck, apc.ActRenamePrefix, cur)
+		}
+		rp.Phase = cmn.RenPrefixCommit // resume
+	case rpmsg.Abort:
+		rp.Phase = cmn.RenPrefixRollback
+	}
+	return p._renamePrefix(bck, rp)
+}
+
+func (p *proxy) _renamePrefix(bck *meta.Bck, rp *cmn.PrefixRename) (xid string, err error) {
+	nlp := newBckNLP(bck)
+	if !nlp.TryLock(cmn.Rom.CplaneOperation() / 2) {
+		return "", cmn.NewErrBusy("bucket", bck, "")
+	}
+	defer nlp.Unlock()
+
+	// 1. confirm existence and (still) the same rename
+	bprops, present := p.owner.bmd.get().Get(bck)
+	if !present {
+		return "", cmn.NewErrBckNotFound(bck.Bucket())
+	}
+	if cur := bprops.RenPrefix; cur != nil && (cur.From != rp.From || cur.To != rp.To) {
+		return "", cmn.NewErrBusy("bucket", bck, apc.ActRenamePrefix+" "+cur.String())
+	}
+
+	// 2. begin
+	var (
+		waitmsync = true
+		msg       = &apc.ActMsg{Action: apc.ActRenamePrefix, Name: rp.Phase, Value: rp}
+		c         = p.prepTxnClient(msg, bck, waitmsync)
+		r         = &_renpfx{p: p, bck: bck, rp: *rp}
+	)
+	if err = c.begin(bck); err != nil {
+		return "", err
+	}
+
+	// 3. update BMD locally & metasync updated BMD
+	ctx := &bmdModifier{
+		pre:   r.bmod,
+		final: p.bmodSync,
+		msg:   msg,
+		txnID: c.uuid,
+		bcks:  []*meta.Bck{bck},
+		wait:  waitmsync,
+	}
+	bmd, err := p.owner.bmd.modify(ctx)
+	if err != nil {
+		c.bcastAbort(bck, err)
+		return "", err
+	}
+	c.msg.BMDVersion = bmd.version()
+
+	// 4. IC (and next phase upon completion)
+	nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
+	nl.SetOwner(equalIC)
+	nl.F = r.cb
+	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+
+	// 5. commit
+	xid, _, err = c.commit(bck, c.cmtTout(waitmsync))
+	debug.Assertf(xid == "" || xid == c.uuid, "committed %q vs generated %q", xid, c.uuid)
+	if err != nil {
+		c.bcastAbort(bck, err) // cleanup txn
+	}
+	return xid, err
+}
+
 func (p *proxy) validateECConf(bck *meta.Bck, confToSet *cmn.ECConfToSet, currConf *cmn.ECConf) error {
 	newConf := *currConf
 	newConf.Enabled = true
@@ -1124,6 +1224,12 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 			return
 		}
 	}
+	// generate the first data key (encryption at rest - see core/lcrypt.go)
+	if nprops.Encrypt.Enabled && len(nprops.Encrypt.Keys) == 0 {
+		if err = nprops.Encrypt.NewKey(); err != nil {
+			return
+		}
+	}
 	// cannot have re-mirroring and erasure coding on the same bucket at the same time
 	remirror := _reMirror(bprops, nprops)
 	targetCnt, reec := _reEC(bprops, nprops, bck, p.owner.smap.get())
@@ -1194,3 +1300,87 @@ func (r *_tcbfin) cb(nl nl.Listener) {
 	// when (tcb aborted) and (did not exist prior)
 	_ = r.p.destroyBucket(&apc.ActMsg{Action: apc.ActDestroyBck}, r.bck)
 }
+
+/////////////
+// _renpfx //
+/////////////
+
+type _renpfx struct {
+	p   *proxy
+	bck *meta.Bck
+	rp  cmn.PrefixRename // phase that's being executed; empty phase when done
+}
+
+func (r *_renpfx) bmod(ctx *bmdModifier, clone *bucketMD) error {
+	bck := ctx.bcks[0]
+	bprops, present := clone.Get(bck)
+	if !present {
+		return cmn.NewErrBckNotFound(bck.Bucket())
+	}
+	if cur := bprops.RenPrefix; cur != nil && (cur.From != r.rp.From || cur.To != r.rp.To) {
+		return cmn.NewErrBusy("bucket", bck, apc.ActRenamePrefix+" "+cur.String())
+	}
+	nprops := bprops.Clone()
+	if r.rp.Phase == "" {
+		nprops.RenPrefix = nil
+	} else {
+		rp := r.rp
+		nprops.RenPrefix = &rp
+	}
+	clone.set(bck, nprops)
+	return nil
+}
+
+// upon completion of a given phase: advance to the next one
+func (r *_renpfx) cb(nl nl.Listener) {
+	var (
+		err     = nl.Err()
+		aborted = nl.Aborted()
+		next    = &_renpfx{p: r.p, bck: r.bck, rp: r.rp}
+	)
+	switch {
+	case aborted:
+		if r.rp.Phase != cmn.RenPrefixCopy {
+			nlog.Warningln(apc.ActRenamePrefix, r.rp.String(), "aborted:", err, "- can be resumed")
+			return
+		}
+		nlog.Warningln(apc.ActRenamePrefix, r.rp.String(), "aborted:", err, "- rolling back")
+		next.rp.Phase = cmn.RenPrefixRollback
+	case err != nil:
+		nlog.Errorln(apc.ActRenamePrefix, r.rp.String(), "failed:", err, "- can be resumed or aborted")
+		return
+	case r.rp.Phase == cmn.RenPrefixCopy:
+		next.rp.Phase = cmn.RenPrefixCommit
+	default:
+		next.rp.Phase = "" // done
+	}
+	go next.run()
+}
+
+func (r *_renpfx) run() {
+	const retries = 3
+	var err error
+	if r.rp.Phase == "" {
+		ctx := &bmdModifier{
+			pre:   r.bmod,
+			final: r.p.bmodSync,
+			msg:   &apc.ActMsg{Action: apc.ActRenamePrefix},
+			bcks:  []*meta.Bck{r.bck},
+		}
+		if _, err = r.p.owner.bmd.modify(ctx); err == nil {
+			nlog.Infoln(apc.ActRenamePrefix, r.rp.From, "=>", r.rp.To, "done")
+			return
+		}
+	} else {
+		// (targets may still be finishing the previous phase, esp. upon abort)
+		for i := range retries {
+			if _, err = r.p._renamePrefix(r.bck, &r.rp); err == nil {
+				return
+			}
+			if i < retries-1 {
+				time.Sleep(cmn.Rom.CplaneOperation())
+			}
+		}
+	}
+	nlog.Errorln("failed to advance", apc.ActRenamePrefix, r.rp.String()+":", err)
+}
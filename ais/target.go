@@ -37,8 +37,10 @@ import (
 	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/ext/dsort"
 	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/ext/webhook"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
@@ -317,6 +319,20 @@ func (t *target) Run() error {
 	// register object type and workfile type
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.PackType, &fs.PackContentResolver{})
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{})
+	fs.CSM.Reg(fs.ShardIdxType, &fs.ShardIdxContentResolver{})
+	fs.CSM.Reg(fs.NameIdxType, &fs.NameIdxContentResolver{})
+	fs.CSM.Reg(fs.SnapMetaType, &fs.SnapMetaContentResolver{})
+
+	// cache tier (fast mountpaths, if configured)
+	core.InitHot(config)
+
+	// read-ahead (see cmn.ReadAheadConf)
+	core.InitReadAhead()
+
+	// shard indexes (see core/lshard.go)
+	core.InitShardIdx()
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -359,6 +375,7 @@ func (t *target) Run() error {
 	}
 
 	t.transactions.init(t)
+	hk.Reg("tier"+hk.NameSuffix, t.tierHK, tierIvalMin)
 
 	t.reb = reb.New(config)
 	t.res = res.New()
@@ -378,6 +395,7 @@ func (t *target) Run() error {
 
 	dsort.Tinit(t.statsT, db, config)
 	dload.Init(t.statsT, db, &config.Client)
+	webhook.Init(t.statsT, &config.Client)
 
 	err = t.htrun.run(config)
 
@@ -401,6 +419,8 @@ func (t *target) gojoin(config *cmn.Config) {
 		}
 	}
 	t.markClusterStarted()
+	go t.wbackReplay()
+	go t.replReplay()
 
 	if t.fsprg.newVol && !config.TestingEnv() {
 		config := cmn.GCO.BeginUpdate()
@@ -439,6 +459,8 @@ func (t *target) endStartupStandby() (err error) {
 	daemon.cli.target.standby = false
 	t.markNodeStarted()
 	t.markClusterStarted()
+	go t.wbackReplay()
+	go t.replReplay()
 	t.regstate.disabled.Store(false)
 	tstats := t.statsT.(*stats.Trunner)
 	tstats.Standby(false)
@@ -456,6 +478,7 @@ func (t *target) initRecvHandlers() {
 	networkHandlers := []networkHandler{
 		{r: apc.Buckets, h: t.bucketHandler, net: accessNetAll},
 		{r: apc.Objects, h: t.objectHandler, net: accessNetAll},
+		{r: apc.Batch, h: t.batchHandler, net: accessNetPublicData},
 		{r: apc.Daemon, h: t.daemonHandler, net: accessNetPublicControl},
 		{r: apc.Metasync, h: t.metasyncHandler, net: accessNetIntraControl},
 		{r: apc.Health, h: t.healthHandler, net: accessNetPublicControl},
@@ -949,6 +972,24 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 			w.Write([]byte(xid))
 			// lom is eventually freed by x-blob
 		}
+	case apc.ActCompose:
+		var (
+			args    cmn.ComposeMsg
+			errCode int
+		)
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if err = cos.MorphMarshal(msg.Value, &args); err != nil {
+			err = fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+			break
+		}
+		if errCode, err = t.compose(lom, &args); err != nil {
+			t.writeErr(w, r, err, errCode)
+		}
+		core.FreeLOM(lom)
+		return
 	default:
 		t.writeErrAct(w, r, msg.Action)
 		return
@@ -1142,6 +1183,12 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 		}
 		return
 	}
+	// (reserved - see core/lcodec.go)
+	zsize, compressed := lom.GetCustomKey(cmn.CompressObjMD)
+	keyID, encrypted := lom.GetCustomKey(cmn.EncryptObjMD)
+	delete(custom, cmn.CompressObjMD)
+	delete(custom, cmn.EncryptObjMD)
+
 	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
 	if delOldSetNew {
 		lom.SetCustomMD(custom)
@@ -1150,6 +1197,12 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 			lom.SetCustomKey(key, val)
 		}
 	}
+	if compressed {
+		lom.SetCustomKey(cmn.CompressObjMD, zsize)
+	}
+	if encrypted {
+		lom.SetCustomKey(cmn.EncryptObjMD, keyID)
+	}
 	lom.Persist()
 }
 
@@ -1211,21 +1264,30 @@ func (t *target) sendECCT(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 			return
 		}
 	}
-	sliceFQN := lom.Mountpath().MakePathFQN(bck.Bucket(), fs.ECSliceType, lom.ObjName)
-	finfo, err := os.Stat(sliceFQN)
+	var (
+		sliceFQN = lom.Mountpath().MakePathFQN(bck.Bucket(), fs.ECSliceType, lom.ObjName)
+		metaFQN  = lom.Mountpath().MakePathFQN(bck.Bucket(), fs.ECMetaType, lom.ObjName)
+	)
+	md, err := ec.LoadMetadata(metaFQN)
 	if err != nil {
 		t.writeErr(w, r, err, http.StatusNotFound, Silent)
 		return
 	}
-	file, err := os.Open(sliceFQN)
+	// (decrypting, if need be - see core/lcrypt.go)
+	size := ec.SliceSize(md.Size, md.Data)
+	file, err := core.OpenSlice(bck, sliceFQN, size)
 	if err != nil {
+		if cos.IsNotExist(err, 0) {
+			t.writeErr(w, r, err, http.StatusNotFound, Silent)
+			return
+		}
 		t.fsErr(err, sliceFQN)
 		t.writeErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set(cos.HdrContentLength, strconv.FormatInt(finfo.Size(), 10))
-	_, err = io.Copy(w, file) // No need for `io.CopyBuffer` as `sendfile` syscall will be used.
+	w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	_, err = io.Copy(w, file) // No need for `io.CopyBuffer` as `sendfile` syscall will be used (when not encrypted)
 	cos.Close(file)
 	if err != nil {
 		nlog.Errorf("Failed to send slice %s: %v", lom.Cname(), err)
@@ -1286,6 +1348,10 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 	var isback bool
 	lom.Lock(true)
+	if err = lom.Fenced(); err != nil { // (see core/lsnap.go)
+		lom.Unlock(true)
+		return http.StatusServiceUnavailable, err
+	}
 	code, err, isback = t.delobj(lom, evict)
 	lom.Unlock(true)
 
@@ -1312,6 +1378,7 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
 		delFromAIS, delFromBackend bool
+		wbpending                  bool
 	)
 	delFromBackend = lom.Bck().IsRemote() && !evict
 	err := lom.Load(false /*cache it*/, true /*locked*/)
@@ -1324,15 +1391,28 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 		}
 	} else {
 		delFromAIS = true
+		if lom.IsWbackPending() {
+			if evict {
+				return http.StatusConflict, fmt.Errorf("cannot evict %s: pending write-back", lom), false
+			}
+			wbpending = true
+		}
 	}
 
 	// do
 	if delFromBackend {
 		backendErrCode, backendErr = t.Backend(lom.Bck()).DeleteObj(lom)
+		if wbpending && cos.IsNotExist(backendErr, backendErrCode) {
+			backendErr, backendErrCode = nil, 0 // (not written back yet)
+		}
 	}
 	if delFromAIS {
 		size := lom.SizeBytes()
+		repl := !evict && t.replLog(lom, core.ReplDel)
 		aisErr = lom.Remove()
+		if repl {
+			t.repl(lom, core.ReplDel)
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1348,6 +1428,9 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
 				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
 			)
+			webhook.Notify(lom, apc.EvtObjEvicted)
+		} else {
+			webhook.Notify(lom, apc.EvtObjDeleted)
 		}
 	}
 	if backendErr != nil {
@@ -1388,10 +1471,20 @@ func (t *target) objMv(lom *core.LOM, msg *apc.ActMsg) (err error) {
 
 	// TODO: combine copy+delete under a single write lock
 	lom.Lock(true)
+	if err := lom.Fenced(); err != nil { // (keeping the source along with its new copy - see core/lsnap.go)
+		lom.Unlock(true)
+		return err
+	}
+	repl := t.replLog(lom, core.ReplDel)
 	if err := lom.Remove(); err != nil {
 		nlog.Warningf("%s: failed to delete renamed object %s (new name %s): %v", t, lom, msg.Name, err)
+	} else {
+		webhook.Notify(lom, apc.EvtObjDeleted)
 	}
 	lom.Unlock(true)
+	if repl {
+		t.repl(lom, core.ReplDel)
+	}
 	return nil
 }
 
@@ -1441,18 +1534,14 @@ func (t *target) blobdl(lom *core.LOM, oa *cmn.ObjAttrs, args *apc.BlobMsg, w ht
 
 // returns empty xid ("") if nothing to do
 func _blobdl(lom *core.LOM, args *apc.BlobMsg, w http.ResponseWriter, oa *cmn.ObjAttrs) (string, *xs.XactBlobDl, error) {
-	// create wfqn
+	// wfqn (created upon start - see xs.blobFactory)
 	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "blob-dl")
-	lmfh, err := lom.CreateFile(wfqn)
-	if err != nil {
-		return "", nil, err
-	}
 
 	// new
 	xid := cos.GenUUID()
-	rns := xs.RenewBlobDl(xid, lom, oa, wfqn, lmfh, args, w)
+	rns := xs.RenewBlobDl(xid, lom, oa, wfqn, args, w)
 	if rns.Err != nil || rns.IsRunning() { // cmn.IsErrXactUsePrev(rns.Err): single blob-downloader per blob
-		if errRemove := cos.RemoveFile(wfqn); errRemove != nil {
+		if errRemove := cos.RemoveFile(wfqn); errRemove != nil && !os.IsNotExist(errRemove) {
 			nlog.Errorln("nested err", errRemove)
 		}
 		return "", nil, rns.Err
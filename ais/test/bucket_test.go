@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -912,6 +913,56 @@ func TestListObjectsStartAfter(t *testing.T) {
 	})
 }
 
+func TestListObjectsFilter(t *testing.T) {
+	runProviderTests(t, func(t *testing.T, bck *meta.Bck) {
+		var (
+			baseParams = tools.BaseAPIParams()
+			m          = ioContext{
+				t:        t,
+				num:      100,
+				bck:      bck.Clone(),
+				prefix:   "lsfilter-" + trand.String(5) + "/",
+				fileSize: 128,
+			}
+		)
+		if !bck.IsAIS() {
+			m.num = 20
+		}
+		m.init(true /*cleanup*/)
+		m.puts()
+		if m.bck.IsRemote() {
+			defer m.del()
+		}
+		objList, err := api.ListObjects(baseParams, m.bck, &apc.LsoMsg{Prefix: m.prefix}, api.ListArgs{})
+		tassert.CheckFatal(t, err)
+		objName := objList.Entries[m.num/2].Name
+
+		tests := []struct {
+			filter string
+			cnt    int
+		}{
+			{"size = 128", m.num},
+			{"size > 128", 0},
+			{"size <= 1KiB, cksum", m.num},
+			{"name ~ " + strconv.Quote("^"+regexp.QuoteMeta(objName)+"$"), 1},
+			{"name != " + strconv.Quote(objName) + " and size = 128", m.num - 1},
+			{"atime > 1h", m.num},
+			{"meta.nonexistent", 0},
+		}
+		for _, test := range tests {
+			msg := &apc.LsoMsg{Prefix: m.prefix, Filter: test.filter, PageSize: 10}
+			objList, err := api.ListObjects(baseParams, m.bck, msg, api.ListArgs{})
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, len(objList.Entries) == test.cnt, "%q: expected %d entries, got %d",
+				test.filter, test.cnt, len(objList.Entries))
+		}
+
+		// invalid expression
+		_, err = api.ListObjects(baseParams, m.bck, &apc.LsoMsg{Filter: "size ~ 1"}, api.ListArgs{})
+		tassert.Errorf(t, err != nil, "expected invalid filter to fail")
+	})
+}
+
 func TestListObjectsProps(t *testing.T) {
 	runProviderTests(t, func(t *testing.T, bck *meta.Bck) {
 		var (
@@ -3460,13 +3511,7 @@ func TestListObjectsNoRecursion(t *testing.T) {
 		count  int
 	}
 	var (
-		bck = cmn.Bck{
-			Name:     t.Name() + "Bucket",
-			Provider: apc.AIS,
-		}
-		proxyURL   = tools.RandomProxyURL(t)
-		baseParams = tools.BaseAPIParams(proxyURL)
-		objs       = []string{
+		objs = []string{
 			"img001", "vid001",
 			"img-test/obj1", "img-test/vid1", "img-test/pics/obj01",
 			"img003", "img-test/pics/vid01"}
@@ -3479,38 +3524,56 @@ func TestListObjectsNoRecursion(t *testing.T) {
 			{prefix: "img-test/pics/", count: 2},
 		}
 	)
+	runProviderTests(t, func(t *testing.T, bck *meta.Bck) {
+		var (
+			baseParams = tools.BaseAPIParams()
+			root       = "nr-" + trand.String(5) + "/"
+		)
+		for _, nm := range objs {
+			objectSize := int64(rand.Intn(256) + 20)
+			reader, _ := readers.NewRand(objectSize, cos.ChecksumNone)
+			_, err := api.PutObject(&api.PutArgs{
+				BaseParams: baseParams,
+				Bck:        bck.Clone(),
+				ObjName:    root + nm,
+				Reader:     reader,
+			})
+			tassert.CheckFatal(t, err)
+			if bck.IsRemote() {
+				defer api.DeleteObject(baseParams, bck.Clone(), root+nm)
+			}
+		}
 
-	tools.CreateBucket(t, proxyURL, bck, nil, true /*cleanup*/)
-	for _, nm := range objs {
-		objectSize := int64(rand.Intn(256) + 20)
-		reader, _ := readers.NewRand(objectSize, cos.ChecksumNone)
-		_, err := api.PutObject(&api.PutArgs{
-			BaseParams: baseParams,
-			Bck:        bck,
-			ObjName:    nm,
-			Reader:     reader,
-		})
-		tassert.CheckFatal(t, err)
-	}
-
-	msg := &apc.LsoMsg{Props: apc.GetPropsName}
-	lst, err := api.ListObjects(baseParams, bck, msg, api.ListArgs{})
-	tassert.CheckFatal(t, err)
-	tassert.Fatalf(t, len(lst.Entries) == len(objs), "Invalid number of objects %d vs %d", len(lst.Entries), len(objs))
-
-	for idx, tst := range tests {
-		msg := &apc.LsoMsg{Flags: apc.LsNoRecursion, Prefix: tst.prefix, Props: apc.GetPropsName}
-		lst, err := api.ListObjects(baseParams, bck, msg, api.ListArgs{})
+		msg := &apc.LsoMsg{Prefix: root, Props: apc.GetPropsName}
+		lst, err := api.ListObjects(baseParams, bck.Clone(), msg, api.ListArgs{})
 		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(lst.Entries) == len(objs), "Invalid number of objects %d vs %d", len(lst.Entries), len(objs))
 
-		if tst.count == len(lst.Entries) {
-			continue
+		flags := []uint64{apc.LsNoRecursion}
+		if bck.IsRemote() {
+			flags = append(flags, apc.LsNoRecursion|apc.LsObjCached)
 		}
-		tlog.Logf("Failed test #%d (prefix %s). Expected %d, got %d\n",
-			idx, tst.prefix, tst.count, len(lst.Entries))
-		for idx, en := range lst.Entries {
-			tlog.Logf("%d. %s (%v)\n", idx, en.Name, en.Flags)
+		for _, fl := range flags {
+			for idx, tst := range tests {
+				msg := &apc.LsoMsg{Flags: fl, Prefix: root + tst.prefix, Props: apc.GetPropsName}
+				lst, err := api.ListObjects(baseParams, bck.Clone(), msg, api.ListArgs{})
+				tassert.CheckFatal(t, err)
+
+				if tst.count == len(lst.Entries) {
+					if tst.prefix == "img-test" {
+						en := lst.Entries[0]
+						tassert.Errorf(t, en.IsDir() && en.Name == root+tst.prefix, "expected directory %q, got %s(%v)",
+							root+tst.prefix, en.Name, en.Flags)
+					}
+					continue
+				}
+				tlog.Logf("Failed test #%d (prefix %s, cached %t). Expected %d, got %d\n",
+					idx, tst.prefix, fl&apc.LsObjCached != 0, tst.count, len(lst.Entries))
+				for idx, en := range lst.Entries {
+					tlog.Logf("%d. %s (%v)\n", idx, en.Name, en.Flags)
+				}
+				tassert.Errorf(t, false, "[%s] Invalid number of objects %d (expected %d)", tst.prefix, len(lst.Entries), tst.count)
+			}
 		}
-		tassert.Errorf(t, false, "[%s] Invalid number of objects %d (expected %d)", tst.prefix, len(lst.Entries), tst.count)
-	}
+	})
 }
@@ -5,6 +5,7 @@
 package integration_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"encoding/hex"
 	"fmt"
@@ -297,6 +298,220 @@ func TestAppendObject(t *testing.T) {
 	}
 }
 
+func TestComposeObject(t *testing.T) {
+	var (
+		proxyURL   = tools.RandomProxyURL(t)
+		baseParams = tools.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		bckSrc     = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		parts      = []string{"1111111111", "222222222222222", "333333333"}
+		objName    = "composed"
+	)
+	tools.CreateBucket(t, proxyURL, bck, nil, true /*cleanup*/)
+	tools.CreateBucket(t, proxyURL, bckSrc, nil, true /*cleanup*/)
+
+	msg := cmn.ComposeMsg{}
+	for i, part := range parts {
+		src := cmn.ComposeSrc{ObjName: fmt.Sprintf("part-%d", i)}
+		if i%2 == 1 {
+			src.Bck = bckSrc
+		}
+		to := src.Bck
+		if to.IsEmpty() {
+			to = bck
+		}
+		_, err := api.PutObject(&api.PutArgs{
+			BaseParams: baseParams,
+			Bck:        to,
+			ObjName:    src.ObjName,
+			Reader:     readers.NewBytes([]byte(part)),
+		})
+		tassert.CheckFatal(t, err)
+		msg.Sources = append(msg.Sources, src)
+	}
+	// plus a byte range (of the 2nd part)
+	msg.Sources = append(msg.Sources, cmn.ComposeSrc{Bck: bckSrc, ObjName: "part-1", Offset: 3, Length: 5})
+	content := strings.Join(parts, "") + parts[1][3:8]
+
+	tassert.CheckFatal(t, api.ComposeObject(baseParams, bck, objName, &msg))
+
+	writer := bytes.NewBuffer(nil)
+	oah, err := api.GetObjectWithValidation(baseParams, bck, objName, &api.GetArgs{Writer: writer})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, writer.String() == content, "invalid composed content: [%d](%s), expected: [%d](%s)",
+		oah.Size(), writer.String(), len(content), content)
+
+	// the destination is one of the sources
+	msg.Sources = []cmn.ComposeSrc{{ObjName: objName}, {ObjName: "part-0"}}
+	tassert.CheckFatal(t, api.ComposeObject(baseParams, bck, objName, &msg))
+	writer.Reset()
+	_, err = api.GetObjectWithValidation(baseParams, bck, objName, &api.GetArgs{Writer: writer})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, writer.String() == content+parts[0], "invalid composed content %q", writer.String())
+
+	// invalid range: must fail and leave the destination intact
+	msg.Sources = []cmn.ComposeSrc{{ObjName: "part-0", Offset: 5, Length: 100}}
+	err = api.ComposeObject(baseParams, bck, objName, &msg)
+	tassert.Errorf(t, err != nil, "expected compose to fail (invalid range)")
+	props, err := api.HeadObject(baseParams, bck, objName, apc.FltPresent, false)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, props.Size == int64(len(content+parts[0])), "unexpected size %d after failed compose", props.Size)
+}
+
+func TestRenamePrefix(t *testing.T) {
+	const (
+		num  = 50
+		from = "tmp/run42/"
+		to   = "final/run42/"
+	)
+	var (
+		proxyURL   = tools.RandomProxyURL(t)
+		baseParams = tools.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+	)
+	tools.CreateBucket(t, proxyURL, bck, nil, true /*cleanup*/)
+
+	for i := range num {
+		_, err := api.PutObject(&api.PutArgs{
+			BaseParams: baseParams,
+			Bck:        bck,
+			ObjName:    fmt.Sprintf("%sobj-%d", from, i),
+			Reader:     readers.NewBytes([]byte(strconv.Itoa(i))),
+		})
+		tassert.CheckFatal(t, err)
+	}
+	_, err := api.PutObject(&api.PutArgs{BaseParams: baseParams, Bck: bck, ObjName: "tmp/other", Reader: readers.NewBytes([]byte("x"))})
+	tassert.CheckFatal(t, err)
+
+	// overlapping prefixes
+	_, err = api.RenamePrefix(baseParams, bck, &cmn.RenamePrefixMsg{From: from, To: from + "sub/"})
+	tassert.Errorf(t, err != nil, "expected rename to fail (overlapping prefixes)")
+
+	xid, err := api.RenamePrefix(baseParams, bck, &cmn.RenamePrefixMsg{From: from, To: to})
+	tassert.CheckFatal(t, err)
+	args := xact.ArgsMsg{ID: xid, Kind: apc.ActRenamePrefix, Timeout: tools.RebalanceTimeout}
+	_, err = api.WaitForXactionIC(baseParams, &args)
+	tassert.CheckFatal(t, err)
+
+	// wait for the commit phase (removing old names) to finish
+	deadline := time.Now().Add(tools.RebalanceTimeout)
+	for {
+		props, err := api.HeadBucket(baseParams, bck, true /* don't add */)
+		tassert.CheckFatal(t, err)
+		if props.RenPrefix == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", props.RenPrefix)
+		}
+		time.Sleep(time.Second)
+	}
+
+	lst, err := api.ListObjects(baseParams, bck, &apc.LsoMsg{Prefix: to}, api.ListArgs{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(lst.Entries) == num, "expected %d objects under %q, got %d", num, to, len(lst.Entries))
+	lst, err = api.ListObjects(baseParams, bck, &apc.LsoMsg{Prefix: "tmp/"}, api.ListArgs{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(lst.Entries) == 1, "expected only tmp/other to remain, got %d", len(lst.Entries))
+
+	writer := bytes.NewBuffer(nil)
+	_, err = api.GetObjectWithValidation(baseParams, bck, to+"obj-7", &api.GetArgs{Writer: writer})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, writer.String() == "7", "invalid content %q", writer.String())
+
+	// the destination exists
+	_, err = api.PutObject(&api.PutArgs{BaseParams: baseParams, Bck: bck, ObjName: from + "new", Reader: readers.NewBytes([]byte("y"))})
+	tassert.CheckFatal(t, err)
+	_, err = api.RenamePrefix(baseParams, bck, &cmn.RenamePrefixMsg{From: from, To: to})
+	tassert.Errorf(t, err != nil, "expected rename to fail (destination exists)")
+}
+
+func TestGetBatch(t *testing.T) {
+	var (
+		proxyURL   = tools.RandomProxyURL(t)
+		baseParams = tools.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		bckOther   = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		msg        = cmn.GetBatchMsg{}
+		names      []string
+		contents   []string
+	)
+	tools.CreateBucket(t, proxyURL, bck, nil, true /*cleanup*/)
+	tools.CreateBucket(t, proxyURL, bckOther, nil, true /*cleanup*/)
+
+	for i := range 20 {
+		in := cmn.GetBatchIn{ObjName: fmt.Sprintf("obj-%02d", i)}
+		if i%3 == 0 {
+			in.Bck = bckOther
+		}
+		to := in.Bck
+		if to.IsEmpty() {
+			to = bck
+		}
+		content := trand.String(10 + i)
+		_, err := api.PutObject(&api.PutArgs{
+			BaseParams: baseParams,
+			Bck:        to,
+			ObjName:    in.ObjName,
+			Reader:     readers.NewBytes([]byte(content)),
+		})
+		tassert.CheckFatal(t, err)
+		msg.In = append(msg.In, in)
+		names = append(names, in.NameInArch(&bck))
+		contents = append(contents, content)
+	}
+
+	// plus a file archived in a shard
+	var (
+		shard    = bytes.NewBuffer(nil)
+		tw       = tar.NewWriter(shard)
+		archpath = "a/b/c.txt"
+		archived = trand.String(100)
+	)
+	tassert.CheckFatal(t, tw.WriteHeader(&tar.Header{Name: archpath, Mode: 0o644, Size: int64(len(archived))}))
+	_, err := tw.Write([]byte(archived))
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, tw.Close())
+	_, err = api.PutObject(&api.PutArgs{
+		BaseParams: baseParams,
+		Bck:        bck,
+		ObjName:    "shard.tar",
+		Reader:     readers.NewBytes(shard.Bytes()),
+	})
+	tassert.CheckFatal(t, err)
+	in := cmn.GetBatchIn{ObjName: "shard.tar", ArchPath: archpath}
+	msg.In = append(msg.In, in)
+	names = append(names, in.NameInArch(&bck))
+	contents = append(contents, archived)
+
+	checkBatch := func(msg *cmn.GetBatchMsg, names, contents []string) {
+		out := bytes.NewBuffer(nil)
+		_, err := api.GetBatch(baseParams, bck, msg, out)
+		tassert.CheckFatal(t, err)
+		tr := tar.NewReader(out)
+		for i := range names {
+			hdr, err := tr.Next()
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, hdr.Name == names[i], "entry #%d: expected %q, got %q", i, names[i], hdr.Name)
+			b, err := io.ReadAll(tr)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, string(b) == contents[i], "entry %q: invalid content", hdr.Name)
+		}
+		_, err = tr.Next()
+		tassert.Errorf(t, err == io.EOF, "expected end of archive, got %v", err)
+	}
+	checkBatch(&msg, names, contents)
+
+	// missing entry: fail
+	msg.In = []cmn.GetBatchIn{msg.In[0], {ObjName: "does-not-exist"}, msg.In[1]}
+	_, err = api.GetBatch(baseParams, bck, &msg, io.Discard)
+	tassert.Errorf(t, err != nil, "expected batch GET to fail (missing entry)")
+
+	// ditto: skip
+	msg.SkipMissing = true
+	checkBatch(&msg, names[:2], contents[:2])
+}
+
 func TestSameBucketName(t *testing.T) {
 	var (
 		proxyURL   = tools.RandomProxyURL(t)
@@ -0,0 +1,422 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// batch GET: multiple objects and/or archived files => single archive (see cmn.GetBatchMsg)
+// - the proxy designates the target that owns the most entries (see prxbatch.go)
+// - entries are written in the specified order: local ones directly, all the rest - from their
+//   respective (HRW) targets via intra-cluster data network
+// - remote entries are fetched ahead of time (up to getBatchWindow entries ahead of the one
+//   being written): in parallel across targets, and in order - one at a time - from each target;
+//   fetched entries of up to getBatchMaxBuf size are buffered in memory, larger ones are streamed
+// - missing entries are either skipped or fail the entire request (cmn.GetBatchMsg.SkipMissing);
+//   failing in the middle of the stream aborts the connection, so that the client never
+//   receives a seemingly complete (but in fact, truncated) archive
+
+const (
+	getBatchWindow = 32
+	getBatchMaxBuf = 4 * cos.MiB
+)
+
+type (
+	getBatch struct {
+		t       *target
+		w       http.ResponseWriter
+		r       *http.Request
+		bck     *meta.Bck
+		msg     *cmn.GetBatchMsg
+		config  *cmn.Config
+		aw      archive.Writer
+		workers map[string]chan *batchEntry // by target ID
+		mime    string
+		atime   int64
+		wg      sync.WaitGroup
+		stopped atomic.Bool
+	}
+	batchEntry struct {
+		r       io.Reader
+		in      *cmn.GetBatchIn
+		lom     *core.LOM
+		tsi     *meta.Snode        // remote (nil when local)
+		done    chan struct{}      // remote: fetched (see getBatch.dispatch)
+		fh      core.LomReader     // local
+		csl     cos.ReadCloseSizer // local archived file
+		resp    *http.Response     // remote
+		sgl     *memsys.SGL        // remote response: buffered or of unknown size
+		cancel  context.CancelFunc
+		err     error
+		errCode int
+		size    int64
+	}
+)
+
+// GET /v1/batch/bucket-name
+func (t *target) batchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	apireq := apiReqAlloc(1, apc.URLPathBatch.L, false)
+	if err := t.parseReq(w, r, apireq); err == nil {
+		t.httpbatchget(w, r, apireq.bck)
+	}
+	apiReqFree(apireq)
+}
+
+func (t *target) httpbatchget(w http.ResponseWriter, r *http.Request, bck *meta.Bck) {
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	mime, err := archive.Mime(msg.Mime, "")
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if err := bck.Init(t.owner.bmd); err != nil {
+		if cmn.IsErrRemoteBckNotFound(err) {
+			t.BMDVersionFixup(r)
+			err = bck.Init(t.owner.bmd)
+		}
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
+	gb := &getBatch{t: t, w: w, r: r, bck: bck, msg: msg, config: cmn.GCO.Get(), mime: mime, atime: time.Now().UnixNano()}
+	gb.run()
+}
+
+//////////////
+// getBatch //
+//////////////
+
+func (gb *getBatch) run() {
+	var (
+		t       = gb.t
+		started = mono.NanoTime()
+		smap    = t.owner.smap.get()
+		entries = make([]*batchEntry, len(gb.msg.In))
+		next    int // next to dispatch
+		n, size int64
+	)
+	for i := range gb.msg.In {
+		entries[i] = gb.resolve(&gb.msg.In[i], smap)
+	}
+	defer gb.cleanup(entries)
+
+	for i, e := range entries {
+		for ; next < min(i+getBatchWindow, len(entries)); next++ {
+			gb.dispatch(entries[next])
+		}
+		errCode, err := gb.open(e)
+		if err != nil {
+			if gb.msg.SkipMissing && cos.IsNotExist(err, errCode) {
+				if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+					nlog.Infoln(t.String(), "get-batch: skipping", e.in.NameInArch(gb.bck.Bucket()), err)
+				}
+				e.close()
+				entries[i] = nil
+				continue
+			}
+			gb.fail(err, errCode)
+			return
+		}
+		gb.begin()
+		err = gb.aw.Write(e.in.NameInArch(gb.bck.Bucket()), cos.SimpleOAH{Size: e.size, Atime: gb.atime}, e.r)
+		if err == nil {
+			e.touch(gb.atime)
+		}
+		e.close()
+		entries[i] = nil
+		if err != nil {
+			gb.fail(err, 0)
+			return
+		}
+		n++
+		size += e.size
+	}
+	gb.begin() // (in the unlikely case all entries were skipped)
+	gb.aw.Fini()
+
+	t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.GetBatchCount, Value: 1},
+		cos.NamedVal64{Name: stats.GetBatchObjCount, Value: n},
+		cos.NamedVal64{Name: stats.GetBatchSize, Value: size},
+	)
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(t.String(), "get-batch", gb.bck.Cname(""), "entries:", n, "size:", size,
+			"latency:", mono.SinceNano(started))
+	}
+}
+
+// upon the first successfully opened entry: respond and start writing the archive
+func (gb *getBatch) begin() {
+	if gb.aw != nil {
+		return
+	}
+	gb.w.Header().Set(cos.HdrContentType, cos.ContentBinary)
+	gb.w.Header().Set(apc.HdrArchmime, gb.mime)
+	gb.w.WriteHeader(http.StatusOK)
+	gb.aw = archive.NewWriter(gb.mime, gb.w, nil /*cksum*/, nil /*opts*/)
+}
+
+func (gb *getBatch) fail(err error, errCode int) {
+	gb.t.statsT.IncErr(stats.ErrGetBatchCount)
+	if gb.aw == nil {
+		gb.t.writeErr(gb.w, gb.r, err, errCode)
+		return
+	}
+	// already streaming: abort the connection (not finalizing the archive)
+	nlog.Errorln(gb.t.String(), "get-batch", gb.bck.Cname(""), "aborted:", err)
+	panic(http.ErrAbortHandler)
+}
+
+func (gb *getBatch) resolve(in *cmn.GetBatchIn, smap *smapX) *batchEntry {
+	bck := in.Bck
+	if bck.IsEmpty() {
+		bck = *gb.bck.Bucket()
+	}
+	e := &batchEntry{in: in, lom: core.AllocLOM(in.ObjName)}
+	if e.err = e.lom.InitBck(&bck); e.err == nil {
+		var local bool
+		if e.tsi, local, e.err = e.lom.HrwTarget(&smap.Smap); local {
+			e.tsi = nil
+		}
+	}
+	return e
+}
+
+// remote entry => the respective target's worker (started upon the first one)
+func (gb *getBatch) dispatch(e *batchEntry) {
+	if e.err != nil || e.tsi == nil {
+		return
+	}
+	if gb.workers == nil {
+		gb.workers = make(map[string]chan *batchEntry, 4)
+	}
+	ch, ok := gb.workers[e.tsi.ID()]
+	if !ok {
+		ch = make(chan *batchEntry, getBatchWindow)
+		gb.workers[e.tsi.ID()] = ch
+		gb.wg.Add(1)
+		go gb.fetch(ch)
+	}
+	e.done = make(chan struct{})
+	ch <- e
+}
+
+func (gb *getBatch) fetch(ch chan *batchEntry) {
+	for e := range ch {
+		if !gb.stopped.Load() {
+			e.errCode, e.err = e.openRemote(gb.t, e.tsi, e.in, gb.config, true /*prefetch*/)
+		}
+		close(e.done)
+	}
+	gb.wg.Done()
+}
+
+func (gb *getBatch) open(e *batchEntry) (int, error) {
+	if e.done != nil {
+		<-e.done
+	}
+	errCode, err := e.errCode, e.err
+	if err == nil && e.done == nil {
+		if err = e.openLocal(gb.t, e.in); err == errBatchGET {
+			// via (local) GET: cold GET, tiered object, misplaced object, etc.
+			errCode, err = e.openRemote(gb.t, gb.t.si, e.in, gb.config, false)
+		}
+	}
+	if err != nil {
+		return errCode, fmt.Errorf("get-batch: %s: %w", e.in.NameInArch(gb.bck.Bucket()), err)
+	}
+	return 0, nil
+}
+
+// stop workers and close the entries that were not written (failure or abort)
+// - when any are still being fetched - asynchronously, once fetched
+func (gb *getBatch) cleanup(entries []*batchEntry) {
+	gb.stopped.Store(true)
+	for _, ch := range gb.workers {
+		close(ch)
+	}
+	closeAll := func() {
+		for _, e := range entries {
+			if e != nil {
+				e.close()
+			}
+		}
+	}
+	for _, e := range entries {
+		if e != nil && e.done != nil {
+			go func() {
+				gb.wg.Wait()
+				closeAll()
+			}()
+			return
+		}
+	}
+	closeAll()
+}
+
+////////////////
+// batchEntry //
+////////////////
+
+// (local object that must be read via regular GET)
+var errBatchGET = errors.New("get-batch: use GET")
+
+// read-locked for the duration
+func (e *batchEntry) openLocal(t *target, in *cmn.GetBatchIn) (err error) {
+	lom := e.lom
+	lom.Lock(false)
+	if err = lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cos.IsNotExist(err, 0) {
+			err = errBatchGET
+		}
+		return err
+	}
+	if lom.IsTiered() {
+		lom.Unlock(false)
+		return errBatchGET
+	}
+	if e.fh, err = lom.Open(); err != nil { // (decoding, if need be)
+		lom.Unlock(false)
+		return err
+	}
+	if in.ArchPath == "" {
+		e.r, e.size = e.fh, lom.SizeBytes()
+		return nil
+	}
+	var mime string
+	if e.csl, _, mime = lom.OpenIndexed(e.fh, "", in.ArchPath); mime == "" {
+		if mime, err = archive.MimeFile(e.fh, t.smm, "", lom.ObjName); err != nil {
+			return err
+		}
+		ar, err := archive.NewReader(mime, e.fh, lom.SizeBytes())
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+		}
+		if e.csl, err = ar.Range(in.ArchPath, nil); err != nil {
+			return cmn.NewErrFailedTo(t, "extract "+in.ArchPath+" from", lom, err)
+		}
+	}
+	if e.csl == nil {
+		return cos.NewErrNotFound(t, in.ArchPath+" in "+lom.Cname())
+	}
+	e.r, e.size = e.csl, e.csl.Size()
+	return nil
+}
+
+func (e *batchEntry) openRemote(t *target, tsi *meta.Snode, in *cmn.GetBatchIn, config *cmn.Config, prefetch bool) (int, error) {
+	var (
+		lom     = e.lom
+		reqArgs = cmn.AllocHra()
+	)
+	{
+		reqArgs.Method = http.MethodGet
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{t.SID()},
+			apc.HdrCallerName: []string{t.callerName()},
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
+		reqArgs.Query = lom.Bck().NewQuery()
+		if in.ArchPath != "" {
+			reqArgs.Query.Set(apc.QparamArchpath, in.ArchPath)
+		}
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(config.Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return 0, err
+	}
+	e.cancel = cancel
+	e.resp, err = g.client.data.Do(req) //nolint:bodyclose // see e.close()
+	if err != nil {
+		return 0, err
+	}
+	if code := e.resp.StatusCode; code != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(e.resp.Body, maxErrBody))
+		if herr := cmn.Str2HTTPErr(string(b)); herr != nil {
+			return code, herr
+		}
+		return code, fmt.Errorf("%s responded with status %d", tsi.StringEx(), code)
+	}
+	if e.size = e.resp.ContentLength; e.size >= 0 && (!prefetch || e.size > getBatchMaxBuf) {
+		e.r = e.resp.Body
+		return 0, nil
+	}
+	// (unknown size or prefetching)
+	e.sgl = t.gmm.NewSGL(max(e.size, 0))
+	if e.size, err = io.Copy(e.sgl, e.resp.Body); err != nil {
+		return 0, err
+	}
+	e.r = e.sgl
+	return 0, nil
+}
+
+// update access time (local objects only)
+func (e *batchEntry) touch(atime int64) {
+	if e.fh == nil {
+		return
+	}
+	e.lom.SetAtimeUnix(atime)
+	e.lom.IncAccess()
+	e.lom.Recache()
+}
+
+func (e *batchEntry) closeLocal() {
+	if e.csl != nil {
+		cos.Close(e.csl)
+		e.csl = nil
+	}
+	if e.fh != nil {
+		cos.Close(e.fh)
+		e.fh = nil
+		e.lom.Unlock(false)
+	}
+}
+
+func (e *batchEntry) close() {
+	e.closeLocal()
+	if e.resp != nil {
+		cos.Close(e.resp.Body)
+	}
+	if e.sgl != nil {
+		e.sgl.Free()
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	core.FreeLOM(e.lom)
+}
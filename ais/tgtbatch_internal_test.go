@@ -0,0 +1,168 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// entries owned by other targets are fetched in parallel across targets (and one at a time
+// from each), and written in the specified order
+func TestGetBatchRemote(t *testing.T) {
+	const (
+		numRemote = 3
+		perTarget = 3
+		delay     = 50 * time.Millisecond
+	)
+	var (
+		tgt      = core.T.(*target)
+		bck      = meta.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
+		smap     = newSmap()
+		inflight atomic.Int32
+		maxIn    atomic.Int32
+		perTsi   = make(map[string]*atomic.Int32, numRemote)
+	)
+	// remote targets: serve object's name as its content
+	smap.Tmap[tgt.SID()] = tgt.si
+	for i := range numRemote {
+		id := fmt.Sprintf("remote-%d", i)
+		cnt := &atomic.Int32{}
+		perTsi[id] = cnt
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inflight.Inc()
+			defer inflight.Dec()
+			for m := maxIn.Load(); n > m && !maxIn.CAS(m, n); m = maxIn.Load() {
+			}
+			tassert.Errorf(t, cnt.Inc() == 1, "%s: expecting one request at a time", id)
+			defer cnt.Dec()
+			time.Sleep(delay)
+
+			objName := path.Base(r.URL.Path)
+			if strings.HasPrefix(objName, "missing") {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			w.Header().Set(cos.HdrContentLength, fmt.Sprint(len(objName)))
+			w.Write([]byte(objName))
+		}))
+		t.Cleanup(ts.Close)
+		host, port, _ := net.SplitHostPort(ts.Listener.Addr().String())
+		ni := meta.NetInfo{}
+		ni.Init("http", host, port)
+		smap.Tmap[id] = newSnode(id, apc.Target, ni, ni, ni)
+	}
+	smap.Version = 2
+	smap0, client := tgt.owner.smap.get(), g.client.data
+	if smap0 == nil {
+		smap0 = newSmap()
+	}
+	t.Cleanup(func() { tgt.owner.smap.put(smap0); g.client.data = client })
+	tgt.owner.smap.put(smap)
+	g.client.data = &http.Client{}
+	config := cmn.GCO.BeginUpdate()
+	sendFile := config.Timeout.SendFile
+	config.Timeout.SendFile = cos.Duration(time.Minute)
+	cmn.GCO.CommitUpdate(config)
+	t.Cleanup(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Timeout.SendFile = sendFile
+		cmn.GCO.CommitUpdate(config)
+	})
+
+	// entries: one local, followed by interleaved remote ones (and a missing one)
+	var (
+		msg   = &cmn.GetBatchMsg{SkipMissing: true}
+		count = make(map[string]int, numRemote+1)
+		local string
+	)
+	for i := 0; len(msg.In) < numRemote*perTarget || local == ""; i++ {
+		objName := fmt.Sprintf("batch-obj-%d", i)
+		tsi, err := smap.HrwName2T(bck.MakeUname(objName))
+		tassert.CheckFatal(t, err)
+		switch {
+		case tsi.ID() == tgt.SID():
+			if local == "" {
+				local = objName
+			}
+		case count[tsi.ID()] < perTarget:
+			count[tsi.ID()]++
+			msg.In = append(msg.In, cmn.GetBatchIn{ObjName: objName})
+		}
+	}
+	msg.In = append([]cmn.GetBatchIn{{ObjName: local}}, msg.In...)
+	for i := 0; ; i++ {
+		objName := fmt.Sprintf("missing-%d", i)
+		if tsi, _ := smap.HrwName2T(bck.MakeUname(objName)); tsi.ID() != tgt.SID() {
+			msg.In = append(msg.In[:2], append([]cmn.GetBatchIn{{ObjName: objName}}, msg.In[2:]...)...)
+			break
+		}
+	}
+
+	lom := core.AllocLOM(local)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       tgt,
+		lom:     lom,
+		r:       io.NopCloser(strings.NewReader(local)),
+		workFQN: path.Join(testMountpath, local+".work"),
+		config:  cmn.GCO.Get(),
+		owt:     cmn.OwtPut,
+		skipEC:  true,
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(t, err)
+	core.FreeLOM(lom)
+
+	// run
+	mime, err := archive.Mime(archive.ExtTar, "")
+	tassert.CheckFatal(t, err)
+	var (
+		w       = httptest.NewRecorder()
+		gb      = &getBatch{t: tgt, w: w, r: httptest.NewRequest(http.MethodGet, "/", http.NoBody), bck: bck, msg: msg, config: cmn.GCO.Get(), mime: mime}
+		started = time.Now()
+	)
+	gb.run()
+	elapsed := time.Since(started)
+	tassert.Fatalf(t, w.Code == http.StatusOK, "expected status 200, got %d", w.Code)
+
+	tr := tar.NewReader(w.Body)
+	for i := range msg.In {
+		objName := msg.In[i].ObjName
+		if strings.HasPrefix(objName, "missing") {
+			continue
+		}
+		hdr, err := tr.Next()
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, hdr.Name == msg.In[i].NameInArch(bck.Bucket()), "entry %d: expected %q, got %q", i, objName, hdr.Name)
+		b, err := io.ReadAll(tr)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, string(b) == objName, "entry %d: content mismatch: %q", i, b)
+	}
+	_, err = tr.Next()
+	tassert.Errorf(t, err == io.EOF, "expected EOF, got %v", err)
+
+	tassert.Errorf(t, maxIn.Load() > 1, "expected parallel requests, got at most %d", maxIn.Load())
+	sequential := time.Duration(numRemote*perTarget+1) * delay
+	tassert.Errorf(t, elapsed < sequential, "expected less than %v, got %v", sequential, elapsed)
+}
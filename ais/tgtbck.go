@@ -424,7 +424,7 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request, apireq *api
 	if err != nil {
 		return
 	}
-	if msg.Action != apc.ActPrefetchObjects {
+	if msg.Action != apc.ActPrefetchObjects && msg.Action != apc.ActExportList {
 		t.writeErrAct(w, r, msg.Action)
 		return
 	}
@@ -438,6 +438,18 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request, apireq *api
 		return
 	}
 
+	if msg.Action == apc.ActExportList {
+		expMsg := &cmn.ExportListMsg{}
+		if err := cos.MorphMarshal(msg.Value, expMsg); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := t.runExportList(msg.UUID, apireq.bck, expMsg); err != nil {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+
 	prfMsg := &apc.PrefetchMsg{}
 	if err := cos.MorphMarshal(msg.Value, prfMsg); err != nil {
 		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
@@ -470,6 +482,23 @@ func (t *target) runPrefetch(xactID string, bck *meta.Bck, prfMsg *apc.PrefetchM
 	return 0, nil
 }
 
+// handle apc.ActExportList <-- via api.ExportList and api.StartX*
+func (t *target) runExportList(xactID string, bck *meta.Bck, msg *cmn.ExportListMsg) error {
+	rns := xreg.RenewExportList(xactID, bck, msg)
+	if rns.Err != nil {
+		return rns.Err
+	}
+	xctn := rns.Entry.Get()
+	notif := &xact.NotifXact{
+		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
+		Xact: xctn,
+	}
+	xctn.AddNotif(notif)
+
+	xact.GoRunW(xctn)
+	return nil
+}
+
 // HEAD /v1/buckets/bucket-name
 func (t *target) httpbckhead(w http.ResponseWriter, r *http.Request, apireq *apiRequest) {
 	var (
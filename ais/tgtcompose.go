@@ -0,0 +1,355 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// compose (concatenate) source objects and/or byte ranges into a single destination object
+// - all sources get checked up front (size and range) so that a bad one fails the request
+//   before anything is written
+// - sources are read in the specified order: local ones directly, all the rest - from their
+//   respective (HRW) targets via intra-cluster data network (GET, with byte range if need be)
+// - the content is written into a workfile and gets committed atomically upon success
+//   (see poi.finalize); the destination checksum is computed as per its bucket's configuration
+
+const maxErrBody = 4 * cos.KiB
+
+type (
+	composeReader struct {
+		t      *target
+		dst    *core.LOM
+		config *cmn.Config
+		srcs   []cmn.ComposeSrc
+		cur    *composeSrc // being read
+		idx    int         // next source
+	}
+	composeSrc struct {
+		r      io.Reader
+		lom    *core.LOM
+		fh     core.LomReader // local
+		resp   *http.Response // remote
+		cancel context.CancelFunc
+		size   int64 // expected (-1 if unknown)
+		read   int64
+	}
+)
+
+// interface guard
+var _ io.ReadCloser = (*composeReader)(nil)
+
+func (t *target) compose(lom *core.LOM, msg *cmn.ComposeMsg) (int, error) {
+	if err := msg.Validate(); err != nil {
+		return http.StatusBadRequest, err
+	}
+	cr := &composeReader{t: t, dst: lom, config: cmn.GCO.Get(), srcs: msg.Sources}
+	if errCode, err := cr.check(); err != nil {
+		return errCode, err
+	}
+	poi := allocPOI()
+	{
+		poi.t = t
+		poi.lom = lom
+		poi.config = cr.config
+		poi.r = cr
+		poi.owt = cmn.OwtPut
+		poi.workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCompose)
+		poi.atime = time.Now().UnixNano()
+	}
+	errCode, err := poi.putObject()
+	freePOI(poi)
+	if err != nil {
+		return errCode, err
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(t.String(), "composed", lom.Cname(), "from", len(msg.Sources), "sources, size", lom.SizeBytes())
+	}
+	return 0, nil
+}
+
+///////////////////
+// composeReader //
+///////////////////
+
+func (cr *composeReader) Read(b []byte) (n int, err error) {
+	for {
+		if cr.cur == nil {
+			if cr.idx >= len(cr.srcs) {
+				return 0, io.EOF
+			}
+			if cr.cur, err = cr.open(&cr.srcs[cr.idx]); err != nil {
+				return 0, err
+			}
+			cr.idx++
+		}
+		n, err = cr.cur.r.Read(b)
+		cr.cur.read += int64(n)
+		if err != io.EOF {
+			return n, err
+		}
+		err = cr.cur.eof()
+		cr.cur.close()
+		cr.cur = nil
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+func (cr *composeReader) Close() error {
+	if cr.cur != nil {
+		cr.cur.close()
+		cr.cur = nil
+	}
+	return nil
+}
+
+// check that all sources exist and all ranges are satisfiable (HEAD remote ones)
+func (cr *composeReader) check() (int, error) {
+	smap := cr.t.owner.smap.get()
+	for i := range cr.srcs {
+		src := &cr.srcs[i]
+		lom, tsi, local, err := cr.initLOM(src, smap)
+		if err != nil {
+			if cmn.IsErrBucketNought(err) {
+				return http.StatusNotFound, err
+			}
+			return http.StatusBadRequest, err
+		}
+		size, errCode, err := cr.size(lom, tsi, local, smap)
+		if err == nil {
+			err = rangeErr(src, size)
+			errCode = http.StatusRequestedRangeNotSatisfiable
+		}
+		if err != nil {
+			err = fmt.Errorf("compose %s: source %s: %w", cr.dst.Cname(), lom.Cname(), err)
+			if errCode == 0 {
+				errCode = http.StatusInternalServerError
+			}
+		}
+		core.FreeLOM(lom)
+		if err != nil {
+			return errCode, err
+		}
+	}
+	return 0, nil
+}
+
+func (cr *composeReader) initLOM(src *cmn.ComposeSrc, smap *smapX) (*core.LOM, *meta.Snode, bool, error) {
+	bck := src.Bck
+	if bck.IsEmpty() {
+		bck = *cr.dst.Bucket()
+	}
+	lom := core.AllocLOM(src.ObjName)
+	if err := lom.InitBck(&bck); err != nil {
+		core.FreeLOM(lom)
+		return nil, nil, false, err
+	}
+	tsi, local, err := lom.HrwTarget(&smap.Smap)
+	if err != nil {
+		core.FreeLOM(lom)
+		return nil, nil, false, err
+	}
+	return lom, tsi, local, nil
+}
+
+func (cr *composeReader) size(lom *core.LOM, tsi *meta.Snode, local bool, smap *smapX) (int64, int, error) {
+	if local {
+		err := lom.Load(true /*cache it*/, false /*locked*/)
+		if err == nil {
+			return lom.SizeBytes(), 0, nil
+		}
+		if !cos.IsNotExist(err, 0) {
+			return 0, http.StatusInternalServerError, err
+		}
+		if !lom.Bck().IsRemote() {
+			return 0, http.StatusNotFound, err
+		}
+		oa, errCode, err := cr.t.Backend(lom.Bck()).HeadObj(context.Background(), lom) // (cold)
+		if err != nil {
+			return 0, errCode, err
+		}
+		return oa.Size, 0, nil
+	}
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodHead,
+			Header: http.Header{
+				apc.HdrCallerID:   []string{cr.t.SID()},
+				apc.HdrCallerName: []string{cr.t.callerName()},
+			},
+			Base:  tsi.URL(cmn.NetIntraControl),
+			Path:  apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName),
+			Query: lom.Bck().NewQuery(),
+		}
+		cargs.timeout = cmn.Rom.CplaneOperation()
+	}
+	res := cr.t.call(cargs, smap)
+	freeCargs(cargs)
+	var (
+		oa           cmn.ObjAttrs
+		errCode, err = res.status, res.err
+	)
+	if err == nil {
+		oa.FromHeader(res.header) // (no content length when empty)
+	}
+	freeCR(res)
+	return oa.Size, errCode, err
+}
+
+// (a non-empty range must start inside the object)
+func rangeErr(src *cmn.ComposeSrc, size int64) error {
+	if !src.IsRange() {
+		return nil
+	}
+	if src.Offset < size && src.Offset+src.Length <= size {
+		return nil
+	}
+	rng := strconv.FormatInt(src.Offset, 10) + "-"
+	if src.Length > 0 {
+		rng += strconv.FormatInt(src.Offset+src.Length-1, 10)
+	}
+	return cmn.NewErrRangeNotSatisfiable(nil, []string{rng}, size)
+}
+
+func (cr *composeReader) open(src *cmn.ComposeSrc) (*composeSrc, error) {
+	smap := cr.t.owner.smap.get()
+	lom, tsi, local, err := cr.initLOM(src, smap)
+	if err != nil {
+		return nil, err
+	}
+	cs := &composeSrc{lom: lom, size: -1}
+	if local {
+		err = cs.openLocal(src)
+		if err != nil && cos.IsNotExist(err, 0) && lom.Bck().IsRemote() {
+			err = cs.openRemote(cr.t, cr.t.si, src, cr.config) // (cold GET)
+		}
+	} else {
+		err = cs.openRemote(cr.t, tsi, src, cr.config)
+	}
+	if err != nil {
+		cs.close()
+		return nil, fmt.Errorf("compose %s: source %s: %w", cr.dst.Cname(), lom.Cname(), err)
+	}
+	return cs, nil
+}
+
+////////////////
+// composeSrc //
+////////////////
+
+// read-locked for the duration
+func (cs *composeSrc) openLocal(src *cmn.ComposeSrc) (err error) {
+	lom := cs.lom
+	lom.Lock(false)
+	if err = lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		return err
+	}
+	size := lom.SizeBytes()
+	if err = rangeErr(src, size); err != nil { // (in case it has changed since cr.check)
+		lom.Unlock(false)
+		return err
+	}
+	if cs.fh, err = lom.Open(); err != nil { // (decoding, if need be)
+		lom.Unlock(false)
+		return err
+	}
+	cs.size = size - src.Offset
+	if src.Length > 0 {
+		cs.size = src.Length
+	}
+	cs.r = cs.fh
+	if src.IsRange() {
+		cs.r = io.NewSectionReader(cs.fh, src.Offset, cs.size)
+	}
+	return nil
+}
+
+func (cs *composeSrc) openRemote(t *target, tsi *meta.Snode, src *cmn.ComposeSrc, config *cmn.Config) error {
+	var (
+		lom     = cs.lom
+		reqArgs = cmn.AllocHra()
+	)
+	{
+		reqArgs.Method = http.MethodGet
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{t.SID()},
+			apc.HdrCallerName: []string{t.callerName()},
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
+		reqArgs.Query = lom.Bck().NewQuery()
+	}
+	if src.IsRange() {
+		rng := "bytes=" + strconv.FormatInt(src.Offset, 10) + "-"
+		if src.Length > 0 {
+			rng += strconv.FormatInt(src.Offset+src.Length-1, 10)
+		}
+		reqArgs.Header.Set(cos.HdrRange, rng)
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(config.Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return err
+	}
+	cs.cancel = cancel
+	cs.resp, err = g.client.data.Do(req) //nolint:bodyclose // see cs.close()
+	if err != nil {
+		return err
+	}
+	if code := cs.resp.StatusCode; code != http.StatusOK && code != http.StatusPartialContent {
+		b, _ := io.ReadAll(io.LimitReader(cs.resp.Body, maxErrBody))
+		if herr := cmn.Str2HTTPErr(string(b)); herr != nil {
+			return herr
+		}
+		return fmt.Errorf("%s responded with status %d", tsi.StringEx(), code)
+	}
+	cs.size = cs.resp.ContentLength
+	if src.Length > 0 && cs.size != src.Length {
+		// (the range extends beyond the end of the object)
+		return fmt.Errorf("invalid range [%d, %d] (content length %d)", src.Offset, src.Length, cs.size)
+	}
+	cs.r = cs.resp.Body
+	return nil
+}
+
+func (cs *composeSrc) eof() error {
+	if cs.size >= 0 && cs.read != cs.size {
+		return fmt.Errorf("compose: source %s: expected %d bytes, got %d: %w",
+			cs.lom.Cname(), cs.size, cs.read, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+func (cs *composeSrc) close() {
+	if cs.fh != nil {
+		cos.Close(cs.fh)
+		cs.lom.Unlock(false)
+	}
+	if cs.resp != nil {
+		cos.Close(cs.resp.Body)
+	}
+	if cs.cancel != nil {
+		cs.cancel()
+	}
+	core.FreeLOM(cs.lom)
+}
@@ -0,0 +1,133 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// all sources are checked (and out-of-range ones rejected with 416) before anything is written
+func TestComposeCheck(t *testing.T) {
+	const (
+		remoteID   = "remote-0"
+		remoteSize = 10
+	)
+	var (
+		tgt   = core.T.(*target)
+		bck   = meta.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
+		smap  = newSmap()
+		heads int
+	)
+	// remote target: HEAD only
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		heads++
+		if r.Method != http.MethodHead || strings.HasPrefix(path.Base(r.URL.Path), "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(cos.HdrContentLength, fmt.Sprint(remoteSize))
+	}))
+	t.Cleanup(ts.Close)
+	host, port, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	ni := meta.NetInfo{}
+	ni.Init("http", host, port)
+	smap.Tmap[tgt.SID()] = tgt.si
+	smap.Tmap[remoteID] = newSnode(remoteID, apc.Target, ni, ni, ni)
+	smap.Version = 2
+
+	smap0, control, data, k := tgt.owner.smap.get(), g.client.control, g.client.data, tgt.keepalive
+	if smap0 == nil {
+		smap0 = newSmap()
+	}
+	t.Cleanup(func() {
+		tgt.owner.smap.put(smap0)
+		g.client.control, g.client.data = control, data
+		tgt.keepalive = k
+	})
+	tgt.owner.smap.put(smap)
+	g.client.control, g.client.data = &http.Client{Timeout: time.Minute}, &http.Client{Timeout: time.Minute}
+	tgt.keepalive = newTalive(tgt, tgt.statsT, atomic.NewBool(true))
+
+	// names: one local (and present), one remote
+	var local, remote string
+	for i := 0; local == "" || remote == ""; i++ {
+		objName := fmt.Sprintf("compose-src-%d", i)
+		tsi, err := smap.HrwName2T(bck.MakeUname(objName))
+		tassert.CheckFatal(t, err)
+		if tsi.ID() == tgt.SID() {
+			if local == "" {
+				local = objName
+			}
+		} else if remote == "" {
+			remote = objName
+		}
+	}
+	var missing string
+	for i := 0; missing == ""; i++ {
+		objName := fmt.Sprintf("missing-%d", i)
+		if tsi, _ := smap.HrwName2T(bck.MakeUname(objName)); tsi.ID() == remoteID {
+			missing = objName
+		}
+	}
+	content := strings.Repeat("x", 100)
+	lom := core.AllocLOM(local)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       tgt,
+		lom:     lom,
+		r:       io.NopCloser(strings.NewReader(content)),
+		workFQN: path.Join(testMountpath, local+".work"),
+		config:  cmn.GCO.Get(),
+		owt:     cmn.OwtPut,
+		skipEC:  true,
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(t, err)
+	core.FreeLOM(lom)
+
+	dst := core.AllocLOM("compose-dst")
+	tassert.CheckFatal(t, dst.InitBck(bck.Bucket()))
+	defer core.FreeLOM(dst)
+
+	tests := []struct {
+		name    string
+		srcs    []cmn.ComposeSrc
+		errCode int
+		heads   int
+	}{
+		{"all", []cmn.ComposeSrc{{ObjName: local}, {ObjName: remote}}, 0, 1},
+		{"ranges", []cmn.ComposeSrc{{ObjName: local, Offset: 90}, {ObjName: remote, Offset: 2, Length: 8}}, 0, 1},
+		{"local out of range", []cmn.ComposeSrc{{ObjName: local, Offset: 100}, {ObjName: remote}}, http.StatusRequestedRangeNotSatisfiable, 0},
+		{"local too long", []cmn.ComposeSrc{{ObjName: local, Offset: 50, Length: 51}}, http.StatusRequestedRangeNotSatisfiable, 0},
+		{"remote to end", []cmn.ComposeSrc{{ObjName: remote, Offset: remoteSize}}, http.StatusRequestedRangeNotSatisfiable, 1},
+		{"remote too long", []cmn.ComposeSrc{{ObjName: remote, Length: remoteSize + 1}}, http.StatusRequestedRangeNotSatisfiable, 1},
+		{"remote missing", []cmn.ComposeSrc{{ObjName: local}, {ObjName: missing}, {ObjName: remote}}, http.StatusNotFound, 1},
+	}
+	for _, test := range tests {
+		heads = 0
+		cr := &composeReader{t: tgt, dst: dst, config: cmn.GCO.Get(), srcs: test.srcs}
+		errCode, err := cr.check()
+		tassert.Errorf(t, errCode == test.errCode, "%s: expected status %d, got %d (%v)", test.name, test.errCode, errCode, err)
+		tassert.Errorf(t, (err == nil) == (test.errCode == 0), "%s: unexpected error %v", test.name, err)
+		tassert.Errorf(t, heads == test.heads, "%s: expected %d HEAD request(s), got %d", test.name, test.heads, heads)
+	}
+}
@@ -679,6 +679,11 @@ func (f *delb) do(nbck *meta.Bck) bool {
 		flt := xreg.Flt{Kind: apc.ActECEncode, Bck: nbck}
 		xreg.DoAbort(flt, errors.New("apply-bmd"))
 	}
+	if f.obck.Props.NameIdx.Enabled && !nbck.Props.NameIdx.Enabled {
+		flt := xreg.Flt{Kind: apc.ActNameIdx, Bck: nbck}
+		xreg.DoAbort(flt, errors.New("apply-bmd"))
+		fs.RemoveNameIdx(nbck.Bucket())
+	}
 	return true // break
 }
 
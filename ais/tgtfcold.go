@@ -6,9 +6,11 @@ package ais
 
 import (
 	"io"
+	"net/http"
 	"os"
 
 	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -17,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/ext/webhook"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
@@ -70,6 +73,7 @@ func (goi *getOI) coldSeek(res *core.GetReaderResult) error {
 
 	// persist lom (main repl.)
 	lom.SetSize(written)
+	lom.ClrEncoded() // (fast path: neither compressing nor encrypting)
 	if cksum != nil {
 		cksum.Finalize()
 		lom.SetCksum(&cksum.Cksum)
@@ -96,32 +100,46 @@ func (goi *getOI) coldSeek(res *core.GetReaderResult) error {
 		return err
 	}
 	var (
-		hrng   *htrange
+		ranges []htrange
+		mr     *mrangeR
 		size             = lom.SizeBytes()
 		reader io.Reader = lmfh
 		whdr             = goi.w.Header()
+		ctype            = cos.ContentBinary
 	)
 	if goi.ranges.Range != "" {
-		rsize := goi.lom.SizeBytes()
-		if goi.ranges.Size > 0 {
-			rsize = goi.ranges.Size
-		}
-		if hrng, _, err = goi.parseRange(whdr, rsize); err != nil {
+		if ranges, _, err = goi.parseRange(whdr, goi.rsize()); err != nil {
 			goi._cleanup(revert, lmfh, buf, slab, err, "(seek)")
 			return err
 		}
-		size = hrng.Length
-		reader = io.NewSectionReader(lmfh, hrng.Start, hrng.Length)
+		switch {
+		case len(ranges) == 1:
+			size = ranges[0].Length
+			reader = io.NewSectionReader(lmfh, ranges[0].Start, ranges[0].Length)
+		case len(ranges) > 1:
+			// (no per-part checksums: not taking this path with EnableReadRange)
+			mr, ctype, size = newMrangeR(ranges, goi.rsize(), cos.ChecksumNone, func(hrng *htrange) (io.ReadCloser, error) {
+				return io.NopCloser(io.NewSectionReader(lmfh, hrng.Start, hrng.Length)), nil
+			})
+			reader = mr
+		}
 	}
 
 	// transmit
-	whdr.Set(cos.HdrContentType, cos.ContentBinary)
+	whdr.Set(cos.HdrContentType, ctype)
 	cmn.ToHeader(lom.ObjAttrs(), whdr)
 	if goi.isS3 {
 		s3.SetEtag(whdr, goi.lom)
 	}
+	if mr != nil {
+		mrangeHdr(whdr, ctype, size)
+		goi.w.WriteHeader(http.StatusPartialContent)
+	}
 
 	written, err = cos.CopyBuffer(goi.w, reader, buf)
+	if mr != nil {
+		mr.Close() // (before closing lmfh)
+	}
 	if err != nil {
 		goi._cleanup(revert, lmfh, buf, slab, err, "(transmit)")
 		return errSendingResp
@@ -141,6 +159,7 @@ func (goi *getOI) coldSeek(res *core.GetReaderResult) error {
 		nlog.Infoln(ftcg+"(ec)", lom, err)
 	}
 	t.putMirror(lom)
+	webhook.Notify(lom, apc.EvtObjColdGet)
 
 	// load; inc stats
 	if err = lom.Load(true /*cache it*/, true /*locked*/); err != nil {
@@ -99,6 +99,15 @@ func (t *target) FinalizeObj(lom *core.LOM, workFQN string, xctn core.Xact, owt
 	if err = cos.Stat(workFQN); err != nil {
 		return
 	}
+	// compress and/or encrypt, if configured (except content written in chunks - see core/lchunk.go)
+	if !lom.IsChunked() || !core.IsChunkManifest(workFQN) {
+		buf, slab := t.gmm.Alloc()
+		err = lom.EncodeFile(workFQN, buf)
+		slab.Free(buf)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
 	poi := allocPOI()
 	{
 		poi.t = t
@@ -142,12 +151,11 @@ func (t *target) CopyObject(lom *core.LOM, dm core.DM, params *core.CopyParams)
 	coi := (*copyOI)(params)
 	// defaults
 	coi.OWT = cmn.OwtCopy
-	coi.Finalize = false
 	if coi.ObjnameTo == "" {
 		coi.ObjnameTo = lom.ObjName
 	}
 	realDM, ok := dm.(*bundle.DataMover) // TODO -- FIXME: eliminate typecast
-	debug.Assert(ok)
+	debug.Assert(ok || dm == nil)        // nil: PUT to the destination target (compare w/ t.objMv)
 
 	size, err = coi.do(t, realDM, lom)
 
@@ -260,6 +268,7 @@ func (t *target) _promLocal(params *core.PromoteParams, lom *core.LOM) (fileSize
 	if err = lom.Load(true /*cache it*/, false /*locked*/); err == nil && !params.OverwriteDst {
 		return
 	}
+	lom.ClrEncoded() // (promoting as is)
 	if params.DeleteSrc {
 		// To use `params.SrcFQN` as `workFQN`, make sure both are
 		// located on the same filesystem. About "filesystem sharing" see also:
@@ -310,6 +319,13 @@ func (t *target) _promLocal(params *core.PromoteParams, lom *core.LOM) (fileSize
 			return
 		}
 	}
+	// compress and/or encrypt, if configured
+	buf, slab := t.gmm.Alloc()
+	err = lom.EncodeFile(workFQN, buf)
+	slab.Free(buf)
+	if err != nil {
+		return
+	}
 	poi := allocPOI()
 	{
 		poi.atime = time.Now().UnixNano()
@@ -359,3 +375,13 @@ func (t *target) DisableMpath(mpath, reason string) (err error) {
 	_, err = t.fsprg.disableMpath(mpath, true /*dont-resilver*/) // NOTE: not resilvering upon FSCH calling
 	return
 }
+
+func (t *target) DegradeMpath(mpath, reason string) error {
+	nlog.Warningf("Degrading mountpath %s: %s", mpath, reason)
+	return t.fsprg.degradeMpath(mpath)
+}
+
+func (t *target) UndegradeMpath(mpath string) error {
+	nlog.Infof("Mountpath %s is no longer degraded", mpath)
+	return t.fsprg.undegradeMpath(mpath)
+}
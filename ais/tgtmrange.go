@@ -0,0 +1,223 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// multi-range GET: respond with multipart/byteranges (https://www.rfc-editor.org/rfc/rfc7233#appendix-A)
+// - the object is present: read the ranges locally;
+// - the object is remote and not present: read the ranges from the backend (GetObjReader
+//   with per-range offsets) and stream them back without cold-GETting the entire object
+// - with checksumming and `EnableReadRange`, each part carries its own checksum (the same way
+//   single-range responses do)
+
+type (
+	// open reader of a given range
+	openRange func(hrng *htrange) (io.ReadCloser, error)
+
+	// multipart/byteranges body (all parts) that is being written by a separate goroutine
+	mrangeR struct {
+		*io.PipeReader
+		done chan struct{}
+	}
+)
+
+// total size of the multipart body (compare w/ net/http rangesMIMESize)
+// (checksum values of a given type are all the same length)
+func mrangeSize(ranges []htrange, size int64, cksumType string) (length int64) {
+	var (
+		n     cntWriter
+		mw    = multipart.NewWriter(&n)
+		cksum *cos.Cksum
+	)
+	if cksumType != cos.ChecksumNone {
+		ck := cos.NewCksumHash(cksumType)
+		ck.Finalize()
+		cksum = ck.Clone()
+	}
+	for i := range ranges {
+		mw.CreatePart(ranges[i].mimeHeader(size, cksum))
+		length += ranges[i].Length
+	}
+	mw.Close()
+	return length + int64(n)
+}
+
+// (counts multipart framing bytes)
+type cntWriter int64
+
+func (n *cntWriter) Write(p []byte) (int, error) { *n += cntWriter(len(p)); return len(p), nil }
+
+func (r htrange) mimeHeader(size int64, cksum *cos.Cksum) textproto.MIMEHeader {
+	hdr := textproto.MIMEHeader{
+		cos.HdrContentRange: {r.contentRange(size)},
+		cos.HdrContentType:  {cos.ContentBinary},
+	}
+	if cksum != nil {
+		hdr.Set(apc.HdrObjCksumType, cksum.Ty())
+		hdr.Set(apc.HdrObjCksumVal, cksum.Val())
+	}
+	return hdr
+}
+
+// returns multipart/byteranges reader, its content type (that includes boundary), and length
+// - cksumType other than none: checksum each range (see mrangeCksumType)
+func newMrangeR(ranges []htrange, size int64, cksumType string, open openRange) (*mrangeR, string, int64) {
+	var (
+		pr, pw = io.Pipe()
+		mw     = multipart.NewWriter(pw)
+		mr     = &mrangeR{PipeReader: pr, done: make(chan struct{})}
+		length = mrangeSize(ranges, size, cksumType)
+	)
+	go func() {
+		defer close(mr.done)
+		for i := range ranges {
+			if err := mrangePart(mw, &ranges[i], size, cksumType, open); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+	return mr, "multipart/byteranges; boundary=" + mw.Boundary(), length
+}
+
+// the part's header goes first - when checksumming, the range is read into SGL
+func mrangePart(mw *multipart.Writer, hrng *htrange, size int64, cksumType string, open openRange) error {
+	r, err := open(hrng)
+	if err != nil {
+		return err
+	}
+	if cksumType == cos.ChecksumNone {
+		part, err := mw.CreatePart(hrng.mimeHeader(size, nil))
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		cos.Close(r)
+		return err
+	}
+
+	sgl := memsys.PageMM().NewSGL(hrng.Length)
+	defer sgl.Free()
+	_, cksum, err := cos.CopyAndChecksum(sgl /*as ReaderFrom*/, r, nil, cksumType)
+	cos.Close(r)
+	if err != nil {
+		return err
+	}
+	part, err := mw.CreatePart(hrng.mimeHeader(size, cksum.Clone()))
+	if err == nil {
+		_, err = io.Copy(part, sgl)
+	}
+	return err
+}
+
+// per-part checksums, if configured (compare w/ single-range GET)
+func mrangeCksumType(ckconf *cmn.CksumConf) string {
+	if ckconf.Type != cos.ChecksumNone && ckconf.EnableReadRange {
+		return ckconf.Type
+	}
+	return cos.ChecksumNone
+}
+
+// stop and wait for the writer
+func (mr *mrangeR) Close() error {
+	err := mr.PipeReader.Close()
+	<-mr.done
+	return err
+}
+
+// set response headers for multipart/byteranges (the object's checksum does not apply)
+func mrangeHdr(hdr http.Header, ctype string, length int64) {
+	hdr.Del(apc.HdrObjCksumVal)
+	hdr.Del(apc.HdrObjCksumType)
+	hdr.Set(cos.HdrContentType, ctype)
+	if length >= 0 {
+		hdr.Set(cos.HdrContentLength, strconv.FormatInt(length, 10))
+	}
+}
+
+// is under rlock; the object is remote and not present (compare w/ goi.get cold-GET)
+func (goi *getOI) coldRanges() (int, error) {
+	var (
+		lom     = goi.lom
+		backend = goi.t.Backend(lom.Bck())
+	)
+	oa, errCode, err := backend.HeadObj(goi.ctx, lom)
+	if err != nil {
+		return errCode, err
+	}
+	lom.CopyAttrs(oa, false /*skip cksum*/)
+	lom.SetSize(oa.Size)
+
+	hdr := goi.w.Header()
+	ranges, errCode, err := goi.parseRange(hdr, oa.Size)
+	if err != nil {
+		return errCode, err
+	}
+	open := func(hrng *htrange) (io.ReadCloser, error) {
+		res := backend.GetObjReader(goi.ctx, lom, hrng.Start, hrng.Length)
+		return res.R, res.Err
+	}
+	cmn.ToHeader(oa, hdr)
+	if goi.isS3 {
+		s3.SetEtag(hdr, lom)
+	}
+
+	var (
+		r      io.ReadCloser
+		length int64
+	)
+	switch len(ranges) {
+	case 0:
+		r, err = open(&htrange{})
+		length = oa.Size
+		hdr.Set(cos.HdrContentType, cos.ContentBinary)
+		hdr.Set(cos.HdrContentLength, strconv.FormatInt(length, 10))
+	case 1:
+		r, err = open(&ranges[0])
+		length = ranges[0].Length
+		hdr.Set(cos.HdrContentType, cos.ContentBinary)
+		hdr.Set(cos.HdrContentLength, strconv.FormatInt(length, 10))
+	default:
+		var ctype string
+		r, ctype, length = newMrangeR(ranges, oa.Size, mrangeCksumType(lom.CksumConf()), open)
+		mrangeHdr(hdr, ctype, length)
+		goi.w.WriteHeader(http.StatusPartialContent)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	buf, slab := goi.t.gmm.AllocSize(min(length, 64*cos.KiB))
+	written, err := cos.CopyBuffer(goi.w, r, buf)
+	slab.Free(buf)
+	cos.Close(r)
+	if err != nil {
+		nlog.Errorln(cmn.NewErrFailedTo(goi.t, "GET (ranges)", lom.Cname(), err))
+		return 0, errSendingResp
+	}
+	goi.t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.GetColdCount, Value: 1},
+		cos.NamedVal64{Name: stats.GetColdSize, Value: written},
+		cos.NamedVal64{Name: stats.GetColdRwLatency, Value: mono.SinceNano(goi.ltime)},
+	)
+	goi.stats(written)
+	return 0, nil
+}
@@ -0,0 +1,68 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// (with per-part checksums when checksumming read ranges - see EnableReadRange)
+func TestMultiRangeReader(t *testing.T) {
+	var (
+		data = []byte(strings.Repeat("0123456789abcdef", 64))
+		size = int64(len(data))
+	)
+	ranges, err := parseMultiRange("bytes=0-9,100-199,-16", size)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(ranges) == 3, "expected 3 ranges, got %d", len(ranges))
+
+	src := bytes.NewReader(data)
+	for _, cksumType := range []string{cos.ChecksumNone, cos.ChecksumXXHash, cos.ChecksumSHA256} {
+		mr, ctype, length := newMrangeR(ranges, size, cksumType, func(hrng *htrange) (io.ReadCloser, error) {
+			return io.NopCloser(io.NewSectionReader(src, hrng.Start, hrng.Length)), nil
+		})
+		body, err := io.ReadAll(mr)
+		tassert.CheckFatal(t, err)
+		mr.Close()
+		tassert.Errorf(t, int64(len(body)) == length, "%s: expected length %d, got %d", cksumType, length, len(body))
+
+		mt, params, err := mime.ParseMediaType(ctype)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, mt == "multipart/byteranges", "unexpected content type %q", ctype)
+
+		r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for i := range ranges {
+			part, err := r.NextPart()
+			tassert.CheckFatal(t, err)
+			exp := ranges[i].contentRange(size)
+			tassert.Errorf(t, part.Header.Get(cos.HdrContentRange) == exp,
+				"part %d: expected %q, got %q", i, exp, part.Header.Get(cos.HdrContentRange))
+			b, err := io.ReadAll(part)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, bytes.Equal(b, data[ranges[i].Start:ranges[i].Start+ranges[i].Length]), "part %d: content mismatch", i)
+
+			ty, val := part.Header.Get(apc.HdrObjCksumType), part.Header.Get(apc.HdrObjCksumVal)
+			if cksumType == cos.ChecksumNone {
+				tassert.Errorf(t, ty == "" && val == "", "part %d: unexpected checksum %s[%s]", i, ty, val)
+				continue
+			}
+			_, cksum, err := cos.CopyAndChecksum(io.Discard, bytes.NewReader(b), nil, cksumType)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, ty == cksumType && val == cksum.Value(),
+				"part %d: expected checksum %s[%s], got %s[%s]", i, cksumType, cksum.Value(), ty, val)
+		}
+		_, err = r.NextPart()
+		tassert.Errorf(t, err == io.EOF, "expected EOF, got %v", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/ext/webhook"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
@@ -39,6 +40,7 @@ import (
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
 )
 
 //
@@ -48,23 +50,26 @@ import (
 type (
 	putOI struct {
 		oreq       *http.Request
-		r          io.ReadCloser // content reader
-		xctn       core.Xact     // xaction that puts
-		t          *target       // this
-		lom        *core.LOM     // obj
-		cksumToUse *cos.Cksum    // if available (not `none`), can be validated and will be stored
-		config     *cmn.Config   // (during this request)
-		resphdr    http.Header   // as implied
-		workFQN    string        // temp fqn to be renamed
-		atime      int64         // access time.Now()
-		ltime      int64         // mono.NanoTime, to measure latency
-		size       int64         // aka Content-Length
-		owt        cmn.OWT       // object write transaction enum { OwtPut, ..., OwtGet* }
-		restful    bool          // being invoked via RESTful API
-		t2t        bool          // by another target
-		skipEC     bool          // do not erasure-encode when finalizing
-		skipVC     bool          // skip loading existing Version and skip comparing Checksums (skip VC)
-		coldGET    bool          // (one implication: proceed to write)
+		r          io.ReadCloser     // content reader
+		xctn       core.Xact         // xaction that puts
+		t          *target           // this
+		lom        *core.LOM         // obj
+		cksumToUse *cos.Cksum        // if available (not `none`), can be validated and will be stored
+		config     *cmn.Config       // (during this request)
+		resphdr    http.Header       // as implied
+		workFQN    string            // temp fqn to be renamed
+		cw         *core.ChunkWriter // when storing in chunks (see core/lchunk.go)
+		atime      int64             // access time.Now()
+		ltime      int64             // mono.NanoTime, to measure latency
+		size       int64             // aka Content-Length
+		owt        cmn.OWT           // object write transaction enum { OwtPut, ..., OwtGet* }
+		restful    bool              // being invoked via RESTful API
+		t2t        bool              // by another target
+		skipEC     bool              // do not erasure-encode when finalizing
+		skipVC     bool              // skip loading existing Version and skip comparing Checksums (skip VC)
+		coldGET    bool              // (one implication: proceed to write)
+		wback      bool              // write back asynchronously (see core/lwback.go)
+		repl       bool              // replicate asynchronously (see core/lrepl.go)
 	}
 
 	getOI struct {
@@ -254,6 +259,9 @@ func (poi *putOI) finalize() (errCode int, err error) {
 			if err2 := cos.RemoveFile(poi.workFQN); err2 != nil && !os.IsNotExist(err2) {
 				nlog.Errorf(fmtNested, poi.t, err1, "remove", poi.workFQN, err2)
 			}
+			if poi.cw != nil {
+				poi.cw.Abort() // (not committed)
+			}
 		}
 		poi.lom.Uncache()
 		if errCode != http.StatusInsufficientStorage && cmn.IsErrCapExceeded(err) {
@@ -261,6 +269,15 @@ func (poi *putOI) finalize() (errCode int, err error) {
 		}
 		return errCode, err
 	}
+	if poi.repl {
+		poi.t.repl(poi.lom, core.ReplPut)
+	}
+	switch {
+	case poi.owt < cmn.OwtRebalance:
+		webhook.Notify(poi.lom, apc.EvtObjCreated)
+	case poi.owt > cmn.OwtRebalance && poi.owt < cmn.OwtNone:
+		webhook.Notify(poi.lom, apc.EvtObjColdGet)
+	}
 	if !poi.skipEC {
 		if ecErr := ec.ECM.EncodeObject(poi.lom, nil); ecErr != nil && ecErr != ec.ErrorECDisabled {
 			err = ecErr
@@ -271,6 +288,9 @@ func (poi *putOI) finalize() (errCode int, err error) {
 		}
 	}
 	poi.t.putMirror(poi.lom)
+	if poi.wback {
+		poi.t.wback(poi.lom)
+	}
 	return 0, nil
 }
 
@@ -280,8 +300,13 @@ func (poi *putOI) fini() (errCode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
-	// put remote
-	if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
+	// put remote (or, write back - see below)
+	if bck.IsRemote() && poi.owt < cmn.OwtRebalance && lom.WriteBack() {
+		poi.wback = true
+		lom.SetWbackPending()
+	} else if poi.owt == cmn.OwtRebalance && lom.IsWbackPending() {
+		poi.wback = true // rebalanced prior to being written back: the new owner takes over
+	} else if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
 		errCode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
@@ -320,6 +345,38 @@ func (poi *putOI) fini() (errCode int, err error) {
 		lom.SetAtimeUnix(poi.atime)
 	}
 
+	// bucket snapshot in progress (see core/lsnap.go)
+	if poi.owt < cmn.OwtRebalance {
+		if err = lom.Fenced(); err != nil {
+			return http.StatusServiceUnavailable, err
+		}
+	}
+
+	// journal prior to committing (see core/lwback.go)
+	if poi.wback {
+		if err = core.WbackLog(lom); err != nil {
+			return
+		}
+		defer func() {
+			if err != nil {
+				core.WbackDone(lom.Uname())
+			}
+		}()
+	}
+	// ditto (see core/lrepl.go) - including rebalance: the new owner takes over
+	if poi.owt <= cmn.OwtRebalance && lom.ReplBck() != nil {
+		if err = core.ReplLog(lom, core.ReplPut); err != nil {
+			return
+		}
+		poi.repl = true
+		defer func() {
+			if err != nil {
+				poi.repl = false
+				core.ReplDone(lom.Uname(), core.ReplPut, 0)
+			}
+		}()
+	}
+
 	// ais versioning
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		if poi.owt < cmn.OwtRebalance {
@@ -346,7 +403,13 @@ func (poi *putOI) fini() (errCode int, err error) {
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime)
 	}
-	err = lom.PersistMain()
+	if err = lom.PersistMain(); err != nil {
+		return
+	}
+	// rebalanced snapshot object (or origin) - to share content again (see core/lsnap.go)
+	if poi.owt == cmn.OwtRebalance && bck.IsAIS() {
+		lom.Reshare()
+	}
 	return
 }
 
@@ -356,7 +419,7 @@ func (poi *putOI) putRemote() (errCode int, err error) {
 		lom     = poi.lom
 		backend = poi.t.Backend(lom.Bck())
 	)
-	lmfh, err := cos.NewFileHandle(poi.workFQN)
+	lmfh, err := lom.OpenFQN(poi.workFQN) // (decoding, if need be)
 	if err != nil {
 		err = cmn.NewErrFailedTo(poi.t, "open", poi.workFQN, err)
 		return
@@ -365,6 +428,7 @@ func (poi *putOI) putRemote() (errCode int, err error) {
 		// some/all of those are set by the backend.PutObj()
 		lom.ObjAttrs().DelCustomKeys(cmn.SourceObjMD, cmn.CRC32CObjMD, cmn.ETag, cmn.MD5ObjMD, cmn.VersionObjMD)
 	}
+	lom.ObjAttrs().DelCustomKeys(cmn.WbackObjMD)
 
 	errCode, err = backend.PutObj(lmfh, lom, poi.oreq)
 	if err == nil && !lom.Bck().IsRemoteAIS() {
@@ -377,6 +441,8 @@ func (poi *putOI) putRemote() (errCode int, err error) {
 // `poi.r` (reader) is also closed upon exit.
 func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh *os.File, err error) {
 	var (
+		w       io.Writer
+		enc     *core.Encoder
 		written int64
 		cksums  = struct {
 			store     *cos.CksumHash // store with LOM
@@ -386,8 +452,20 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh *os.File, err err
 		}{}
 		ckconf = poi.lom.CksumConf()
 	)
-	if lmfh, err = poi.lom.CreateFile(poi.workFQN); err != nil {
-		return
+	// chunked storage of large objects (see core/lchunk.go)
+	if poi.cw = poi.lom.NewChunkWriter(poi.workFQN, poi.size); poi.cw != nil {
+		w = poi.cw
+	} else {
+		if lmfh, err = poi.lom.CreateFile(poi.workFQN); err != nil {
+			return
+		}
+		// compression and/or encryption at rest (see core/lcodec.go)
+		if enc, err = poi.lom.NewEncoder(lmfh); err != nil {
+			return
+		}
+		if w = lmfh; enc != nil {
+			w = enc
+		}
 	}
 	if poi.size <= 0 {
 		buf, slab = poi.t.gmm.Alloc()
@@ -400,13 +478,13 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh *os.File, err err
 		poi.lom.SetCksum(cos.NoneCksum)
 		// not using `ReadFrom` of the `*os.File` -
 		// ultimately, https://github.com/golang/go/blob/master/src/internal/poll/copy_file_range_linux.go#L100
-		written, err = cos.CopyBuffer(lmfh, poi.r, buf)
+		written, err = cos.CopyBuffer(w, poi.r, buf)
 	case !poi.cksumToUse.IsEmpty() && !poi.validateCksum(ckconf):
 		// if the corresponding validation is not configured/enabled we just go ahead
 		// and use the checksum that has arrived with the object
 		poi.lom.SetCksum(poi.cksumToUse)
 		// (ditto)
-		written, err = cos.CopyBuffer(lmfh, poi.r, buf)
+		written, err = cos.CopyBuffer(w, poi.r, buf)
 	default:
 		writers := make([]io.Writer, 0, 3)
 		cksums.store = cos.NewCksumHash(ckconf.Type) // always according to the bucket
@@ -421,7 +499,7 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh *os.File, err err
 				writers = append(writers, cksums.compt.H)
 			}
 		}
-		writers = append(writers, lmfh)
+		writers = append(writers, w)
 		written, err = cos.CopyBuffer(cos.NewWriterMulti(writers...), poi.r, buf) // (ditto)
 	}
 	if err != nil {
@@ -443,13 +521,26 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh *os.File, err err
 	}
 
 	// ok
-	if poi.lom.IsFeatureSet(feat.FsyncPUT) {
-		err = lmfh.Sync() // compare w/ cos.FlushClose
-		debug.AssertNoErr(err)
+	switch {
+	case poi.cw != nil:
+		if err = poi.cw.Finish(); err != nil { // (fsync-s chunks if FsyncPUT)
+			return
+		}
+	case enc != nil:
+		if err = enc.Finish(poi.lom); err != nil {
+			return
+		}
+	default:
+		poi.lom.ClrEncoded() // (e.g., migrating or copying)
+	}
+	if lmfh != nil {
+		if poi.lom.IsFeatureSet(feat.FsyncPUT) {
+			err = lmfh.Sync() // compare w/ cos.FlushClose
+			debug.AssertNoErr(err)
+		}
+		cos.Close(lmfh)
+		lmfh = nil
 	}
-
-	cos.Close(lmfh)
-	lmfh = nil
 
 	poi.lom.SetSize(written) // TODO: compare with non-zero lom.SizeBytes() that may have been set via oa.FromHeader()
 	if cksums.store != nil {
@@ -473,7 +564,9 @@ func (poi *putOI) _cleanup(buf []byte, slab *memsys.Slab, lmfh *os.File, err err
 
 	// not ok
 	poi.r.Close()
-	if nerr := lmfh.Close(); nerr != nil {
+	if poi.cw != nil {
+		poi.cw.Abort()
+	} else if nerr := lmfh.Close(); nerr != nil {
 		nlog.Errorf(fmtNested, poi.t, err, "close", poi.workFQN, nerr)
 	}
 	if nerr := cos.RemoveFile(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
@@ -517,6 +610,7 @@ func (goi *getOI) get() (errCode int, err error) {
 		doubleCheck bool
 		retried     bool
 		cold        bool
+		misplaced   bool
 	)
 do:
 	err = goi.lom.Load(true /*cache it*/, true /*locked*/)
@@ -562,8 +656,25 @@ do:
 			return 0, err
 		}
 		goto fin // ok, done
+	case cold && !misplaced && fs.NumDegraded() > 0:
+		// may have been stored on a mountpath that is now degraded (see fs/degraded.go)
+		misplaced = true
+		goi.lom.Unlock(false)
+		restored := goi.lom.RestoreToLocation()
+		goi.lom.Lock(false)
+		if restored {
+			goto do
+		}
+		fallthrough
 	case cold:
 		// have remote backend - use it
+		if goi.archive.filename == "" && strings.IndexByte(goi.ranges.Range, ',') > 0 {
+			// multiple ranges: read them directly from the backend (not caching the object)
+			return goi.coldRanges()
+		}
+	case goi.lom.IsTiered():
+		// metadata-only stub - re-hydrate from the tier bucket (see core/ltier.go)
+		cold = true
 	case goi.latestVer:
 		// apc.QparamLatestVer or 'versioning.validate_warm_get'
 		res := goi.lom.CheckRemoteMD(true /* rlocked */, false /*synchronize*/)
@@ -624,11 +735,14 @@ do:
 			goto fin
 		}
 
-		// zero-out prev. version custom metadata, if any
-		goi.lom.SetCustomMD(nil)
-
 		// get remote reader (compare w/ t.GetCold)
-		res = goi.t.Backend(goi.lom.Bck()).GetObjReader(goi.ctx, goi.lom, 0, 0)
+		tier := goi.lom.TierBck()
+		if tier != nil {
+			res = goi.lom.TierReader(goi.ctx, tier)
+		} else {
+			goi.lom.SetCustomMD(nil) // zero-out prev. version custom metadata, if any
+			res = goi.t.Backend(goi.lom.Bck()).GetObjReader(goi.ctx, goi.lom, 0, 0)
+		}
 		if res.Err != nil {
 			goi.lom.Unlock(true)
 			goi.unlocked = true
@@ -638,11 +752,14 @@ do:
 			return res.ErrCode, res.Err
 		}
 		goi.cold = true
+		if tier != nil {
+			goi.lom.Untier() // re-hydrating: retain version and custom metadata
+		}
 
 		// two alternative ways to perform cold GET: "fast" and "regular"
-		// "fast" limitations: read archived; compute more checksums (TODO)
-		if goi.archive.filename == "" &&
-			(ckconf.Type == cos.ChecksumNone || (!ckconf.ValidateColdGet && !ckconf.EnableReadRange)) {
+		// "fast" limitations: read archived; compute more checksums (TODO); compression, encryption, and chunks at rest
+		if goi.archive.filename == "" && tier == nil && !goi.lom.CompressEnabled() && !goi.lom.EncryptEnabled() &&
+			!goi.lom.ChunkEnabled() && (ckconf.Type == cos.ChecksumNone || (!ckconf.ValidateColdGet && !ckconf.EnableReadRange)) {
 			// fast path
 			err = goi.coldSeek(&res)
 			goi.unlocked = true // always
@@ -691,7 +808,7 @@ func (goi *getOI) _coldLock() (loaded bool, err error) {
 	)
 outer:
 	for lom.UpgradeLock() {
-		if erl := lom.Load(true /*cache it*/, true /*locked*/); erl == nil {
+		if erl := lom.Load(true /*cache it*/, true /*locked*/); erl == nil && !lom.IsTiered() {
 			// nothing to do
 			// (lock was upgraded by another goroutine that had also performed PUT on our behalf)
 			return true, nil
@@ -790,7 +907,7 @@ validate:
 	//
 	// try to recover from BAD CHECKSUM
 	//
-	cos.RemoveFile(lom.FQN) // TODO: ditto
+	core.RemoveFQN(lom.FQN) // TODO: ditto
 
 	if lom.HasCopies() {
 		retried = true
@@ -970,14 +1087,22 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 
 func (goi *getOI) finalize() (errCode int, err error) {
 	var (
-		lmfh *os.File
-		hrng *htrange
-		fqn  = goi.lom.FQN
+		lmfh   core.LomReader
+		ranges []htrange
+		fqn    = goi.lom.FQN
 	)
 	if !goi.cold && !goi.isGFN {
+		if hfqn := goi.lom.HotFQN(); hfqn != "" {
+			if lmfh, err = goi.lom.OpenFQN(hfqn); err == nil {
+				fqn = hfqn
+				goi.t.statsT.Inc(stats.HotGetCount)
+				goto opened
+			}
+			goi.lom.HotDrop()
+		}
 		fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
 	}
-	lmfh, err = os.Open(fqn)
+	lmfh, err = goi.lom.OpenFQN(fqn) // (decompressing, if need be)
 	if err != nil {
 		if os.IsNotExist(err) {
 			errCode = http.StatusNotFound
@@ -989,33 +1114,25 @@ func (goi *getOI) finalize() (errCode int, err error) {
 		}
 		return
 	}
-
+opened:
 	hdr := goi.w.Header()
 	if goi.ranges.Range != "" {
-		rsize := goi.lom.SizeBytes()
-		if goi.ranges.Size > 0 {
-			rsize = goi.ranges.Size
-		}
-		if hrng, errCode, err = goi.parseRange(hdr, rsize); err != nil {
-			goto ret
-		}
-		if goi.archive.filename != "" {
-			err = cmn.NewErrUnsupp("range-read archived file", goi.archive.filename)
-			errCode = http.StatusRequestedRangeNotSatisfiable
+		if ranges, errCode, err = goi.parseRange(hdr, goi.rsize()); err != nil {
 			goto ret
 		}
 	}
-	errCode, err = goi.fini(fqn, lmfh, hdr, hrng)
+	errCode, err = goi.fini(fqn, lmfh, hdr, ranges)
 ret:
 	cos.Close(lmfh)
 	return
 }
 
 // in particular, setup reader and writer and set headers
-func (goi *getOI) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *htrange) (errCode int, err error) {
+func (goi *getOI) fini(fqn string, lmfh core.LomReader, hdr http.Header, ranges []htrange) (errCode int, err error) {
 	var (
 		size   int64
 		reader io.Reader = lmfh
+		ctype            = cos.ContentBinary
 	)
 	cmn.ToHeader(goi.lom.ObjAttrs(), hdr) // (defaults)
 	if goi.isS3 {
@@ -1027,19 +1144,23 @@ func (goi *getOI) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *htrange
 			mime string
 			ar   archive.Reader
 			csl  cos.ReadCloseSizer
+			e    *archive.IndexEntry
 		)
-		mime, err = archive.MimeFile(lmfh, goi.t.smm, goi.archive.mime, goi.lom.ObjName)
-		if err != nil {
-			return
-		}
-		ar, err = archive.NewReader(mime, lmfh, goi.lom.SizeBytes())
-		if err != nil {
-			return 0, fmt.Errorf("failed to open %s: %w", goi.lom.Cname(), err)
-		}
-		csl, err = ar.Range(goi.archive.filename, nil)
-		if err != nil {
-			err = cmn.NewErrFailedTo(goi.t, "extract "+goi.archive.filename+" from", goi.lom, err)
-			return
+		// indexed shard: seek directly; otherwise, scan
+		if csl, e, mime = goi.lom.OpenIndexed(lmfh, goi.archive.mime, goi.archive.filename); mime == "" {
+			mime, err = archive.MimeFile(lmfh, goi.t.smm, goi.archive.mime, goi.lom.ObjName)
+			if err != nil {
+				return
+			}
+			ar, err = archive.NewReader(mime, lmfh, goi.lom.SizeBytes())
+			if err != nil {
+				return 0, fmt.Errorf("failed to open %s: %w", goi.lom.Cname(), err)
+			}
+			csl, err = ar.Range(goi.archive.filename, nil)
+			if err != nil {
+				err = cmn.NewErrFailedTo(goi.t, "extract "+goi.archive.filename+" from", goi.lom, err)
+				return
+			}
 		}
 		if csl == nil {
 			return http.StatusNotFound,
@@ -1050,15 +1171,33 @@ func (goi *getOI) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *htrange
 			csl.Close()
 		}()
 		reader, size = csl, csl.Size()
-		hdr.Del(apc.HdrObjCksumVal)
-		hdr.Del(apc.HdrObjCksumType)
+		if e != nil {
+			hdr.Set(apc.HdrObjCksumType, archive.IndexCksumType)
+			hdr.Set(apc.HdrObjCksumVal, e.Cksum)
+		} else {
+			hdr.Del(apc.HdrObjCksumVal)
+			hdr.Del(apc.HdrObjCksumType)
+		}
 		hdr.Set(apc.HdrArchmime, mime)
 		hdr.Set(apc.HdrArchpath, goi.archive.filename)
-	case hrng != nil: // range
+	case len(ranges) > 1: // multiple ranges
+		var mr *mrangeR
+		mr, ctype, size = newMrangeR(ranges, goi.rsize(), mrangeCksumType(goi.lom.CksumConf()), func(hrng *htrange) (io.ReadCloser, error) {
+			return io.NopCloser(io.NewSectionReader(lmfh, hrng.Start, hrng.Length)), nil
+		})
+		reader = mr
+		defer mr.Close()
+	case len(ranges) == 1: // range
+		hrng := &ranges[0]
 		ckconf := goi.lom.CksumConf()
 		cksumRange := ckconf.Type != cos.ChecksumNone && ckconf.EnableReadRange
 		size = hrng.Length
-		reader = io.NewSectionReader(lmfh, hrng.Start, hrng.Length)
+		if sgl := goi.readAhead(hrng.Start, size, true /*ranged*/); sgl != nil {
+			reader = sgl
+			defer sgl.Free()
+		} else {
+			reader = io.NewSectionReader(lmfh, hrng.Start, hrng.Length)
+		}
 		if cksumRange {
 			var (
 				cksum *cos.CksumHash
@@ -1078,10 +1217,19 @@ func (goi *getOI) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *htrange
 		}
 	default:
 		size = goi.lom.SizeBytes()
+		if sgl := goi.readAhead(0, size, false /*ranged*/); sgl != nil {
+			reader = sgl
+			defer sgl.Free()
+		}
 	}
 
-	hdr.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
-	hdr.Set(cos.HdrContentType, cos.ContentBinary)
+	if len(ranges) > 1 {
+		mrangeHdr(hdr, ctype, size)
+		goi.w.WriteHeader(http.StatusPartialContent)
+	} else {
+		hdr.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+		hdr.Set(cos.HdrContentType, ctype)
+	}
 
 	buf, slab := goi.t.gmm.AllocSize(min(size, 64*cos.KiB))
 	err = goi.transmit(reader, buf, fqn)
@@ -1112,7 +1260,9 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 			return errSendingResp
 		}
 		goi.lom.SetAtimeUnix(goi.atime)
+		goi.lom.IncAccess()
 		goi.lom.Recache()
+		goi.lom.HotHit()
 	}
 	//
 	// stats
@@ -1121,6 +1271,22 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 	return nil
 }
 
+// served from (and feeding) target's read-ahead - see core/lra.go
+func (goi *getOI) readAhead(off, size int64, ranged bool) *memsys.SGL {
+	if goi.isGFN {
+		return nil
+	}
+	return goi.lom.ReadAhead(off, size, ranged)
+}
+
+// object size for the purposes of range read
+func (goi *getOI) rsize() int64 {
+	if goi.ranges.Size > 0 {
+		return goi.ranges.Size
+	}
+	return goi.lom.SizeBytes()
+}
+
 func (goi *getOI) stats(written int64) {
 	goi.t.statsT.AddMany(
 		cos.NamedVal64{Name: stats.GetCount, Value: 1},
@@ -1136,8 +1302,8 @@ func (goi *getOI) stats(written int64) {
 }
 
 // parse & validate user-spec-ed goi.ranges, and set response header
-func (goi *getOI) parseRange(resphdr http.Header, size int64) (hrng *htrange, errCode int, err error) {
-	var ranges []htrange
+// (multiple ranges: see tgtmrange.go)
+func (goi *getOI) parseRange(resphdr http.Header, size int64) (ranges []htrange, errCode int, err error) {
 	ranges, err = parseMultiRange(goi.ranges.Range, size)
 	if err != nil {
 		if cmn.IsErrRangeNotSatisfiable(err) {
@@ -1150,11 +1316,6 @@ func (goi *getOI) parseRange(resphdr http.Header, size int64) (hrng *htrange, er
 	if len(ranges) == 0 {
 		return
 	}
-	if len(ranges) > 1 {
-		err = cmn.NewErrUnsupp("multi-range read", goi.lom.Cname())
-		errCode = http.StatusRequestedRangeNotSatisfiable
-		return
-	}
 	if goi.archive.filename != "" {
 		err = cmn.NewErrUnsupp("range-read archived file", goi.archive.filename)
 		errCode = http.StatusRequestedRangeNotSatisfiable
@@ -1162,9 +1323,10 @@ func (goi *getOI) parseRange(resphdr http.Header, size int64) (hrng *htrange, er
 	}
 
 	// set response header
-	hrng = &ranges[0]
 	resphdr.Set(cos.HdrAcceptRanges, "bytes")
-	resphdr.Set(cos.HdrContentRange, hrng.contentRange(size))
+	if len(ranges) == 1 {
+		resphdr.Set(cos.HdrContentRange, ranges[0].contentRange(size))
+	}
 	return
 }
 
@@ -1205,18 +1367,42 @@ func (a *apndOI) do(r *http.Request) (packedHdl string, errCode int, err error)
 }
 
 func (a *apndOI) apnd(buf []byte) (packedHdl string, errCode int, err error) {
-	var (
-		fh      *os.File
-		workFQN = a.hdl.workFQN
+	var workFQN string
+	// chunked storage of large objects (see core/lchunk.go)
+	if a.hdl.workFQN == "" && a.lom.ChunkEnabled() || a.hdl.workFQN != "" && core.IsChunkManifest(a.hdl.workFQN) {
+		workFQN, err = a.apndChunks(buf)
+	} else {
+		workFQN, err = a.apndFile(buf)
+	}
+	if err != nil {
+		errCode = http.StatusInternalServerError
+		return
+	}
+
+	packedHdl = a.pack(workFQN)
+
+	// stats (TODO: add `stats.FlushCount` for symmetry)
+	lat := time.Now().UnixNano() - a.started
+	a.t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.AppendCount, Value: 1},
+		cos.NamedVal64{Name: stats.AppendLatency, Value: lat},
 	)
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infof("APPEND %s: %s", a.lom, lat)
+	}
+	return
+}
+
+func (a *apndOI) apndFile(buf []byte) (workFQN string, err error) {
+	var fh *os.File
+	workFQN = a.hdl.workFQN
 	if workFQN == "" {
 		workFQN = fs.CSM.Gen(a.lom, fs.WorkfileType, fs.WorkfileAppend)
 		a.lom.Lock(false)
 		if a.lom.Load(false /*cache it*/, false /*locked*/) == nil {
-			_, a.hdl.partialCksum, err = cos.CopyFile(a.lom.FQN, workFQN, buf, a.lom.CksumType())
+			a.hdl.partialCksum, err = a.lom.CopyContent(workFQN, buf, a.lom.CksumType())
 			a.lom.Unlock(false)
 			if err != nil {
-				errCode = http.StatusInternalServerError
 				return
 			}
 			fh, err = os.OpenFile(workFQN, os.O_APPEND|os.O_WRONLY, cos.PermRWR)
@@ -1230,28 +1416,39 @@ func (a *apndOI) apnd(buf []byte) (packedHdl string, errCode int, err error) {
 		debug.Assert(a.hdl.partialCksum != nil)
 	}
 	if err != nil { // failed to open or create
-		errCode = http.StatusInternalServerError
 		return
 	}
 
 	w := cos.NewWriterMulti(fh, a.hdl.partialCksum.H)
 	_, err = cos.CopyBuffer(w, a.r, buf)
 	cos.Close(fh)
+	return
+}
+
+// the work file contains chunk manifest that gets updated with each APPEND
+func (a *apndOI) apndChunks(buf []byte) (workFQN string, err error) {
+	var cw *core.ChunkWriter
+	workFQN = a.hdl.workFQN
+	if workFQN == "" {
+		workFQN = fs.CSM.Gen(a.lom, fs.WorkfileType, fs.WorkfileAppend)
+		a.hdl.partialCksum = cos.NewCksumHash(a.lom.CksumType())
+		a.lom.Lock(false)
+		exists := a.lom.Load(false /*cache it*/, false /*locked*/) == nil
+		cw, err = a.lom.ChunkAppend(workFQN, a.hdl.partialCksum, buf, exists)
+		a.lom.Unlock(false)
+	} else {
+		cw, err = a.lom.OpenChunkWriter(workFQN)
+		debug.Assert(a.hdl.partialCksum != nil)
+	}
 	if err != nil {
-		errCode = http.StatusInternalServerError
 		return
 	}
-
-	packedHdl = a.pack(workFQN)
-
-	// stats (TODO: add `stats.FlushCount` for symmetry)
-	lat := time.Now().UnixNano() - a.started
-	a.t.statsT.AddMany(
-		cos.NamedVal64{Name: stats.AppendCount, Value: 1},
-		cos.NamedVal64{Name: stats.AppendLatency, Value: lat},
-	)
-	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
-		nlog.Infof("APPEND %s: %s", a.lom, lat)
+	w := cos.NewWriterMulti(cw, a.hdl.partialCksum.H)
+	if _, err = cos.CopyBuffer(w, a.r, buf); err == nil {
+		err = cw.Finish()
+	}
+	if err != nil {
+		cw.Abort()
 	}
 	return
 }
@@ -1269,6 +1466,10 @@ func (a *apndOI) flush() (int, error) {
 		return http.StatusInternalServerError, cos.NewErrDataCksum(partialCksum, a.cksum)
 	}
 
+	if core.IsChunkManifest(a.hdl.workFQN) {
+		return a.flushChunks(partialCksum)
+	}
+
 	params := core.PromoteParams{
 		Bck:    a.lom.Bck(),
 		Cksum:  partialCksum,
@@ -1283,6 +1484,20 @@ func (a *apndOI) flush() (int, error) {
 	return a.t.Promote(&params)
 }
 
+// (compare w/ t.Promote)
+func (a *apndOI) flushChunks(cksum *cos.Cksum) (int, error) {
+	_ = a.lom.Load(true /*cache it*/, false /*locked*/) // (current version, if exists)
+	cw, err := a.lom.OpenChunkWriter(a.hdl.workFQN)
+	if err == nil {
+		err = cw.Finish()
+	}
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.lom.SetCksum(cksum)
+	return a.t.FinalizeObj(a.lom, a.hdl.workFQN, nil, cmn.OwtPut)
+}
+
 func (a *apndOI) parse(packedHdl string) error {
 	if packedHdl == "" {
 		return nil
@@ -1324,7 +1539,8 @@ func (coi *copyOI) do(t *target, dm *bundle.DataMover, lom *core.LOM) (size int6
 	}
 
 	// DP == nil: use default (no-op transform) if source bucket is remote
-	if coi.DP == nil && lom.Bck().IsRemote() {
+	// or the object is tiered (read-through - see core/ltier.go)
+	if coi.DP == nil && (lom.Bck().IsRemote() || lom.IsTiered()) {
 		coi.DP = &core.LDP{}
 	}
 
@@ -1614,13 +1830,16 @@ func (a *putA2I) do() (int, error) {
 	}
 	// standard library does not support appending to tgz, zip, and such;
 	// for TAR there is an optimizing workaround not requiring a full copy
-	if a.mime == archive.ExtTar && !a.put {
+	// (but only when the shard is neither shared with a bucket snapshot nor compressed/encrypted/packed/chunked)
+	if a.mime == archive.ExtTar && !a.put && a.lom.IsRaw() && !fs.IsHardLinked(a.lom.FQN) {
 		var (
 			err       error
 			fh        *os.File
 			size      int64
 			tarFormat tar.Format
+			off       int64
 			workFQN   = fs.CSM.Gen(a.lom, fs.WorkfileType, fs.WorkfileAppendToArch)
+			prev      = a.lom.LoadShardIdx() // to extend (see core/lshard.go)
 		)
 		if err = os.Rename(a.lom.FQN, workFQN); err != nil {
 			return http.StatusInternalServerError, err
@@ -1636,10 +1855,16 @@ func (a *putA2I) do() (int, error) {
 			}
 			return http.StatusInternalServerError, err
 		}
+		if prev != nil {
+			if off, err = fh.Seek(0, io.SeekCurrent); err != nil {
+				prev = nil
+			}
+		}
 		// do - fast
 		if size, err = a.fast(fh, tarFormat); err == nil {
 			// NOTE: checksum traded off
 			if err = a.finalize(size, cos.NoneCksum, workFQN); err == nil {
+				a.lom.ExtendShardIdx(prev, off)
 				return http.StatusInternalServerError, nil // ok
 			}
 		}
@@ -1651,11 +1876,12 @@ func (a *putA2I) do() (int, error) {
 
 cpap: // copy + append
 	var (
-		err       error
-		lmfh, wfh *os.File
-		workFQN   string
-		cksum     cos.CksumHashSize
-		aw        archive.Writer
+		err     error
+		lmfh    core.LomReader
+		wfh     *os.File
+		workFQN string
+		cksum   cos.CksumHashSize
+		aw      archive.Writer
 	)
 	workFQN = fs.CSM.Gen(a.lom, fs.WorkfileType, fs.WorkfileAppendToArch)
 	wfh, err = os.OpenFile(workFQN, os.O_CREATE|os.O_WRONLY, cos.PermRWR)
@@ -1672,7 +1898,7 @@ cpap: // copy + append
 		aw.Fini()
 	} else {
 		// copy + append
-		lmfh, err = os.Open(a.lom.FQN)
+		lmfh, err = a.lom.Open()
 		if err != nil {
 			cos.Close(wfh)
 			return http.StatusNotFound, err
@@ -1737,6 +1963,13 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 		debug.AssertNoErr(err)
 		debug.Assertf(finfo.Size() == size, "%d != %d", finfo.Size(), size)
 	})
+	// compress and/or encrypt, if configured
+	buf, slab := a.t.gmm.Alloc()
+	err := a.lom.EncodeFile(fqn, buf)
+	slab.Free(buf)
+	if err != nil {
+		return err
+	}
 	// done
 	if err := a.lom.RenameFrom(fqn); err != nil {
 		return err
@@ -1753,6 +1986,7 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 		}
 	}
 	a.t.putMirror(a.lom)
+	webhook.Notify(a.lom, apc.EvtObjAppended)
 	return nil
 }
 
@@ -1788,6 +2022,86 @@ func (t *target) putMirror(lom *core.LOM) {
 	xputlrep.Repl(lom)
 }
 
+// enqueue journaled (pending) object to be written back
+func (t *target) wback(lom *core.LOM) {
+	rns := xreg.RenewWback(lom)
+	if rns.Err != nil {
+		nlog.Errorf("%s: %s %v", t, lom, rns.Err) // (remains in the journal)
+		return
+	}
+	xctn := rns.Entry.Get()
+	xwb := xctn.(*xs.XactWback)
+	xwb.Enqueue(lom.Uname())
+}
+
+// upon restart: write back objects that remain pending
+func (t *target) wbackReplay() {
+	unames := core.WbackReplay()
+	if len(unames) == 0 {
+		return
+	}
+	var n int
+	for _, uname := range unames {
+		b, objName := cmn.ParseUname(uname)
+		lom := core.AllocLOM(objName)
+		if lom.InitBck(&b) == nil && lom.Load(false /*cache it*/, false /*locked*/) == nil && lom.IsWbackPending() {
+			if err := core.WbackLog(lom); err != nil {
+				nlog.Errorln(t.String(), "failed to journal", lom.Cname(), "err:", err)
+			} else {
+				t.wback(lom)
+				n++
+			}
+		}
+		core.FreeLOM(lom)
+	}
+	nlog.Infoln(t.String(), "write-back journal:", len(unames), "entries,", n, "pending")
+}
+
+// log changed (e.g., deleted) object in the replication change log - unless not replicated
+// (compare w/ putOI.fini)
+func (t *target) replLog(lom *core.LOM, op core.ReplOp) bool {
+	if lom.ReplBck() == nil {
+		return false
+	}
+	if err := core.ReplLog(lom, op); err != nil {
+		nlog.Errorln(t.String(), "failed to log", lom.Cname(), "for replication, err:", err)
+		t.statsT.Inc(stats.ReplErrCount)
+		return false
+	}
+	return true
+}
+
+// enqueue logged object to be replicated
+func (t *target) repl(lom *core.LOM, op core.ReplOp) {
+	rns := xreg.RenewRepl(lom)
+	if rns.Err != nil {
+		nlog.Errorf("%s: %s %v", t, lom, rns.Err) // (remains in the change log)
+		return
+	}
+	xctn := rns.Entry.Get()
+	xrepl := xctn.(*xs.XactRepl)
+	xrepl.Enqueue(lom.Uname(), op)
+}
+
+// upon restart: replicate logged changes that may not have been shipped
+func (t *target) replReplay() {
+	changes := core.ReplReplay()
+	if len(changes) == 0 {
+		return
+	}
+	var n int
+	for _, change := range changes {
+		b, objName := cmn.ParseUname(change.Uname)
+		lom := core.AllocLOM(objName)
+		if lom.InitBck(&b) == nil && t.replLog(lom, change.Op) {
+			t.repl(lom, change.Op)
+			n++
+		}
+		core.FreeLOM(lom)
+	}
+	nlog.Infoln(t.String(), "replication change log:", len(changes), "entries,", n, "pending")
+}
+
 //
 // mem pools
 //
@@ -5,22 +5,34 @@
 package ais
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
 	"flag"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/zblk"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/ext/webhook"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
@@ -61,6 +73,8 @@ func TestMain(m *testing.M) {
 	fs.TestDisableValidation()
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.PackType, &fs.PackContentResolver{}, true)
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{}, true)
 
 	// target
 	config := cmn.GCO.Get()
@@ -91,6 +105,239 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
+// objects received via rebalance generate no events (compare w/ regular PUT and cold GET)
+func TestObjPutEvents(t *testing.T) {
+	var (
+		tgt    = core.T.(*target)
+		events = make(chan apc.ObjEvent, 4)
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var ev apc.ObjEvent
+		if err := jsoniter.NewDecoder(r.Body).Decode(&ev); err == nil {
+			events <- ev
+		}
+	}))
+	defer ts.Close()
+	webhook.Init(tgt.statsT, &cmn.GCO.Get().Client)
+
+	bck := meta.NewBck("bck-events", apc.AIS, cmn.NsGlobal)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.Bprops{
+		Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+		Events: cmn.EventsConf{Rules: []cmn.EventRule{{Endpoint: ts.URL}}, Enabled: true},
+	})
+	tassert.CheckFatal(t, tgt.owner.bmd.putPersist(bmd, nil))
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+
+	put := func(objName string, owt cmn.OWT) {
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		r, err := readers.NewRand(cos.KiB, cos.ChecksumNone)
+		tassert.CheckFatal(t, err)
+		poi := &putOI{
+			atime:   time.Now().UnixNano(),
+			t:       tgt,
+			lom:     lom,
+			r:       r,
+			workFQN: path.Join(testMountpath, objName+".work"),
+			config:  cmn.GCO.Get(),
+			owt:     owt,
+			skipEC:  true,
+		}
+		_, err = poi.putObject()
+		tassert.CheckFatal(t, err)
+	}
+	put("rebalanced", cmn.OwtRebalance)
+	put("created", cmn.OwtPut)
+
+	// (delivered sequentially, in the order of generation)
+	select {
+	case ev := <-events:
+		tassert.Errorf(t, ev.Name == "created" && ev.Type == apc.EvtObjCreated, "unexpected event %+v", ev)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+// data keys "wrapped" as is
+type testKeyProvider struct{}
+
+func (testKeyProvider) Name() string                                    { return "test" }
+func (testKeyProvider) Wrap(dek []byte) ([]byte, string, error)         { return dek, "test", nil }
+func (testKeyProvider) Unwrap(wrapped []byte, _ string) ([]byte, error) { return wrapped, nil }
+
+// objects that are stored compressed, encrypted, packed, or chunked get sent (decoded) and
+// received as per the receiving bucket's configuration (compare w/ reb/globrun.go and reb/recv.go)
+func TestObjRebalanceRecv(t *testing.T) {
+	var (
+		tgt   = core.T.(*target)
+		cksum = cmn.CksumConf{Type: cos.ChecksumXXHash}
+		keys  = []cmn.DataKey{{ID: 1, Wrapped: bytes.Repeat([]byte{1}, crypt.KeySize), MasterID: "test"}}
+		bmd   = tgt.owner.bmd.get().clone()
+		bcks  = map[string]*cmn.Bprops{
+			"reb-plain": {Cksum: cksum},
+			"reb-lz4":   {Cksum: cksum, Compress: cmn.CompressConf{Algo: zblk.AlgoLZ4, BlockSize: 4 * cos.KiB, Enabled: true}},
+			"reb-enc":   {Cksum: cksum, Encrypt: cmn.EncryptConf{Keys: keys, Enabled: true}},
+			"reb-zstd-enc": {
+				Cksum: cksum, Compress: cmn.CompressConf{Algo: zblk.AlgoZstd, Enabled: true}, Encrypt: cmn.EncryptConf{Keys: keys, Enabled: true},
+			},
+			"reb-pack":   {Cksum: cksum, Pack: cmn.PackConf{MaxObjSize: 4 * cos.KiB, Enabled: true}},
+			"reb-chunks": {Cksum: cksum, Chunks: cmn.ChunkConf{ChunkSize: 4 * cos.KiB, MinObjSize: 8 * cos.KiB, Enabled: true}},
+		}
+	)
+	crypt.SetProvider(testKeyProvider{})
+	t.Cleanup(func() { crypt.SetProvider(nil) })
+	for name, props := range bcks {
+		bmd.add(meta.NewBck(name, apc.AIS, cmn.NsGlobal), props)
+	}
+	tassert.CheckFatal(t, tgt.owner.bmd.putPersist(bmd, nil))
+	for name := range bcks {
+		fs.CreateBucket(&cmn.Bck{Name: name, Provider: apc.AIS, Ns: cmn.NsGlobal}, false /*nilbmd*/)
+	}
+
+	newLOM := func(bname, objName string) *core.LOM {
+		lom := core.AllocLOM(objName)
+		tassert.CheckFatal(t, lom.InitBck(&cmn.Bck{Name: bname, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+		return lom
+	}
+	put := func(lom *core.LOM, content []byte, owt cmn.OWT, cksum *cos.Cksum) {
+		params := core.AllocPutParams()
+		{
+			params.WorkTag = fs.WorkfilePut
+			params.Reader = io.NopCloser(bytes.NewReader(content))
+			params.Size = int64(len(content))
+			params.OWT = owt
+			params.Cksum = cksum
+			params.Atime = time.Now()
+		}
+		tassert.CheckFatal(t, tgt.PutObject(lom, params))
+		core.FreePutParams(params)
+	}
+	check := func(lom *core.LOM, content []byte) {
+		lom.UncacheUnless()
+		tassert.CheckFatal(t, lom.Load(false, false))
+		tassert.Fatalf(t, lom.SizeBytes() == int64(len(content)), "%s: size %d != %d", lom, lom.SizeBytes(), len(content))
+		tassert.CheckFatal(t, lom.ValidateContentChecksum())
+		tassert.Errorf(t, lom.IsCompressed() == lom.CompressEnabled(), "%s: compressed %t", lom, lom.IsCompressed())
+		tassert.Errorf(t, lom.IsEncrypted() == lom.EncryptEnabled(), "%s: encrypted %t", lom, lom.IsEncrypted())
+		var (
+			pack   = &lom.Bprops().Pack
+			chunks = &lom.Bprops().Chunks
+			size   = int64(len(content))
+		)
+		tassert.Errorf(t, lom.IsPacked() == (pack.Enabled && size <= pack.ObjSize()), "%s: packed %t", lom, lom.IsPacked())
+		tassert.Errorf(t, lom.IsChunked() == (chunks.Enabled && size >= chunks.ObjSize()), "%s: chunked %t", lom, lom.IsChunked())
+		fh, err := lom.Open()
+		tassert.CheckFatal(t, err)
+		b, err := io.ReadAll(fh)
+		fh.Close()
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b, content), "%s: content mismatch", lom)
+	}
+
+	for src := range bcks {
+		size := 50 * cos.KiB
+		switch src {
+		case "reb-pack":
+			size = 3 * cos.KiB
+		case "reb-chunks":
+			size = 10*cos.KiB + 123
+		}
+		content := make([]byte, size)
+		_, _ = rand.Read(content[:size/2]) // (compressible)
+
+		lom := newLOM(src, "reb-obj")
+		put(lom, content, cmn.OwtPut, nil)
+		check(lom, content)
+
+		// send
+		lom.Lock(false)
+		tassert.CheckFatal(t, lom.Load(false, true))
+		oa := &cmn.ObjAttrs{}
+		oa.CopyFrom(lom.ObjAttrs(), false /*skip cksum*/)
+		roc, err := lom.NewDeferROC() // (unlocks upon close)
+		tassert.CheckFatal(t, err)
+		b, err := io.ReadAll(roc)
+		roc.Close()
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b, content), "%s: sent content mismatch", lom)
+		core.FreeLOM(lom)
+
+		// receive (a different name - not to overwrite the sender)
+		for dst := range bcks {
+			rlom := newLOM(dst, "reb-obj-"+src)
+			rlom.CopyAttrs(oa, true /*skip-checksum*/)
+			put(rlom, b, cmn.OwtRebalance, oa.Cksum)
+			check(rlom, content)
+			core.FreeLOM(rlom)
+		}
+	}
+}
+
+// fast cold GET (default checksum config - see coldSeek) generates cold-GET event
+func TestObjColdGetEvents(t *testing.T) {
+	var (
+		tgt    = core.T.(*target)
+		events = make(chan apc.ObjEvent, 4)
+		bp     = mock.NewBackend()
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var ev apc.ObjEvent
+		if err := jsoniter.NewDecoder(r.Body).Decode(&ev); err == nil {
+			events <- ev
+		}
+	}))
+	defer ts.Close()
+	webhook.Init(tgt.statsT, &cmn.GCO.Get().Client)
+
+	config := cmn.GCO.BeginUpdate()
+	providers := config.Backend.Providers
+	config.Backend.Providers = map[string]cmn.Ns{apc.AWS: cmn.NsGlobal}
+	cmn.GCO.CommitUpdate(config)
+	tgt.backend[apc.AWS] = bp
+	t.Cleanup(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Backend.Providers = providers
+		cmn.GCO.CommitUpdate(config)
+		delete(tgt.backend, apc.AWS)
+	})
+
+	bck := meta.NewBck("bck-cold-events", apc.AWS, cmn.NsGlobal)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.Bprops{
+		Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash}, // (default)
+		Events: cmn.EventsConf{Rules: []cmn.EventRule{{Endpoint: ts.URL}}, Enabled: true},
+	})
+	tassert.CheckFatal(t, tgt.owner.bmd.putPersist(bmd, nil))
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+
+	lom := core.AllocLOM("cold")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	bp.Objs[lom.Uname()] = make([]byte, cos.KiB)
+
+	goi := &getOI{
+		ctx:   context.Background(),
+		t:     tgt,
+		lom:   lom,
+		w:     newDiscardRW(),
+		atime: time.Now().UnixNano(),
+		ltime: mono.NanoTime(),
+	}
+	_, err := goi.getObject()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, goi.cold, "expected cold GET")
+
+	select {
+	case ev := <-events:
+		tassert.Errorf(t, ev.Name == "cold" && ev.Type == apc.EvtObjColdGet && ev.Size == cos.KiB, "unexpected event %+v", ev)
+		tassert.Errorf(t, strings.HasPrefix(ev.Cksum, cos.ChecksumXXHash+":"), "expected checksum, got %+v", ev)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
 func BenchmarkObjPut(b *testing.B) {
 	benches := []struct {
 		fileSize int64
@@ -366,6 +366,17 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	// .5 finalize
 	lom.SetSize(size)
 	lom.SetCustomKey(cmn.ETag, etag)
+	// compress and/or encrypt, if configured
+	buf, slab = t.gmm.Alloc()
+	errE := lom.EncodeFile(wfqn, buf)
+	slab.Free(buf)
+	if errE != nil {
+		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+			nlog.Errorf(fmtNested, t, errE, "remove", wfqn, nerr)
+		}
+		s3.WriteMptErr(w, r, errE, 0, lom, uploadID)
+		return
+	}
 
 	poi := allocPOI()
 	{
@@ -541,7 +552,7 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	if err != nil {
 		s3.WriteErr(w, r, err, status)
 	}
-	fh, err := os.Open(lom.FQN)
+	fh, err := lom.Open()
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
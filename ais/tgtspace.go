@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
@@ -30,6 +31,12 @@ const (
 	minAutoDetectInterval = 10 * time.Minute
 )
 
+// automatic tiering: how often to (re)start tiering xaction (see tierHK)
+const (
+	tierIvalMin = time.Minute
+	tierIvalMax = time.Hour
+)
+
 var (
 	lastTrigOOS atomic.Int64
 )
@@ -63,13 +70,13 @@ func (t *target) OOS(csRefreshed *fs.CapStatus) (cs fs.CapStatus) {
 		lastTrigOOS.Store(mono.NanoTime())
 		if cs.Err() != nil {
 			nlog.Warningln(t.String(), "still out of space, running LRU eviction now:", cs.String())
-			t.runLRU("" /*uuid*/, nil /*wg*/, false)
+			t.runLRU("" /*uuid*/, nil /*wg*/, false /*force*/, false /*dry-run*/)
 		}
 	}()
 	return
 }
 
-func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.Bck) {
+func (t *target) runLRU(id string, wg *sync.WaitGroup, force, dryRun bool, bcks ...cmn.Bck) {
 	regToIC := id == ""
 	if regToIC {
 		id = cos.GenUUID()
@@ -98,6 +105,7 @@ func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.B
 		GetFSStats:          ios.GetFSStats,
 		WG:                  wg,
 		Force:               force,
+		DryRun:              dryRun,
 	}
 	xlru.AddNotif(&xact.NotifXact{
 		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
@@ -139,3 +147,30 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 	})
 	return space.RunCleanup(&ini)
 }
+
+// housekeeping: periodically start tiering xaction for each bucket that has tiering enabled
+// - skipping while cluster is starting up or rebalancing/resilvering
+// - the interval is half the smallest configured `tier.age`, within [tierIvalMin, tierIvalMax]
+// - compare w/ on-demand apc.ActTier
+func (t *target) tierHK() time.Duration {
+	ival := tierIvalMax
+	if !t.ClusterStarted() || t.regstate.disabled.Load() {
+		return tierIvalMin
+	}
+	if xreg.GetRebMarked().Xact != nil || xreg.GetResilverMarked().Xact != nil {
+		return tierIvalMin
+	}
+	bmd := t.owner.bmd.get()
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		conf := &bck.Props.Tier
+		if !conf.Enabled {
+			return false
+		}
+		ival = min(ival, max(conf.Age.D()/2, tierIvalMin))
+		if rns := xreg.RenewTier(cos.GenUUID(), bck); rns.Err != nil {
+			nlog.Warningln(t.String(), "failed to start tiering", bck.Cname(""), rns.Err)
+		}
+		return false
+	})
+	return ival
+}
@@ -144,6 +144,10 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 		xid, err = t.tcobjs(c, tcomsg, dp)
 	case apc.ActECEncode:
 		xid, err = t.ecEncode(c)
+	case apc.ActRenamePrefix:
+		xid, err = t.renamePrefix(c)
+	case apc.ActCreateSnap, apc.ActRestoreSnap:
+		xid, err = t.snapshot(c)
 	case apc.ActArchive:
 		xid, err = t.createArchMultiObj(c)
 	case apc.ActStartMaintenance, apc.ActDecommissionNode, apc.ActShutdownNode:
@@ -780,6 +784,199 @@ func (t *target) validateECEncode(bck *meta.Bck, msg *aisMsg) error {
 	return xreg.LimitedCoexistence(t.si, bck, msg.Action)
 }
 
+//
+// create or restore bucket snapshot
+//
+
+func (t *target) snapshot(c *txnSrv) (string, error) {
+	switch c.phase {
+	case apc.ActBegin:
+		if err := c.bck.Init(t.owner.bmd); err != nil {
+			return "", err
+		}
+		snap, err := t.validateSnap(c.bck, c.msg)
+		if err != nil {
+			return "", err
+		}
+		nlp := newBckNLP(c.bck)
+		if !nlp.TryLock(c.timeout.netw / 2) {
+			return "", cmn.NewErrBusy("bucket", c.bck, "")
+		}
+		// fence writes until done (see core/lsnap.go)
+		if !core.FenceWrites(c.bck.Bucket(), c.uuid) {
+			nlp.Unlock()
+			return "", cmn.NewErrBusy("bucket", c.bck, "snapshot in progress")
+		}
+		txn := newTxnSnap(c, snap)
+		if err := t.transactions.begin(txn, nlp); err != nil {
+			core.UnfenceWrites(c.bck.Bucket(), c.uuid)
+			return "", err
+		}
+	case apc.ActAbort:
+		t.transactions.find(c.uuid, apc.ActAbort) // (unfences - see txnSnap.abort)
+	case apc.ActCommit:
+		if err := c.bck.Init(t.owner.bmd); err != nil {
+			return "", err
+		}
+		txn, err := t.transactions.find(c.uuid, "")
+		if err != nil {
+			return "", err
+		}
+		txnSnap := txn.(*txnSnap)
+		if c.msg.Action == apc.ActCreateSnap {
+			// wait for newBMD (that contains the snapshot) w/timeout
+			if err = t.transactions.wait(txn, c.timeout.netw, c.timeout.host); err != nil {
+				return "", cmn.NewErrFailedTo(t, "commit", txn, err)
+			}
+		} else {
+			t.transactions.find(c.uuid, apc.ActCommit)
+		}
+		snap := txnSnap.snap
+		if err := snap.Init(t.owner.bmd); err != nil {
+			core.UnfenceWrites(c.bck.Bucket(), c.uuid)
+			return "", err
+		}
+		rns := xreg.RenewSnap(c.msg.Action, c.uuid, c.bck, snap)
+		if rns.Err != nil {
+			core.UnfenceWrites(c.bck.Bucket(), c.uuid)
+			nlog.Errorf("%s: %s %v", t, txn, rns.Err)
+			return "", rns.Err
+		}
+		xctn := rns.Entry.Get()
+		c.addNotif(xctn) // notify upon completion
+		xact.GoRunW(xctn)
+
+		return xctn.ID(), nil
+	default:
+		debug.Assert(false)
+	}
+	return "", nil
+}
+
+func (t *target) validateSnap(bck *meta.Bck, msg *aisMsg) (snap *meta.Bck, err error) {
+	if err = validateSnapSrc(bck); err != nil {
+		return
+	}
+	if err = cmn.ValidateSnapName(msg.Name); err != nil {
+		return
+	}
+	sbck := bck.Bucket().SnapBck(msg.Name)
+	snap = meta.CloneBck(&sbck)
+	switch msg.Action {
+	case apc.ActCreateSnap:
+		if err = snap.InitNoBackend(t.owner.bmd); err == nil {
+			return nil, cmn.NewErrBckAlreadyExists(snap.Bucket())
+		}
+		err = nil
+	case apc.ActRestoreSnap:
+		if err = snap.InitNoBackend(t.owner.bmd); err != nil {
+			return
+		}
+	}
+	// one snapshot operation per bucket at a time
+	for _, kind := range []string{apc.ActCreateSnap, apc.ActRestoreSnap} {
+		if entry := xreg.GetRunning(xreg.Flt{Kind: kind, Bck: bck}); entry != nil {
+			return nil, cmn.NewErrBusy("bucket", bck, entry.Get().Name())
+		}
+	}
+	err = xreg.LimitedCoexistence(t.si, bck, msg.Action)
+	return
+}
+
+//
+// renamePrefix
+//
+
+func (t *target) renamePrefix(c *txnSrv) (string, error) {
+	switch c.phase {
+	case apc.ActBegin:
+		if err := c.bck.Init(t.owner.bmd); err != nil {
+			return "", err
+		}
+		rp := &cmn.PrefixRename{}
+		if err := cos.MorphMarshal(c.msg.Value, rp); err != nil {
+			return "", fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, c.msg.Action, c.msg.Value, err)
+		}
+		if err := t.validateRenPrefix(c.bck, rp, c.msg); err != nil {
+			return "", err
+		}
+		nlp := newBckNLP(c.bck)
+		if !nlp.TryLock(c.timeout.netw / 4) {
+			return "", cmn.NewErrBusy("bucket", c.bck, "")
+		}
+		txn := newTxnRenamePrefix(c, c.bck)
+		if err := t.transactions.begin(txn, nlp); err != nil {
+			return "", err
+		}
+	case apc.ActAbort:
+		t.transactions.find(c.uuid, apc.ActAbort)
+	case apc.ActCommit:
+		txn, err := t.transactions.find(c.uuid, "")
+		if err != nil {
+			return "", err
+		}
+		// wait for newBMD w/timeout (the phase to execute is stored in the bucket's props)
+		if err = t.transactions.wait(txn, c.timeout.netw, c.timeout.host); err != nil {
+			return "", cmn.NewErrFailedTo(t, "commit", txn, err)
+		}
+		if err := c.bck.Init(t.owner.bmd); err != nil {
+			return "", err
+		}
+		rns := xreg.RenewRenamePrefix(c.uuid, c.bck)
+		if rns.Err != nil {
+			nlog.Errorf("%s: %s %v", t, txn, rns.Err)
+			return "", rns.Err
+		}
+		xctn := rns.Entry.Get()
+		c.addNotif(xctn) // notify upon completion
+		xact.GoRunW(xctn)
+		return xctn.ID(), nil
+	default:
+		debug.Assert(false)
+	}
+	return "", nil
+}
+
+func (t *target) validateRenPrefix(bck *meta.Bck, rp *cmn.PrefixRename, msg *aisMsg) error {
+	cs := fs.Cap()
+	if err := cs.Err(); err != nil {
+		return err
+	}
+	if err := xreg.LimitedCoexistence(t.si, bck, msg.Action); err != nil {
+		return err
+	}
+	if entry := xreg.GetRunning(xreg.Flt{Kind: apc.ActRenamePrefix, Bck: bck}); entry != nil {
+		return cmn.NewErrBusy("bucket", bck, entry.Get().Name())
+	}
+	if bck.Props.RenPrefix != nil || rp.Phase != cmn.RenPrefixCopy {
+		return nil // (resuming)
+	}
+	// new names must not exist
+	for _, mi := range fs.GetAvail() {
+		var found string
+		opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Prefix: rp.To}
+		opts.Bck.Copy(bck.Bucket())
+		opts.Callback = func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			ct, err := core.NewCTFromFQN(fqn, nil)
+			if err == nil && strings.HasPrefix(ct.ObjectName(), rp.To) {
+				found = ct.ObjectName()
+				return cmn.NewErrAborted("walk", found, nil)
+			}
+			return nil
+		}
+		if err := fs.Walk(opts); err != nil && found == "" {
+			return err
+		}
+		if found != "" {
+			return fmt.Errorf("%s: cannot rename %q => %q: %s already exists", t, rp.From, rp.To, bck.Cname(found))
+		}
+	}
+	return nil
+}
+
 //
 // createArchMultiObj
 //
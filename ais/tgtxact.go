@@ -202,7 +202,7 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 		}
 		wg := &sync.WaitGroup{}
 		wg.Add(1)
-		go t.runLRU(args.ID, wg, args.Force, args.Buckets...)
+		go t.runLRU(args.ID, wg, args.Force, args.DryRun, args.Buckets...)
 		wg.Wait()
 	case apc.ActStoreCleanup:
 		wg := &sync.WaitGroup{}
@@ -231,6 +231,27 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(args.ID, bck)
 		return xid, rns.Err
+	case apc.ActTier:
+		rns := xreg.RenewTier(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActReplResync:
+		rns := xreg.RenewReplResync(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActScrub:
+		rns := xreg.RenewScrub(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActReencrypt:
+		rns := xreg.RenewReencrypt(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActNameIdx:
+		rns := xreg.RenewNameIdx(args.ID, bck)
+		return xid, rns.Err
+	case apc.ActExportList:
+		// default format, props, and naming (see cmn.ExportListMsg)
+		if len(args.Buckets) == 0 {
+			return xid, fmt.Errorf("%q requires destination bucket", args)
+		}
+		return xid, t.runExportList(args.ID, bck, &cmn.ExportListMsg{ToBck: args.Buckets[0]})
 	case apc.ActBlobDl:
 		debug.Assert(msg.Name != "")
 		lom := core.AllocLOM(msg.Name)
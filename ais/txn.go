@@ -120,6 +120,13 @@ type (
 	txnECEncode struct {
 		txnBckBase
 	}
+	txnRenamePrefix struct {
+		txnBckBase
+	}
+	txnSnap struct {
+		snap *meta.Bck
+		txnBckBase
+	}
 	txnArchMultiObj struct {
 		xarch *xs.XactArch
 		msg   *cmn.ArchiveBckMsg
@@ -146,6 +153,8 @@ var (
 	_ txn = (*txnTCB)(nil)
 	_ txn = (*txnTCObjs)(nil)
 	_ txn = (*txnECEncode)(nil)
+	_ txn = (*txnRenamePrefix)(nil)
+	_ txn = (*txnSnap)(nil)
 	_ txn = (*txnPromote)(nil)
 )
 
@@ -610,6 +619,38 @@ func newTxnECEncode(c *txnSrv, bck *meta.Bck) (txn *txnECEncode) {
 	return
 }
 
+/////////////////////
+// txnRenamePrefix //
+/////////////////////
+
+func newTxnRenamePrefix(c *txnSrv, bck *meta.Bck) (txn *txnRenamePrefix) {
+	txn = &txnRenamePrefix{}
+	txn.init(bck)
+	txn.fillFromCtx(c)
+	return
+}
+
+/////////////
+// txnSnap //
+/////////////
+
+func newTxnSnap(c *txnSrv, snap *meta.Bck) (txn *txnSnap) {
+	txn = &txnSnap{snap: snap}
+	txn.init(c.bck)
+	txn.fillFromCtx(c)
+	return
+}
+
+func (txn *txnSnap) abort(err error) {
+	txn.unlock()
+	core.UnfenceWrites(txn.bck.Bucket(), txn.uuid())
+	nlog.Infoln(txn.String(), "aborted:", err)
+}
+
+func (txn *txnSnap) String() string {
+	return txn.txnBckBase.String() + "-snap(" + txn.snap.Name + ")"
+}
+
 ///////////////////////////
 // txnCreateArchMultiObj //
 ///////////////////////////
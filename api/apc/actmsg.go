@@ -38,6 +38,34 @@ const (
 
 	ActMakeNCopies = "make-n-copies"
 	ActPutCopies   = "put-copies"
+	ActWriteBack   = "write-back" // see apc.WriteDelayed
+
+	// bucket snapshots (cmn/snap.go)
+	ActCreateSnap  = "create-snapshot"
+	ActRestoreSnap = "restore-snapshot"
+	ActDestroySnap = "destroy-snapshot"
+
+	// move cold objects to the bucket's tier (see cmn.TierConf)
+	ActTier = "tier-cold"
+
+	// async replication to remote AIS cluster (see cmn.ReplConf)
+	ActReplicate  = "replicate"
+	ActReplResync = "repl-resync"
+
+	// verify object checksums and repair corrupted replicas (see xs.XactScrub)
+	ActScrub = "scrub"
+
+	// rotate bucket's data key and rewrite (re-encrypt) existing objects (see xs.XactReencrypt)
+	ActReencrypt = "re-encrypt"
+
+	// (re)build persistent name index of an ais:// bucket (see cmn.NameIdxConf and xs.XactNameIdx)
+	ActNameIdx = "name-index"
+
+	// write bucket listing as CSV, JSONL, or Parquet object(s) (see cmn.ExportListMsg and xs.XactExportList)
+	ActExportList = "export-list"
+
+	// rename virtual directory, all or nothing (see cmn.RenamePrefixMsg and xs.XactRenPrefix)
+	ActRenamePrefix = "rename-prefix"
 
 	ActRebalance = "rebalance"
 	ActMoveBck   = "move-bck"
@@ -56,6 +84,7 @@ const (
 	ActNewPrimary     = "new-primary"
 	ActPromote        = "promote"
 	ActRenameObject   = "rename-obj"
+	ActCompose        = "compose" // server-side concatenation (see cmn.ComposeMsg)
 
 	// cp (reverse)
 	ActResetStats  = "reset-stats"
@@ -169,6 +198,7 @@ type (
 		Available []string `json:"available"`
 		WaitingDD []string `json:"waiting_dd"`
 		Disabled  []string `json:"disabled"`
+		Degraded  []string `json:"degraded,omitempty"` // (subset of available)
 	}
 )
 
@@ -26,9 +26,10 @@ type (
 			Max int64 `json:"obj_max_size"`
 		}
 		TotalSize struct {
-			OnDisk      uint64 `json:"size_on_disk,string"`          // sum(dir sizes) aka "apparent size"
-			PresentObjs uint64 `json:"size_all_present_objs,string"` // sum(cached object sizes)
-			RemoteObjs  uint64 `json:"size_all_remote_objs,string"`  // sum(all object sizes in a remote bucket)
+			OnDisk      uint64 `json:"size_on_disk,string"`           // sum(dir sizes) aka "apparent size"
+			PresentObjs uint64 `json:"size_all_present_objs,string"`  // sum(cached object sizes)
+			PhysObjs    uint64 `json:"phys_size_present_objs,string"` // ditto, physical (less than the above when compressed at rest)
+			RemoteObjs  uint64 `json:"size_all_remote_objs,string"`   // sum(all object sizes in a remote bucket)
 			Disks       uint64 `json:"total_disks_size,string"`
 		}
 		UsedPct      uint64 `json:"used_pct"`
@@ -0,0 +1,33 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// object event notifications (webhooks) - see cmn.EventsConf
+
+// event types
+const (
+	EvtObjCreated  = "created"       // PUT, copy, promote, archive, transform
+	EvtObjDeleted  = "deleted"       // ditto
+	EvtObjEvicted  = "evicted"       // evicted (remote buckets) or removed by LRU
+	EvtObjColdGet  = "cold-get"      // ditto (includes prefetch)
+	EvtObjAppended = "arch-appended" // appended to existing archive (see api.PutApndArch)
+)
+
+var SupportedEvents = []string{EvtObjCreated, EvtObjDeleted, EvtObjEvicted, EvtObjColdGet, EvtObjAppended}
+
+// ObjEvent is POST-ed (as JSON) to the configured endpoint(s)
+//   - delivery is at-least-once: the same event may be delivered more than once
+//     (use ID to deduplicate)
+type ObjEvent struct {
+	ID      string `json:"id"`   // unique
+	Type    string `json:"type"` // one of the event types (above)
+	Bucket  string `json:"bucket"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Cksum   string `json:"checksum,omitempty"` // "type:value"
+	Node    string `json:"node"`               // target ID
+	Size    int64  `json:"size,omitempty"`
+	Time    int64  `json:"time"` // Unix nanoseconds
+}
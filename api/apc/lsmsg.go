@@ -66,10 +66,10 @@ const (
 	LsWantOnlyRemoteProps
 
 	// List bucket entries without recursion (POSIX-wise). Note that the result in this case
-	// will include matching directories.
-	// TODO: works only with AIS bucket and only via S3 (no CLI yet). More exactly:
-	// - update AIS CLI to support non-recursive list-objects operation
-	// - when listing remote bucket, call backend (`Backend()`) to list non-recursively
+	// will include matching directories (flagged `EntryIsDir`, no trailing '/').
+	// Remote buckets get listed via their respective backends using '/' as a delimiter,
+	// while in-cluster (`LsObjCached`) listing walks local directories.
+	// (Not supported with `LsInventory`.)
 	LsNoRecursion
 
 	// For remote metadata-capable buckets (ie., bck.HasVersioningMD() == true):
@@ -147,6 +147,7 @@ type LsoMsg struct {
 	Props             string `json:"props"`              // comma-delimited, e.g. "checksum,size,custom" (see GetProps* enum)
 	TimeFormat        string `json:"time_format"`        // RFC822 is the default
 	Prefix            string `json:"prefix"`             // return obj names starting with prefix (TODO: e.g. "A.tar/tutorials/")
+	Filter            string `json:"filter,omitempty"`   // filter expression, e.g. "size > 1MiB and name = *.tar" (see cmn.LsoFilter)
 	StartAfter        string `json:"start_after"`        // start listing after (AIS buckets only)
 	ContinuationToken string `json:"continuation_token"` // => LsoResult.ContinuationToken => LsoMsg.ContinuationToken
 	SID               string `json:"target"`             // selected target to solely execute backend.list-objects
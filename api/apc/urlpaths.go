@@ -33,6 +33,7 @@ const (
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
 	IC        = "ic"       // information center
+	Batch     = "batch"    // multi-object GET (see cmn.GetBatchMsg)
 
 	// l3 ---
 
@@ -108,6 +109,7 @@ var (
 	URLPathHealth    = urlpath(Version, Health)
 	URLPathMetasync  = urlpath(Version, Metasync)
 	URLPathRebalance = urlpath(Version, Rebalance)
+	URLPathBatch     = urlpath(Version, Batch)
 
 	URLPathClu        = urlpath(Version, Cluster)
 	URLPathCluProxy   = urlpath(Version, Cluster, Proxy)
@@ -8,12 +8,15 @@ import "fmt"
 
 // write policy (enum and accessors)
 // applies to both AIS metadata and data; bucket-configurable with global defaults via cluster config
+//   - metadata: "delayed" means cache and flush when not accessed for a while (lom_cache_hk.go)
+//   - data: "delayed" means write-back - that is, PUT to a remote bucket completes after writing locally,
+//     while writing to remote backend is done asynchronously (xact/xs/wback.go)
 type WritePolicy string
 
 const (
 	WriteImmediate = WritePolicy("immediate") // immediate write (default)
-	WriteDelayed   = WritePolicy("delayed")   // cache and flush when not accessed for a while (lom_cache_hk.go)
-	WriteNever     = WritePolicy("never")     // transient - in-memory only
+	WriteDelayed   = WritePolicy("delayed")   // see above
+	WriteNever     = WritePolicy("never")     // transient - in-memory only (metadata only)
 
 	WriteDefault = WritePolicy("") // same as `WriteImmediate` - see IsImmediate() below
 )
@@ -0,0 +1,51 @@
+// Package api provides Go based AIStore API/SDK over HTTP(S)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// GetBatch retrieves multiple objects and/or files archived in shards - possibly
+// from different buckets - as a single archive (TAR by default) written into `w`.
+// Entries are written in the order of `msg.In`; entries that belong to a bucket other
+// than `bck` are named `<bucket-name>/<object-name>` (see cmn.GetBatchIn.NameInArch).
+// Returns the number of bytes written.
+func GetBatch(bp BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg, w io.Writer) (int64, error) {
+	reqParams := batchReqParams(bp, bck, msg)
+	wresp, err := reqParams.doWriter(w)
+	FreeRp(reqParams)
+	if err != nil {
+		return 0, err
+	}
+	return wresp.n, nil
+}
+
+// same as above except that it returns the resulting archive as io.ReadCloser
+// for subsequent (streaming) reading; caller is responsible for closing the reader
+func GetBatchReader(bp BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg) (io.ReadCloser, error) {
+	reqParams := batchReqParams(bp, bck, msg)
+	r, _, err := reqParams.doReader()
+	FreeRp(reqParams)
+	return r, err
+}
+
+func batchReqParams(bp BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg) *ReqParams {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBatch.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	return reqParams
+}
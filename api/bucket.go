@@ -223,6 +223,49 @@ func RenameBucket(bp BaseParams, bckFrom, bckTo cmn.Bck) (xid string, err error)
 	return
 }
 
+// RenamePrefix renames the virtual directory `msg.From` as `msg.To` within a given ais:// bucket,
+// all or nothing: readers see either the old or the new names (but never both).
+// The same call resumes the rename that has been interrupted, or aborts it (msg.Abort).
+// Returns xaction ID of the current phase (copy, commit, or rollback) - see cmn.PrefixRename
+func RenamePrefix(bp BaseParams, bck cmn.Bck, msg *cmn.RenamePrefixMsg) (xid string, err error) {
+	if err = msg.Validate(); err != nil {
+		return
+	}
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActRenamePrefix, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return
+}
+
+// ExportList writes the listing of a given bucket into the destination bucket (`msg.ToBck`)
+// as one or more CSV, JSONL, or Parquet objects - one (or more) per target (see cmn.ExportListMsg).
+// Returns xaction ID.
+func ExportList(bp BaseParams, bck cmn.Bck, msg *cmn.ExportListMsg) (xid string, err error) {
+	if err = msg.Validate(&bck); err != nil {
+		return
+	}
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActExportList, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return
+}
+
 // EvictRemoteBucket sends request to evict an entire remote bucket from the AIStore
 // - keepMD: evict objects but keep bucket metadata
 func EvictRemoteBucket(bp BaseParams, bck cmn.Bck, keepMD bool) error {
@@ -24,6 +24,8 @@ var (
 		CertKey       string
 		ClientCA      string
 		SkipVerifyCrt string
+		// encryption at rest
+		MasterKeyFile string
 		// tests, CI
 		NumTarget string
 		NumProxy  string
@@ -48,6 +50,9 @@ var (
 		// TLS: common
 		SkipVerifyCrt: "AIS_SKIP_VERIFY_CRT", // cluster config: "net.http.skip_verify"
 
+		// master key to wrap (encrypt) bucket data keys (see cmn/crypt)
+		MasterKeyFile: "AIS_MASTER_KEY_FILE",
+
 		// variables used in tests and CI
 		NumTarget: "NUM_TARGET",
 		NumProxy:  "NUM_PROXY",
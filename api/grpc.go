@@ -0,0 +1,337 @@
+// Package api provides Go based AIStore API/SDK over HTTP(S)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/rpc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xact"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// gRPC client: object GET (streaming), PUT (streaming), HEAD, and DELETE; list-objects;
+// xaction start and status.
+// - requires aistore node(s) listening on the configured `port_grpc` (see api/rpc);
+// - proxy serves all of the above; target - object operations only;
+// - object calls get redirected by proxy to the owning target (see rpc.MdLocation) - the
+//   client follows, and keeps the connections to redirect endpoints open for reuse;
+// - errors are returned as *cmn.ErrHTTP - same as HTTP (see also: api.HTTPStatus)
+
+type GRPCParams struct {
+	Conn  *grpc.ClientConn                                // e.g. grpc.Dial(<aistore node>:<port_grpc>, ...); is not closed by the API
+	Ctx   context.Context                                 // optional; defaults to context.Background()
+	Dial  func(endpoint string) (*grpc.ClientConn, error) // to follow redirects; nil: grpc.Dial with insecure credentials
+	Token string                                          // authentication token (same as BaseParams.Token)
+}
+
+const grpcMaxRedirects = 2 // proxy => target, and target => target (when Smap changes)
+
+// redirect endpoint => connection
+var grpcConns sync.Map
+
+func (gp *GRPCParams) ctx() context.Context {
+	ctx := gp.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if gp.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+gp.Token)
+	}
+	return ctx
+}
+
+func (*GRPCParams) opts(trailer *metadata.MD) []grpc.CallOption {
+	return []grpc.CallOption{
+		grpc.CallContentSubtype(rpc.Codec),
+		grpc.MaxCallRecvMsgSize(rpc.MaxMsgSize),
+		grpc.Trailer(trailer),
+	}
+}
+
+func (gp *GRPCParams) invoke(method, httpMethod string, in, out any) error {
+	req, _ := in.(*rpc.ObjReq)
+	for i := 0; ; i++ {
+		var trailer metadata.MD
+		err := gp.Conn.Invoke(gp.ctx(), method, in, out, gp.opts(&trailer)...)
+		if err == nil {
+			return nil
+		}
+		if i < grpcMaxRedirects {
+			redirected, errR := gp.redirect(trailer, req)
+			if errR != nil {
+				return errR
+			}
+			if redirected {
+				continue
+			}
+		}
+		return rpc.ToErrHTTP(err, trailer, httpMethod)
+	}
+}
+
+// follow redirect, if any: switch to the new endpoint and use the redirect's query
+func (gp *GRPCParams) redirect(trailer metadata.MD, req *rpc.ObjReq) (bool, error) {
+	endpoint, query, err := rpc.Location(trailer)
+	if endpoint == "" || err != nil {
+		return false, err
+	}
+	if gp.Conn, err = gp.dial(endpoint); err != nil {
+		return false, err
+	}
+	if req != nil {
+		req.Query = make(map[string]string, len(query))
+		for k := range query {
+			req.Query[k] = query.Get(k)
+		}
+	}
+	return true, nil
+}
+
+func (gp *GRPCParams) dial(endpoint string) (*grpc.ClientConn, error) {
+	if conn, ok := grpcConns.Load(endpoint); ok {
+		return conn.(*grpc.ClientConn), nil
+	}
+	dial := gp.Dial
+	if dial == nil {
+		dial = func(endpoint string) (*grpc.ClientConn, error) {
+			return grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		}
+	}
+	conn, err := dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if other, loaded := grpcConns.LoadOrStore(endpoint, conn); loaded {
+		conn.Close()
+		return other.(*grpc.ClientConn), nil
+	}
+	return conn, nil
+}
+
+// Same as api.GetObject - object's content is streamed in `rpc.ChunkSize` messages.
+// Returns an error if the number of received bytes does not match the object's size.
+func GRPCGetObject(gp GRPCParams, bck cmn.Bck, objName string, args *GetArgs) (oah ObjAttrs, err error) {
+	var (
+		w, q, hdr = args.ret()
+		req       = &rpc.ObjReq{Bck: bck, Name: objName, Header: hdr}
+	)
+	if len(q) > 0 {
+		req.Query = make(map[string]string, len(q))
+		for k := range q {
+			req.Query[k] = q.Get(k)
+		}
+	}
+	for i := 0; ; i++ {
+		trailer, err := gp.get(req, w, &oah)
+		if err == nil {
+			break
+		}
+		if i < grpcMaxRedirects && oah.n == 0 {
+			redirected, errR := gp.redirect(trailer, req)
+			if errR != nil {
+				return oah, errR
+			}
+			if redirected {
+				continue
+			}
+		}
+		if trailer == nil {
+			return oah, err // (writer's error)
+		}
+		return oah, rpc.ToErrHTTP(err, trailer, http.MethodGet)
+	}
+	if cl := oah.wrespHeader.Get(cos.HdrContentLength); cl != "" {
+		if size, errV := strconv.ParseInt(cl, 10, 64); errV == nil && size != oah.n {
+			return oah, fmt.Errorf("GET %s: received %d bytes, expected %d", bck.Cname(objName), oah.n, size)
+		}
+	}
+	return oah, nil
+}
+
+func (gp *GRPCParams) get(req *rpc.ObjReq, w io.Writer, oah *ObjAttrs) (trailer metadata.MD, err error) {
+	ctx, cancel := context.WithCancel(gp.ctx())
+	defer cancel()
+	stream, err := gp.Conn.NewStream(ctx, &rpc.GetStream, rpc.MethodGet, gp.opts(&trailer)...)
+	if err != nil {
+		return trailer, err
+	}
+	if err = stream.SendMsg(req); err == nil {
+		err = stream.CloseSend()
+	}
+	for err == nil {
+		var msg rpc.ObjChunk
+		if err = stream.RecvMsg(&msg); err != nil {
+			break
+		}
+		if msg.Header != nil {
+			oah.wrespHeader = msg.Header
+		}
+		if len(msg.Data) > 0 {
+			if _, errW := w.Write(msg.Data); errW != nil {
+				return nil, errW
+			}
+			oah.n += int64(len(msg.Data))
+		}
+	}
+	if err == io.EOF {
+		return nil, nil
+	}
+	return stream.Trailer(), err
+}
+
+// Same as api.PutObject - the reader's content is streamed in `rpc.ChunkSize` messages;
+// `args.BaseParams` is ignored.
+func GRPCPutObject(gp GRPCParams, args *PutArgs) (oah ObjAttrs, err error) {
+	var (
+		resp   rpc.ObjResp
+		reader io.ReadCloser = args.Reader
+		req                  = &rpc.ObjReq{Bck: args.Bck, Name: args.ObjName, Header: http.Header{}, Size: int64(args.Size)}
+	)
+	computed, err := args.setCksum(req.Header)
+	if err != nil {
+		return oah, err
+	}
+	if computed {
+		if reader, err = args.Reader.Open(); err != nil {
+			return oah, err
+		}
+	}
+	defer cos.Close(reader)
+	if args.SkipVC {
+		req.Query = map[string]string{apc.QparamSkipVC: "true"}
+	}
+	for i := 0; ; i++ {
+		trailer, sent, err := gp.put(req, reader, &resp)
+		if err == nil {
+			break
+		}
+		if i < grpcMaxRedirects && !sent {
+			redirected, errR := gp.redirect(trailer, req)
+			if errR != nil {
+				return oah, errR
+			}
+			if redirected {
+				continue
+			}
+		}
+		if trailer == nil {
+			return oah, err // (reader's error)
+		}
+		return oah, rpc.ToErrHTTP(err, trailer, http.MethodPut)
+	}
+	oah.wrespHeader = resp.Header
+	return oah, nil
+}
+
+// send the request and wait for the target's response header (see rpc.MdContinue) -
+// proxy, on the other hand, terminates the stream with redirect; only then stream the content
+func (gp *GRPCParams) put(req *rpc.ObjReq, reader io.Reader, resp *rpc.ObjResp) (trailer metadata.MD, sent bool, err error) {
+	ctx, cancel := context.WithCancel(gp.ctx())
+	defer cancel()
+	stream, err := gp.Conn.NewStream(ctx, &rpc.PutStream, rpc.MethodPut, gp.opts(&trailer)...)
+	if err != nil {
+		return trailer, false, err
+	}
+	if err = stream.SendMsg(&rpc.ObjChunk{Req: req}); err == nil {
+		var md metadata.MD
+		if md, err = stream.Header(); err == nil && md == nil {
+			err = io.EOF // (terminated by the server - see RecvMsg below)
+		}
+	}
+	if err == nil {
+		buf := make([]byte, rpc.ChunkSize)
+		sent = true
+		for {
+			n, errR := io.ReadFull(reader, buf)
+			if errR != nil && errR != io.EOF && errR != io.ErrUnexpectedEOF {
+				return nil, sent, errR
+			}
+			if n > 0 {
+				if err = stream.SendMsg(&rpc.ObjChunk{Data: buf[:n]}); err != nil {
+					break // (io.EOF: the server has terminated the stream - see RecvMsg below)
+				}
+			}
+			if errR != nil {
+				break
+			}
+		}
+	}
+	if err == nil || err == io.EOF {
+		if err = stream.CloseSend(); err == nil {
+			err = stream.RecvMsg(resp)
+		}
+	}
+	return stream.Trailer(), sent, err
+}
+
+// Same as api.HeadObject.
+func GRPCHeadObject(gp GRPCParams, bck cmn.Bck, objName string, fltPresence int, silent bool) (*cmn.ObjectProps, error) {
+	var (
+		resp rpc.ObjResp
+		req  = &rpc.ObjReq{Bck: bck, Name: objName, Query: map[string]string{apc.QparamFltPresence: strconv.Itoa(fltPresence)}}
+	)
+	if silent {
+		req.Query[apc.QparamSilent] = "true"
+	}
+	if err := gp.invoke(rpc.MethodHead, http.MethodHead, req, &resp); err != nil {
+		return nil, err
+	}
+	if fltPresence == apc.FltPresentNoProps {
+		return nil, nil
+	}
+	return hdr2props(resp.Header)
+}
+
+func GRPCDeleteObject(gp GRPCParams, bck cmn.Bck, objName string) error {
+	var resp rpc.ObjResp
+	return gp.invoke(rpc.MethodDelete, http.MethodDelete, &rpc.ObjReq{Bck: bck, Name: objName}, &resp)
+}
+
+// Same as api.ListObjectsPage - on success, updates `lsmsg.ContinuationToken`
+// to fetch the next page.
+func GRPCListObjectsPage(gp GRPCParams, bck cmn.Bck, lsmsg *apc.LsoMsg) (*cmn.LsoResult, error) {
+	if lsmsg == nil {
+		lsmsg = &apc.LsoMsg{}
+	}
+	page := &cmn.LsoResult{}
+	if err := gp.invoke(rpc.MethodListObjects, http.MethodGet, &rpc.LsoReq{Bck: bck, Msg: lsmsg}, page); err != nil {
+		return nil, err
+	}
+	lsmsg.UUID = page.UUID
+	lsmsg.ContinuationToken = page.ContinuationToken
+	return page, nil
+}
+
+// Same as api.StartXaction.
+func GRPCStartXaction(gp GRPCParams, args *xact.ArgsMsg, extra string) (string, error) {
+	if !xact.Table[args.Kind].Startable {
+		return "", fmt.Errorf("xaction %q is not startable", args.Kind)
+	}
+	var resp rpc.XactResp
+	err := gp.invoke(rpc.MethodStartXact, http.MethodPut, &rpc.XactReq{Args: args, Extra: extra}, &resp)
+	return resp.ID, err
+}
+
+// Same as api.GetOneXactionStatus.
+func GRPCGetOneXactionStatus(gp GRPCParams, args *xact.ArgsMsg) (*nl.Status, error) {
+	status := &nl.Status{}
+	if err := gp.invoke(rpc.MethodXactStatus, http.MethodGet, &rpc.XactReq{Args: args}, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
@@ -47,6 +47,8 @@ type (
 		// E.g. range:
 		// * Header.Set(cos.HdrRange, fmt.Sprintf("bytes=%d-%d", fromOffset, toOffset))
 		//   For range formatting, see https://www.rfc-editor.org/rfc/rfc7233#section-2.1
+		//   Multiple ranges (e.g. "bytes=0-99,1000-1099") are returned as a single `multipart/byteranges`
+		//   response (status 206) with each part carrying its own Content-Range
 		// E.g. blob download:
 		// * Header.Set(apc.HdrBlobDownload, "true")
 		Header http.Header
@@ -256,17 +258,8 @@ func (args *PutArgs) put(reqArgs *cmn.HreqArgs) (*http.Request, error) {
 	}
 	// Go http doesn't automatically set this for files, so to handle redirect we do it here.
 	req.GetBody = args.getBody
-	if args.Cksum != nil && args.Cksum.Ty() != cos.ChecksumNone {
-		req.Header.Set(apc.HdrObjCksumType, args.Cksum.Ty())
-		ckVal := args.Cksum.Value()
-		if ckVal == "" {
-			_, ckhash, err := cos.CopyAndChecksum(io.Discard, args.Reader, nil, args.Cksum.Ty())
-			if err != nil {
-				return nil, newErrCreateHTTPRequest(err)
-			}
-			ckVal = hex.EncodeToString(ckhash.Sum())
-		}
-		req.Header.Set(apc.HdrObjCksumVal, ckVal)
+	if _, err := args.setCksum(req.Header); err != nil {
+		return nil, newErrCreateHTTPRequest(err)
 	}
 	if args.Size != 0 {
 		req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
@@ -275,6 +268,25 @@ func (args *PutArgs) put(reqArgs *cmn.HreqArgs) (*http.Request, error) {
 	return req, nil
 }
 
+// set checksum headers, if requested; compute the checksum unless provided
+// (in which case the reader gets consumed - see `computed`)
+func (args *PutArgs) setCksum(hdr http.Header) (computed bool, _ error) {
+	if args.Cksum == nil || args.Cksum.Ty() == cos.ChecksumNone {
+		return false, nil
+	}
+	hdr.Set(apc.HdrObjCksumType, args.Cksum.Ty())
+	ckVal := args.Cksum.Value()
+	if ckVal == "" {
+		_, ckhash, err := cos.CopyAndChecksum(io.Discard, args.Reader, nil, args.Cksum.Ty())
+		if err != nil {
+			return false, err
+		}
+		ckVal, computed = hex.EncodeToString(ckhash.Sum()), true
+	}
+	hdr.Set(apc.HdrObjCksumVal, ckVal)
+	return computed, nil
+}
+
 ////////////////
 // AppendArgs //
 ////////////////
@@ -322,12 +334,15 @@ func HeadObject(bp BaseParams, bck cmn.Bck, objName string, fltPresence int, sil
 	if fltPresence == apc.FltPresentNoProps {
 		return nil, err
 	}
+	return hdr2props(hdr)
+}
 
+func hdr2props(hdr http.Header) (*cmn.ObjectProps, error) {
 	// first, cnm.ObjAttrs (NOTE: compare with `headObject()` in target.go)
 	op := &cmn.ObjectProps{}
 	op.Cksum = op.ObjAttrs.FromHeader(hdr)
 	// second, all the rest
-	err = cmn.IterFields(op, func(tag string, field cmn.IterField) (error, bool) {
+	err := cmn.IterFields(op, func(tag string, field cmn.IterField) (error, bool) {
 		headerName := apc.PropToHeader(tag)
 		// skip the missing ones
 		if _, ok := hdr[textproto.CanonicalMIMEHeaderKey(headerName)]; !ok {
@@ -548,6 +563,24 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// ComposeObject creates (or overwrites) the destination object by concatenating, in order,
+// the specified source objects and/or byte ranges (see cmn.ComposeMsg).
+// The operation is executed by the cluster - the content never leaves the cluster.
+func ComposeObject(bp BaseParams, bck cmn.Bck, objName string, msg *cmn.ComposeMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActCompose, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // promote files and directories to ais objects
 func Promote(bp BaseParams, bck cmn.Bck, args *apc.PromoteArgs) (xid string, err error) {
 	actMsg := apc.ActMsg{Action: apc.ActPromote, Name: args.SrcFQN, Value: args}
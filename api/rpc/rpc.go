@@ -0,0 +1,218 @@
+// Package rpc provides gRPC service definition, wire messages, and codec that are shared
+// by aistore nodes (gRPC server) and Go clients (see api/grpc.go).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xact"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC API:
+// - the service mirrors a subset of the HTTP API: object GET (server streaming), PUT (client
+//   streaming), HEAD, and DELETE; list-objects; xaction start and status;
+// - messages are JSON-encoded - content subtype "json" (ie., "application/grpc+json");
+// - each call gets translated into the corresponding HTTP request and served by the node's
+//   HTTP handlers - same routing, validation, and access control;
+// - HTTP status of the failed call (as in: cmn.ErrHTTP) is returned in the trailer (see MdStatus);
+// - object calls are never relayed: proxy redirects the client to the owning target - same
+//   as HTTP 307 but in the trailer (see MdLocation); PUT stream waits for the target's
+//   response header before sending any data (see MdContinue)
+
+const (
+	ServiceName = "aistore.v1.AIStore"
+
+	MethodGet         = "/" + ServiceName + "/Get"
+	MethodPut         = "/" + ServiceName + "/Put"
+	MethodHead        = "/" + ServiceName + "/Head"
+	MethodDelete      = "/" + ServiceName + "/Delete"
+	MethodListObjects = "/" + ServiceName + "/ListObjects"
+	MethodStartXact   = "/" + ServiceName + "/StartXaction"
+	MethodXactStatus  = "/" + ServiceName + "/XactionStatus"
+
+	// metadata
+	MdStatus   = "ais-status"   // HTTP status code (trailer)
+	MdLocation = "ais-location" // redirect: gRPC endpoint of the target that owns the object (trailer)
+	MdQuery    = "ais-query"    // redirect: query to use with the target (trailer)
+	MdContinue = "ais-continue" // PUT: target is ready to receive the object's content (header)
+
+	Codec = "json"
+
+	// max size of the object's data carried by a single message
+	ChunkSize = 64 * cos.KiB
+
+	// (client) max size of the received message, e.g. list-objects page
+	MaxMsgSize = 64 * cos.MiB
+)
+
+// wire messages
+type (
+	// object (GET, HEAD, DELETE; and the first message of the PUT stream)
+	ObjReq struct {
+		Header http.Header       `json:"header,omitempty"` // e.g. Range
+		Query  map[string]string `json:"query,omitempty"`
+		Bck    cmn.Bck           `json:"bck"`
+		Name   string            `json:"name"`
+		Size   int64             `json:"size,omitempty"` // PUT: content length (optional)
+	}
+	// GET stream: the first message carries response header; PUT stream: the first message
+	// carries the request
+	ObjChunk struct {
+		Req    *ObjReq     `json:"req,omitempty"`
+		Header http.Header `json:"header,omitempty"`
+		Data   []byte      `json:"data,omitempty"`
+	}
+	// HEAD, DELETE, PUT
+	ObjResp struct {
+		Header http.Header `json:"header,omitempty"`
+	}
+
+	LsoReq struct {
+		Msg *apc.LsoMsg `json:"msg"`
+		Bck cmn.Bck     `json:"bck"`
+	}
+
+	XactReq struct {
+		Args  *xact.ArgsMsg `json:"args"`
+		Extra string        `json:"extra,omitempty"` // (see api.StartXaction)
+	}
+	XactResp struct {
+		ID string `json:"id"`
+	}
+)
+
+// server-side interface (implemented by ais nodes)
+type (
+	GetServer interface {
+		Send(*ObjChunk) error
+		grpc.ServerStream
+	}
+	PutServer interface {
+		SendAndClose(*ObjResp) error
+		Recv() (*ObjChunk, error)
+		grpc.ServerStream
+	}
+	Server interface {
+		Get(*ObjReq, GetServer) error
+		Put(PutServer) error
+		Head(context.Context, *ObjReq) (*ObjResp, error)
+		Delete(context.Context, *ObjReq) (*ObjResp, error)
+		ListObjects(context.Context, *LsoReq) (*cmn.LsoResult, error)
+		StartXaction(context.Context, *XactReq) (*XactResp, error)
+		XactionStatus(context.Context, *XactReq) (*nl.Status, error)
+	}
+)
+
+///////////
+// codec //
+///////////
+
+type jsonCodec struct{}
+
+// interface guard
+var _ encoding.Codec = jsonCodec{}
+
+func init() { encoding.RegisterCodec(jsonCodec{}) }
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return cos.JSON.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return cos.JSON.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return Codec }
+
+////////////
+// errors //
+////////////
+
+// HTTP status => gRPC code
+func Code(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusMovedPermanently, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return codes.FailedPrecondition // (see MdLocation)
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusRequestedRangeNotSatisfiable:
+		return codes.OutOfRange
+	case http.StatusTooManyRequests, http.StatusInsufficientStorage:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// server: error with the HTTP status (trailer)
+func Error(ctx context.Context, httpStatus int, msg string) error {
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(MdStatus, strconv.Itoa(httpStatus)))
+	return status.Error(Code(httpStatus), msg)
+}
+
+// server: redirect the client to the node's gRPC `endpoint`
+func Redirect(ctx context.Context, endpoint, query string) error {
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(MdLocation, endpoint, MdQuery, query))
+	return Error(ctx, http.StatusTemporaryRedirect, "redirect to "+endpoint)
+}
+
+// client: redirect's endpoint and query, if any
+func Location(trailer metadata.MD) (endpoint string, query url.Values, err error) {
+	v := trailer.Get(MdLocation)
+	if len(v) == 0 {
+		return "", nil, nil
+	}
+	endpoint = v[0]
+	if v = trailer.Get(MdQuery); len(v) > 0 {
+		query, err = url.ParseQuery(v[0])
+	}
+	return endpoint, query, err
+}
+
+// client: gRPC error => cmn.ErrHTTP
+func ToErrHTTP(err error, trailer metadata.MD, method string) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	herr := &cmn.ErrHTTP{Message: st.Message(), Method: method}
+	if v := trailer.Get(MdStatus); len(v) > 0 {
+		herr.Status, _ = strconv.Atoi(v[0])
+	}
+	if herr.Status == 0 {
+		switch st.Code() {
+		case codes.NotFound:
+			herr.Status = http.StatusNotFound
+		case codes.Unimplemented:
+			herr.Status = http.StatusNotImplemented
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return fmt.Errorf("%s: %w", method, err)
+		default:
+			herr.Status = http.StatusInternalServerError
+		}
+	}
+	return herr
+}
@@ -0,0 +1,120 @@
+// Package rpc provides gRPC service definition, wire messages, and codec that are shared
+// by aistore nodes (gRPC server) and Go clients (see api/grpc.go).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// service descriptor (in lieu of protoc-generated code)
+
+var (
+	GetStream = grpc.StreamDesc{StreamName: "Get", ServerStreams: true}
+	PutStream = grpc.StreamDesc{StreamName: "Put", ClientStreams: true}
+
+	ServiceDesc = grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*Server)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "Head", Handler: headHandler},
+			{MethodName: "Delete", Handler: deleteHandler},
+			{MethodName: "ListObjects", Handler: lsoHandler},
+			{MethodName: "StartXaction", Handler: startXactHandler},
+			{MethodName: "XactionStatus", Handler: xactStatusHandler},
+		},
+		Streams: []grpc.StreamDesc{
+			{StreamName: GetStream.StreamName, Handler: getHandler, ServerStreams: true},
+			{StreamName: PutStream.StreamName, Handler: putHandler, ClientStreams: true},
+		},
+	}
+)
+
+func Register(s *grpc.Server, srv Server) { s.RegisterService(&ServiceDesc, srv) }
+
+//
+// unary
+//
+
+func unary[T any](srv any, ctx context.Context, dec func(any) error, icpt grpc.UnaryServerInterceptor,
+	method string, cb func(Server, context.Context, *T) (any, error)) (any, error) {
+	in := new(T)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if icpt == nil {
+		return cb(srv.(Server), ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: method}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return cb(srv.(Server), ctx, req.(*T))
+	}
+	return icpt(ctx, in, info, handler)
+}
+
+func headHandler(srv any, ctx context.Context, dec func(any) error, icpt grpc.UnaryServerInterceptor) (any, error) {
+	return unary(srv, ctx, dec, icpt, MethodHead, func(s Server, ctx context.Context, in *ObjReq) (any, error) {
+		return s.Head(ctx, in)
+	})
+}
+
+func deleteHandler(srv any, ctx context.Context, dec func(any) error, icpt grpc.UnaryServerInterceptor) (any, error) {
+	return unary(srv, ctx, dec, icpt, MethodDelete, func(s Server, ctx context.Context, in *ObjReq) (any, error) {
+		return s.Delete(ctx, in)
+	})
+}
+
+func lsoHandler(srv any, ctx context.Context, dec func(any) error, icpt grpc.UnaryServerInterceptor) (any, error) {
+	return unary(srv, ctx, dec, icpt, MethodListObjects, func(s Server, ctx context.Context, in *LsoReq) (any, error) {
+		return s.ListObjects(ctx, in)
+	})
+}
+
+func startXactHandler(srv any, ctx context.Context, dec func(any) error, icpt grpc.UnaryServerInterceptor) (any, error) {
+	return unary(srv, ctx, dec, icpt, MethodStartXact, func(s Server, ctx context.Context, in *XactReq) (any, error) {
+		return s.StartXaction(ctx, in)
+	})
+}
+
+func xactStatusHandler(srv any, ctx context.Context, dec func(any) error, icpt grpc.UnaryServerInterceptor) (any, error) {
+	return unary(srv, ctx, dec, icpt, MethodXactStatus, func(s Server, ctx context.Context, in *XactReq) (any, error) {
+		return s.XactionStatus(ctx, in)
+	})
+}
+
+//
+// streaming
+//
+
+type (
+	getServer struct{ grpc.ServerStream }
+	putServer struct{ grpc.ServerStream }
+)
+
+func getHandler(srv any, stream grpc.ServerStream) error {
+	in := new(ObjReq)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(Server).Get(in, &getServer{stream})
+}
+
+func (x *getServer) Send(m *ObjChunk) error { return x.ServerStream.SendMsg(m) }
+
+func putHandler(srv any, stream grpc.ServerStream) error {
+	return srv.(Server).Put(&putServer{stream})
+}
+
+func (x *putServer) SendAndClose(m *ObjResp) error { return x.ServerStream.SendMsg(m) }
+
+func (x *putServer) Recv() (*ObjChunk, error) {
+	m := new(ObjChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
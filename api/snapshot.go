@@ -0,0 +1,67 @@
+// Package api provides Go based AIStore API/SDK over HTTP(S)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket snapshots (see cmn/snap.go)
+// - snapshot `snap` of a given ais:// bucket `bck` is a read-only bucket named "<bck>@<snap>"
+// - to read (get, list, copy) snapshot content, use regular APIs with `bck.SnapBck(snap)`
+
+// CreateSnapshot takes a point-in-time snapshot of the (ais://) bucket.
+// Returns xaction ID if successful, an error otherwise.
+func CreateSnapshot(bp BaseParams, bck cmn.Bck, snap string) (xid string, err error) {
+	return _snap(bp, bck, apc.ActMsg{Action: apc.ActCreateSnap, Name: snap})
+}
+
+// RestoreSnapshot reverts the bucket to its state at the time the snapshot was taken.
+// Returns xaction ID if successful, an error otherwise.
+func RestoreSnapshot(bp BaseParams, bck cmn.Bck, snap string) (xid string, err error) {
+	return _snap(bp, bck, apc.ActMsg{Action: apc.ActRestoreSnap, Name: snap})
+}
+
+// DestroySnapshot removes the snapshot; the bucket itself remains intact.
+func DestroySnapshot(bp BaseParams, bck cmn.Bck, snap string) error {
+	_, err := _snap(bp, bck, apc.ActMsg{Action: apc.ActDestroySnap, Name: snap})
+	return err
+}
+
+func _snap(bp BaseParams, bck cmn.Bck, actMsg apc.ActMsg) (xid string, err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(actMsg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return
+}
+
+// ListSnapshots returns all snapshots of a given bucket.
+func ListSnapshots(bp BaseParams, bck cmn.Bck) (snaps cmn.Bcks, err error) {
+	qbck := cmn.QueryBcks{Provider: apc.AIS, Ns: bck.Ns}
+	bcks, err := ListBuckets(bp, qbck, apc.FltPresent)
+	if err != nil {
+		return nil, err
+	}
+	prefix := bck.Name + string(cmn.SnapSepa)
+	for i := range bcks {
+		if strings.HasPrefix(bcks[i].Name, prefix) {
+			snaps = append(snaps, bcks[i])
+		}
+	}
+	return snaps, nil
+}
@@ -272,6 +272,42 @@ func _lsTip(c *cli.Context, qbck cmn.QueryBcks) {
 	}
 }
 
+// Similar to the above: replace `tier.bck=s3://bucket_name` with `tier.bck.name=bucket_name`
+// and `tier.bck.provider=aws`.
+func reformatTierProps(c *cli.Context, nvs cos.StrKVs) error {
+	if v, ok := nvs[cmn.PropTierBck]; ok {
+		delete(nvs, cmn.PropTierBck)
+		tier, err := parseBckURI(c, v, true /*error only*/)
+		if err != nil {
+			return fmt.Errorf("invalid '%s=%s': expecting %q to be a valid bucket name", cmn.PropTierBck, v, v)
+		}
+		nvs[cmn.PropTierBckName], nvs[cmn.PropTierBckProvider] = tier.Name, tier.Provider
+		return nil
+	}
+	if v, ok := nvs[cmn.PropTierBckProvider]; ok && v != "" {
+		var err error
+		nvs[cmn.PropTierBckProvider], err = cmn.NormalizeProvider(v)
+		return err
+	}
+	return nil
+}
+
+// e.g., `replication.bck=ais://@remais/abc` => name, provider, and namespace
+func reformatReplProps(c *cli.Context, nvs cos.StrKVs) error {
+	v, ok := nvs[cmn.PropReplBck]
+	if !ok {
+		return nil
+	}
+	delete(nvs, cmn.PropReplBck)
+	dst, err := parseBckURI(c, v, true /*error only*/)
+	if err != nil {
+		return fmt.Errorf("invalid '%s=%s': expecting %q to be a valid bucket name", cmn.PropReplBck, v, v)
+	}
+	nvs[cmn.PropReplBckName], nvs[cmn.PropReplBckProvider] = dst.Name, dst.Provider
+	nvs[cmn.PropReplBckNsUUID], nvs[cmn.PropReplBckNsName] = dst.Ns.UUID, dst.Ns.Name
+	return nil
+}
+
 // If both backend_bck.name and backend_bck.provider are present, use them.
 // Otherwise, replace as follows:
 //   - e.g., `backend_bck=gcp://bucket_name` with `backend_bck.name=bucket_name` and
@@ -121,8 +121,10 @@ var (
 			nameOnlyFlag,
 			objPropsFlag,
 			regexLsAnyFlag,
+			lsFilterFlag,
 			templateFlag,
 			listObjPrefixFlag,
+			nonRecursFlag,
 			pageSizeFlag,
 			pagedFlag,
 			objLimitFlag,
@@ -246,6 +248,7 @@ var (
 			},
 			bucketCmdCopy,
 			bucketCmdRename,
+			bucketCmdSnapshot,
 			{
 				Name:      commandRemove,
 				Usage:     "remove ais buckets",
@@ -17,6 +17,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/tabular"
+	"github.com/NVIDIA/aistore/cmn/zblk"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/ext/dsort"
@@ -62,13 +64,25 @@ var (
 		"checksum.validate_cold_get":          supportedBool,
 		"checksum.validate_warm_get":          supportedBool,
 		"checksum.validate_obj_move":          supportedBool,
+		"compression.algo":                    zblk.SupportedAlgos,
+		"compression.enabled":                 supportedBool,
+		"encryption.enabled":                  supportedBool,
+		"packing.enabled":                     supportedBool,
+		"chunks.enabled":                      supportedBool,
+		"name_index.enabled":                  supportedBool,
+		"inventory.enabled":                   supportedBool,
+		"inventory.format":                    tabular.Formats,
 		"ec.enabled":                          supportedBool,
+		"events.enabled":                      supportedBool,
 		"fshc.enabled":                        supportedBool,
 		"lru.enabled":                         supportedBool,
+		"lru.policy":                          cmn.SupportedEvictPolicies,
 		"mirror.enabled":                      supportedBool,
 		"rebalance.enabled":                   supportedBool,
 		"resilver.enabled":                    supportedBool,
+		"tier.enabled":                        supportedBool,
 		"versioning.enabled":                  supportedBool,
+		"replication.enabled":                 supportedBool,
 		"replication.on_cold_get":             supportedBool,
 		"replication.on_lru_eviction":         supportedBool,
 		"replication.on_put":                  supportedBool,
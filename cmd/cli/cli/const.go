@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/tabular"
 	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/urfave/cli"
 )
@@ -56,6 +58,8 @@ const (
 const (
 	commandCat       = "cat"
 	commandConcat    = "concat"
+	commandCompose   = apc.ActCompose
+	commandGetBatch  = "get-batch"
 	commandCopy      = "cp"
 	commandCreate    = "create"
 	commandGet       = "get"
@@ -78,6 +82,9 @@ const (
 	cmdObject = "object"
 	cmdProps  = "props"
 
+	cmdSnapshot = "snapshot"
+	cmdRestore  = "restore"
+
 	// NOTE implicit assumption: AIS xaction kind _eq_ the command name (e.g. "download")
 	commandRebalance = apc.ActRebalance
 	commandResilver  = apc.ActResilver
@@ -93,6 +100,7 @@ const (
 	cmdDsort        = apc.ActDsort
 	cmdRebalance    = apc.ActRebalance
 	cmdLRU          = apc.ActLRU
+	cmdExportList   = apc.ActExportList
 	cmdStgCleanup   = "cleanup" // display name for apc.ActStoreCleanup
 	cmdStgValidate  = "validate"
 	cmdSummary      = "summary" // ditto apc.ActSummaryBck
@@ -245,6 +253,8 @@ const (
 	bucketDstArgument       = "DST_BUCKET"
 	bucketNewArgument       = "NEW_BUCKET"
 
+	bucketSnapArgument = bucketArgument + " SNAPSHOT"
+
 	dsortSpecArgument = "[JSON_SPECIFICATION|YAML_SPECIFICATION|-] [SRC_BUCKET] [DST_BUCKET]"
 
 	// Objects
@@ -265,6 +275,10 @@ const (
 
 	concatObjectArgument = "FILE|DIRECTORY[/PATTERN] [ FILE|DIRECTORY[/PATTERN] ...] " + objectArgument
 
+	composeObjectArgument = objectArgument + " [" + objectArgument + " ...] DST_BUCKET/OBJECT_NAME"
+
+	getBatchArgument = objectArgument + " [" + objectArgument + " ...] OUT_FILE|-"
+
 	renameObjectArgument = objectArgument + " NEW_OBJECT_NAME"
 
 	setCustomArgument = objectArgument + " " + jsonKeyValueArgument + " | " + keyValuePairsArgument + ", e.g.:\n" +
@@ -387,6 +401,22 @@ var (
 			indent4 + "\t'--prefix a/b/c' - list virtual directory a/b/c and/or objects from the virtual directory\n" +
 			indent4 + "\ta/b that have their names (relative to this directory) starting with the letter 'c'",
 	}
+	nonRecursFlag = cli.BoolFlag{
+		Name: "non-recursive,nr",
+		Usage: "list only objects and virtual subdirectories immediately under the (optional) prefix, e.g.:\n" +
+			indent4 + "\t'ais ls s3://abc --prefix a/b/ --non-recursive' - list a/b/c.txt and a/b/d/, but not a/b/d/e.txt\n" +
+			indent4 + "\t(subdirectories are shown with a trailing '/')",
+	}
+	// filter expression evaluated by targets (see cmn.LsoFilter)
+	lsFilterFlag = cli.StringFlag{
+		Name: "filter",
+		Usage: "filter expression evaluated in the cluster, so that only matching objects are returned, e.g.:\n" +
+			indent4 + "\t--filter 'size >= 1MiB and size < 1GiB'\t- objects sized between 1MiB and 1GiB;\n" +
+			indent4 + "\t--filter 'atime > 7d, name = *.tar'\t- TAR files accessed within the last 7 days;\n" +
+			indent4 + "\t--filter 'mtime < 2024-01-01 and meta.source ~ \"^s3\"'\t- by modification time and custom metadata;\n" +
+			indent4 + "\t--filter '!cksum'\t- objects without checksums\n" +
+			indent4 + "\t(attributes: name, size, atime, mtime, cksum, meta.KEY; operators: =, !=, <, <=, >, >=, ~, !~)",
+	}
 	getObjPrefixFlag = cli.StringFlag{
 		Name: listObjPrefixFlag.Name,
 		Usage: "get objects that start with the specified prefix, e.g.:\n" +
@@ -529,7 +559,7 @@ var (
 	}
 	useInventoryFlag = cli.BoolFlag{
 		Name:  "inventory",
-		Usage: "experimental; requires s3:// backend",
+		Usage: "list via bucket inventory (manifest): bucket's 'inventory' property if configured, otherwise S3 inventory (experimental)",
 	}
 
 	keepMDFlag       = cli.BoolFlag{Name: "keep-md", Usage: "keep bucket metadata"}
@@ -842,8 +872,46 @@ var (
 		Name:  "cont-on-err",
 		Usage: "keep running archiving xaction (job) in presence of errors in a any given multi-object transaction",
 	}
+	// 'ais object get-batch'
+	batchSpecFlag = cli.StringFlag{
+		Name: "spec,f",
+		Usage: "path to JSON specification of the batch, or '-' for standard input; when specified, the command\n" +
+			indent4 + "\ttakes BUCKET (to resolve entries that do not specify their own) and OUT_FILE, e.g.:\n" +
+			indent4 + "\t{\"in\": [{\"objname\": \"a.jpg\"}, {\"objname\": \"shard.tar\", \"archpath\": \"b/c.jpg\"},\n" +
+			indent4 + "\t        {\"bck\": {\"name\": \"abc\", \"provider\": \"s3\"}, \"objname\": \"d.jpg\"}]}",
+	}
+	skipMissingFlag = cli.BoolFlag{
+		Name:  "skip-missing",
+		Usage: "skip missing objects and archived files (by default, any missing entry fails the entire batch)",
+	}
+	archmimeFlag = cli.StringFlag{
+		Name:  "archmime",
+		Usage: "output format, one of: " + strings.Join(archive.FileExtensions, ", ") + " (default: " + archive.ExtTar + ")",
+	}
 	// end archive
 
+	// export bucket listing (see cmn.ExportListMsg)
+	exportFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "output format, one of: " + strings.Join(tabular.Formats, ", ") + " (default: " + tabular.Parquet + ")",
+	}
+	exportPropsFlag = cli.StringFlag{
+		Name: "props",
+		Usage: "comma-separated list of object properties to export, e.g.:\n" +
+			indent4 + "\t--props name,size,checksum\n" +
+			indent4 + "\t--props \"atime, version, custom, location\"\n" +
+			indent4 + "\t(name is always included; default: name,size)",
+	}
+	exportObjNameFlag = cli.StringFlag{
+		Name: "objname",
+		Usage: "destination virtual directory for the resulting objects named <target ID>-<N>.<format>\n" +
+			indent4 + "\t(default: source bucket name)",
+	}
+	exportMaxRowsFlag = cli.IntFlag{
+		Name:  "max-rows",
+		Usage: "maximum number of rows (objects) per resulting object (0 - unlimited: one object per target)",
+	}
+
 	// AuthN
 	tokenFileFlag = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to file"}
 	passwordFlag  = cli.StringFlag{Name: "password,p", Value: "", Usage: "user password"}
@@ -974,6 +1042,10 @@ var (
 	}
 
 	// LRU
+	lruDryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show objects that would be evicted (in eviction order) without evicting anything",
+	}
 	lruBucketsFlag = cli.StringFlag{
 		Name: "buckets",
 		Usage: "comma-separated list of bucket names, e.g.:\n" +
@@ -112,6 +112,7 @@ func collapseDisks(dsh []teb.DiskStatsHelper, numTs int) {
 		dst.Stat.WBps += src.Stat.WBps
 		dst.Stat.Wavg += src.Stat.Wavg
 		dst.Stat.Util += src.Stat.Util
+		dst.Stat.Await = max(dst.Stat.Await, src.Stat.Await)
 	}
 	for tid, dst := range tsums {
 		dn := int64(dnums[tid])
@@ -9,17 +9,21 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/ext/dsort"
 	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/space"
 	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
@@ -96,8 +100,19 @@ var (
 			latestVerFlag,
 			nonverboseFlag,
 		},
+		cmdExportList: {
+			exportFormatFlag,
+			exportPropsFlag,
+			verbObjPrefixFlag,
+			exportObjNameFlag,
+			exportMaxRowsFlag,
+			waitFlag,
+			waitJobXactFinishedFlag,
+			nonverboseFlag,
+		},
 		cmdLRU: {
 			lruBucketsFlag,
+			lruDryRunFlag,
 			forceFlag,
 			nonverboseFlag,
 		},
@@ -133,12 +148,25 @@ var (
 		BashComplete: remoteBucketCompletions(bcmplop{multiple: true}),
 	}
 
+	exportListCmd = cli.Command{
+		Name: cmdExportList,
+		Usage: "write the listing of a bucket into the destination bucket as CSV, JSONL, or Parquet object(s), e.g.:\n" +
+			indent1 + "\t- 'export-list ais://abc ais://manifests'\t- write ais://manifests/abc/<target ID>-0.parquet objects (name, size);\n" +
+			indent1 + "\t- 'export-list s3://abc ais://manifests --format csv --props name,size,checksum,atime --prefix images/'\n" +
+			indent1 + "(each target lists the objects it stores; for remote buckets, only the objects present in the cluster get listed)",
+		ArgsUsage:    bucketSrcArgument + " " + bucketDstArgument,
+		Flags:        startSpecialFlags[cmdExportList],
+		Action:       startExportListHandler,
+		BashComplete: bucketCompletions(bcmplop{multiple: true}),
+	}
+
 	jobStartSub = cli.Command{
 		Name:  commandStart,
 		Usage: "run batch job",
 		Subcommands: []cli.Command{
 			prefetchStartCmd,
 			blobDownloadCmd,
+			exportListCmd,
 			{
 				Name:      cmdDownload,
 				Usage:     "download files and objects from remote sources",
@@ -343,6 +371,48 @@ func startXactionKind(c *cli.Context, xname string) (err error) {
 	return startXaction(c, &xargs, extra)
 }
 
+func startExportListHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() == 1 {
+		return missingArgumentsError(c, bucketDstArgument)
+	}
+	bck, err := parseBckURI(c, c.Args().Get(0), false)
+	if err != nil {
+		return err
+	}
+	bckTo, err := parseBckURI(c, c.Args().Get(1), false)
+	if err != nil {
+		return err
+	}
+	if _, err := headBucket(bck, false /* don't add */); err != nil {
+		return err
+	}
+	if _, err := headBucket(bckTo, false /* don't add */); err != nil {
+		return err
+	}
+	msg := &cmn.ExportListMsg{
+		ToBck:   bckTo,
+		ObjName: parseStrFlag(c, exportObjNameFlag),
+		Format:  parseStrFlag(c, exportFormatFlag),
+		Props:   parseStrFlag(c, exportPropsFlag),
+		Prefix:  parseStrFlag(c, verbObjPrefixFlag),
+		MaxRows: int64(parseIntFlag(c, exportMaxRowsFlag)),
+	}
+	xid, err := api.ExportList(apiBP, bck, msg)
+	if err != nil {
+		return V(err)
+	}
+	xargs := xact.ArgsMsg{ID: xid, Kind: apc.ActExportList, Bck: bck}
+	actionX(c, &xargs, " => "+bckTo.Cname(msg.ObjName+"/"))
+
+	if !flagIsSet(c, waitFlag) && !flagIsSet(c, waitJobXactFinishedFlag) {
+		return nil
+	}
+	return waitJob(c, apc.ActExportList, xid, bck)
+}
+
 func startResilverHandler(c *cli.Context) error {
 	var tid string
 	if c.NArg() > 0 {
@@ -665,11 +735,12 @@ func waitDownload(c *cli.Context, id string) (err error) {
 }
 
 func startLRUHandler(c *cli.Context) (err error) {
-	if !flagIsSet(c, lruBucketsFlag) {
+	dryRun := flagIsSet(c, lruDryRunFlag)
+	if !flagIsSet(c, lruBucketsFlag) && !dryRun {
 		return startXactionHandler(c)
 	}
 
-	if flagIsSet(c, forceFlag) {
+	if flagIsSet(c, forceFlag) && !dryRun {
 		warn := fmt.Sprintf("LRU eviction with %s option will evict buckets _ignoring_ their respective `lru.enabled` properties.",
 			qflprn(forceFlag))
 		if ok := confirm(c, "Would you like to continue?", warn); !ok {
@@ -677,29 +748,77 @@ func startLRUHandler(c *cli.Context) (err error) {
 		}
 	}
 
-	s := parseStrFlag(c, lruBucketsFlag)
-	bckArgs := splitCsv(s)
-	buckets := make([]cmn.Bck, len(bckArgs))
-	for idx, bckArg := range bckArgs {
-		bck, err := parseBckURI(c, bckArg, false)
-		if err != nil {
-			return err
+	var buckets []cmn.Bck
+	if flagIsSet(c, lruBucketsFlag) {
+		s := parseStrFlag(c, lruBucketsFlag)
+		bckArgs := splitCsv(s)
+		buckets = make([]cmn.Bck, len(bckArgs))
+		for idx, bckArg := range bckArgs {
+			bck, err := parseBckURI(c, bckArg, false)
+			if err != nil {
+				return err
+			}
+			buckets[idx] = bck
 		}
-		buckets[idx] = bck
 	}
 
 	var (
 		id    string
-		xargs = xact.ArgsMsg{Kind: apc.ActLRU, Buckets: buckets, Force: flagIsSet(c, forceFlag)}
+		xargs = xact.ArgsMsg{Kind: apc.ActLRU, Buckets: buckets, Force: flagIsSet(c, forceFlag), DryRun: dryRun}
 	)
 	if id, err = api.StartXaction(apiBP, &xargs, ""); err != nil {
 		return
 	}
+	if dryRun {
+		return showLRUDryRun(c, id)
+	}
 
 	actionX(c, &xact.ArgsMsg{Kind: apc.ActLRU, ID: id}, "")
 	return
 }
 
+// wait for the dry-run to finish and show (per target) objects that would be evicted
+func showLRUDryRun(c *cli.Context, id string) error {
+	xargs := xact.ArgsMsg{Kind: apc.ActLRU, ID: id}
+	dryRunCptn(c)
+	if err := waitXact(&xargs); err != nil {
+		return err
+	}
+	xs, err := queryXactions(&xargs)
+	if err != nil {
+		return err
+	}
+	var (
+		total int
+		tids  = make([]string, 0, len(xs))
+		tw    = &tabwriter.Writer{}
+	)
+	for tid := range xs {
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NODE	 BUCKET	 OBJECT	 SIZE	 ATIME	 ACCESS COUNT	 POLICY")
+	for _, tid := range tids {
+		for _, snap := range xs[tid] {
+			var ext space.ExtLRUStats
+			if snap.Ext == nil || cos.MorphMarshal(snap.Ext, &ext) != nil {
+				continue
+			}
+			for _, cand := range ext.Cands {
+				fmt.Fprintf(tw, "%s\t %s\t %s\t %s\t %s\t %d\t %s\n", meta.Tname(tid), cand.Bck.Cname(""), cand.ObjName,
+					cos.ToSizeIEC(cand.Size, 2), cos.FormatNanoTime(cand.Atime, ""), cand.AccessCnt, cand.Policy)
+			}
+			total += len(ext.Cands)
+		}
+	}
+	if total == 0 {
+		actionDone(c, "Nothing to evict (used capacity is below high watermark, or LRU is disabled)")
+		return nil
+	}
+	return tw.Flush()
+}
+
 //
 // job stop
 //
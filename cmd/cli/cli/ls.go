@@ -311,6 +311,27 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) erro
 	if flagIsSet(c, useInventoryFlag) {
 		msg.SetFlag(apc.LsInventory)
 	}
+	if flagIsSet(c, nonRecursFlag) {
+		if flagIsSet(c, useInventoryFlag) {
+			return fmt.Errorf(errFmtExclusive, qflprn(nonRecursFlag), qflprn(useInventoryFlag))
+		}
+		msg.SetFlag(apc.LsNoRecursion)
+	}
+
+	// server-side filtering; in addition, name regex - unless showing unmatched objects
+	// or listing archived files (that are matched by their full names)
+	msg.Filter = parseStrFlag(c, lsFilterFlag)
+	if regexStr := parseStrFlag(c, regexLsAnyFlag); regexStr != "" && !flagIsSet(c, showUnmatchedFlag) && !listArch {
+		term := "name ~ " + strconv.Quote(regexStr)
+		if msg.Filter == "" {
+			msg.Filter = term
+		} else {
+			msg.Filter += ", " + term
+		}
+	}
+	if _, err := cmn.NewLsoFilter(msg.Filter); err != nil {
+		return err
+	}
 
 	var (
 		props    []string
@@ -20,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 	"github.com/vbauerster/mpb/v4"
@@ -91,6 +92,55 @@ func setCustomProps(c *cli.Context, bck cmn.Bck, objName string) (err error) {
 }
 
 // replace common abbreviations (such as `~/`) and return an absolute path
+// Rename virtual directory (all or nothing): copy, commit, and remove the old names
+func mvPrefix(c *cli.Context, bck cmn.Bck, from, to string) error {
+	xid, err := api.RenamePrefix(apiBP, bck, &cmn.RenamePrefixMsg{From: from, To: to})
+	if err != nil {
+		return V(err)
+	}
+	_, xname := xact.GetKindName(apc.ActRenamePrefix)
+	text := fmt.Sprintf("%s %s => %s", xact.Cname(xname, xid), bck.Cname(from), bck.Cname(to))
+	if !flagIsSet(c, waitFlag) && !flagIsSet(c, waitJobXactFinishedFlag) {
+		if flagIsSet(c, nonverboseFlag) {
+			fmt.Fprintln(c.App.Writer, xid)
+		} else {
+			actionDone(c, text+". "+toMonitorMsg(c, xid, ""))
+		}
+		return nil
+	}
+
+	// wait for the copy phase
+	var timeout time.Duration
+	if flagIsSet(c, waitJobXactFinishedFlag) {
+		timeout = parseDurationFlag(c, waitJobXactFinishedFlag)
+	}
+	fmt.Fprintln(c.App.Writer, text+" ...")
+	xargs := xact.ArgsMsg{ID: xid, Kind: apc.ActRenamePrefix, Timeout: timeout}
+	if err := waitXact(&xargs); err != nil {
+		fmt.Fprintf(c.App.ErrWriter, fmtXactFailed, "rename", from, to)
+		return err
+	}
+	// and then for the commit (that also removes the old names)
+	for started := time.Now(); ; time.Sleep(refreshRateMinDur) {
+		props, err := api.HeadBucket(apiBP, bck, true /* don't add */)
+		if err != nil {
+			return V(err)
+		}
+		rp := props.RenPrefix
+		if rp == nil || rp.From != from || rp.To != to {
+			break
+		}
+		if rp.Phase == cmn.RenPrefixRollback {
+			return fmt.Errorf("%s: aborted", text)
+		}
+		if timeout > 0 && time.Since(started) > timeout {
+			return fmt.Errorf("%s: timed out waiting for %s", text, rp)
+		}
+	}
+	fmt.Fprint(c.App.Writer, fmtXactSucceeded)
+	return nil
+}
+
 func absPath(fileName string) (path string, err error) {
 	path = cos.ExpandPath(fileName)
 	if path, err = filepath.Abs(path); err != nil {
@@ -6,6 +6,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/urfave/cli"
 )
 
@@ -31,7 +33,16 @@ var (
 			nonverboseFlag,
 			yesFlag,
 		),
-		commandRename: {},
+		commandGetBatch: {
+			batchSpecFlag,
+			skipMissingFlag,
+			archmimeFlag,
+		},
+		commandRename: {
+			waitFlag,
+			waitJobXactFinishedFlag,
+			nonverboseFlag,
+		},
 		commandGet: {
 			offsetFlag,
 			lengthFlag,
@@ -165,6 +176,29 @@ var (
 		Action:    concatHandler,
 	}
 
+	objectCmdCompose = cli.Command{
+		Name: commandCompose,
+		Usage: "server-side concatenation: create (or overwrite) destination object from the specified source objects\n" +
+			indent1 + "(in the order of the arguments); the content is read and written by the cluster, e.g.:\n" +
+			indent1 + "$ ais object compose ais://nnn/part-1 ais://nnn/part-2 s3://abc/part-3 ais://nnn/all-parts",
+		ArgsUsage:    composeObjectArgument,
+		Action:       composeHandler,
+		BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
+	}
+
+	objectCmdGetBatch = cli.Command{
+		Name: commandGetBatch,
+		Usage: "get multiple objects and/or archived files (possibly, from different buckets) as a single archive\n" +
+			indent1 + "(TAR by default), in the specified order; the archive is assembled by the cluster and written\n" +
+			indent1 + "into OUT_FILE or standard output ('-'), e.g.:\n" +
+			indent1 + "\t- 'ais object get-batch ais://nnn/a ais://nnn/b s3://abc/c /tmp/batch.tar'\t- three objects from two buckets;\n" +
+			indent1 + "\t- 'ais object get-batch ais://nnn --spec batch.json - | tar tv'\t- objects and archived files listed in batch.json",
+		ArgsUsage:    getBatchArgument,
+		Flags:        objectCmdsFlags[commandGetBatch],
+		Action:       getBatchHandler,
+		BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
+	}
+
 	objectCmdSetCustom = cli.Command{
 		Name:      commandSetCustom,
 		Usage:     "set object's custom properties",
@@ -207,14 +241,18 @@ var (
 			objectCmdPromote,
 			makeAlias(bucketCmdCopy, "", true, commandCopy), // alias for `ais [bucket] cp`
 			objectCmdConcat,
+			objectCmdCompose,
+			objectCmdGetBatch,
 			objectCmdSetCustom,
 			objectCmdRemove,
 			objectCmdPrefetch,
 			bucketObjCmdEvict,
 			makeAlias(showCmdObject, "", true, commandShow), // alias for `ais show`
 			{
-				Name:         commandRename,
-				Usage:        "move/rename object",
+				Name: commandRename,
+				Usage: "move/rename object or virtual directory, e.g.:\n" +
+					indent1 + "\t- 'ais object mv ais://nnn/aaa bbb'\t- rename object aaa as bbb;\n" +
+					indent1 + "\t- 'ais object mv ais://nnn/tmp/run42/ final/run42/'\t- rename virtual directory (all or nothing)",
 				ArgsUsage:    renameObjectArgument,
 				Flags:        objectCmdsFlags[commandRename],
 				Action:       mvObjectHandler,
@@ -270,6 +308,9 @@ func mvObjectHandler(c *cli.Context) (err error) {
 	if newObj == oldObj {
 		return incorrectUsageMsg(c, "source and destination are the same object")
 	}
+	if cos.IsLastB(oldObj, '/') {
+		return mvPrefix(c, bck, oldObj, newObj)
+	}
 
 	if err = api.RenameObject(apiBP, bck, oldObj, newObj); err != nil {
 		return
@@ -433,6 +474,136 @@ func concatHandler(c *cli.Context) (err error) {
 	return concatObject(c, bck, objName, fileNames)
 }
 
+func composeHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "destination object in the form "+objectArgument)
+	}
+	var (
+		msg = cmn.ComposeMsg{Sources: make([]cmn.ComposeSrc, 0, c.NArg()-1)}
+		dst = c.Args().Get(c.NArg() - 1)
+	)
+	for i := range c.NArg() - 1 {
+		bck, objName, err := parseBckObjURI(c, c.Args().Get(i), false)
+		if err != nil {
+			return err
+		}
+		msg.Sources = append(msg.Sources, cmn.ComposeSrc{Bck: bck, ObjName: objName})
+	}
+	bck, objName, err := parseBckObjURI(c, dst, false)
+	if err != nil {
+		return err
+	}
+	if err := api.ComposeObject(apiBP, bck, objName, &msg); err != nil {
+		return V(err)
+	}
+	actionDone(c, fmt.Sprintf("Composed %s from %d source object%s", bck.Cname(objName), len(msg.Sources), cos.Plural(len(msg.Sources))))
+	return nil
+}
+
+func getBatchHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "output file (or '-' for standard output)")
+	}
+	var (
+		msg  cmn.GetBatchMsg
+		bck  cmn.Bck
+		outf = c.Args().Get(c.NArg() - 1)
+	)
+	if flagIsSet(c, batchSpecFlag) {
+		if c.NArg() > 2 {
+			return incorrectUsageMsg(c, "with %s option specified expecting BUCKET and OUT_FILE, got %v",
+				qflprn(batchSpecFlag), c.Args())
+		}
+		var err error
+		if bck, err = parseBckURI(c, c.Args().Get(0), false); err != nil {
+			return err
+		}
+		if err := readBatchSpec(parseStrFlag(c, batchSpecFlag), &msg); err != nil {
+			return err
+		}
+	} else {
+		msg.In = make([]cmn.GetBatchIn, 0, c.NArg()-1)
+		for i := range c.NArg() - 1 {
+			b, objName, err := parseBckObjURI(c, c.Args().Get(i), false)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				bck = b
+			}
+			in := cmn.GetBatchIn{ObjName: objName}
+			if !b.Equal(&bck) {
+				in.Bck = b
+			}
+			msg.In = append(msg.In, in)
+		}
+	}
+	if flagIsSet(c, skipMissingFlag) {
+		msg.SkipMissing = true
+	}
+	if flagIsSet(c, archmimeFlag) {
+		msg.Mime = parseStrFlag(c, archmimeFlag)
+	}
+
+	var (
+		w    io.Writer
+		file *os.File
+	)
+	switch {
+	case outf == fileStdIO:
+		w = os.Stdout
+	case discardOutput(outf):
+		w = io.Discard
+	default:
+		var err error
+		if file, err = os.Create(outf); err != nil {
+			return err
+		}
+		w = file
+	}
+	n, err := api.GetBatch(apiBP, bck, &msg, w)
+	if file != nil {
+		cos.Close(file)
+		if err != nil {
+			os.Remove(outf)
+		}
+	}
+	if err != nil {
+		return V(err)
+	}
+	if outf != fileStdIO {
+		actionDone(c, fmt.Sprintf("GET batch of %d object%s => %s (%s)", len(msg.In), cos.Plural(len(msg.In)),
+			outf, cos.ToSizeIEC(n, 2)))
+	}
+	return nil
+}
+
+// JSON batch specification (cmn.GetBatchMsg) from file or standard input
+func readBatchSpec(path string, msg *cmn.GetBatchMsg) error {
+	var (
+		b   []byte
+		err error
+	)
+	if path == fileStdIO {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	if err := jsoniter.Unmarshal(b, msg); err != nil {
+		return fmt.Errorf("failed to parse batch specification %q: %v", path, err)
+	}
+	return msg.Validate()
+}
+
 func promoteHandler(c *cli.Context) (err error) {
 	if c.NArg() < 1 {
 		return missingArgumentsError(c, "source file|directory to promote")
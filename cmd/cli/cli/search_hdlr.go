@@ -25,7 +25,7 @@ var (
 
 	similarWords = map[string][]string{
 		cmdMountpath:    {"mount", "unmount", "umount", "disk"},
-		commandList:     {"list", "dir", "contents"},
+		commandList:     {"list", "dir", "contents", "filter", "find"},
 		commandSet:      {"update", "assign", "modify"},
 		commandShow:     {"view", "display", "list"},
 		commandRemove:   {"remove", "delete", "del", "evict", "destroy", "cleanup"},
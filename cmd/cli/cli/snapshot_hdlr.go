@@ -0,0 +1,191 @@
+// Package cli provides easy-to-use commands to manage, monitor, and utilize AIS clusters.
+// This file handles CLI commands that pertain to bucket snapshots.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/urfave/cli"
+)
+
+var (
+	snapCmdsFlags = map[string][]cli.Flag{
+		commandCreate: {
+			waitFlag,
+			waitJobXactFinishedFlag,
+			nonverboseFlag,
+		},
+		cmdRestore: {
+			waitFlag,
+			waitJobXactFinishedFlag,
+			nonverboseFlag,
+			yesFlag,
+		},
+		commandRemove: {
+			yesFlag,
+		},
+	}
+
+	bucketCmdSnapshot = cli.Command{
+		Name: cmdSnapshot,
+		Usage: "create, list, restore, and remove point-in-time (read-only) snapshots of ais buckets, e.g.:\n" +
+			indent1 + "\t* ais bucket snapshot create ais://abc snap1\t- take snapshot named 'snap1';\n" +
+			indent1 + "\t* ais ls ais://abc@snap1\t- list snapshot content (snapshots are read-only buckets named BUCKET@SNAPSHOT);\n" +
+			indent1 + "\t* ais get ais://abc@snap1/obj /tmp/obj\t- get object from the snapshot;\n" +
+			indent1 + "\t* ais bucket snapshot restore ais://abc snap1\t- revert bucket to its snapshotted state",
+		Subcommands: []cli.Command{
+			{
+				Name:         commandCreate,
+				Usage:        "take point-in-time snapshot of a given ais bucket",
+				ArgsUsage:    bucketSnapArgument,
+				Flags:        snapCmdsFlags[commandCreate],
+				Action:       createSnapHandler,
+				BashComplete: bucketCompletions(bcmplop{provider: apc.AIS}),
+			},
+			{
+				Name:         commandList,
+				Usage:        "list bucket snapshots",
+				ArgsUsage:    bucketArgument,
+				Action:       listSnapsHandler,
+				BashComplete: bucketCompletions(bcmplop{provider: apc.AIS}),
+			},
+			{
+				Name:         cmdRestore,
+				Usage:        "restore ais bucket from a given snapshot (objects added after the snapshot was taken will be removed)",
+				ArgsUsage:    bucketSnapArgument,
+				Flags:        snapCmdsFlags[cmdRestore],
+				Action:       restoreSnapHandler,
+				BashComplete: bucketCompletions(bcmplop{provider: apc.AIS}),
+			},
+			{
+				Name:         commandRemove,
+				Usage:        "remove bucket snapshot (the bucket itself remains intact)",
+				ArgsUsage:    bucketSnapArgument,
+				Flags:        snapCmdsFlags[commandRemove],
+				Action:       removeSnapHandler,
+				BashComplete: bucketCompletions(bcmplop{provider: apc.AIS}),
+			},
+		},
+	}
+)
+
+func parseBckSnap(c *cli.Context) (bck cmn.Bck, snap string, err error) {
+	if c.NArg() == 0 {
+		return bck, "", missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() == 1 {
+		return bck, "", missingArgumentsError(c, "SNAPSHOT")
+	}
+	if bck, err = parseBckURI(c, c.Args().Get(0), false); err != nil {
+		return bck, "", err
+	}
+	snap = c.Args().Get(1)
+	err = cmn.ValidateSnapName(snap)
+	return bck, snap, err
+}
+
+func createSnapHandler(c *cli.Context) error {
+	bck, snap, err := parseBckSnap(c)
+	if err != nil {
+		return err
+	}
+	xid, err := api.CreateSnapshot(apiBP, bck, snap)
+	if err != nil {
+		return V(err)
+	}
+	sbck := bck.SnapBck(snap)
+	return _waitSnap(c, apc.ActCreateSnap, xid, bck.Cname("")+" => "+sbck.Cname(""))
+}
+
+func restoreSnapHandler(c *cli.Context) error {
+	bck, snap, err := parseBckSnap(c)
+	if err != nil {
+		return err
+	}
+	if !flagIsSet(c, yesFlag) {
+		warn := fmt.Sprintf("%s will be reverted to its %q snapshot state (newer content will be lost)", bck.Cname(""), snap)
+		if ok := confirm(c, "Proceed?", warn); !ok {
+			return nil
+		}
+	}
+	xid, err := api.RestoreSnapshot(apiBP, bck, snap)
+	if err != nil {
+		return V(err)
+	}
+	sbck := bck.SnapBck(snap)
+	return _waitSnap(c, apc.ActRestoreSnap, xid, sbck.Cname("")+" => "+bck.Cname(""))
+}
+
+func _waitSnap(c *cli.Context, kind, xid, what string) error {
+	text := xact.Cname(kind, xid) + " " + what
+	if !flagIsSet(c, waitFlag) && !flagIsSet(c, waitJobXactFinishedFlag) {
+		if flagIsSet(c, nonverboseFlag) {
+			fmt.Fprintln(c.App.Writer, xid)
+		} else {
+			actionDone(c, text+". "+toMonitorMsg(c, xid, ""))
+		}
+		return nil
+	}
+	var timeout time.Duration
+	if flagIsSet(c, waitJobXactFinishedFlag) {
+		timeout = parseDurationFlag(c, waitJobXactFinishedFlag)
+	}
+	fmt.Fprintln(c.App.Writer, text+" ...")
+	xargs := xact.ArgsMsg{ID: xid, Kind: kind, Timeout: timeout}
+	if err := waitXact(&xargs); err != nil {
+		return err
+	}
+	fmt.Fprint(c.App.Writer, fmtXactSucceeded)
+	return nil
+}
+
+func listSnapsHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	bck, err := parseBckURI(c, c.Args().Get(0), false)
+	if err != nil {
+		return err
+	}
+	snaps, err := api.ListSnapshots(apiBP, bck)
+	if err != nil {
+		return V(err)
+	}
+	if len(snaps) == 0 {
+		fmt.Fprintf(c.App.Writer, "%s has no snapshots\n", bck.Cname(""))
+		return nil
+	}
+	for i := range snaps {
+		fmt.Fprintln(c.App.Writer, snaps[i].Cname(""))
+	}
+	return nil
+}
+
+func removeSnapHandler(c *cli.Context) error {
+	bck, snap, err := parseBckSnap(c)
+	if err != nil {
+		return err
+	}
+	sbck := bck.SnapBck(snap)
+	if !flagIsSet(c, yesFlag) {
+		if ok := confirm(c, fmt.Sprintf("Proceed to remove %s?", sbck.Cname(""))); !ok {
+			return nil
+		}
+	}
+	if err := api.DestroySnapshot(apiBP, bck, snap); err != nil {
+		if cmn.IsStatusNotFound(err) {
+			return &errDoesNotExist{what: "snapshot", name: sbck.Cname("")}
+		}
+		return V(err)
+	}
+	actionDone(c, sbck.Cname("")+" removed")
+	return nil
+}
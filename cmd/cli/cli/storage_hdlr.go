@@ -320,6 +320,7 @@ func showDiskStats(c *cli.Context, tid string) error {
 			tally.Stat.WBps += ds.Stat.WBps
 			tally.Stat.Wavg += ds.Stat.Wavg
 			tally.Stat.Util += ds.Stat.Util
+			tally.Stat.Await = max(tally.Stat.Await, ds.Stat.Await)
 		}
 		tally.Stat.Ravg = cos.DivRound(tally.Stat.Ravg, l)
 		tally.Stat.Wavg = cos.DivRound(tally.Stat.Wavg, l)
@@ -454,6 +454,12 @@ func parseBpropsFromContext(c *cli.Context) (props *cmn.BpropsToSet, err error)
 	if err = reformatBackendProps(c, nvs); err != nil {
 		return
 	}
+	if err = reformatTierProps(c, nvs); err != nil {
+		return
+	}
+	if err = reformatReplProps(c, nvs); err != nil {
+		return
+	}
 
 	props, err = cmn.NewBpropsToSet(nvs)
 	return
@@ -565,6 +571,15 @@ func bckPropList(props *cmn.Bprops, verbose bool) (propList nvpairList) {
 			{"mirror", props.Mirror.String()},
 			{"ec", props.EC.String()},
 			{"lru", props.LRU.String()},
+			{"tier", props.Tier.String()},
+			{"replication", props.Replication.String()},
+			{"events", props.Events.String()},
+			{"compression", props.Compress.String()},
+			{"encryption", props.Encrypt.String()},
+			{"packing", props.Pack.String()},
+			{"chunks", props.Chunks.String()},
+			{"name_index", props.NameIdx.String()},
+			{"inventory", props.Inventory.String()},
 			{"versioning", props.Versioning.String()},
 		}
 		if props.Provider == apc.HTTP {
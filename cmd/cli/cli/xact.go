@@ -197,11 +197,12 @@ func flattenXactStats(snap *core.Snap, units string) nvpairList {
 				}
 			}
 			if value == "" { // not ".size"
-				if mapVal, ok := v.(map[string]any); ok {
-					vv, err := jsonMarshalIndent(mapVal)
+				switch v.(type) {
+				case map[string]any, []any:
+					vv, err := jsonMarshalIndent(v)
 					debug.AssertNoErr(err)
 					value = string(vv)
-				} else {
+				default:
 					value = fmt.Sprintf("%v", v)
 				}
 			}
@@ -6,6 +6,7 @@ package teb
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/core/meta"
@@ -19,6 +20,7 @@ const (
 	colWrite    = "WRITE"
 	colWriteAvg = "WRITE(avg size)"
 	colUtil     = "UTIL(%)"
+	colAwait    = "AWAIT(ms)"
 )
 
 func NewDiskTab(dsh []DiskStatsHelper, smap *meta.Smap, regex *regexp.Regexp, units, totalsHdr string) *Table {
@@ -31,6 +33,7 @@ func NewDiskTab(dsh []DiskStatsHelper, smap *meta.Smap, regex *regexp.Regexp, un
 		{name: colWrite},
 		{name: colWriteAvg},
 		{name: colUtil},
+		{name: colAwait},
 	}
 	if regex != nil {
 		cols = _flt(cols, regex)
@@ -63,6 +66,9 @@ func NewDiskTab(dsh []DiskStatsHelper, smap *meta.Smap, regex *regexp.Regexp, un
 		if _idx(cols, colUtil) >= 0 {
 			row = append(row, FmtStatValue("", "", stat.Util, units)+"%")
 		}
+		if _idx(cols, colAwait) >= 0 {
+			row = append(row, strconv.FormatInt(stat.Await, 10))
+		}
 
 		if ds.TargetID == totalsHdr {
 			row[len(row)-1] += fcyan(" ---")
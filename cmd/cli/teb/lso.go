@@ -83,6 +83,9 @@ func LsoTemplate(propsList []string, hideHeader, addCachedCol, addStatusCol bool
 func fmtLsObjStatus(e *cmn.LsoEntry) string {
 	switch e.Status() {
 	case apc.LocOK:
+		if e.IsDir() {
+			return ""
+		}
 		if !e.IsPresent() {
 			return UnknownStatusVal
 		}
@@ -109,5 +112,8 @@ func fmtLsObjStatus(e *cmn.LsoEntry) string {
 }
 
 func fmtLsObjIsCached(e *cmn.LsoEntry) string {
+	if e.IsDir() {
+		return ""
+	}
 	return FmtBool(e.IsPresent())
 }
@@ -241,12 +241,12 @@ const (
 	ListBucketsTmplNoSummary = ListBucketsHdrNoSummary + ListBucketsBodyNoSummary
 
 	// Bucket summary templates
-	BucketsSummariesTmpl = "NAME\t OBJECTS (cached, remote)\t OBJECT SIZES (min, avg, max)\t TOTAL OBJECT SIZE (cached, remote)\t USAGE(%)\n" +
+	BucketsSummariesTmpl = "NAME\t OBJECTS (cached, remote)\t OBJECT SIZES (min, avg, max)\t TOTAL OBJECT SIZE (cached, physical, remote)\t USAGE(%)\n" +
 		BucketsSummariesBody
 	BucketsSummariesBody = "{{range $k, $v := . }}" +
 		"{{FormatBckName $v.Bck}}\t {{$v.ObjCount.Present}} {{$v.ObjCount.Remote}}\t " +
 		"{{FormatMAM $v.ObjSize.Min}} {{FormatMAM $v.ObjSize.Avg}} {{FormatMAM $v.ObjSize.Max}}\t " +
-		"{{FormatBytesUns $v.TotalSize.PresentObjs 2}} {{FormatBytesUns $v.TotalSize.RemoteObjs 2}}\t {{$v.UsedPct}}%\n" +
+		"{{FormatBytesUns $v.TotalSize.PresentObjs 2}} {{FormatBytesUns $v.TotalSize.PhysObjs 2}} {{FormatBytesUns $v.TotalSize.RemoteObjs 2}}\t {{$v.UsedPct}}%\n" +
 		"{{end}}"
 
 	BucketSummaryValidateTmpl = "BUCKET\t OBJECTS\t MISPLACED\t MISSING COPIES\n" + bucketSummaryValidateBody
@@ -354,6 +354,11 @@ See '--help' and docs/cli for details.`
 		"{{range $mp := $p.Mpl.Disabled }}" +
 		"\t\t{{ $mp }}\n" +
 		"{{end}}{{end}}" +
+		"{{if ne (len $p.Mpl.Degraded) 0}}" +
+		"\tDegraded (slow disks - avoided by new writes):\n" +
+		"{{range $mp := $p.Mpl.Degraded }}" +
+		"\t\t{{ $mp }}\n" +
+		"{{end}}{{end}}" +
 		"{{if ne (len $p.Mpl.WaitingDD) 0}}" +
 		"\tTransitioning to disabled or detached pending resilver:\n" +
 		"{{range $mp := $p.Mpl.WaitingDD }}" +
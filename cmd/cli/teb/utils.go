@@ -207,10 +207,14 @@ func fmtACL(acl apc.AccessAttrs) string {
 }
 
 func fmtNameArch(val string, flags uint16) string {
-	if flags&apc.EntryInArch == 0 {
+	switch {
+	case flags&apc.EntryIsDir != 0:
+		return val + "/" // (non-recursive listing)
+	case flags&apc.EntryInArch != 0:
+		return "    " + val
+	default:
 		return val
 	}
-	return "    " + val
 }
 
 func dsortJobInfoStatus(j *dsort.JobInfo) string {
@@ -8,15 +8,20 @@ package cmn
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/tabular"
+	"github.com/NVIDIA/aistore/cmn/zblk"
 )
 
 // Bprops - manageable, user-configurable, and inheritable (from cluster config).
@@ -38,6 +43,14 @@ const (
 	PropBackendBck         = "backend_bck"
 	PropBackendBckName     = PropBackendBck + ".name"
 	PropBackendBckProvider = PropBackendBck + ".provider"
+	PropTierBck            = "tier.bck"
+	PropTierBckName        = PropTierBck + ".name"
+	PropTierBckProvider    = PropTierBck + ".provider"
+	PropReplBck            = "replication.bck"
+	PropReplBckName        = PropReplBck + ".name"
+	PropReplBckProvider    = PropReplBck + ".provider"
+	PropReplBckNsUUID      = PropReplBck + ".namespace.uuid"
+	PropReplBckNsName      = PropReplBck + ".namespace.name"
 )
 
 type (
@@ -45,17 +58,27 @@ type (
 		BackendBck  Bck             `json:"backend_bck,omitempty"` // makes remote bucket out of a given ais bucket
 		Extra       ExtraProps      `json:"extra,omitempty" list:"omitempty"`
 		WritePolicy WritePolicyConf `json:"write_policy"`
-		Provider    string          `json:"provider" list:"readonly"`       // backend provider
-		Renamed     string          `list:"omit"`                           // non-empty if the bucket has been renamed
-		Cksum       CksumConf       `json:"checksum"`                       // the bucket's checksum
-		EC          ECConf          `json:"ec"`                             // erasure coding
-		LRU         LRUConf         `json:"lru"`                            // LRU (watermarks and enabled/disabled)
-		Mirror      MirrorConf      `json:"mirror"`                         // mirroring
-		Access      apc.AccessAttrs `json:"access,string"`                  // access permissions
-		Features    feat.Flags      `json:"features,string"`                // assorted features from feat.Bucket
-		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
-		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
-		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit")
+		Provider    string          `json:"provider" list:"readonly"`            // backend provider
+		Renamed     string          `list:"omit"`                                // non-empty if the bucket has been renamed
+		RenPrefix   *PrefixRename   `json:"rename_prefix,omitempty" list:"omit"` // virtual directory rename in progress (if any)
+		Cksum       CksumConf       `json:"checksum"`                            // the bucket's checksum
+		EC          ECConf          `json:"ec"`                                  // erasure coding
+		LRU         LRUConf         `json:"lru"`                                 // LRU (watermarks and enabled/disabled)
+		Tier        TierConf        `json:"tier"`                                // tiering of cold objects to remote backend
+		Replication ReplConf        `json:"replication"`                         // async replication to remote AIS cluster
+		Events      EventsConf      `json:"events"`                              // object event notifications (webhooks)
+		Compress    CompressConf    `json:"compression"`                         // compression at rest
+		Encrypt     EncryptConf     `json:"encryption"`                          // encryption at rest
+		Pack        PackConf        `json:"packing"`                             // packing small objects
+		Chunks      ChunkConf       `json:"chunks"`                              // chunked storage of large objects
+		NameIdx     NameIdxConf     `json:"name_index"`                          // persistent sorted name index (list-objects)
+		Inventory   InvConf         `json:"inventory"`                           // list remote bucket via manifest object (inventory)
+		Mirror      MirrorConf      `json:"mirror"`                              // mirroring
+		Access      apc.AccessAttrs `json:"access,string"`                       // access permissions
+		Features    feat.Flags      `json:"features,string"`                     // assorted features from feat.Bucket
+		BID         uint64          `json:"bid,string" list:"omit"`              // unique ID
+		Created     int64           `json:"created,string" list:"readonly"`      // creation timestamp
+		Versioning  VersionConf     `json:"versioning"`                          // versioning (see "inherit")
 	}
 
 	ExtraProps struct {
@@ -112,6 +135,170 @@ type (
 		RefDirectory *string `json:"ref_directory"`
 	}
 
+	// Tiering: upload cold ais:// objects to a remote bucket (any supported backend)
+	// and replace local replicas with metadata-only stubs; subsequent GET re-hydrates
+	// the object via regular cold GET (see xact/xs/tier.go)
+	TierConf struct {
+		// destination (remote) bucket
+		Bck Bck `json:"bck"`
+
+		// objects that were not accessed for at least so long are considered cold
+		Age cos.Duration `json:"age"`
+
+		// do not tier objects smaller than
+		MinSize cos.SizeIEC `json:"min_size"`
+
+		Enabled bool `json:"enabled"`
+	}
+	TierConfToSet struct {
+		Bck     *BackendBckToSet `json:"bck,omitempty"`
+		Age     *cos.Duration    `json:"age,omitempty"`
+		MinSize *cos.SizeIEC     `json:"min_size,omitempty"`
+		Enabled *bool            `json:"enabled,omitempty"`
+	}
+
+	// Replication: continuously and asynchronously replicate ais:// bucket to a bucket
+	// in a remote AIS cluster (see BackendConfAIS); all PUT, DELETE, and rename operations
+	// are logged in the per-target change log and shipped in the background
+	// (see core/lrepl.go and xact/xs/repl.go)
+	ReplConf struct {
+		// destination bucket, e.g. ais://@remais/abc where "remais" is the remote cluster's alias
+		Bck     Bck  `json:"bck"`
+		Enabled bool `json:"enabled"`
+	}
+	ReplConfToSet struct {
+		Bck     *ReplBckToSet `json:"bck,omitempty"`
+		Enabled *bool         `json:"enabled,omitempty"`
+	}
+	ReplBckToSet struct {
+		Name     *string  `json:"name"`
+		Provider *string  `json:"provider"`
+		Ns       *NsToSet `json:"namespace"`
+	}
+	NsToSet struct {
+		UUID *string `json:"uuid"`
+		Name *string `json:"name"`
+	}
+
+	// Object event notifications: POST JSON events (apc.ObjEvent) to HTTP endpoints
+	// (webhooks) - at least once (see ext/webhook)
+	EventsConf struct {
+		Rules   []EventRule `json:"rules" list:"readonly"` // (to set, use JSON)
+		Enabled bool        `json:"enabled"`
+	}
+	EventsConfToSet struct {
+		Rules   *[]EventRule `json:"rules,omitempty"`
+		Enabled *bool        `json:"enabled,omitempty"`
+	}
+	EventRule struct {
+		Endpoint string   `json:"endpoint"`         // http(s) URL
+		Events   []string `json:"events,omitempty"` // event types (apc.SupportedEvents); empty - all
+		Prefix   string   `json:"prefix,omitempty"` // object name filters
+		Suffix   string   `json:"suffix,omitempty"` // ditto
+	}
+
+	// Compression at rest: store objects in block-indexed compressed format (see cmn/zblk)
+	// and transparently decompress upon reading (see core/lzblk.go and core/lcodec.go)
+	CompressConf struct {
+		Algo      string      `json:"algo"`       // one of zblk.SupportedAlgos (default: lz4)
+		BlockSize cos.SizeIEC `json:"block_size"` // granularity of range reads (default: zblk.DefaultBlockSize)
+		Enabled   bool        `json:"enabled"`
+	}
+	CompressConfToSet struct {
+		Algo      *string      `json:"algo,omitempty"`
+		BlockSize *cos.SizeIEC `json:"block_size,omitempty"`
+		Enabled   *bool        `json:"enabled,omitempty"`
+	}
+
+	// Encryption at rest: AES-GCM with per-bucket data keys (see cmn/crypt and core/lcrypt.go);
+	// data keys are generated by the primary and stored wrapped by the master key
+	EncryptConf struct {
+		Keys    []DataKey `json:"keys" list:"readonly"` // the last one is current (see also: re-encrypt)
+		Enabled bool      `json:"enabled"`
+	}
+	EncryptConfToSet struct {
+		Enabled *bool `json:"enabled,omitempty"`
+	}
+
+	// Packing small objects: objects below `max_obj_size` get appended to per-mountpath
+	// pack files (see fs/pack.go and core/lpack.go) instead of being stored as separate files
+	PackConf struct {
+		MaxObjSize  cos.SizeIEC `json:"max_obj_size"`  // objects of this size or smaller get packed (default: 16KiB)
+		MaxPackSize cos.SizeIEC `json:"max_pack_size"` // pack file size upon reaching which the next one gets started (default: 256MiB)
+		CompactPct  int64       `json:"compact_pct"`   // compact pack files that have this or greater percentage of deleted content (default: 50)
+		Enabled     bool        `json:"enabled"`
+	}
+	PackConfToSet struct {
+		MaxObjSize  *cos.SizeIEC `json:"max_obj_size,omitempty"`
+		MaxPackSize *cos.SizeIEC `json:"max_pack_size,omitempty"`
+		CompactPct  *int64       `json:"compact_pct,omitempty"`
+		Enabled     *bool        `json:"enabled,omitempty"`
+	}
+
+	// Chunked storage of large objects: objects of at least `min_obj_size` are stored as
+	// a manifest (in place of the object's file) plus fixed-size chunks spread across
+	// the target's mountpaths (see core/lchunk.go)
+	ChunkConf struct {
+		ChunkSize  cos.SizeIEC `json:"chunk_size"`   // size of each chunk except the last one (default: 1GiB)
+		MinObjSize cos.SizeIEC `json:"min_obj_size"` // objects of this size or larger get chunked (default: 4GiB)
+		Enabled    bool        `json:"enabled"`
+	}
+	ChunkConfToSet struct {
+		ChunkSize  *cos.SizeIEC `json:"chunk_size,omitempty"`
+		MinObjSize *cos.SizeIEC `json:"min_obj_size,omitempty"`
+		Enabled    *bool        `json:"enabled,omitempty"`
+	}
+
+	// Name index: per-target sorted index of object names that list-objects pages from
+	// (instead of walking the filesystem) - see fs/nameidx.go and core/lnidx.go
+	NameIdxConf struct {
+		Enabled bool `json:"enabled"`
+	}
+	NameIdxConfToSet struct {
+		Enabled *bool `json:"enabled,omitempty"`
+	}
+
+	// Inventory: list remote bucket by paging through a manifest object - a tabular
+	// listing in CSV, JSONL, or Parquet format (see cmn/tabular) - instead of calling
+	// the backend's list API; applies to list-objects, prefetch, and copy (see xact/xs/linv.go)
+	InvConf struct {
+		Bck     Bck        `json:"bck"`     // bucket that contains the manifest (default: the bucket itself)
+		Name    string     `json:"name"`    // manifest object name, e.g. "manifests/2024-06-01.parquet"
+		Format  string     `json:"format"`  // one of tabular.Formats (default: by the name's extension)
+		Columns InvColumns `json:"columns"` // column mapping
+		Enabled bool       `json:"enabled"`
+	}
+	// CSV header names or (header-less CSV) 0-based indices, JSONL keys, or Parquet column names;
+	// all but the object name are optional
+	InvColumns struct {
+		Name     string `json:"name"` // default: "name"
+		Size     string `json:"size"`
+		Checksum string `json:"checksum"`
+		Version  string `json:"version"`
+		ETag     string `json:"etag"`
+		Mtime    string `json:"mtime"` // last modified
+	}
+	InvConfToSet struct {
+		Bck     *BackendBckToSet `json:"bck,omitempty"`
+		Name    *string          `json:"name,omitempty"`
+		Format  *string          `json:"format,omitempty"`
+		Columns *InvColumnsToSet `json:"columns,omitempty"`
+		Enabled *bool            `json:"enabled,omitempty"`
+	}
+	InvColumnsToSet struct {
+		Name     *string `json:"name,omitempty"`
+		Size     *string `json:"size,omitempty"`
+		Checksum *string `json:"checksum,omitempty"`
+		Version  *string `json:"version,omitempty"`
+		ETag     *string `json:"etag,omitempty"`
+		Mtime    *string `json:"mtime,omitempty"`
+	}
+	DataKey struct {
+		Wrapped  []byte `json:"key"`       // (base64)
+		MasterID string `json:"master_id"` // the master key that was used to wrap
+		ID       uint32 `json:"id"`
+	}
+
 	// Once validated, BpropsToSet are copied to Bprops.
 	// The struct may have extra fields that do not exist in Bprops.
 	// Add tag 'copy:"skip"' to ignore those fields when copying values.
@@ -120,6 +307,15 @@ type (
 		Versioning  *VersionConfToSet     `json:"versioning,omitempty"`
 		Cksum       *CksumConfToSet       `json:"checksum,omitempty"`
 		LRU         *LRUConfToSet         `json:"lru,omitempty"`
+		Tier        *TierConfToSet        `json:"tier,omitempty"`
+		Replication *ReplConfToSet        `json:"replication,omitempty"`
+		Events      *EventsConfToSet      `json:"events,omitempty"`
+		Compress    *CompressConfToSet    `json:"compression,omitempty"`
+		Encrypt     *EncryptConfToSet     `json:"encryption,omitempty"`
+		Pack        *PackConfToSet        `json:"packing,omitempty"`
+		Chunks      *ChunkConfToSet       `json:"chunks,omitempty"`
+		NameIdx     *NameIdxConfToSet     `json:"name_index,omitempty"`
+		Inventory   *InvConfToSet         `json:"inventory,omitempty"`
 		Mirror      *MirrorConfToSet      `json:"mirror,omitempty"`
 		EC          *ECConfToSet          `json:"ec,omitempty"`
 		Access      *apc.AccessAttrs      `json:"access,string,omitempty"`
@@ -216,12 +412,24 @@ func (bp *Bprops) Validate(targetCnt int) error {
 		}
 	}
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Tier, &bp.Replication, &bp.Events, &bp.Compress, &bp.Encrypt, &bp.Pack, &bp.Chunks, &bp.NameIdx, &bp.Inventory} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
 		} else if pv == &bp.Extra {
 			err = bp.Extra.ValidateAsProps(bp.Provider)
+		} else if pv == &bp.Tier {
+			err = bp.Tier.ValidateAsProps(bp)
+		} else if pv == &bp.Replication {
+			err = bp.Replication.ValidateAsProps(bp)
+		} else if pv == &bp.Pack {
+			err = bp.Pack.ValidateAsProps(bp)
+		} else if pv == &bp.Chunks {
+			err = bp.Chunks.ValidateAsProps(bp)
+		} else if pv == &bp.NameIdx {
+			err = bp.NameIdx.ValidateAsProps(bp)
+		} else if pv == &bp.Inventory {
+			err = bp.Inventory.ValidateAsProps(bp)
 		} else {
 			err = pv.ValidateAsProps()
 		}
@@ -245,6 +453,426 @@ func (bp *Bprops) Validate(targetCnt int) error {
 	return softErr
 }
 
+//////////////
+// TierConf //
+//////////////
+
+func (c *TierConf) ValidateAsProps(arg ...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	bp, ok := arg[0].(*Bprops)
+	debug.Assert(ok)
+	if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+		return errors.New("tiering is supported only for ais:// buckets without remote backend (use LRU to evict remote content)")
+	}
+	if bp.EC.Enabled {
+		return errors.New("tiering and erasure coding cannot be enabled at the same time")
+	}
+	if c.Bck.Name == "" || c.Bck.Provider == "" {
+		return fmt.Errorf("invalid tier bucket %q: both name and provider must be defined", c.Bck.String())
+	}
+	if !c.Bck.IsRemote() {
+		return fmt.Errorf("tier bucket %q must be remote", c.Bck.String())
+	}
+	if c.Age < 0 || c.MinSize < 0 {
+		return fmt.Errorf("invalid tiering age (%v) and/or min. size (%v)", c.Age, c.MinSize)
+	}
+	return nil
+}
+
+func (c *TierConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return fmt.Sprintf("=> %s (age %v, min-size %s)", c.Bck.Cname(""), c.Age, cos.ToSizeIEC(int64(c.MinSize), 0))
+}
+
+//////////////
+// ReplConf //
+//////////////
+
+func (c *ReplConf) ValidateAsProps(arg ...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	bp, ok := arg[0].(*Bprops)
+	debug.Assert(ok)
+	if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+		return errors.New("replication is supported only for ais:// buckets without remote backend")
+	}
+	if bp.Tier.Enabled {
+		return errors.New("replication and tiering cannot be enabled at the same time")
+	}
+	if c.Bck.Name == "" {
+		return fmt.Errorf("invalid replication bucket %q: name must be defined", c.Bck.String())
+	}
+	if !c.Bck.IsRemoteAIS() {
+		return fmt.Errorf("replication bucket %q must be an ais:// bucket in a remote AIS cluster (e.g., ais://@remais/%s)",
+			c.Bck.String(), c.Bck.Name)
+	}
+	return nil
+}
+
+func (c *ReplConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "=> " + c.Bck.Cname("")
+}
+
+////////////////
+// EventsConf //
+////////////////
+
+func (c *EventsConf) ValidateAsProps(...any) error {
+	for i := range c.Rules {
+		if err := c.Rules[i].validate(); err != nil {
+			return err
+		}
+	}
+	if c.Enabled && len(c.Rules) == 0 {
+		return errors.New("event notifications enabled but no rules (endpoints) are defined")
+	}
+	return nil
+}
+
+func (c *EventsConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return fmt.Sprintf("%d rule%s", len(c.Rules), cos.Plural(len(c.Rules)))
+}
+
+func (rule *EventRule) validate() error {
+	u, err := url.Parse(rule.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid event notification endpoint %q (expecting http(s) URL)", rule.Endpoint)
+	}
+	for _, e := range rule.Events {
+		if !cos.StringInSlice(e, apc.SupportedEvents) {
+			return fmt.Errorf("invalid event type %q (expecting one of: %v)", e, apc.SupportedEvents)
+		}
+	}
+	return nil
+}
+
+// matches a given event type and object name
+func (rule *EventRule) Match(evtype, objName string) bool {
+	if len(rule.Events) > 0 && !cos.StringInSlice(evtype, rule.Events) {
+		return false
+	}
+	return strings.HasPrefix(objName, rule.Prefix) && strings.HasSuffix(objName, rule.Suffix)
+}
+
+//////////////////
+// CompressConf //
+//////////////////
+
+func (c *CompressConf) ValidateAsProps(...any) error {
+	if c.Algo != "" && !zblk.IsValidAlgo(c.Algo) {
+		return fmt.Errorf("invalid compression algorithm %q (expecting one of: %v)", c.Algo, zblk.SupportedAlgos)
+	}
+	return zblk.ValidateBlockSize(int64(c.BlockSize))
+}
+
+func (c *CompressConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	algo := c.Algo
+	if algo == "" {
+		algo = zblk.AlgoLZ4
+	}
+	bsize := int64(c.BlockSize)
+	if bsize == 0 {
+		bsize = zblk.DefaultBlockSize
+	}
+	return algo + " (block " + cos.ToSizeIEC(bsize, 0) + ")"
+}
+
+/////////////////
+// EncryptConf //
+/////////////////
+
+func (c *EncryptConf) ValidateAsProps(...any) error {
+	for i := range c.Keys {
+		k := &c.Keys[i]
+		if len(k.Wrapped) == 0 || k.MasterID == "" || (i > 0 && k.ID <= c.Keys[i-1].ID) {
+			return fmt.Errorf("invalid data key #%d (id %d)", i, k.ID)
+		}
+	}
+	if c.Enabled && len(c.Keys) == 0 {
+		return errors.New("encryption enabled but no data key is defined")
+	}
+	return nil
+}
+
+func (c *EncryptConf) String() string {
+	if !c.Enabled || len(c.Keys) == 0 {
+		return "Disabled"
+	}
+	return fmt.Sprintf("AES-GCM (data key %d)", c.Current().ID)
+}
+
+// generate new (current) data key
+func (c *EncryptConf) NewKey() error {
+	wrapped, mkid, err := crypt.NewDataKey()
+	if err != nil {
+		return err
+	}
+	var id uint32 = 1
+	if len(c.Keys) > 0 {
+		id = c.Current().ID + 1
+	}
+	c.Keys = append(c.Keys, DataKey{ID: id, Wrapped: wrapped, MasterID: mkid})
+	return nil
+}
+
+func (c *EncryptConf) Current() *DataKey {
+	if len(c.Keys) == 0 {
+		return nil
+	}
+	return &c.Keys[len(c.Keys)-1]
+}
+
+func (c *EncryptConf) Key(id uint32) *DataKey {
+	for i := range c.Keys {
+		if c.Keys[i].ID == id {
+			return &c.Keys[i]
+		}
+	}
+	return nil
+}
+
+//////////////
+// PackConf //
+//////////////
+
+const (
+	DefaultPackObjSize  = 16 * cos.KiB
+	DefaultPackSize     = 256 * cos.MiB
+	DefaultPackCompact  = 50
+	maxPackObjSize      = cos.MiB
+	minPackSize         = cos.MiB
+	maxPackSize         = 4 * cos.GiB
+	packExclusiveErrFmt = "packing small objects and %s cannot be enabled at the same time"
+)
+
+func (c *PackConf) ValidateAsProps(arg ...any) error {
+	if c.MaxObjSize < 0 || c.MaxObjSize > maxPackObjSize {
+		return fmt.Errorf("invalid packing.max_obj_size %s (expecting up to %s)", c.MaxObjSize, cos.SizeIEC(maxPackObjSize))
+	}
+	if c.MaxPackSize != 0 && (c.MaxPackSize < minPackSize || c.MaxPackSize > maxPackSize) {
+		return fmt.Errorf("invalid packing.max_pack_size %s (expecting %s to %s)",
+			c.MaxPackSize, cos.SizeIEC(minPackSize), cos.SizeIEC(maxPackSize))
+	}
+	if c.CompactPct < 0 || c.CompactPct > 100 {
+		return fmt.Errorf("invalid packing.compact_pct %d (expecting 0 to 100)", c.CompactPct)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	bp, ok := arg[0].(*Bprops)
+	debug.Assert(ok)
+	switch {
+	case bp.EC.Enabled:
+		return fmt.Errorf(packExclusiveErrFmt, "erasure coding")
+	case bp.Compress.Enabled:
+		return fmt.Errorf(packExclusiveErrFmt, "compression")
+	case bp.Encrypt.Enabled:
+		return fmt.Errorf(packExclusiveErrFmt, "encryption")
+	case bp.Tier.Enabled:
+		return fmt.Errorf(packExclusiveErrFmt, "tiering")
+	}
+	return nil
+}
+
+func (c *PackConf) ObjSize() int64 {
+	if c.MaxObjSize == 0 {
+		return DefaultPackObjSize
+	}
+	return int64(c.MaxObjSize)
+}
+
+func (c *PackConf) PackSize() int64 {
+	if c.MaxPackSize == 0 {
+		return DefaultPackSize
+	}
+	return int64(c.MaxPackSize)
+}
+
+func (c *PackConf) Compact() int64 {
+	if c.CompactPct == 0 {
+		return DefaultPackCompact
+	}
+	return c.CompactPct
+}
+
+func (c *PackConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "objects up to " + cos.ToSizeIEC(c.ObjSize(), 0)
+}
+
+///////////////
+// ChunkConf //
+///////////////
+
+const (
+	DefaultChunkSize     = cos.GiB
+	DefaultChunkObjSize  = 4 * cos.GiB
+	minChunkSize         = cos.MiB
+	maxChunkSize         = 64 * cos.GiB
+	chunkExclusiveErrFmt = "chunked storage and %s cannot be enabled at the same time"
+)
+
+func (c *ChunkConf) ValidateAsProps(arg ...any) error {
+	if c.ChunkSize != 0 && (c.ChunkSize < minChunkSize || c.ChunkSize > maxChunkSize) {
+		return fmt.Errorf("invalid chunks.chunk_size %s (expecting %s to %s)",
+			c.ChunkSize, cos.SizeIEC(minChunkSize), cos.SizeIEC(maxChunkSize))
+	}
+	if c.MinObjSize < 0 {
+		return fmt.Errorf("invalid chunks.min_obj_size %s", c.MinObjSize)
+	}
+	if c.MinObjSize != 0 && c.MinObjSize < cos.SizeIEC(c.Size()) {
+		return fmt.Errorf("invalid chunks.min_obj_size %s (expecting at least chunk size %s)",
+			c.MinObjSize, cos.SizeIEC(c.Size()))
+	}
+	if !c.Enabled {
+		return nil
+	}
+	bp, ok := arg[0].(*Bprops)
+	debug.Assert(ok)
+	switch {
+	case bp.Mirror.Enabled:
+		return fmt.Errorf(chunkExclusiveErrFmt, "mirroring")
+	case bp.EC.Enabled:
+		return fmt.Errorf(chunkExclusiveErrFmt, "erasure coding")
+	case bp.Compress.Enabled:
+		return fmt.Errorf(chunkExclusiveErrFmt, "compression")
+	case bp.Encrypt.Enabled:
+		return fmt.Errorf(chunkExclusiveErrFmt, "encryption")
+	case bp.Pack.Enabled:
+		return fmt.Errorf(chunkExclusiveErrFmt, "packing small objects")
+	}
+	return nil
+}
+
+func (c *ChunkConf) Size() int64 {
+	if c.ChunkSize == 0 {
+		return DefaultChunkSize
+	}
+	return int64(c.ChunkSize)
+}
+
+func (c *ChunkConf) ObjSize() int64 {
+	if c.MinObjSize == 0 {
+		return max(DefaultChunkObjSize, c.Size())
+	}
+	return int64(c.MinObjSize)
+}
+
+func (c *ChunkConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return cos.ToSizeIEC(c.Size(), 0) + " chunks, objects of at least " + cos.ToSizeIEC(c.ObjSize(), 0)
+}
+
+/////////////////
+// NameIdxConf //
+/////////////////
+
+func (c *NameIdxConf) ValidateAsProps(arg ...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	bp, ok := arg[0].(*Bprops)
+	debug.Assert(ok)
+	if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+		return errors.New("name index applies only to ais:// buckets (with no backend)")
+	}
+	return nil
+}
+
+func (c *NameIdxConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "Enabled"
+}
+
+/////////////
+// InvConf //
+/////////////
+
+const DefaultInvNameCol = "name"
+
+func (c *InvConf) ValidateAsProps(arg ...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	bp, ok := arg[0].(*Bprops)
+	debug.Assert(ok)
+	if bp.Provider == apc.AIS && bp.BackendBck.IsEmpty() {
+		return errors.New("inventory applies only to remote buckets (including ais:// buckets with remote backend)")
+	}
+	if c.Name == "" {
+		return errors.New("inventory: manifest object name is required")
+	}
+	if !c.Bck.IsEmpty() && (c.Bck.Name == "" || c.Bck.Provider == "") {
+		return fmt.Errorf("invalid inventory bucket %q: both name and provider must be defined", c.Bck.String())
+	}
+	format, _ := c.Fmt()
+	if format == "" {
+		return fmt.Errorf("inventory: cannot determine manifest format given %q (expecting one of %v)", c.Name, tabular.Formats)
+	}
+	if err := tabular.ValidateFormat(format); err != nil {
+		return err
+	}
+	_, err := tabular.ValidateColumns(format, c.Columns.Cols())
+	return err
+}
+
+// returns manifest format and whether the manifest is gzip-compressed
+func (c *InvConf) Fmt() (format string, gz bool) {
+	format, gz = tabular.FormatByExt(c.Name)
+	if c.Format != "" {
+		format = c.Format
+	}
+	return format, gz
+}
+
+// the bucket that contains the manifest
+func (c *InvConf) ManifestBck(bck *Bck) *Bck {
+	if c.Bck.IsEmpty() {
+		return bck
+	}
+	return &c.Bck
+}
+
+func (c *InvConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	format, _ := c.Fmt()
+	if c.Bck.IsEmpty() {
+		return c.Name + " (" + format + ")"
+	}
+	return c.Bck.Cname(c.Name) + " (" + format + ")"
+}
+
+// in order: name, size, checksum, version, etag, mtime
+func (c *InvColumns) Cols() []string {
+	name := c.Name
+	if name == "" {
+		name = DefaultInvNameCol
+	}
+	return []string{name, c.Size, c.Checksum, c.Version, c.ETag, c.Mtime}
+}
+
 func (bp *Bprops) Apply(propsToSet *BpropsToSet) {
 	err := copyProps(propsToSet, bp, apc.Daemon)
 	debug.AssertNoErr(err)
@@ -331,6 +959,7 @@ func aggr(from, to *BsummResult) {
 	to.ObjCount.Remote += from.ObjCount.Remote
 	to.TotalSize.OnDisk += from.TotalSize.OnDisk
 	to.TotalSize.PresentObjs += from.TotalSize.PresentObjs
+	to.TotalSize.PhysObjs += from.TotalSize.PhysObjs
 	to.TotalSize.RemoteObjs += from.TotalSize.RemoteObjs
 }
 
@@ -374,3 +1003,242 @@ type (
 )
 
 func (msg *ArchiveBckMsg) Cname() string { return msg.ToBck.Cname(msg.ArchName) }
+
+//
+// Compose (concatenate) objects and/or byte ranges into a single destination object --------------------------
+//
+
+type (
+	// ComposeMsg contains the ordered list of sources to compose the destination object from.
+	// Sources are objects or byte ranges, in any bucket accessible to the cluster.
+	// See also: api.ComposeObject
+	ComposeMsg struct {
+		Sources []ComposeSrc `json:"sources"`
+	}
+	ComposeSrc struct {
+		Bck     Bck    `json:"bck"` // empty: same as destination
+		ObjName string `json:"objname"`
+		Offset  int64  `json:"offset,omitempty"`
+		Length  int64  `json:"length,omitempty"` // zero: through the end of the object
+	}
+)
+
+func (msg *ComposeMsg) Validate() error {
+	if len(msg.Sources) == 0 {
+		return errors.New("compose: empty list of sources")
+	}
+	for i := range msg.Sources {
+		src := &msg.Sources[i]
+		if src.ObjName == "" {
+			return fmt.Errorf("compose: source #%d: missing object name", i)
+		}
+		if src.Offset < 0 || src.Length < 0 {
+			return fmt.Errorf("compose: source #%d (%s): invalid range [%d, %d]", i, src.ObjName, src.Offset, src.Length)
+		}
+	}
+	return nil
+}
+
+// is a byte range (as opposed to the entire object)
+func (src *ComposeSrc) IsRange() bool { return src.Offset > 0 || src.Length > 0 }
+
+//
+// Rename virtual directory (prefix) ---------------------------------------------------------------------------
+//
+
+const (
+	RenPrefixCopy     = "copy"     // copying From => To; new names are hidden
+	RenPrefixCommit   = "commit"   // committed: new names are visible and old ones hidden (and getting removed)
+	RenPrefixRollback = "rollback" // aborted: removing the copies
+)
+
+type (
+	// RenamePrefixMsg requests renaming all objects that have names starting with `From`
+	// (e.g. "tmp/run42/") so that their new names start with `To` (e.g. "final/run42/").
+	// Re-issuing the same request resumes the rename that has been interrupted;
+	// `Abort` aborts it (provided it's not committed yet).
+	// See also: api.RenamePrefix
+	RenamePrefixMsg struct {
+		From  string `json:"from"`
+		To    string `json:"to"`
+		Abort bool   `json:"abort,omitempty"`
+	}
+
+	// PrefixRename is the state of the rename in progress (stored in the bucket's props)
+	PrefixRename struct {
+		From  string `json:"from"`
+		To    string `json:"to"`
+		Phase string `json:"phase"` // one of the RenPrefix* enum above
+	}
+)
+
+func (msg *RenamePrefixMsg) Validate() error {
+	if msg.From == "" || msg.To == "" {
+		return errors.New("rename-prefix: source and destination prefixes must be non-empty")
+	}
+	if err := ValidatePrefix(msg.From); err != nil {
+		return err
+	}
+	if err := ValidatePrefix(msg.To); err != nil {
+		return err
+	}
+	if strings.HasPrefix(msg.From, msg.To) || strings.HasPrefix(msg.To, msg.From) {
+		return fmt.Errorf("rename-prefix: %q and %q overlap", msg.From, msg.To)
+	}
+	return nil
+}
+
+func (rp *PrefixRename) String() string { return rp.From + " => " + rp.To + " (" + rp.Phase + ")" }
+
+// hidden from readers: new names until committed, old names thereafter
+func (rp *PrefixRename) Hidden(objName string) bool {
+	if rp.Phase == RenPrefixCommit {
+		return strings.HasPrefix(objName, rp.From)
+	}
+	return strings.HasPrefix(objName, rp.To)
+}
+
+// writes under either prefix are not permitted for the duration
+func (rp *PrefixRename) Busy(objName string) bool {
+	return strings.HasPrefix(objName, rp.From) || strings.HasPrefix(objName, rp.To)
+}
+
+//
+// Batch GET: multiple objects and/or archived files => single streamed archive ---------------------------------
+//
+
+type (
+	// GetBatchMsg contains the ordered list of objects (and/or files archived in shards)
+	// to be returned as a single archive (TAR by default), in the specified order.
+	// See also: api.GetBatch
+	GetBatchMsg struct {
+		Mime        string       `json:"mime,omitempty"` // output format (default: .tar); see cmn/archive
+		In          []GetBatchIn `json:"in"`
+		SkipMissing bool         `json:"skip_missing,omitempty"` // true: skip missing entries; false: fail
+	}
+	GetBatchIn struct {
+		Bck      Bck    `json:"bck"` // empty: same as the request bucket
+		ObjName  string `json:"objname"`
+		ArchPath string `json:"archpath,omitempty"` // file archived in the `ObjName` shard
+	}
+)
+
+func (msg *GetBatchMsg) Validate() error {
+	if len(msg.In) == 0 {
+		return errors.New("get-batch: empty list of entries")
+	}
+	for i := range msg.In {
+		in := &msg.In[i]
+		if in.ObjName == "" {
+			return fmt.Errorf("get-batch: entry #%d: missing object name", i)
+		}
+		if !in.Bck.IsEmpty() {
+			if err := in.Bck.Validate(); err != nil {
+				return fmt.Errorf("get-batch: entry #%d (%s): %w", i, in.ObjName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// name of the entry in the resulting archive:
+// [<bucket-name>/]<object-name>[/<archpath>], where the bucket name is included
+// only when it differs from the request bucket's name
+func (in *GetBatchIn) NameInArch(bck *Bck) string {
+	name := in.ObjName
+	if in.Bck.Name != "" && in.Bck.Name != bck.Name {
+		name = in.Bck.Name + "/" + name
+	}
+	if in.ArchPath != "" {
+		name += "/" + in.ArchPath
+	}
+	return name
+}
+
+//
+// Export bucket listing as CSV, JSONL, or Parquet object(s) --------------------------------------------------------
+//
+
+// (name always included and always goes first)
+var ExportListProps = []string{
+	apc.GetPropsName, apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsVersion,
+	apc.GetPropsAtime, apc.GetPropsCustom, apc.GetPropsLocation,
+}
+
+// ExportListMsg requests writing the listing of a bucket into the destination bucket
+// as one or more (CSV, JSONL, or Parquet) objects. Each target walks its mountpaths in parallel
+// and writes the objects it stores, sorted by name:
+// <ObjName>/<target ID>-<N>.<Format>, where N = 0, 1, ... when MaxRows is exceeded.
+// See also: api.ExportList
+type ExportListMsg struct {
+	ToBck   Bck    `json:"tobck"`
+	ObjName string `json:"objname,omitempty"`  // default: source bucket name
+	Format  string `json:"format,omitempty"`   // one of tabular.Formats (default: parquet)
+	Props   string `json:"props,omitempty"`    // comma-separated ExportListProps (default: "name,size")
+	Prefix  string `json:"prefix,omitempty"`   // export only objects with names starting with prefix
+	MaxRows int64  `json:"max_rows,omitempty"` // max rows per object (0: unlimited)
+}
+
+// validate and fill in defaults
+func (msg *ExportListMsg) Validate(bck *Bck) error {
+	if msg.ToBck.IsEmpty() {
+		return errors.New("export-list: destination bucket is not specified")
+	}
+	if err := msg.ToBck.Validate(); err != nil {
+		return err
+	}
+	if msg.ObjName == "" {
+		msg.ObjName = bck.Name
+	}
+	msg.ObjName = strings.TrimSuffix(msg.ObjName, "/")
+	if err := ValidateObjName(msg.ObjName); err != nil {
+		return err
+	}
+	if msg.Format == "" {
+		msg.Format = tabular.Parquet
+	}
+	if err := tabular.ValidateFormat(msg.Format); err != nil {
+		return err
+	}
+	if err := ValidatePrefix(msg.Prefix); err != nil {
+		return err
+	}
+	if msg.MaxRows < 0 {
+		return fmt.Errorf("export-list: invalid max rows %d", msg.MaxRows)
+	}
+	if msg.Props == "" {
+		msg.Props = apc.GetPropsNameSize
+	}
+	for _, prop := range strings.Split(msg.Props, apc.LsPropsSepa) {
+		if !cos.StringInSlice(strings.TrimSpace(prop), ExportListProps) {
+			return fmt.Errorf("export-list: invalid property %q (expecting one of: %v)", prop, ExportListProps)
+		}
+	}
+	return nil
+}
+
+// columns in the ExportListProps order
+func (msg *ExportListMsg) Columns() (cols []tabular.Column) {
+	props := strings.Split(msg.Props, apc.LsPropsSepa)
+	for i := range props {
+		props[i] = strings.TrimSpace(props[i])
+	}
+	for _, prop := range ExportListProps {
+		if prop != apc.GetPropsName && !cos.StringInSlice(prop, props) {
+			continue
+		}
+		col := tabular.Column{Name: prop, Type: tabular.ColString}
+		switch prop {
+		case apc.GetPropsSize:
+			col.Type = tabular.ColInt64
+		case apc.GetPropsAtime:
+			col.Type = tabular.ColTime
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+func (msg *ExportListMsg) OutName(tid string, n int) string {
+	return msg.ObjName + "/" + tid + "-" + strconv.Itoa(n) + "." + msg.Format
+}
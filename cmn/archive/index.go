@@ -0,0 +1,172 @@
+// Package archive: write, read, copy, append, list primitives
+// across all supported formats
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/flate"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Index of archived files (members) facilitates direct access to a given file in
+// the archive without scanning the latter (see also: Reader.Range).
+// Supported formats: TAR and ZIP - compressed TAR formats do not allow for random access.
+
+const IndexCksumType = cos.ChecksumXXHash
+
+type (
+	Index struct {
+		Entries map[string]*IndexEntry `json:"entries"` // by (normalized) name
+		Mime    string                 `json:"mime"`
+		End     int64                  `json:"end,omitempty"` // TAR: end of the last indexed member (see Extend)
+	}
+	IndexEntry struct {
+		Cksum  string `json:"c"`           // IndexCksumType of the (uncompressed) content
+		Offset int64  `json:"o"`           // content offset in the archive
+		Size   int64  `json:"s"`           // ditto, size
+		CSize  int64  `json:"z,omitempty"` // ZIP: compressed size
+		Method uint16 `json:"m,omitempty"` // ZIP: compression method (zip.Store or zip.Deflate)
+	}
+)
+
+func Indexable(mime string) bool { return mime == ExtTar || mime == ExtZip }
+
+// NewIndex reads the entire archive: all members get checksummed
+func NewIndex(mime string, r io.ReaderAt, size int64) (*Index, error) {
+	idx := &Index{Mime: mime, Entries: make(map[string]*IndexEntry, 64)}
+	switch mime {
+	case ExtTar:
+		return idx, idx.Extend(r, 0, size)
+	case ExtZip:
+		return idx, idx.zip(r, size)
+	default:
+		return nil, fmt.Errorf("cannot index %q archive", mime)
+	}
+}
+
+// Extend indexes TAR members that start at the given offset
+// (e.g., the offset at which new files were appended to an existing archive)
+func (idx *Index) Extend(r io.ReaderAt, off, size int64) error {
+	if idx.Mime != ExtTar {
+		return fmt.Errorf("cannot extend %q index", idx.Mime)
+	}
+	var (
+		cr   = &countingReader{r: io.NewSectionReader(r, off, size-off), n: off} // (not a Seeker)
+		tr   = tar.NewReader(cr)
+		hash = cos.NewCksumHash(IndexCksumType)
+	)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		e := &IndexEntry{Offset: cr.n, Size: hdr.Size}
+		hash.H.Reset()
+		if _, err := io.Copy(hash.H, tr); err != nil {
+			return err
+		}
+		hash.Finalize()
+		e.Cksum = hash.Value()
+		idx.add(hdr.Name, e)
+
+		idx.End = e.Offset + e.Size
+		if rem := idx.End % 512; rem != 0 {
+			idx.End += 512 - rem // (tar block padding)
+		}
+	}
+}
+
+func (idx *Index) zip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	hash := cos.NewCksumHash(IndexCksumType)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if f.Method != zip.Store && f.Method != zip.Deflate {
+			return fmt.Errorf("cannot index %s: unsupported compression method %d", f.Name, f.Method)
+		}
+		off, err := f.DataOffset()
+		if err != nil {
+			return err
+		}
+		e := &IndexEntry{
+			Offset: off,
+			Size:   int64(f.UncompressedSize64),
+			CSize:  int64(f.CompressedSize64),
+			Method: f.Method,
+		}
+		fh, err := f.Open() // (validates CRC-32 upon EOF)
+		if err != nil {
+			return err
+		}
+		hash.H.Reset()
+		_, err = io.Copy(hash.H, fh)
+		fh.Close()
+		if err != nil {
+			return err
+		}
+		hash.Finalize()
+		e.Cksum = hash.Value()
+		idx.add(f.Name, e)
+	}
+	return nil
+}
+
+// same as Reader.Range: the first one wins
+func (idx *Index) add(name string, e *IndexEntry) {
+	if name == "" {
+		return
+	}
+	name = idxName(name)
+	if _, ok := idx.Entries[name]; !ok {
+		idx.Entries[name] = e
+	}
+}
+
+func (idx *Index) Lookup(filename string) *IndexEntry {
+	if filename == "" {
+		return nil
+	}
+	return idx.Entries[idxName(filename)]
+}
+
+// Open returns reader of the named (and indexed) file
+func (*Index) Open(r io.ReaderAt, e *IndexEntry) cos.ReadCloseSizer {
+	if e.Method == zip.Deflate {
+		return &cslFile{file: flate.NewReader(io.NewSectionReader(r, e.Offset, e.CSize)), size: e.Size}
+	}
+	return &cslLimited{LimitedReader: io.LimitedReader{R: io.NewSectionReader(r, e.Offset, e.Size), N: e.Size}}
+}
+
+// in re `--absolute-names` (see namesEq)
+func idxName(name string) string {
+	if name[0] == '/' {
+		return name[1:]
+	}
+	return name
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(b []byte) (n int, err error) {
+	n, err = cr.r.Read(b)
+	cr.n += int64(n)
+	return
+}
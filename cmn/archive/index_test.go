@@ -0,0 +1,145 @@
+// Package archive: write, read, copy, append, list primitives
+// across all supported formats
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var archIdxFiles = map[string][]byte{
+	"a.txt":         []byte("hello"),
+	"b/c.bin":       bytes.Repeat([]byte("0123456789"), 1000),
+	"d/e/empty.cls": {},
+}
+
+func TestArchIndexTar(t *testing.T) {
+	var (
+		fqn = filepath.Join(t.TempDir(), "shard.tar")
+		buf bytes.Buffer
+		tw  = tar.NewWriter(&buf)
+	)
+	for name, b := range archIdxFiles {
+		writeTarMember(t, tw, name, b)
+	}
+	tassert.CheckFatal(t, tw.Close())
+	tassert.CheckFatal(t, os.WriteFile(fqn, buf.Bytes(), cos.PermRWR))
+
+	idx := newArchIndex(t, archive.ExtTar, fqn)
+	checkArchIndex(t, idx, fqn, archIdxFiles)
+
+	// append (see archive.OpenTarSeekEnd) and extend
+	fh, _, err := archive.OpenTarSeekEnd("shard.tar", fqn)
+	tassert.CheckFatal(t, err)
+	off, err := fh.Seek(0, io.SeekCurrent)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, off == idx.End, "expected index end %d to be the append offset %d", idx.End, off)
+
+	appended := map[string][]byte{"f/g.txt": []byte("appended"), "h.bin": bytes.Repeat([]byte{7}, 513)}
+	tw = tar.NewWriter(fh)
+	for name, b := range appended {
+		writeTarMember(t, tw, name, b)
+	}
+	tassert.CheckFatal(t, tw.Close())
+	size, err := fh.Seek(0, io.SeekCurrent)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, idx.Extend(fh, off, size))
+	fh.Close()
+
+	all := maps.Clone(archIdxFiles)
+	maps.Copy(all, appended)
+	checkArchIndex(t, idx, fqn, all)
+}
+
+func TestArchIndexZip(t *testing.T) {
+	var (
+		fqn = filepath.Join(t.TempDir(), "shard.zip")
+		buf bytes.Buffer
+		zw  = zip.NewWriter(&buf)
+		i   int
+	)
+	for name, b := range archIdxFiles {
+		method := zip.Deflate
+		if i%2 == 0 {
+			method = zip.Store
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: method})
+		tassert.CheckFatal(t, err)
+		_, err = w.Write(b)
+		tassert.CheckFatal(t, err)
+		i++
+	}
+	tassert.CheckFatal(t, zw.Close())
+	tassert.CheckFatal(t, os.WriteFile(fqn, buf.Bytes(), cos.PermRWR))
+
+	idx := newArchIndex(t, archive.ExtZip, fqn)
+	checkArchIndex(t, idx, fqn, archIdxFiles)
+
+	tassert.Errorf(t, idx.Extend(nil, 0, 0) != nil, "expected ZIP index to be non-extendable")
+}
+
+func TestArchIndexable(t *testing.T) {
+	for _, mime := range archive.FileExtensions {
+		expected := mime == archive.ExtTar || mime == archive.ExtZip
+		tassert.Errorf(t, archive.Indexable(mime) == expected, "%s: expected indexable=%t", mime, expected)
+	}
+	_, err := archive.NewIndex(archive.ExtTgz, bytes.NewReader(nil), 0)
+	tassert.Errorf(t, err != nil, "expected %s to be non-indexable", archive.ExtTgz)
+}
+
+func writeTarMember(t *testing.T, tw *tar.Writer, name string, b []byte) {
+	hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(b)), Mode: int64(cos.PermRWRR)}
+	tassert.CheckFatal(t, tw.WriteHeader(hdr))
+	_, err := tw.Write(b)
+	tassert.CheckFatal(t, err)
+}
+
+func newArchIndex(t *testing.T, mime, fqn string) *archive.Index {
+	fh, err := os.Open(fqn)
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	finfo, err := fh.Stat()
+	tassert.CheckFatal(t, err)
+	idx, err := archive.NewIndex(mime, fh, finfo.Size())
+	tassert.CheckFatal(t, err)
+	return idx
+}
+
+func checkArchIndex(t *testing.T, idx *archive.Index, fqn string, files map[string][]byte) {
+	fh, err := os.Open(fqn)
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+
+	tassert.Errorf(t, len(idx.Entries) == len(files), "expected %d index entries, got %d", len(files), len(idx.Entries))
+	for name, b := range files {
+		e := idx.Lookup(name)
+		tassert.Fatalf(t, e != nil, "%s: not indexed", name)
+		tassert.Errorf(t, idx.Lookup("/"+name) == e, "%s: expected lookup with leading slash", name)
+
+		csl := idx.Open(fh, e)
+		tassert.Errorf(t, csl.Size() == int64(len(b)), "%s: expected size %d, got %d", name, len(b), csl.Size())
+		content, err := io.ReadAll(csl)
+		csl.Close()
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bytes.Equal(content, b), "%s: content mismatch", name)
+
+		cksum := cos.NewCksumHash(archive.IndexCksumType)
+		cksum.H.Write(b)
+		cksum.Finalize()
+		tassert.Errorf(t, cksum.Value() == e.Cksum, "%s: checksum mismatch", name)
+	}
+	tassert.Errorf(t, idx.Lookup("missing") == nil, "expected missing file not to be indexed")
+}
@@ -37,24 +37,45 @@ func List(fqn string) ([]*Entry, error) {
 	}
 	mime, err := MimeFile(fh, nil /*NOTE: not reading file magic*/, "", fqn)
 	if err != nil {
+		cos.Close(fh)
 		return nil, err
 	}
+	var size int64
+	if mime == ExtZip {
+		finfo, err = os.Stat(fqn)
+		if err != nil {
+			cos.Close(fh)
+			return nil, err
+		}
+		size = finfo.Size()
+	}
+	lst, err = _ls(fh, mime, size)
+	cos.Close(fh)
+	return lst, err
+}
+
+// same as above, given reader, (logical) size, and archive name (to determine its format by extension)
+func ListReader(r cos.ReadReaderAt, archname string, size int64) ([]*Entry, error) {
+	mime, err := Mime("", archname)
+	if err != nil {
+		return nil, err
+	}
+	return _ls(r, mime, size)
+}
+
+func _ls(r cos.ReadReaderAt, mime string, size int64) (lst []*Entry, err error) {
 	switch mime {
 	case ExtTar:
-		lst, err = lsTar(fh)
+		lst, err = lsTar(r)
 	case ExtTgz, ExtTarGz:
-		lst, err = lsTgz(fh)
+		lst, err = lsTgz(r)
 	case ExtZip:
-		finfo, err = os.Stat(fqn)
-		if err == nil {
-			lst, err = lsZip(fh, finfo.Size())
-		}
+		lst, err = lsZip(r, size)
 	case ExtTarLz4:
-		lst, err = lsLz4(fh)
+		lst, err = lsLz4(r)
 	default:
 		debug.Assert(false, mime)
 	}
-	cos.Close(fh)
 	if err != nil {
 		return nil, err
 	}
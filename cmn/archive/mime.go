@@ -112,7 +112,7 @@ func byExt(filename string) (string, error) {
 }
 
 // NOTE convention: caller may pass nil `smm` _not_ to spend time (usage: listing and reading)
-func MimeFile(file *os.File, smm *memsys.MMSA, mime, archname string) (m string, err error) {
+func MimeFile(file io.ReadSeeker, smm *memsys.MMSA, mime, archname string) (m string, err error) {
 	m, err = Mime(mime, archname)
 	if err == nil || IsErrUnknownMime(err) {
 		return
@@ -164,7 +164,7 @@ func MimeFQN(smm *memsys.MMSA, mime, archname string) (m string, err error) {
 	return
 }
 
-func _detect(file *os.File, archname string, buf []byte) (m string, n int, err error) {
+func _detect(file io.Reader, archname string, buf []byte) (m string, n int, err error) {
 	n, err = file.Read(buf)
 	if err != nil {
 		return
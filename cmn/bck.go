@@ -277,6 +277,9 @@ func (b *Bck) ValidateName() (err error) {
 	if b.Name == "." {
 		return fmt.Errorf(fmtErrBckName, b.Name)
 	}
+	if b.IsSnap() {
+		return b.validateSnapName()
+	}
 	if !cos.IsAlphaPlus(b.Name) {
 		err = fmt.Errorf(fmtErrBckName, b.Name)
 	}
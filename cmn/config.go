@@ -58,6 +58,7 @@ type (
 		HostNet   LocalNetConfig `json:"host_net"`
 		FSP       FSPConf        `json:"fspaths"`
 		TestFSP   TestFSPConf    `json:"test_fspaths"`
+		CacheFSP  CacheFSPConf   `json:"cache_fspaths"`
 	}
 
 	// ais node: (local) network config
@@ -65,9 +66,10 @@ type (
 		Hostname             string `json:"hostname"`
 		HostnameIntraControl string `json:"hostname_intra_control"`
 		HostnameIntraData    string `json:"hostname_intra_data"`
-		Port                 int    `json:"port,string"`               // listening port
-		PortIntraControl     int    `json:"port_intra_control,string"` // --/-- for intra-cluster control
-		PortIntraData        int    `json:"port_intra_data,string"`    // --/-- for intra-cluster data
+		Port                 int    `json:"port,string"`                // listening port
+		PortIntraControl     int    `json:"port_intra_control,string"`  // --/-- for intra-cluster control
+		PortIntraData        int    `json:"port_intra_data,string"`     // --/-- for intra-cluster data
+		PortGRPC             int    `json:"port_grpc,string,omitempty"` // optional gRPC API (0 - disabled)
 		// omit
 		UseIntraControl bool `json:"-"`
 		UseIntraData    bool `json:"-"`
@@ -83,6 +85,20 @@ type (
 		Count    int    `json:"count"`
 		Instance int    `json:"instance"`
 	}
+
+	// cache tier: fast (e.g., NVMe) mountpaths that keep copies of hot objects while
+	// placement and metadata remain on the (capacity tier) `fspaths` (see core/lhot.go)
+	CacheFSPConf struct {
+		Paths []string `json:"paths,omitempty" list:"readonly"`
+		// do not promote objects larger than (default: 1GiB)
+		MaxObjSize cos.SizeIEC `json:"max_obj_size,omitempty"`
+		// promote upon the n-th warm GET (default: 2)
+		PromoteHits int `json:"promote_hits,omitempty"`
+		// demote least recently served copies when cache-tier used capacity (%) exceeds HighWM,
+		// and until it drops below LowWM (defaults: 90% and 75%, respectively)
+		LowWM  int64 `json:"lowwm,omitempty"`
+		HighWM int64 `json:"highwm,omitempty"`
+	}
 )
 
 // global configuration
@@ -119,6 +135,9 @@ type (
 		// metadata write policy: (immediate | delayed | never)
 		WritePolicy WritePolicyConf `json:"write_policy"`
 
+		// target read-ahead of sequentially accessed ranges and objects
+		ReadAhead ReadAheadConf `json:"read_ahead"`
+
 		// standalone enumerated features that can be configured
 		// to flip assorted global defaults (see cmn/feat/feat.go)
 		Features feat.Flags `json:"features,string" allow:"cluster"`
@@ -154,6 +173,7 @@ type (
 		Memsys      *MemsysConfToSet      `json:"memsys,omitempty"`
 		TCB         *TCBConfToSet         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
+		ReadAhead   *ReadAheadConfToSet   `json:"read_ahead,omitempty"`
 		Proxy       *ProxyConfToSet       `json:"proxy,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 
@@ -330,12 +350,20 @@ type (
 		// CapacityUpdTimeStr denotes the frequency at which AIStore updates local capacity utilization
 		CapacityUpdTime cos.Duration `json:"capacity_upd_time"`
 
+		// Policy: eviction order, one of: "lru" (default), "lfu", "size" (see space/lru.go)
+		Policy string `json:"policy"`
+
+		// Priority: buckets with higher priority are evicted last
+		Priority int `json:"priority"`
+
 		// Enabled: LRU will only run when set to true
 		Enabled bool `json:"enabled"`
 	}
 	LRUConfToSet struct {
 		DontEvictTime   *cos.Duration `json:"dont_evict_time,omitempty"`
 		CapacityUpdTime *cos.Duration `json:"capacity_upd_time,omitempty"`
+		Policy          *string       `json:"policy,omitempty"`
+		Priority        *int          `json:"priority,omitempty"`
 		Enabled         *bool         `json:"enabled,omitempty"`
 	}
 
@@ -474,14 +502,22 @@ type (
 	}
 
 	FSHCConf struct {
-		TestFileCount int  `json:"test_files"`  // number of files to read/write
-		ErrorLimit    int  `json:"error_limit"` // exceeding err limit causes disabling mountpath
-		Enabled       bool `json:"enabled"`
+		TestFileCount int `json:"test_files"`  // number of files to read/write
+		ErrorLimit    int `json:"error_limit"` // exceeding err limit causes disabling mountpath
+		// mountpath gets degraded when its disk(s) keep running slower than the peers, namely:
+		// when the disk's average IO latency (await) exceeds `outlier_factor` times the median await
+		// of all other mountpaths for `outlier_samples` consecutive samples (zero - use defaults);
+		// conversely, degraded mountpath recovers after the same number of non-outlier samples
+		OutlierFactor  int  `json:"outlier_factor,omitempty"`
+		OutlierSamples int  `json:"outlier_samples,omitempty"`
+		Enabled        bool `json:"enabled"`
 	}
 	FSHCConfToSet struct {
-		TestFileCount *int  `json:"test_files,omitempty"`
-		ErrorLimit    *int  `json:"error_limit,omitempty"`
-		Enabled       *bool `json:"enabled,omitempty"`
+		TestFileCount  *int  `json:"test_files,omitempty"`
+		ErrorLimit     *int  `json:"error_limit,omitempty"`
+		OutlierFactor  *int  `json:"outlier_factor,omitempty"`
+		OutlierSamples *int  `json:"outlier_samples,omitempty"`
+		Enabled        *bool `json:"enabled,omitempty"`
 	}
 
 	AuthConf struct {
@@ -600,9 +636,27 @@ type (
 		MD   apc.WritePolicy `json:"md"`
 	}
 	WritePolicyConfToSet struct {
-		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
+		Data *apc.WritePolicy `json:"data,omitempty"`
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
+
+	// read-ahead: upon detecting sequential access - consecutive ranges of the same object or
+	// objects in name order - targets read the next ranges (objects) into memory (see core/lra.go)
+	ReadAheadConf struct {
+		// total size of read-ahead buffers (default: 1GiB)
+		MaxMem cos.SizeIEC `json:"max_mem,omitempty"`
+		// do not read ahead ranges (objects) larger than (default: 16MiB)
+		MaxSize cos.SizeIEC `json:"max_size,omitempty"`
+		// number of ranges (objects) to read ahead (default: 4)
+		Depth   int  `json:"depth,omitempty"`
+		Enabled bool `json:"enabled"`
+	}
+	ReadAheadConfToSet struct {
+		MaxMem  *cos.SizeIEC `json:"max_mem,omitempty"`
+		MaxSize *cos.SizeIEC `json:"max_size,omitempty"`
+		Depth   *int         `json:"depth,omitempty"`
+		Enabled *bool        `json:"enabled,omitempty"`
+	}
 )
 
 // assorted named fields that require (cluster | node) restart for changes to make an effect
@@ -617,6 +671,15 @@ const (
 
 var SupportedReactions = []string{IgnoreReaction, WarnReaction, AbortReaction}
 
+// LRU eviction policies (see LRUConf.Policy)
+const (
+	EvictLRU  = "lru"  // least recently used first (default)
+	EvictLFU  = "lfu"  // least frequently used first (access counter in object metadata)
+	EvictSize = "size" // large and cold first (size weighted by idle time)
+)
+
+var SupportedEvictPolicies = []string{EvictLRU, EvictLFU, EvictSize}
+
 //
 // config meta-versioning & serialization
 //
@@ -661,9 +724,12 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*FSHCConf)(nil)
+	_ Validator = (*ReadAheadConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
+	_ PropsValidator = (*LRUConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
@@ -698,6 +764,9 @@ func (c *Config) Validate() error {
 	if err := c.LocalConfig.TestFSP.Validate(c); err != nil {
 		return err
 	}
+	if err := c.LocalConfig.CacheFSP.Validate(c); err != nil {
+		return err
+	}
 
 	opts := IterOpts{VisitAll: true}
 	return IterFields(c, vdate, opts)
@@ -1001,6 +1070,40 @@ func (c *DiskConf) Validate() (err error) {
 	return nil
 }
 
+//////////////
+// FSHCConf //
+//////////////
+
+const (
+	DefaultOutlierFactor  = 4
+	DefaultOutlierSamples = 3
+	maxOutlierSamples     = 100
+)
+
+func (c *FSHCConf) Validate() error {
+	if c.OutlierFactor < 0 || c.OutlierFactor == 1 {
+		return fmt.Errorf("invalid fshc.outlier_factor %d (expecting 0 (default) or greater than 1)", c.OutlierFactor)
+	}
+	if c.OutlierSamples < 0 || c.OutlierSamples > maxOutlierSamples {
+		return fmt.Errorf("invalid fshc.outlier_samples %d (expecting range [0, %d])", c.OutlierSamples, maxOutlierSamples)
+	}
+	return nil
+}
+
+func (c *FSHCConf) Factor() int64 {
+	if c.OutlierFactor == 0 {
+		return DefaultOutlierFactor
+	}
+	return int64(c.OutlierFactor)
+}
+
+func (c *FSHCConf) Samples() int {
+	if c.OutlierSamples == 0 {
+		return DefaultOutlierSamples
+	}
+	return c.OutlierSamples
+}
+
 ///////////////
 // SpaceConf //
 ///////////////
@@ -1027,14 +1130,36 @@ func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("lru.dont_evict_time=%v, lru.capacity_upd_time=%v", c.DontEvictTime, c.CapacityUpdTime)
+	s := fmt.Sprintf("lru.dont_evict_time=%v, lru.capacity_upd_time=%v", c.DontEvictTime, c.CapacityUpdTime)
+	if c.Policy != "" && c.Policy != EvictLRU {
+		s += ", lru.policy=" + c.Policy
+	}
+	if c.Priority != 0 {
+		s += ", lru.priority=" + strconv.Itoa(c.Priority)
+	}
+	return s
 }
 
 func (c *LRUConf) Validate() (err error) {
 	if c.CapacityUpdTime.D() < 10*time.Second {
-		err = fmt.Errorf("invalid %s (expecting: lru.capacity_upd_time >= 10s)", c)
+		return fmt.Errorf("invalid %s (expecting: lru.capacity_upd_time >= 10s)", c)
 	}
-	return
+	return c.ValidateAsProps()
+}
+
+func (c *LRUConf) ValidateAsProps(...any) error {
+	if c.Policy != "" && !cos.StringInSlice(c.Policy, SupportedEvictPolicies) {
+		return fmt.Errorf("invalid lru.policy %q (expecting one of: %v)", c.Policy, SupportedEvictPolicies)
+	}
+	return nil
+}
+
+// EvictPolicy returns the configured policy (default: "lru")
+func (c *LRUConf) EvictPolicy() string {
+	if c.Policy == "" {
+		return EvictLRU
+	}
+	return c.Policy
 }
 
 ///////////////
@@ -1213,8 +1338,8 @@ func (c *ECConf) RequiredRestoreTargets() int {
 func (c *WritePolicyConf) Validate() (err error) {
 	err = c.Data.Validate()
 	if err == nil {
-		if !c.Data.IsImmediate() {
-			return fmt.Errorf("invalid write policy for data: %q not implemented yet", c.Data)
+		if c.Data == apc.WriteNever {
+			return fmt.Errorf("invalid write policy for data: %q not supported", c.Data)
 		}
 		err = c.MD.Validate()
 	}
@@ -1321,6 +1446,14 @@ func (c *LocalNetConfig) Validate(contextConfig *Config) (err error) {
 			return fmt.Errorf("invalid %s port specified: %v", NetIntraData, err)
 		}
 	}
+	if c.PortGRPC != 0 {
+		if _, err := ValidatePort(c.PortGRPC); err != nil {
+			return fmt.Errorf("invalid gRPC port specified: %v", err)
+		}
+		if c.PortGRPC == c.Port || c.PortGRPC == c.PortIntraControl || c.PortGRPC == c.PortIntraData {
+			return fmt.Errorf("gRPC port %d must differ from (public, intra-control, intra-data) ports", c.PortGRPC)
+		}
+	}
 
 	// NOTE: intra-cluster networks
 	differentIPs := c.Hostname != c.HostnameIntraControl
@@ -1452,6 +1585,94 @@ func IsNestedMpath(a string, la int, b string) (err error) {
 	return
 }
 
+//////////////////
+// CacheFSPConf //
+//////////////////
+
+const (
+	DefaultCacheObjSize  = cos.GiB
+	DefaultCachePromote  = 2
+	DefaultCacheLowWM    = 75
+	DefaultCacheHighWM   = 90
+	maxCachePromoteHits  = 1000
+	minCacheWatermarkGap = 5
+)
+
+func (c *CacheFSPConf) Validate(contextConfig *Config) error {
+	if len(c.Paths) == 0 {
+		return nil
+	}
+	if c.MaxObjSize < 0 || c.PromoteHits < 0 || c.PromoteHits > maxCachePromoteHits {
+		return fmt.Errorf("invalid cache_fspaths: max_obj_size (%d) must be non-negative, promote_hits (%d) in range [0, %d]",
+			c.MaxObjSize, c.PromoteHits, maxCachePromoteHits)
+	}
+	if c.LowWM < 0 || c.HighWM < 0 || c.HighWM > 100 {
+		return fmt.Errorf("invalid cache_fspaths watermarks: lowwm=%d, highwm=%d", c.LowWM, c.HighWM)
+	}
+	if low, high := c.LowWatermark(), c.HighWatermark(); low > high-minCacheWatermarkGap {
+		return fmt.Errorf("invalid cache_fspaths watermarks: expecting lowwm (%d) <= highwm (%d) - %d",
+			low, high, minCacheWatermarkGap)
+	}
+	if contextConfig.TestingEnv() || contextConfig.role != apc.Target {
+		return nil
+	}
+	clean := make([]string, 0, len(c.Paths))
+	for _, fspath := range c.Paths {
+		mpath, err := ValidateMpath(fspath)
+		if err != nil {
+			return err
+		}
+		l := len(mpath)
+		for _, mpath2 := range clean {
+			if mpath2 == mpath {
+				return NewErrInvalidFSPathsConf(fmt.Errorf("cache-tier %q (%q) is duplicated", mpath, fspath))
+			}
+			if err := IsNestedMpath(mpath, l, mpath2); err != nil {
+				return NewErrInvalidFSPathsConf(err)
+			}
+		}
+		for mpath2 := range contextConfig.FSP.Paths {
+			if mpath2 == mpath {
+				return NewErrInvalidFSPathsConf(fmt.Errorf("%q cannot be both cache-tier and capacity-tier mountpath", mpath))
+			}
+			if err := IsNestedMpath(mpath, l, mpath2); err != nil {
+				return NewErrInvalidFSPathsConf(err)
+			}
+		}
+		clean = append(clean, mpath)
+	}
+	c.Paths = clean
+	return nil
+}
+
+func (c *CacheFSPConf) ObjSize() int64 {
+	if c.MaxObjSize == 0 {
+		return DefaultCacheObjSize
+	}
+	return int64(c.MaxObjSize)
+}
+
+func (c *CacheFSPConf) Hits() int {
+	if c.PromoteHits == 0 {
+		return DefaultCachePromote
+	}
+	return c.PromoteHits
+}
+
+func (c *CacheFSPConf) LowWatermark() int64 {
+	if c.LowWM == 0 {
+		return DefaultCacheLowWM
+	}
+	return c.LowWM
+}
+
+func (c *CacheFSPConf) HighWatermark() int64 {
+	if c.HighWM == 0 {
+		return DefaultCacheHighWM
+	}
+	return c.HighWM
+}
+
 /////////////////
 // TestFSPConf //
 /////////////////
@@ -1567,6 +1788,52 @@ func (c *TransportConf) Validate() (err error) {
 	return nil
 }
 
+///////////////////
+// ReadAheadConf //
+///////////////////
+
+const (
+	DefaultReadAheadMem   = cos.GiB
+	DefaultReadAheadSize  = 16 * cos.MiB
+	DefaultReadAheadDepth = 4
+	maxReadAheadDepth     = 64
+)
+
+func (c *ReadAheadConf) Validate() error {
+	if c.MaxMem < 0 || c.MaxSize < 0 {
+		return fmt.Errorf("invalid read_ahead: max_mem (%d) and max_size (%d) must be non-negative", c.MaxMem, c.MaxSize)
+	}
+	if c.Depth < 0 || c.Depth > maxReadAheadDepth {
+		return fmt.Errorf("invalid read_ahead.depth: %d (expected range [0, %d])", c.Depth, maxReadAheadDepth)
+	}
+	if c.Size() > c.Mem() {
+		return fmt.Errorf("invalid read_ahead: max_size (%s) cannot exceed max_mem (%s)",
+			cos.ToSizeIEC(c.Size(), 0), cos.ToSizeIEC(c.Mem(), 0))
+	}
+	return nil
+}
+
+func (c *ReadAheadConf) Mem() int64 {
+	if c.MaxMem == 0 {
+		return DefaultReadAheadMem
+	}
+	return int64(c.MaxMem)
+}
+
+func (c *ReadAheadConf) Size() int64 {
+	if c.MaxSize == 0 {
+		return DefaultReadAheadSize
+	}
+	return int64(c.MaxSize)
+}
+
+func (c *ReadAheadConf) NumAhead() int {
+	if c.Depth == 0 {
+		return DefaultReadAheadDepth
+	}
+	return c.Depth
+}
+
 /////////////
 // TCBConf //
 /////////////
@@ -0,0 +1,141 @@
+// Package crypt implements block-based authenticated encryption (AES-GCM) of the content at rest
+// that provides for random access (range reads), and master key providers to wrap data keys.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// Format:
+//
+//	| header | block 0 | block 1 | ... | block N-1 |
+//
+// - header (fixed size): magic, version, data key ID, block size, and salt
+// - each file (object) is encrypted with its own subkey that is derived from the data key
+//   (HKDF-SHA256) using the salt (random, per file) - data keys are shared by all objects
+//   in a bucket, and random nonces under a shared key would eventually collide
+// - the original content is split into fixed-size blocks, and each block is sealed
+//   (encrypted and authenticated) independently; the last block may be shorter
+//   (and is empty when the content is)
+// - block nonce: the block index (a counter - unique under the subkey)
+// - additional authenticated data: header, and whether the block is the last one
+//   (which is how truncation at block boundaries gets detected)
+
+const (
+	BlockSize  = 64 * cos.KiB
+	HeaderSize = 48
+	KeySize    = 32 // AES-256
+
+	saltSize = 32
+	tagSize  = 16
+)
+
+const version = 1
+
+var magic = [4]byte{'a', 'i', 's', 'e'}
+
+var hkdfInfo = []byte("aistore crypt subkey")
+
+var ErrBadFormat = errors.New("crypt: invalid format or authentication failed")
+
+type header struct {
+	keyID     uint32
+	blockSize uint32
+	salt      [saltSize]byte
+}
+
+// physical (encrypted) size given the original one
+func PhysSize(size int64) int64 {
+	if size == 0 {
+		return HeaderSize + tagSize
+	}
+	nfull := (size - 1) / BlockSize
+	return HeaderSize + nfull*(BlockSize+tagSize) + (size - nfull*BlockSize) + tagSize
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("crypt: invalid key size %d (expecting %d)", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// given data key and salt, derive the (per-file) subkey and return the respective AEAD
+func subkeyAEAD(key, salt []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("crypt: invalid key size %d (expecting %d)", len(key), KeySize)
+	}
+	subkey := make([]byte, KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, salt, hkdfInfo), subkey); err != nil {
+		return nil, err
+	}
+	return newAEAD(subkey)
+}
+
+////////////
+// header //
+////////////
+
+func (h *header) pack(b []byte) {
+	copy(b, magic[:])
+	b[4] = version
+	b[5], b[6], b[7] = 0, 0, 0
+	binary.LittleEndian.PutUint32(b[8:], h.keyID)
+	binary.LittleEndian.PutUint32(b[12:], h.blockSize)
+	copy(b[16:], h.salt[:])
+}
+
+func (h *header) unpack(b []byte) error {
+	if [4]byte(b[:4]) != magic || b[4] != version {
+		return ErrBadFormat
+	}
+	h.keyID = binary.LittleEndian.Uint32(b[8:])
+	h.blockSize = binary.LittleEndian.Uint32(b[12:])
+	copy(h.salt[:], b[16:HeaderSize])
+	if h.blockSize == 0 || h.blockSize > 64*cos.MiB {
+		return ErrBadFormat
+	}
+	return nil
+}
+
+// block nonce and additional data
+func (h *header) seal(nonce, ad []byte, bidx int64, last bool) {
+	clear(nonce[:len(nonce)-8])
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], uint64(bidx))
+	if last {
+		ad[HeaderSize] = 1
+	} else {
+		ad[HeaderSize] = 0
+	}
+}
+
+// given physical size: the number of blocks and the original size
+func (h *header) sizes(physSize int64) (nblocks, size int64, err error) {
+	p := physSize - HeaderSize - tagSize
+	if p < 0 {
+		return 0, 0, ErrBadFormat
+	}
+	bsize := int64(h.blockSize)
+	nfull, rem := p/(bsize+tagSize), p%(bsize+tagSize)
+	if rem > bsize || (rem == 0 && nfull > 0) {
+		return 0, 0, ErrBadFormat
+	}
+	return nfull + 1, nfull*bsize + rem, nil
+}
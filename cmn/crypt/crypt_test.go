@@ -0,0 +1,192 @@
+// Package crypt implements block-based authenticated encryption (AES-GCM) of the content at rest
+// that provides for random access (range reads), and master key providers to wrap data keys.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package crypt_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const keyID = 3
+
+func genKey(t *testing.T) []byte {
+	key := make([]byte, crypt.KeySize)
+	_, err := rand.Read(key)
+	tassert.CheckFatal(t, err)
+	return key
+}
+
+func keyFunc(key []byte) crypt.KeyFunc {
+	return func(id uint32) ([]byte, error) {
+		if id != keyID {
+			return nil, fmt.Errorf("unknown key %d", id)
+		}
+		return key, nil
+	}
+}
+
+func encrypt(t *testing.T, content, key []byte) []byte {
+	var (
+		out    bytes.Buffer
+		ew, er = crypt.NewWriter(&out, keyID, key)
+	)
+	tassert.CheckFatal(t, er)
+	// write in odd-size chunks
+	for off := 0; off < len(content); off += 1000 {
+		_, err := ew.Write(content[off:min(off+1000, len(content))])
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, ew.Close())
+	tassert.Fatalf(t, ew.Size() == int64(len(content)), "size %d != %d", ew.Size(), len(content))
+	tassert.Fatalf(t, crypt.PhysSize(ew.Size()) == int64(out.Len()), "phys size %d != %d",
+		crypt.PhysSize(ew.Size()), out.Len())
+	return out.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	key := genKey(t)
+	for _, size := range []int{0, 1, crypt.BlockSize - 1, crypt.BlockSize, crypt.BlockSize + 1, 3 * crypt.BlockSize, cos.MiB + 7} {
+		content := make([]byte, size)
+		_, _ = rand.Read(content)
+		e := encrypt(t, content, key)
+		id, err := crypt.KeyID(bytes.NewReader(e))
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, id == keyID, "key ID %d != %d", id, keyID)
+
+		er, err := crypt.NewReader(bytes.NewReader(e), int64(len(e)), keyFunc(key))
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, er.Size() == int64(size), "size %d != %d", er.Size(), size)
+		b, err := io.ReadAll(er)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b, content), "content mismatch (size %d)", size)
+	}
+}
+
+func TestRangeRead(t *testing.T) {
+	const size = 300*cos.KiB + 123
+	var (
+		key     = genKey(t)
+		content = make([]byte, size)
+	)
+	_, _ = rand.Read(content)
+	e := encrypt(t, content, key)
+	er, err := crypt.NewReader(bytes.NewReader(e), int64(len(e)), keyFunc(key))
+	tassert.CheckFatal(t, err)
+	for range 100 {
+		var (
+			off = mrand.Int63n(size)
+			l   = mrand.Int63n(size - off + 1)
+			b   = make([]byte, l)
+		)
+		n, err := io.ReadFull(io.NewSectionReader(er, off, l), b)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b[:n], content[off:off+l]), "range [%d, %d) mismatch", off, off+l)
+	}
+	_, err = er.Seek(-10, io.SeekEnd)
+	tassert.CheckFatal(t, err)
+	b, err := io.ReadAll(er)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(b, content[size-10:]), "seek-end mismatch")
+}
+
+func TestTampered(t *testing.T) {
+	var (
+		key     = genKey(t)
+		content = make([]byte, 3*crypt.BlockSize)
+	)
+	_, _ = rand.Read(content)
+	e := encrypt(t, content, key)
+
+	read := func(b, key []byte) error {
+		er, err := crypt.NewReader(bytes.NewReader(b), int64(len(b)), keyFunc(key))
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadAll(er)
+		return err
+	}
+	// wrong key
+	if err := read(e, genKey(t)); !errors.Is(err, crypt.ErrBadFormat) {
+		t.Fatalf("wrong key: expected ErrBadFormat, got %v", err)
+	}
+	// truncated at the block boundary
+	blk := crypt.BlockSize + int(crypt.PhysSize(1)-crypt.HeaderSize-1) // (sealed block)
+	if err := read(e[:crypt.HeaderSize+2*blk], key); !errors.Is(err, crypt.ErrBadFormat) {
+		t.Fatalf("truncated: expected ErrBadFormat, got %v", err)
+	}
+	// modified block, modified header (block size, salt)
+	for _, off := range []int{crypt.HeaderSize + blk + 10, 12, crypt.HeaderSize - 1} {
+		b := bytes.Clone(e)
+		b[off] ^= 0xff
+		if err := read(b, key); err == nil {
+			t.Fatalf("modified byte at %d: expected error", off)
+		}
+	}
+}
+
+// same content and data key: different subkeys (salts) and, therefore, ciphertexts;
+// blocks cannot be moved between objects
+func TestSubkey(t *testing.T) {
+	var (
+		key     = genKey(t)
+		content = make([]byte, 2*crypt.BlockSize)
+	)
+	_, _ = rand.Read(content)
+	e1, e2 := encrypt(t, content, key), encrypt(t, content, key)
+	tassert.Fatalf(t, !bytes.Equal(e1[:crypt.HeaderSize], e2[:crypt.HeaderSize]), "expected different salts")
+	tassert.Fatalf(t, !bytes.Equal(e1[crypt.HeaderSize:], e2[crypt.HeaderSize:]), "expected different ciphertexts")
+
+	b := bytes.Clone(e1)
+	copy(b[crypt.HeaderSize:], e2[crypt.HeaderSize:crypt.HeaderSize+crypt.BlockSize])
+	er, err := crypt.NewReader(bytes.NewReader(b), int64(len(b)), keyFunc(key))
+	tassert.CheckFatal(t, err)
+	if _, err := io.ReadAll(er); !errors.Is(err, crypt.ErrBadFormat) {
+		t.Fatalf("block from another object: expected ErrBadFormat, got %v", err)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		fqn = filepath.Join(dir, "master.key")
+	)
+	tassert.CheckFatal(t, os.WriteFile(fqn, []byte(hex.EncodeToString(genKey(t))+"\n"), 0o600))
+	p, err := crypt.NewFileProvider(fqn)
+	tassert.CheckFatal(t, err)
+
+	dek := genKey(t)
+	wrapped, mkid, err := p.Wrap(dek)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, !bytes.Contains(wrapped, dek), "data key is not wrapped")
+	unwrapped, err := p.Unwrap(wrapped, mkid)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(unwrapped, dek), "unwrapped data key mismatch")
+
+	// different master key
+	tassert.CheckFatal(t, os.WriteFile(fqn, genKey(t), 0o600))
+	p2, err := crypt.NewFileProvider(fqn)
+	tassert.CheckFatal(t, err)
+	if _, err := p2.Unwrap(wrapped, mkid); err == nil {
+		t.Fatal("expected error unwrapping with a different master key")
+	}
+	// invalid
+	tassert.CheckFatal(t, os.WriteFile(fqn, []byte("short"), 0o600))
+	if _, err := crypt.NewFileProvider(fqn); err == nil {
+		t.Fatal("expected invalid master key error")
+	}
+}
@@ -0,0 +1,145 @@
+// Package crypt implements block-based authenticated encryption (AES-GCM) of the content at rest
+// that provides for random access (range reads), and master key providers to wrap data keys.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package crypt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Envelope encryption:
+// - content is encrypted with (per-bucket) data keys
+// - data keys are stored wrapped (encrypted) by the master key that never leaves
+//   the key provider: a local key file (below) or, e.g., external KMS
+
+type (
+	KeyProvider interface {
+		Name() string
+		// wrap data key with the current master key; return the latter's ID
+		Wrap(dek []byte) (wrapped []byte, mkid string, err error)
+		Unwrap(wrapped []byte, mkid string) (dek []byte, err error)
+	}
+
+	// master key from a local file (env.AIS.MasterKeyFile)
+	fileProvider struct {
+		fqn  string
+		mkid string
+		key  []byte
+	}
+)
+
+// interface guard
+var _ KeyProvider = (*fileProvider)(nil)
+
+var provider KeyProvider
+
+var ErrNoProvider = errors.New("encryption at rest requires master key provider (see " + env.AIS.MasterKeyFile + ")")
+
+// load master key (if configured)
+func Init() error {
+	fqn := os.Getenv(env.AIS.MasterKeyFile)
+	if fqn == "" {
+		return nil
+	}
+	p, err := NewFileProvider(fqn)
+	if err != nil {
+		return err
+	}
+	SetProvider(p)
+	nlog.Infoln("master key:", p.Name())
+	return nil
+}
+
+func SetProvider(p KeyProvider) { provider = p }
+
+// generate new data key and wrap it with the master key
+func NewDataKey() (wrapped []byte, mkid string, err error) {
+	if provider == nil {
+		return nil, "", ErrNoProvider
+	}
+	dek := make([]byte, KeySize)
+	if _, err = rand.Read(dek); err != nil {
+		return nil, "", err
+	}
+	return provider.Wrap(dek)
+}
+
+func UnwrapDataKey(wrapped []byte, mkid string) ([]byte, error) {
+	if provider == nil {
+		return nil, ErrNoProvider
+	}
+	dek, err := provider.Unwrap(wrapped, mkid)
+	if err == nil && len(dek) != KeySize {
+		err = fmt.Errorf("crypt: invalid data key size %d", len(dek))
+	}
+	return dek, err
+}
+
+//////////////////
+// fileProvider //
+//////////////////
+
+// the file contains 32-byte master key: raw, hex, or base64-encoded
+func NewFileProvider(fqn string) (KeyProvider, error) {
+	b, err := os.ReadFile(fqn)
+	if err != nil {
+		return nil, err
+	}
+	key := b
+	if len(key) != KeySize {
+		s := strings.TrimSpace(string(b))
+		if key, err = hex.DecodeString(s); err != nil {
+			key, err = base64.StdEncoding.DecodeString(s)
+		}
+		if err != nil || len(key) != KeySize {
+			return nil, fmt.Errorf("invalid master key in %q (expecting %d bytes: raw, hex, or base64)", fqn, KeySize)
+		}
+	}
+	sum := sha256.Sum256(key)
+	return &fileProvider{fqn: fqn, key: key, mkid: hex.EncodeToString(sum[:8])}, nil
+}
+
+func (p *fileProvider) Name() string { return "file:" + p.fqn + "[" + p.mkid + "]" }
+
+func (p *fileProvider) Wrap(dek []byte) ([]byte, string, error) {
+	aead, err := newAEAD(p.key)
+	if err != nil {
+		return nil, "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(dek)+tagSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+	return aead.Seal(nonce, nonce, dek, []byte(p.mkid)), p.mkid, nil
+}
+
+func (p *fileProvider) Unwrap(wrapped []byte, mkid string) ([]byte, error) {
+	if mkid != p.mkid {
+		return nil, fmt.Errorf("crypt: data key wrapped by a different master key (%s, have %s)", mkid, p.mkid)
+	}
+	aead, err := newAEAD(p.key)
+	if err != nil {
+		return nil, err
+	}
+	ns := aead.NonceSize()
+	if len(wrapped) < ns+tagSize {
+		return nil, ErrBadFormat
+	}
+	dek, err := aead.Open(nil, wrapped[:ns], wrapped[ns:], []byte(p.mkid))
+	if err != nil {
+		return nil, fmt.Errorf("crypt: failed to unwrap data key: %w", err)
+	}
+	return dek, nil
+}
@@ -0,0 +1,172 @@
+// Package crypt implements block-based authenticated encryption (AES-GCM) of the content at rest
+// that provides for random access (range reads), and master key providers to wrap data keys.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package crypt
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// given data key ID, return the key
+type KeyFunc func(keyID uint32) ([]byte, error)
+
+// Reader provides for reading (io.Reader, io.Seeker) and random access (io.ReaderAt)
+// to the original content; the last decrypted block is cached, which makes
+// sequential reads efficient
+type Reader struct {
+	r       io.ReaderAt
+	aead    cipher.AEAD
+	blk     []byte // cached block (decrypted)
+	sbuf    []byte // sealed
+	nonce   []byte
+	ad      []byte
+	h       header
+	nblocks int64
+	size    int64 // original
+	bidx    int64 // cached block index
+	off     int64 // Read and Seek
+	mu      sync.Mutex
+}
+
+// interface guard
+var (
+	_ io.ReadSeeker = (*Reader)(nil)
+	_ io.ReaderAt   = (*Reader)(nil)
+)
+
+// read the header and return the ID of the data key that was used to encrypt
+func KeyID(r io.ReaderAt) (uint32, error) {
+	var (
+		h header
+		b = make([]byte, HeaderSize)
+	)
+	if _, err := r.ReadAt(b, 0); err != nil {
+		return 0, _eof(err)
+	}
+	err := h.unpack(b)
+	return h.keyID, err
+}
+
+// given physical (encrypted) size, read and validate the header
+func NewReader(r io.ReaderAt, physSize int64, key KeyFunc) (*Reader, error) {
+	er := &Reader{r: r, bidx: -1, ad: make([]byte, HeaderSize+1)}
+	if physSize < HeaderSize {
+		return nil, ErrBadFormat
+	}
+	if _, err := r.ReadAt(er.ad[:HeaderSize], 0); err != nil {
+		return nil, _eof(err)
+	}
+	if err := er.h.unpack(er.ad); err != nil {
+		return nil, err
+	}
+	nblocks, size, err := er.h.sizes(physSize)
+	if err != nil {
+		return nil, err
+	}
+	k, err := key(er.h.keyID)
+	if err != nil {
+		return nil, err
+	}
+	if er.aead, err = subkeyAEAD(k, er.h.salt[:]); err != nil {
+		return nil, err
+	}
+	er.nblocks, er.size = nblocks, size
+	er.nonce = make([]byte, er.aead.NonceSize())
+	return er, nil
+}
+
+// original (decrypted) size
+func (er *Reader) Size() int64 { return er.size }
+
+func (er *Reader) Read(p []byte) (int, error) {
+	n, err := er.ReadAt(p, er.off)
+	er.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (er *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += er.off
+	case io.SeekEnd:
+		offset += er.size
+	default:
+		return 0, errors.New("crypt: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("crypt: negative position")
+	}
+	er.off = offset
+	return offset, nil
+}
+
+func (er *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("crypt: negative offset")
+	}
+	if off >= er.size {
+		return 0, io.EOF
+	}
+	er.mu.Lock()
+	for n < len(p) && off < er.size {
+		bsize := int64(er.h.blockSize)
+		if err = er.load(off / bsize); err != nil {
+			break
+		}
+		l := copy(p[n:], er.blk[off%bsize:])
+		n += l
+		off += int64(l)
+	}
+	er.mu.Unlock()
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// read, authenticate, and decrypt (ie., cache) a given block
+func (er *Reader) load(bidx int64) error {
+	if bidx == er.bidx {
+		return nil
+	}
+	var (
+		bsize = int64(er.h.blockSize)
+		start = HeaderSize + bidx*(bsize+tagSize)
+		size  = min(bsize, er.size-bidx*bsize) // (the last one may be shorter)
+	)
+	if er.sbuf == nil {
+		er.sbuf = make([]byte, bsize+tagSize)
+		er.blk = make([]byte, 0, bsize)
+	}
+	er.bidx = -1
+	sbuf := er.sbuf[:size+tagSize]
+	if _, err := er.r.ReadAt(sbuf, start); err != nil {
+		return _eof(err)
+	}
+	er.h.seal(er.nonce, er.ad, bidx, bidx == er.nblocks-1)
+	blk, err := er.aead.Open(er.blk[:0], er.nonce, sbuf, er.ad)
+	if err != nil {
+		return fmt.Errorf("%w: block %d", ErrBadFormat, bidx)
+	}
+	er.blk = blk
+	er.bidx = bidx
+	return nil
+}
+
+// (truncated)
+func _eof(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+// Package crypt implements block-based authenticated encryption (AES-GCM) of the content at rest
+// that provides for random access (range reads), and master key providers to wrap data keys.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package crypt
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+)
+
+// Writer encrypts and writes the content to the underlying writer;
+// Close must be called to seal and write out the last block
+// (the underlying writer is not closed)
+type Writer struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	blk   []byte // current block (plaintext)
+	sbuf  []byte // sealed
+	nonce []byte
+	ad    []byte // header + last-block flag
+	h     header
+	bidx  int64
+	size  int64 // original size
+}
+
+// interface guard
+var _ io.WriteCloser = (*Writer)(nil)
+
+func NewWriter(w io.Writer, keyID uint32, key []byte) (*Writer, error) {
+	ew := &Writer{
+		w:    w,
+		blk:  make([]byte, 0, BlockSize),
+		sbuf: make([]byte, 0, BlockSize+tagSize),
+		ad:   make([]byte, HeaderSize+1),
+		h:    header{keyID: keyID, blockSize: BlockSize},
+	}
+	if _, err := rand.Read(ew.h.salt[:]); err != nil {
+		return nil, err
+	}
+	aead, err := subkeyAEAD(key, ew.h.salt[:])
+	if err != nil {
+		return nil, err
+	}
+	ew.aead = aead
+	ew.nonce = make([]byte, aead.NonceSize())
+	ew.h.pack(ew.ad)
+	if _, err := w.Write(ew.ad[:HeaderSize]); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// (the last block is sealed upon Close - see the format)
+func (ew *Writer) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		if len(ew.blk) == cap(ew.blk) {
+			if err = ew.flush(false); err != nil {
+				return n, err
+			}
+		}
+		l := min(cap(ew.blk)-len(ew.blk), len(p))
+		ew.blk = append(ew.blk, p[:l]...)
+		n += l
+		p = p[l:]
+	}
+	return n, nil
+}
+
+func (ew *Writer) flush(last bool) error {
+	ew.h.seal(ew.nonce, ew.ad, ew.bidx, last)
+	sealed := ew.aead.Seal(ew.sbuf[:0], ew.nonce, ew.blk, ew.ad)
+	if _, err := ew.w.Write(sealed); err != nil {
+		return err
+	}
+	ew.size += int64(len(ew.blk))
+	ew.blk = ew.blk[:0]
+	ew.bidx++
+	return nil
+}
+
+func (ew *Writer) Close() error { return ew.flush(true) }
+
+// original size
+func (ew *Writer) Size() int64 { return ew.size }
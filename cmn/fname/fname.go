@@ -49,4 +49,13 @@ const (
 	RebalanceMarker     = "rebalance"
 	NodeRestartedMarker = "node_restarted"
 	NodeRestartedPrev   = "node_restarted.prev"
+
+	// write-back journal: per mountpath (see core/lwback.go)
+	WbackJournal = ".ais.wback"
+
+	// replication change log: per mountpath (see core/lrepl.go)
+	ReplJournal = ".ais.repl"
+
+	// object event notifications (webhooks) queue: per mountpath (see ext/webhook)
+	WebhookSpool = ".ais.webhook"
 )
@@ -37,6 +37,32 @@ const (
 
 	// additional backend
 	LastModified = "LastModified"
+
+	// metadata-only stub of a tiered object; the value is the (remote) bucket
+	// that contains the object's content (see TierConf)
+	TierObjMD = "tier"
+
+	// object written locally but not yet written back to its remote bucket
+	// (see WritePolicyConf and write-back journal)
+	WbackObjMD = "wback"
+
+	// object stored compressed (see CompressConf and cmn/zblk);
+	// the value is the compressed size
+	CompressObjMD = "compressed"
+
+	// object stored encrypted (see EncryptConf and cmn/crypt);
+	// the value is the ID of the data key
+	EncryptObjMD = "encrypted"
+
+	// small object appended to a per-mountpath pack file (see PackConf and fs/pack.go)
+	PackObjMD = "packed"
+
+	// object stored as a manifest plus chunks across mountpaths (see ChunkConf and core/lchunk.go)
+	ChunkObjMD = "chunked"
+
+	// number of (warm) GETs; maintained only when the bucket's eviction policy is LFU
+	// (see LRUConf.Policy and space/lru.go)
+	AccessCntObjMD = "access_cnt"
 )
 
 // object properties
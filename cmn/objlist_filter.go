@@ -0,0 +1,445 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// List-objects filter expression (see apc.LsoMsg.Filter), e.g.:
+//
+//	size >= 1MiB and size < 1GiB and name = *.tar
+//	atime > 7d, meta.source = aws, cksum
+//
+// - terms are separated by commas or the keyword "and"; an object must match all terms;
+// - operators: =, !=, <, <=, >, >=, ~ (regex), !~ (negated regex);
+// - attributes:
+//   - name:     glob (=, !=) or regex (~, !~); globs without '/' match the object's base name
+//   - size:     number of bytes with optional IEC or SI units (e.g., 10KiB, 1MB)
+//   - atime:    access time; only locally present objects have one
+//   - mtime:    modification time: remote (backend) "LastModified" if available, local otherwise
+//   - cksum:    presence ("cksum"), absence ("!cksum"), or checksum type (=, !=)
+//   - meta.KEY: custom metadata - presence ("meta.KEY"), absence ("!meta.KEY"), value (=, !=, ~, !~)
+// - time values are either absolute (RFC3339 or YYYY-MM-DD) or durations relative to now,
+//   so that `atime > 7d` selects objects accessed within the last 7 days;
+// - values containing separators or spaces can be double-quoted;
+// - a term that refers to an unknown attribute (e.g., atime of a remote object that is not
+//   present in the cluster) does not match - with the only exception of absence terms.
+
+const (
+	lsoFltName  = "name"
+	lsoFltSize  = "size"
+	lsoFltAtime = "atime"
+	lsoFltMtime = "mtime"
+	lsoFltCksum = "cksum"
+	lsoFltMeta  = "meta."
+)
+
+// (longest first)
+var lsoFltOps = []string{"<=", ">=", "!=", "!~", "<", ">", "=", "~"}
+
+type (
+	LsoFilter struct {
+		expr  string
+		terms []lsoTerm
+	}
+	lsoTerm struct {
+		re   *regexp.Regexp
+		attr string // one of the lsoFlt* enum above
+		key  string // custom metadata key
+		op   string // one of lsoFltOps, or "" for (presence | absence)
+		sval string
+		ival int64
+		neg  bool // absence
+	}
+	// what's known about a given object
+	lsoFltAttrs struct {
+		custom cos.StrKVs
+		name   string
+		fqn    string
+		ckty   string
+		size   int64
+		atime  int64
+		mtime  int64 // (lazily, via getMtime)
+		haveCk bool
+		haveMt bool
+	}
+)
+
+// NewLsoFilter parses filter expression; returns (nil, nil) given an empty one
+func NewLsoFilter(expr string) (*LsoFilter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	parts, err := splitLsoFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		f   = &LsoFilter{expr: expr, terms: make([]lsoTerm, 0, len(parts))}
+		now = time.Now().UnixNano()
+	)
+	for _, s := range parts {
+		t, err := parseLsoTerm(s, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list-objects filter %q: %w", expr, err)
+		}
+		f.terms = append(f.terms, t)
+	}
+	return f, nil
+}
+
+func (f *LsoFilter) String() string { return f.expr }
+
+// whether all terms can be evaluated given only the object's name
+func (f *LsoFilter) NameOnly() bool {
+	for i := range f.terms {
+		if f.terms[i].attr != lsoFltName {
+			return false
+		}
+	}
+	return true
+}
+
+// list-objects properties (apc.GetProps*) that the filter needs to evaluate listed entries
+func (f *LsoFilter) Props() (props []string) {
+	for i := range f.terms {
+		switch f.terms[i].attr {
+		case lsoFltSize:
+			props = append(props, apc.GetPropsSize)
+		case lsoFltAtime:
+			props = append(props, apc.GetPropsAtime)
+		case lsoFltMtime, lsoFltMeta:
+			props = append(props, apc.GetPropsCustom)
+		case lsoFltCksum:
+			props = append(props, apc.GetPropsChecksum)
+		}
+	}
+	return props
+}
+
+// MatchName evaluates name terms only (and is a fast path to reject objects prior to loading their metadata)
+func (f *LsoFilter) MatchName(name string) bool {
+	a := &lsoFltAttrs{name: name}
+	for i := range f.terms {
+		if t := &f.terms[i]; t.attr == lsoFltName && !t.match(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match evaluates all terms given (locally stored) object's metadata;
+// `fqn`, if not empty, is used to determine modification time when there's no remote one
+func (f *LsoFilter) Match(name string, oa *ObjAttrs, fqn string) bool {
+	a := &lsoFltAttrs{
+		name:   name,
+		fqn:    fqn,
+		size:   oa.Size,
+		atime:  oa.Atime,
+		custom: oa.CustomMD,
+	}
+	if !oa.Cksum.IsEmpty() {
+		a.haveCk, a.ckty = true, oa.Cksum.Ty()
+	}
+	return f.match(a)
+}
+
+// MatchEntry evaluates all terms given listed entry's properties
+// (e.g., remote object that is not present in the cluster)
+func (f *LsoFilter) MatchEntry(e *LsoEntry) bool {
+	a := &lsoFltAttrs{
+		name:   e.Name,
+		size:   e.Size,
+		custom: s2custom(e.Custom),
+		haveCk: e.Checksum != "",
+	}
+	return f.match(a)
+}
+
+func (f *LsoFilter) match(a *lsoFltAttrs) bool {
+	for i := range f.terms {
+		if !f.terms[i].match(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// split by (unquoted) commas and "and" keywords
+func splitLsoFilter(expr string) (parts []string, _ error) {
+	var (
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\\' && quoted:
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ',':
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		case c == ' ' && i+4 < len(expr) && strings.EqualFold(expr[i+1:i+4], "and") && expr[i+4] == ' ':
+			parts = append(parts, expr[start:i])
+			i += 4
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("invalid list-objects filter %q: unterminated quoted value", expr)
+	}
+	return append(parts, expr[start:]), nil
+}
+
+func parseLsoTerm(s string, now int64) (t lsoTerm, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return t, errors.New("empty term")
+	}
+	i := strings.IndexAny(s, "<>=!~")
+	if i == 0 && s[0] == '!' && !strings.ContainsAny(s[1:], "<>=!~") {
+		t.neg = true
+		s = strings.TrimSpace(s[1:])
+		i = -1
+	}
+	if i < 0 {
+		// presence | absence
+		if err = t.setAttr(s); err != nil {
+			return t, err
+		}
+		if t.attr != lsoFltCksum && t.attr != lsoFltMeta {
+			return t, fmt.Errorf("%q: expecting '%s' or '%sKEY' (presence or absence)", s, lsoFltCksum, lsoFltMeta)
+		}
+		return t, nil
+	}
+	if err = t.setAttr(strings.TrimSpace(s[:i])); err != nil {
+		return t, err
+	}
+	rest := s[i:]
+	for _, op := range lsoFltOps {
+		if strings.HasPrefix(rest, op) {
+			t.op = op
+			break
+		}
+	}
+	if t.op == "" {
+		return t, fmt.Errorf("%q: invalid operator", s)
+	}
+	val := strings.TrimSpace(rest[len(t.op):])
+	if len(val) > 1 && val[0] == '"' {
+		if val, err = strconv.Unquote(val); err != nil {
+			return t, fmt.Errorf("%q: invalid quoted value: %w", s, err)
+		}
+	}
+	if val == "" {
+		return t, fmt.Errorf("%q: missing value", s)
+	}
+	if err = t.setValue(val, now); err != nil {
+		return t, fmt.Errorf("%q: %w", s, err)
+	}
+	return t, nil
+}
+
+func (t *lsoTerm) setAttr(attr string) error {
+	switch attr {
+	case lsoFltName, lsoFltSize, lsoFltAtime, lsoFltMtime, lsoFltCksum:
+		t.attr = attr
+	default:
+		if !strings.HasPrefix(attr, lsoFltMeta) || len(attr) == len(lsoFltMeta) {
+			return fmt.Errorf("unknown attribute %q (expecting one of: %s, %s, %s, %s, %s, %sKEY)", attr,
+				lsoFltName, lsoFltSize, lsoFltAtime, lsoFltMtime, lsoFltCksum, lsoFltMeta)
+		}
+		t.attr, t.key = lsoFltMeta, attr[len(lsoFltMeta):]
+	}
+	return nil
+}
+
+func (t *lsoTerm) setValue(val string, now int64) (err error) {
+	var (
+		isRegex   = t.op == "~" || t.op == "!~"
+		isCompare = t.op != "=" && t.op != "!=" && !isRegex
+	)
+	switch t.attr {
+	case lsoFltName, lsoFltMeta:
+		if isCompare {
+			return fmt.Errorf("operator %q does not apply to %q", t.op, t.attr)
+		}
+		if isRegex {
+			t.re, err = regexp.Compile(val)
+			return err
+		}
+		if t.attr == lsoFltName {
+			_, err = path.Match(val, "")
+		}
+	case lsoFltSize:
+		if isRegex {
+			return fmt.Errorf("operator %q does not apply to %q", t.op, t.attr)
+		}
+		t.ival, err = cos.ParseSize(val, cos.UnitsIEC)
+	case lsoFltAtime, lsoFltMtime:
+		if isRegex {
+			return fmt.Errorf("operator %q does not apply to %q", t.op, t.attr)
+		}
+		t.ival, err = parseLsoTime(val, now)
+	case lsoFltCksum:
+		if t.op != "=" && t.op != "!=" {
+			return fmt.Errorf("operator %q does not apply to %q", t.op, t.attr)
+		}
+		err = cos.ValidateCksumType(val)
+	}
+	t.sval = val
+	return err
+}
+
+// absolute time or duration relative to `now`
+func parseLsoTime(val string, now int64) (int64, error) {
+	if tm, err := time.Parse(time.RFC3339, val); err == nil {
+		return tm.UnixNano(), nil
+	}
+	if tm, err := time.ParseInLocation(time.DateOnly, val, time.Local); err == nil {
+		return tm.UnixNano(), nil
+	}
+	var (
+		d   time.Duration
+		err error
+	)
+	if days, ok := strings.CutSuffix(val, "d"); ok {
+		var n int64
+		n, err = strconv.ParseInt(days, 10, 64)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(val)
+	}
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid time %q (expecting RFC3339, YYYY-MM-DD, or duration, e.g. 90m, 7d)", val)
+	}
+	return now - int64(d), nil
+}
+
+/////////////
+// lsoTerm //
+/////////////
+
+func (t *lsoTerm) match(a *lsoFltAttrs) bool {
+	switch t.attr {
+	case lsoFltName:
+		var ok bool
+		if t.re != nil {
+			ok = t.re.MatchString(a.name)
+		} else {
+			name := a.name
+			if !strings.Contains(t.sval, "/") {
+				name = path.Base(name)
+			}
+			ok, _ = path.Match(t.sval, name)
+		}
+		if t.op == "!=" || t.op == "!~" {
+			return !ok
+		}
+		return ok
+	case lsoFltSize:
+		return t.cmp(a.size)
+	case lsoFltAtime:
+		return a.atime != 0 && t.cmp(a.atime)
+	case lsoFltMtime:
+		mtime := a.getMtime()
+		return mtime != 0 && t.cmp(mtime)
+	case lsoFltCksum:
+		switch t.op {
+		case "":
+			return a.haveCk != t.neg
+		case "=":
+			return a.haveCk && a.ckty == t.sval
+		default:
+			return a.haveCk && a.ckty != "" && a.ckty != t.sval
+		}
+	case lsoFltMeta:
+		v, ok := a.custom[t.key]
+		switch t.op {
+		case "":
+			return ok != t.neg
+		case "=":
+			return ok && v == t.sval
+		case "!=":
+			return ok && v != t.sval
+		case "~":
+			return ok && t.re.MatchString(v)
+		default:
+			return ok && !t.re.MatchString(v)
+		}
+	}
+	return false
+}
+
+func (t *lsoTerm) cmp(v int64) bool {
+	switch t.op {
+	case "<":
+		return v < t.ival
+	case "<=":
+		return v <= t.ival
+	case ">":
+		return v > t.ival
+	case ">=":
+		return v >= t.ival
+	case "=":
+		return v == t.ival
+	default:
+		return v != t.ival
+	}
+}
+
+/////////////////
+// lsoFltAttrs //
+/////////////////
+
+// remote "LastModified", if available, otherwise local
+func (a *lsoFltAttrs) getMtime() int64 {
+	if a.haveMt {
+		return a.mtime
+	}
+	a.haveMt = true
+	if s, ok := a.custom[LastModified]; ok {
+		if tm, err := time.Parse(time.RFC3339, s); err == nil {
+			a.mtime = tm.UnixNano()
+			return a.mtime
+		}
+	}
+	if a.fqn != "" {
+		if finfo, err := os.Stat(a.fqn); err == nil {
+			a.mtime = finfo.ModTime().UnixNano()
+		}
+	}
+	return a.mtime
+}
+
+// parse all key:value pairs of the formatted custom metadata (see CustomMD2S and compare with S2CustomMD)
+func s2custom(custom string) (md cos.StrKVs) {
+	if len(custom) < 8 || !strings.HasPrefix(custom, "map[") {
+		return nil
+	}
+	lst := strings.Split(custom[4:len(custom)-1], " ")
+	md = make(cos.StrKVs, len(lst))
+	for _, kv := range lst {
+		if k, v, ok := strings.Cut(kv, ":"); ok && k != "" {
+			md[k] = v
+		}
+	}
+	return md
+}
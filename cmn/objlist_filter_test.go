@@ -0,0 +1,144 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestLsoFilterParse(t *testing.T) {
+	tests := []struct {
+		expr  string
+		valid bool
+	}{
+		{"", true},
+		{"size > 1MiB", true},
+		{"size >= 1MiB and size < 1GiB, name = *.tar", true},
+		{"atime > 7d AND mtime < 2024-01-01", true},
+		{"mtime >= 2024-01-01T10:00:00Z", true},
+		{"cksum, !cksum, cksum = xxhash", true},
+		{"meta.source, !meta.etag, meta.source ~ \"^(s3|gs)$\"", true},
+		{`name ~ "a,b and c"`, true},
+		{"name !~ ^tmp/", true},
+
+		{"size", false},
+		{"foo = bar", false},
+		{"size > ", false},
+		{"size ~ 10", false},
+		{"name < abc", false},
+		{"atime > yesterday", false},
+		{"cksum = foo", false},
+		{"name ~ (", false},
+		{"name = [", false},
+		{`name = "abc`, false},
+		{"size > 1, ", false},
+		{"meta. = x", false},
+	}
+	for _, test := range tests {
+		_, err := cmn.NewLsoFilter(test.expr)
+		tassert.Errorf(t, (err == nil) == test.valid, "%q: expected valid=%t, got err=%v", test.expr, test.valid, err)
+	}
+}
+
+func TestLsoFilterMatch(t *testing.T) {
+	var (
+		now = time.Now()
+		oa  = &cmn.ObjAttrs{
+			Size:     2 * cos.MiB,
+			Atime:    now.Add(-time.Hour).UnixNano(),
+			Cksum:    cos.NewCksum(cos.ChecksumXXHash, "0123456789abcdef"),
+			CustomMD: cos.StrKVs{cmn.SourceObjMD: "aws", cmn.LastModified: "2023-06-01T00:00:00Z"},
+		}
+		name = "a/b/shard-001.tar"
+	)
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{"size > 1MiB", true},
+		{"size >= 1MiB and size < 2MiB", false},
+		{"size = 2MiB", true},
+		{"name = *.tar", true},
+		{"name = a/*/shard-*.tar", true},
+		{"name = *.tgz", false},
+		{"name != *.tgz", true},
+		{"name ~ ^a/b/", true},
+		{"name !~ shard", false},
+		{"atime > 1d", true},
+		{"atime > 30m", false},
+		{"atime < 30m", true},
+		{"mtime < 2024-01-01", true},
+		{"mtime > 2023-06-02", false},
+		{"cksum", true},
+		{"!cksum", false},
+		{"cksum = xxhash", true},
+		{"cksum != xxhash", false},
+		{"meta.source = aws", true},
+		{"meta.source != aws", false},
+		{"meta.source ~ \"^(aws|gcp)$\"", true},
+		{"meta.etag", false},
+		{"!meta.etag", true},
+		{"meta.etag != x", false}, // (unknown attribute)
+		{"size > 1MiB, name = *.tar, meta.source = aws, atime > 1d", true},
+		{"size > 1MiB, name = *.tar, meta.source = gcp", false},
+	}
+	for _, test := range tests {
+		f, err := cmn.NewLsoFilter(test.expr)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, f.Match(name, oa, "") == test.match, "%q: expected match=%t", test.expr, test.match)
+	}
+}
+
+func TestLsoFilterMatchEntry(t *testing.T) {
+	e := &cmn.LsoEntry{
+		Name:   "x/y.jpg",
+		Size:   1000,
+		Custom: cmn.CustomMD2S(cos.StrKVs{cmn.ETag: "abc", cmn.LastModified: "2023-06-01T00:00:00Z"}),
+	}
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{"size < 1KiB, name = *.jpg", true},
+		{"mtime < 2024-01-01", true},
+		{"meta.ETag = abc", true},
+		{"atime < 1d", false}, // not present in the cluster - no access time
+		{"cksum", false},
+		{"!cksum", true},
+	}
+	for _, test := range tests {
+		f, err := cmn.NewLsoFilter(test.expr)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, f.MatchEntry(e) == test.match, "%q: expected match=%t", test.expr, test.match)
+	}
+
+	// name-only (fast path)
+	f, err := cmn.NewLsoFilter("name = *.jpg and size > 1")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !f.NameOnly(), "expected not name-only")
+	tassert.Errorf(t, f.MatchName("a.jpg") && !f.MatchName("a.png"), "name-only match failed")
+}
+
+// local modification time when there's no remote one
+func TestLsoFilterLocalMtime(t *testing.T) {
+	fqn := filepath.Join(t.TempDir(), "obj")
+	tassert.CheckFatal(t, os.WriteFile(fqn, []byte("data"), cos.PermRWR))
+	mtime := time.Now().Add(-48 * time.Hour)
+	tassert.CheckFatal(t, os.Chtimes(fqn, mtime, mtime))
+
+	oa := &cmn.ObjAttrs{Size: 4}
+	f, err := cmn.NewLsoFilter("mtime < 1d")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, f.Match("obj", oa, fqn), "expected local mtime to match")
+	tassert.Errorf(t, !f.Match("obj", oa, ""), "expected unknown mtime not to match")
+}
@@ -0,0 +1,79 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket snapshot is a point-in-time, read-only view of an ais:// bucket.
+// Snapshots are created by hard-linking (on the same mountpath) the bucket's objects
+// into a separate per-snapshot directory, so that no data gets copied - AIS never
+// overwrites objects in place (PUT, append, and friends write a new file and then
+// rename it), which makes the resulting layout copy-on-write by construction.
+//
+// Snapshot content is addressed via virtual bucket named "<bucket>@<snapshot>",
+// e.g. `ais://data@snap1`. Each snapshot is a separate (read-only) BMD entry
+// that shares BID with its origin; snapshot objects are placed (HRW) together
+// with the origin bucket's objects - see `Bck.HrwUname` below.
+
+const SnapSepa = '@'
+
+func ValidateSnapName(name string) error {
+	if name == "" {
+		return errors.New("snapshot name is missing")
+	}
+	if !cos.IsAlphaPlus(name) {
+		return fmt.Errorf("snapshot name %q is invalid: %s", name, cos.OnlyPlus)
+	}
+	return nil
+}
+
+///////////////////
+// Bck snapshots //
+///////////////////
+
+func (b *Bck) IsSnap() bool { return strings.IndexByte(b.Name, SnapSepa) > 0 }
+
+// returns origin bucket and snapshot name, or ok == false when `b` is not a snapshot
+func (b *Bck) SnapBase() (base Bck, snap string, ok bool) {
+	i := strings.IndexByte(b.Name, SnapSepa)
+	if i <= 0 {
+		return
+	}
+	base = Bck{Name: b.Name[:i], Provider: b.Provider, Ns: b.Ns}
+	return base, b.Name[i+1:], true
+}
+
+// virtual (read-only) bucket to address a given snapshot of `b`
+func (b *Bck) SnapBck(snap string) Bck {
+	return Bck{Name: b.Name + string(SnapSepa) + snap, Provider: b.Provider, Ns: b.Ns}
+}
+
+// HrwUname returns the name to use with HRW (target and mountpath) placement:
+// snapshot objects are always placed together with their origin
+func (b *Bck) HrwUname(objName string) string {
+	if base, _, ok := b.SnapBase(); ok {
+		return base.MakeUname(objName)
+	}
+	return b.MakeUname(objName)
+}
+
+func (b *Bck) validateSnapName() error {
+	base, snap, _ := b.SnapBase()
+	if err := base.ValidateName(); err != nil {
+		return err
+	}
+	if b.Provider != "" && b.Provider != apc.AIS {
+		return fmt.Errorf("bucket %q: snapshots are supported only for ais:// buckets", b.Cname(""))
+	}
+	return ValidateSnapName(snap)
+}
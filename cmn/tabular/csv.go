@@ -0,0 +1,120 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type (
+	csvReader struct {
+		r    *csv.Reader
+		idx  []int // column indices (-1 when not requested)
+		vals []string
+		base int64
+	}
+	csvWriter struct {
+		w *csv.Writer
+	}
+)
+
+func _newCSV(ra io.ReaderAt, off, size int64) *csv.Reader {
+	r := csv.NewReader(io.NewSectionReader(ra, off, size-off))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.ReuseRecord = true
+	return r
+}
+
+func newCSVReader(ra io.ReaderAt, size int64, cols []string, noHeader bool, pos int64) (*csvReader, error) {
+	cr := &csvReader{idx: make([]int, len(cols)), vals: make([]string, len(cols))}
+	if noHeader {
+		for i, col := range cols {
+			cr.idx[i] = -1
+			if col != "" {
+				n, _ := strconv.Atoi(col)
+				cr.idx[i] = n
+			}
+		}
+		cr.r, cr.base = _newCSV(ra, pos, size), pos
+		return cr, nil
+	}
+
+	// header
+	r := _newCSV(ra, 0, size)
+	hdr, err := r.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("tabular: missing CSV header: %w", ErrBadFormat)
+		}
+		return nil, err
+	}
+	names := make(map[string]int, len(hdr))
+	for i, h := range hdr {
+		if i == 0 {
+			h = strings.TrimPrefix(h, "\ufeff") // (BOM)
+		}
+		names[strings.TrimSpace(h)] = i
+	}
+	for i, col := range cols {
+		cr.idx[i] = -1
+		if col == "" {
+			continue
+		}
+		j, ok := names[col]
+		if !ok {
+			return nil, fmt.Errorf("tabular: column %q not found in the CSV header %q", col, hdr)
+		}
+		cr.idx[i] = j
+	}
+	if off := r.InputOffset(); pos <= off {
+		cr.r = r
+	} else {
+		cr.r, cr.base = _newCSV(ra, pos, size), pos
+	}
+	return cr, nil
+}
+
+func (cr *csvReader) Next() ([]string, error) {
+	rec, err := cr.r.Read() // (skips empty lines)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := range cr.idx {
+		cr.vals[i] = ""
+		if j >= 0 && j < len(rec) {
+			cr.vals[i] = rec[j]
+		}
+	}
+	return cr.vals, nil
+}
+
+func (cr *csvReader) Pos() int64 { return cr.base + cr.r.InputOffset() }
+
+//
+// writer
+//
+
+func newCSVWriter(w io.Writer, cols []Column) *csvWriter {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	hdr := make([]string, len(cols))
+	for i := range cols {
+		hdr[i] = cols[i].Name
+	}
+	cw.w.Write(hdr) //nolint:errcheck // (returned by Flush)
+	return cw
+}
+
+func (cw *csvWriter) Write(vals []string) error { return cw.w.Write(vals) }
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
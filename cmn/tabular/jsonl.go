@@ -0,0 +1,174 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const jsonlBufSize = 64 * 1024
+
+type (
+	jsonlReader struct {
+		br   *bufio.Reader
+		cols []string
+		vals []string
+		line []byte
+		pos  int64
+	}
+	jsonlWriter struct {
+		bw   *bufio.Writer
+		cols []Column
+		buf  []byte
+	}
+)
+
+func newJSONLReader(ra io.ReaderAt, size int64, cols []string, pos int64) *jsonlReader {
+	return &jsonlReader{
+		br:   bufio.NewReaderSize(io.NewSectionReader(ra, pos, size-pos), jsonlBufSize),
+		cols: cols,
+		vals: make([]string, len(cols)),
+		pos:  pos,
+	}
+}
+
+func (jr *jsonlReader) Next() ([]string, error) {
+	for {
+		line, err := jr.readLine()
+		if err != nil {
+			return nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var (
+			m   map[string]any
+			dec = json.NewDecoder(bytes.NewReader(line))
+		)
+		dec.UseNumber()
+		if err := dec.Decode(&m); err != nil {
+			return nil, fmt.Errorf("tabular: JSONL at offset %d: %w", jr.pos-int64(len(jr.line)), err)
+		}
+		for i, col := range jr.cols {
+			jr.vals[i] = ""
+			if col != "" {
+				jr.vals[i] = jsonVal(lookup(m, col))
+			}
+		}
+		return jr.vals, nil
+	}
+}
+
+func (jr *jsonlReader) Pos() int64 { return jr.pos }
+
+// (the last line may not be newline-terminated)
+func (jr *jsonlReader) readLine() ([]byte, error) {
+	jr.line = jr.line[:0]
+	for {
+		b, err := jr.br.ReadSlice('\n')
+		jr.line = append(jr.line, b...)
+		jr.pos += int64(len(b))
+		switch err {
+		case nil:
+			return jr.line, nil
+		case bufio.ErrBufferFull:
+			continue
+		case io.EOF:
+			if len(jr.line) > 0 {
+				return jr.line, nil
+			}
+			return nil, io.EOF
+		default:
+			return nil, err
+		}
+	}
+}
+
+// top-level key or dot-separated path, e.g. "meta.etag"
+func lookup(m map[string]any, col string) any {
+	if v, ok := m[col]; ok {
+		return v
+	}
+	for {
+		i := strings.IndexByte(col, '.')
+		if i <= 0 {
+			return nil
+		}
+		nested, ok := m[col[:i]].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m, col = nested, col[i+1:]
+		if v, ok := m[col]; ok {
+			return v
+		}
+	}
+}
+
+func jsonVal(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+//
+// writer
+//
+
+func newJSONLWriter(w io.Writer, cols []Column) *jsonlWriter {
+	return &jsonlWriter{bw: bufio.NewWriterSize(w, jsonlBufSize), cols: cols}
+}
+
+// empty values are omitted
+func (jw *jsonlWriter) Write(vals []string) error {
+	if len(vals) != len(jw.cols) {
+		return fmt.Errorf("tabular: expecting %d values, got %d", len(jw.cols), len(vals))
+	}
+	b := append(jw.buf[:0], '{')
+	for i, v := range vals {
+		if v == "" {
+			continue
+		}
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+		qn, _ := json.Marshal(jw.cols[i].Name)
+		b = append(b, qn...)
+		b = append(b, ':')
+		if jw.cols[i].Type == ColInt64 {
+			if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+				return fmt.Errorf("tabular: column %q: invalid integer %q", jw.cols[i].Name, v)
+			}
+			b = append(b, v...)
+		} else {
+			qv, _ := json.Marshal(v)
+			b = append(b, qv...)
+		}
+	}
+	b = append(b, '}', '\n')
+	jw.buf = b
+	_, err := jw.bw.Write(b)
+	return err
+}
+
+func (jw *jsonlWriter) Close() error { return jw.bw.Flush() }
@@ -0,0 +1,716 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+)
+
+// Parquet reader:
+// - reads the footer (FileMetaData) and, row group by row group, only the column chunks
+//   of the requested columns - one page at a time;
+// - supports non-repeated (flat or nested-optional) leaf columns of all physical types;
+// - data pages v1 and v2, dictionary pages, PLAIN, PLAIN_DICTIONARY, RLE_DICTIONARY,
+//   DELTA_BINARY_PACKED, DELTA_LENGTH_BYTE_ARRAY, and DELTA_BYTE_ARRAY encodings;
+// - UNCOMPRESSED, SNAPPY, GZIP, LZ4 (Hadoop), LZ4_RAW, and ZSTD compression.
+
+const pqMagic = "PAR1"
+
+// physical types
+const (
+	pqBoolean = iota
+	pqInt32
+	pqInt64
+	pqInt96
+	pqFloat
+	pqDouble
+	pqByteArray
+	pqFixedLenByteArray
+)
+
+// page types
+const (
+	pqDataPage       = 0
+	pqDictionaryPage = 2
+	pqDataPageV2     = 3
+)
+
+// encodings
+const (
+	pqPlain              = 0
+	pqPlainDictionary    = 2
+	pqRLE                = 3
+	pqDeltaBinaryPacked  = 5
+	pqDeltaLengthByteArr = 6
+	pqDeltaByteArray     = 7
+	pqRLEDictionary      = 8
+)
+
+// compression codecs
+const (
+	pqUncompressed = 0
+	pqSnappy       = 1
+	pqGzip         = 2
+	pqLZ4          = 5
+	pqZstd         = 6
+	pqLZ4Raw       = 7
+)
+
+// schema: repetition types and converted types
+const (
+	pqRequired = 0
+	pqOptional = 1
+	pqRepeated = 2
+
+	pqConvUTF8      = 0
+	pqConvTsMillis  = 9
+	pqConvTsMicros  = 10
+	pqLogicalTsType = 8 // LogicalType union: TIMESTAMP
+)
+
+const (
+	pqMaxPageSize = 256 << 20
+	julianUnix    = 2440588 // Julian day of 1970-01-01 (INT96 timestamps)
+)
+
+type (
+	pqLeaf struct {
+		path   string
+		typ    int64
+		tlen   int
+		unit   time.Duration // timestamps: time unit; zero otherwise
+		maxDef int
+		maxRep int
+	}
+	pqCol struct {
+		pqLeaf
+		leaf  int // column chunk index
+		codec int64
+		off   int64 // next page
+		end   int64
+		dict  []string
+		vals  []string // current page: one value per row ("" when null)
+		next  int
+		lvls  []uint64
+	}
+	pqReader struct {
+		ra     io.ReaderAt
+		rgs    []tstruct
+		cols   []*pqCol // nil when not requested
+		vals   []string
+		rg     int   // current row group
+		rgRows int64 // remaining rows in the current row group
+		row    int64
+	}
+)
+
+var zdec, _ = zstd.NewReader(nil)
+
+func pqErr(format string, a ...any) error {
+	return fmt.Errorf("tabular: parquet: "+format+": %w", append(a, ErrBadFormat)...)
+}
+
+func newPqReader(ra io.ReaderAt, size int64, cols []string, pos int64) (*pqReader, error) {
+	meta, err := pqFooter(ra, size)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := pqSchema(meta.list(2))
+	if err != nil {
+		return nil, err
+	}
+	pr := &pqReader{ra: ra, cols: make([]*pqCol, len(cols)), vals: make([]string, len(cols)), rg: -1}
+	for i, name := range cols {
+		if name == "" {
+			continue
+		}
+		for j := range leaves {
+			if leaves[j].path == name {
+				pr.cols[i] = &pqCol{pqLeaf: leaves[j], leaf: j}
+				break
+			}
+		}
+		if pr.cols[i] == nil {
+			return nil, fmt.Errorf("tabular: parquet: column %q not found", name)
+		}
+		if pr.cols[i].maxRep > 0 {
+			return nil, fmt.Errorf("tabular: parquet: column %q: repeated columns are not supported", name)
+		}
+	}
+	for _, v := range meta.list(4) {
+		rg, ok := v.(tstruct)
+		if !ok {
+			return nil, pqErr("row group")
+		}
+		pr.rgs = append(pr.rgs, rg)
+	}
+	return pr, pr.seek(pos)
+}
+
+func pqFooter(ra io.ReaderAt, size int64) (tstruct, error) {
+	var tail [8]byte
+	if size < 12 {
+		return nil, pqErr("file too short")
+	}
+	if _, err := ra.ReadAt(tail[:], size-8); err != nil {
+		return nil, err
+	}
+	flen := int64(binary.LittleEndian.Uint32(tail[:4]))
+	if string(tail[4:]) != pqMagic || flen > size-12 {
+		return nil, pqErr("footer")
+	}
+	b := make([]byte, flen)
+	if _, err := ra.ReadAt(b, size-8-flen); err != nil {
+		return nil, err
+	}
+	d := &tdec{b: b}
+	return d.structure()
+}
+
+// depth-first; leaf index corresponds to the column chunk index in each row group
+func pqSchema(elems []any) ([]pqLeaf, error) {
+	var (
+		leaves []pqLeaf
+		i      int
+		walk   func(prefix string, def, rep int) error
+	)
+	walk = func(prefix string, def, rep int) error {
+		if i >= len(elems) {
+			return pqErr("schema")
+		}
+		el, ok := elems[i].(tstruct)
+		if !ok {
+			return pqErr("schema element")
+		}
+		i++
+		name := el.str(4)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		switch el.i64(3) {
+		case pqOptional:
+			def++
+		case pqRepeated:
+			def++
+			rep++
+		}
+		if n := el.i64(5); n > 0 {
+			for range n {
+				if err := walk(name, def, rep); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		leaf := pqLeaf{path: name, typ: el.i64(1), tlen: int(el.i64(2)), maxDef: def, maxRep: rep}
+		switch el.i64(6) {
+		case pqConvTsMillis:
+			leaf.unit = time.Millisecond
+		case pqConvTsMicros:
+			leaf.unit = time.Microsecond
+		}
+		if ts := el.st(10).st(pqLogicalTsType); ts != nil {
+			unit := ts.st(2)
+			switch {
+			case unit.has(1):
+				leaf.unit = time.Millisecond
+			case unit.has(2):
+				leaf.unit = time.Microsecond
+			case unit.has(3):
+				leaf.unit = time.Nanosecond
+			}
+		}
+		leaves = append(leaves, leaf)
+		return nil
+	}
+	if len(elems) == 0 {
+		return nil, pqErr("empty schema")
+	}
+	root, ok := elems[0].(tstruct)
+	if !ok {
+		return nil, pqErr("schema root")
+	}
+	i = 1
+	for range root.i64(5) {
+		if err := walk("", 0, 0); err != nil {
+			return nil, err
+		}
+	}
+	return leaves, nil
+}
+
+func (pr *pqReader) Pos() int64 { return pr.row }
+
+func (pr *pqReader) Next() ([]string, error) {
+	for pr.rgRows == 0 {
+		if pr.rg+1 >= len(pr.rgs) {
+			return nil, io.EOF
+		}
+		if err := pr.nextRowGroup(); err != nil {
+			return nil, err
+		}
+	}
+	for i, col := range pr.cols {
+		pr.vals[i] = ""
+		if col == nil {
+			continue
+		}
+		if col.next >= len(col.vals) {
+			if err := col.nextPage(pr.ra); err != nil {
+				return nil, err
+			}
+		}
+		pr.vals[i] = col.vals[col.next]
+		col.next++
+	}
+	pr.rgRows--
+	pr.row++
+	return pr.vals, nil
+}
+
+func (pr *pqReader) nextRowGroup() error {
+	pr.rg++
+	rg := pr.rgs[pr.rg]
+	pr.rgRows = rg.i64(3)
+	chunks := rg.list(1)
+	for _, col := range pr.cols {
+		if col == nil {
+			continue
+		}
+		if col.leaf >= len(chunks) {
+			return pqErr("row group %d: missing column chunk %d", pr.rg, col.leaf)
+		}
+		cc, ok := chunks[col.leaf].(tstruct)
+		if !ok {
+			return pqErr("column chunk")
+		}
+		if cc.str(1) != "" {
+			return fmt.Errorf("tabular: parquet: external column chunks (%q) are not supported", cc.str(1))
+		}
+		md := cc.st(3)
+		if md == nil {
+			return pqErr("column chunk metadata")
+		}
+		col.codec = md.i64(4)
+		col.off = md.i64(9)
+		if dpo := md.i64(11); dpo > 0 && dpo < col.off {
+			col.off = dpo
+		}
+		col.end = col.off + md.i64(7)
+		col.dict, col.vals, col.next = nil, col.vals[:0], 0
+	}
+	return nil
+}
+
+// position at a given row
+func (pr *pqReader) seek(row int64) error {
+	if row == 0 {
+		return nil
+	}
+	var skip = row
+	for pr.rg+1 < len(pr.rgs) {
+		n := pr.rgs[pr.rg+1].i64(3)
+		if skip < n {
+			break
+		}
+		skip -= n
+		pr.rg++
+	}
+	if skip > 0 {
+		if pr.rg+1 >= len(pr.rgs) {
+			return fmt.Errorf("tabular: parquet: invalid position %d (num rows %d)", row, row-skip)
+		}
+		if err := pr.nextRowGroup(); err != nil {
+			return err
+		}
+		for _, col := range pr.cols {
+			if col != nil {
+				if err := col.skip(pr.ra, skip); err != nil {
+					return err
+				}
+			}
+		}
+		pr.rgRows -= skip
+	}
+	pr.row = row
+	return nil
+}
+
+//
+// column chunk: pages
+//
+
+func (col *pqCol) skip(ra io.ReaderAt, n int64) error {
+	for n > 0 {
+		if col.next < len(col.vals) {
+			k := min(n, int64(len(col.vals)-col.next))
+			col.next += int(k)
+			n -= k
+			continue
+		}
+		// skip entire data pages (flat: num_values == num_rows) without decoding
+		hdr, hlen, err := col.header(ra)
+		if err != nil {
+			return err
+		}
+		var nv int64
+		switch hdr.i64(1) {
+		case pqDataPage:
+			nv = hdr.st(5).i64(1)
+		case pqDataPageV2:
+			nv = hdr.st(8).i64(1)
+		}
+		if nv > 0 && nv <= n {
+			col.off += hlen + hdr.i64(3)
+			n -= nv
+			continue
+		}
+		if err := col.nextPage(ra); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (col *pqCol) header(ra io.ReaderAt) (hdr tstruct, hlen int64, err error) {
+	for size := int64(1024); ; size *= 4 {
+		n := min(size, col.end-col.off)
+		if n <= 0 {
+			return nil, 0, pqErr("column %q: unexpected end of column chunk", col.path)
+		}
+		b := make([]byte, n)
+		if _, err := ra.ReadAt(b, col.off); err != nil && err != io.EOF {
+			return nil, 0, err
+		}
+		d := &tdec{b: b}
+		hdr, err = d.structure()
+		if err == nil {
+			return hdr, int64(d.off), nil
+		}
+		if err != errShort || n < size {
+			return nil, 0, err
+		}
+	}
+}
+
+// read (decompress and decode) the next data page, processing dictionary pages (if any) on the way
+func (col *pqCol) nextPage(ra io.ReaderAt) error {
+	for {
+		hdr, hlen, err := col.header(ra)
+		if err != nil {
+			return err
+		}
+		var (
+			ptype = hdr.i64(1)
+			usize = hdr.i64(2)
+			csize = hdr.i64(3)
+		)
+		if csize < 0 || usize < 0 || csize > pqMaxPageSize || usize > pqMaxPageSize {
+			return pqErr("column %q: page size", col.path)
+		}
+		body := make([]byte, csize)
+		if _, err := ra.ReadAt(body, col.off+hlen); err != nil && err != io.EOF {
+			return err
+		}
+		col.off += hlen + csize
+
+		switch ptype {
+		case pqDictionaryPage:
+			if body, err = col.decompress(body, usize); err != nil {
+				return err
+			}
+			dh := hdr.st(7)
+			col.dict, _, err = col.plain(body, int(dh.i64(1)))
+			if err != nil {
+				return err
+			}
+		case pqDataPage:
+			if body, err = col.decompress(body, usize); err != nil {
+				return err
+			}
+			return col.dataPage(hdr.st(5), body)
+		case pqDataPageV2:
+			return col.dataPageV2(hdr.st(8), body, usize)
+		default:
+			// index page, etc. - skip
+		}
+	}
+}
+
+func (col *pqCol) dataPage(dh tstruct, body []byte) (err error) {
+	n := int(dh.i64(1))
+	if n < 0 {
+		return pqErr("column %q: num values", col.path)
+	}
+	col.lvls = col.lvls[:0]
+	if col.maxDef > 0 {
+		if dh.i64(3) != pqRLE {
+			return fmt.Errorf("tabular: parquet: column %q: unsupported definition levels encoding %d", col.path, dh.i64(3))
+		}
+		if len(body) < 4 {
+			return pqErr("column %q: definition levels", col.path)
+		}
+		l := int(binary.LittleEndian.Uint32(body))
+		if l == 0 && n > 0 && len(body) >= 8 {
+			// tolerate (empty) repetition levels written for non-repeated columns
+			body = body[4:]
+			l = int(binary.LittleEndian.Uint32(body))
+		}
+		if 4+l > len(body) {
+			return pqErr("column %q: definition levels", col.path)
+		}
+		if col.lvls, err = rleDecode(body[4:4+l], bitWidth(col.maxDef), n, col.lvls); err != nil {
+			return err
+		}
+		body = body[4+l:]
+	}
+	return col.values(body, n, dh.i64(2))
+}
+
+func (col *pqCol) dataPageV2(dh tstruct, body []byte, usize int64) (err error) {
+	var (
+		n    = int(dh.i64(1))
+		dlen = dh.i64(5)
+		rlen = dh.i64(6)
+	)
+	if n < 0 || dlen < 0 || rlen < 0 || rlen+dlen > int64(len(body)) {
+		return pqErr("column %q: data page v2", col.path)
+	}
+	col.lvls = col.lvls[:0]
+	if col.maxDef > 0 {
+		if col.lvls, err = rleDecode(body[rlen:rlen+dlen], bitWidth(col.maxDef), n, col.lvls); err != nil {
+			return err
+		}
+	}
+	vals := body[rlen+dlen:]
+	if dh.bool(7, true) {
+		if vals, err = col.decompress(vals, usize-rlen-dlen); err != nil {
+			return err
+		}
+	}
+	return col.values(vals, n, dh.i64(4))
+}
+
+// decode values and expand them to one per row
+func (col *pqCol) values(b []byte, n int, enc int64) (err error) {
+	nvals := n
+	if col.maxDef > 0 {
+		nvals = 0
+		for _, l := range col.lvls {
+			if int(l) == col.maxDef {
+				nvals++
+			}
+		}
+	}
+	var vals []string
+	switch enc {
+	case pqPlain:
+		vals, _, err = col.plain(b, nvals)
+	case pqPlainDictionary, pqRLEDictionary:
+		vals, err = col.dictIdx(b, nvals)
+	case pqDeltaBinaryPacked:
+		var ints []int64
+		if ints, _, err = deltaDecode(b); err == nil {
+			vals = make([]string, len(ints))
+			for i, v := range ints {
+				vals[i] = col.int2s(v)
+			}
+		}
+	case pqDeltaLengthByteArr:
+		vals, _, err = deltaLenDecode(b)
+	case pqDeltaByteArray:
+		vals, err = deltaStrDecode(b)
+	default:
+		return fmt.Errorf("tabular: parquet: column %q: unsupported encoding %d", col.path, enc)
+	}
+	if err != nil {
+		return err
+	}
+	if len(vals) < nvals {
+		return pqErr("column %q: expected %d values, got %d", col.path, nvals, len(vals))
+	}
+	if col.maxDef == 0 {
+		col.vals, col.next = vals[:nvals], 0
+		return nil
+	}
+	col.vals = col.vals[:0]
+	var j int
+	for _, l := range col.lvls {
+		if int(l) == col.maxDef {
+			col.vals = append(col.vals, vals[j])
+			j++
+		} else {
+			col.vals = append(col.vals, "")
+		}
+	}
+	col.next = 0
+	return nil
+}
+
+func (col *pqCol) dictIdx(b []byte, n int) ([]string, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	if len(b) < 1 {
+		return nil, pqErr("column %q: dictionary indices", col.path)
+	}
+	idx, err := rleDecode(b[1:], int(b[0]), n, make([]uint64, 0, n))
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]string, n)
+	for i, k := range idx {
+		if k >= uint64(len(col.dict)) {
+			return nil, pqErr("column %q: dictionary index %d out of range", col.path, k)
+		}
+		vals[i] = col.dict[k]
+	}
+	return vals, nil
+}
+
+func (col *pqCol) plain(b []byte, n int) (vals []string, off int, err error) {
+	vals = make([]string, 0, n)
+	for len(vals) < n {
+		var v string
+		switch col.typ {
+		case pqBoolean:
+			i := len(vals)
+			if i/8 >= len(b) {
+				return nil, 0, pqErr("column %q: PLAIN boolean", col.path)
+			}
+			v = strconv.FormatBool(b[i/8]>>(i%8)&1 == 1)
+			off = i/8 + 1
+		case pqInt32:
+			if off+4 > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN int32", col.path)
+			}
+			v = strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(b[off:]))), 10)
+			off += 4
+		case pqInt64:
+			if off+8 > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN int64", col.path)
+			}
+			v = col.int2s(int64(binary.LittleEndian.Uint64(b[off:])))
+			off += 8
+		case pqInt96:
+			if off+12 > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN int96", col.path)
+			}
+			var (
+				nanos = int64(binary.LittleEndian.Uint64(b[off:]))
+				days  = int64(int32(binary.LittleEndian.Uint32(b[off+8:])))
+			)
+			v = time.Unix(0, (days-julianUnix)*int64(24*time.Hour)+nanos).UTC().Format(time.RFC3339Nano)
+			off += 12
+		case pqFloat:
+			if off+4 > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN float", col.path)
+			}
+			v = strconv.FormatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b[off:]))), 'g', -1, 32)
+			off += 4
+		case pqDouble:
+			if off+8 > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN double", col.path)
+			}
+			v = strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(b[off:])), 'g', -1, 64)
+			off += 8
+		case pqByteArray:
+			if off+4 > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN byte array", col.path)
+			}
+			l := int(binary.LittleEndian.Uint32(b[off:]))
+			if l < 0 || off+4+l > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN byte array", col.path)
+			}
+			v = string(b[off+4 : off+4+l])
+			off += 4 + l
+		case pqFixedLenByteArray:
+			if col.tlen <= 0 || off+col.tlen > len(b) {
+				return nil, 0, pqErr("column %q: PLAIN fixed-length byte array", col.path)
+			}
+			v = hex.EncodeToString(b[off : off+col.tlen])
+			off += col.tlen
+		default:
+			return nil, 0, pqErr("column %q: type %d", col.path, col.typ)
+		}
+		vals = append(vals, v)
+	}
+	return vals, off, nil
+}
+
+func (col *pqCol) int2s(v int64) string {
+	if col.unit == 0 {
+		return strconv.FormatInt(v, 10)
+	}
+	return time.Unix(0, v*int64(col.unit)).UTC().Format(time.RFC3339Nano)
+}
+
+func (col *pqCol) decompress(b []byte, usize int64) ([]byte, error) {
+	switch col.codec {
+	case pqUncompressed:
+		return b, nil
+	case pqSnappy:
+		return snappy.Decode(make([]byte, 0, usize), b)
+	case pqGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		out := bytes.NewBuffer(make([]byte, 0, usize))
+		_, err = io.Copy(out, io.LimitReader(zr, pqMaxPageSize))
+		zr.Close()
+		return out.Bytes(), err
+	case pqZstd:
+		return zdec.DecodeAll(b, make([]byte, 0, usize))
+	case pqLZ4Raw:
+		out := make([]byte, usize)
+		n, err := lz4.UncompressBlock(b, out)
+		return out[:n], err
+	case pqLZ4:
+		return lz4Hadoop(b, usize)
+	default:
+		return nil, fmt.Errorf("tabular: parquet: column %q: unsupported compression codec %d", col.path, col.codec)
+	}
+}
+
+// Hadoop LZ4 framing: (big-endian) uncompressed and compressed block sizes followed by the block;
+// some writers produce raw LZ4 blocks instead - falling back
+func lz4Hadoop(b []byte, usize int64) ([]byte, error) {
+	out := make([]byte, 0, usize)
+	for src := b; len(src) >= 8; {
+		var (
+			ul = int(binary.BigEndian.Uint32(src))
+			cl = int(binary.BigEndian.Uint32(src[4:]))
+		)
+		if cl > len(src)-8 || len(out)+ul > int(usize) {
+			break
+		}
+		blk := out[len(out) : len(out)+ul]
+		n, err := lz4.UncompressBlock(src[8:8+cl], blk)
+		if err != nil || n != ul {
+			break
+		}
+		out = out[:len(out)+ul]
+		if src = src[8+cl:]; len(src) == 0 && int64(len(out)) == usize {
+			return out, nil
+		}
+	}
+	out = out[:usize]
+	n, err := lz4.UncompressBlock(b, out)
+	return out[:n], err
+}
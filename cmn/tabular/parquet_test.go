@@ -0,0 +1,236 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular_test
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/tabular"
+	"github.com/NVIDIA/aistore/tools/tassert"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+)
+
+// Parquet reader and writer vs. reference implementation (github.com/parquet-go/parquet-go):
+// - files written by tabular.NewWriter must be readable by the reference reader;
+// - golden files (testdata/*.parquet) written by the reference writer, with a variety of
+//   codecs, encodings, page versions, and row group sizes, must be readable by tabular.NewReader
+//
+// to regenerate golden files: go test ./cmn/tabular -run TestParquetGolden -update
+
+var pqUpdate = flag.Bool("update", false, "regenerate golden parquet files in testdata")
+
+const pqGoldenRows = 1000
+
+type pqVariant struct {
+	name     string
+	codec    compress.Codec
+	nameEnc  encoding.Encoding // nil => PLAIN
+	sizeEnc  encoding.Encoding
+	unit     parquet.TimeUnit
+	required bool // required (non-nullable) size and mtime
+	opts     []parquet.WriterOption
+}
+
+var pqVariants = []pqVariant{
+	{name: "snappy-plain", codec: &parquet.Snappy, unit: parquet.Nanosecond},
+	{
+		name: "zstd-dict-v2", codec: &parquet.Zstd, nameEnc: &parquet.RLEDictionary, sizeEnc: &parquet.RLEDictionary,
+		unit: parquet.Nanosecond, opts: []parquet.WriterOption{parquet.DataPageVersion(2)},
+	},
+	{
+		name: "gzip-delta", codec: &parquet.Gzip, nameEnc: &parquet.DeltaByteArray, sizeEnc: &parquet.DeltaBinaryPacked,
+		unit: parquet.Millisecond, required: true,
+	},
+	{
+		name: "lz4raw-delta-length-v2", codec: &parquet.Lz4Raw, nameEnc: &parquet.DeltaLengthByteArray,
+		unit: parquet.Microsecond, opts: []parquet.WriterOption{parquet.DataPageVersion(2)},
+	},
+	{
+		name: "uncompressed-row-groups", codec: &parquet.Uncompressed, unit: parquet.Nanosecond,
+		opts: []parquet.WriterOption{parquet.MaxRowsPerRowGroup(300), parquet.PageBufferSize(1024)},
+	},
+}
+
+func TestParquetGolden(t *testing.T) {
+	rows := tabRows(pqGoldenRows)
+	for _, v := range pqVariants {
+		t.Run(v.name, func(t *testing.T) {
+			fqn := filepath.Join("testdata", v.name+".parquet")
+			if *pqUpdate {
+				tassert.CheckFatal(t, os.MkdirAll("testdata", 0o755))
+				tassert.CheckFatal(t, os.WriteFile(fqn, v.write(t, rows), 0o644))
+			}
+			b, err := os.ReadFile(fqn)
+			tassert.CheckFatal(t, err)
+
+			cols := []string{"name", "size", "etag", "mtime"}
+			got := readTab(t, b, tabular.Parquet, cols, 0, -1)
+			tassert.Fatalf(t, len(got) == len(rows), "expected %d rows, got %d", len(rows), len(got))
+			for i, row := range got {
+				tassert.Fatalf(t, reflect.DeepEqual(row, rows[i]), "row %d mismatch: %q vs %q", i, row, rows[i])
+			}
+
+			// resume mid-way (in the uncompressed variant: mid-row-group and mid-page)
+			pos := int64(len(rows)/2 + 7)
+			got = readTab(t, b, tabular.Parquet, []string{"mtime", "name"}, pos, 3)
+			for i, row := range got {
+				exp := rows[int(pos)+i]
+				tassert.Errorf(t, row[0] == exp[3] && row[1] == exp[0], "resume: row %d mismatch: %q", int(pos)+i, row)
+			}
+		})
+	}
+}
+
+// (note: reference writer orders group fields by name)
+func (v *pqVariant) write(t *testing.T, rows [][]string) []byte {
+	leaf := func(node parquet.Node, enc encoding.Encoding, optional bool) parquet.Node {
+		if enc == nil {
+			enc = &parquet.Plain // (the reference writer defaults to DELTA_LENGTH_BYTE_ARRAY for strings)
+		}
+		node = parquet.Encoded(node, enc)
+		if optional {
+			node = parquet.Optional(node)
+		}
+		return parquet.Compressed(node, v.codec)
+	}
+	schema := parquet.NewSchema("listing", parquet.Group{
+		"name":  leaf(parquet.String(), v.nameEnc, false),
+		"size":  leaf(parquet.Int(64), v.sizeEnc, !v.required),
+		"etag":  leaf(parquet.String(), nil, true),
+		"mtime": leaf(parquet.Timestamp(v.unit), nil, !v.required),
+	})
+	var (
+		buf  bytes.Buffer
+		opts = append([]parquet.WriterOption{schema, parquet.CreatedBy("parquet-go", "0.23.0", "")}, v.opts...)
+		w    = parquet.NewWriter(&buf, opts...)
+		div  = map[parquet.TimeUnit]int64{parquet.Millisecond: 1e6, parquet.Microsecond: 1e3, parquet.Nanosecond: 1}[v.unit]
+	)
+	for _, row := range rows {
+		var (
+			prow = make(parquet.Row, 0, 4)
+			col  = func(name string) int {
+				leaf, ok := schema.Lookup(name)
+				tassert.Fatalf(t, ok, "column %q not found", name)
+				return leaf.ColumnIndex
+			}
+			add = func(name string, val parquet.Value, null, optional bool) {
+				switch {
+				case null:
+					prow = append(prow, parquet.Value{}.Level(0, 0, col(name)))
+				case optional:
+					prow = append(prow, val.Level(0, 1, col(name)))
+				default:
+					prow = append(prow, val.Level(0, 0, col(name)))
+				}
+			}
+		)
+		size, err := strconv.ParseInt(row[1], 10, 64)
+		tassert.CheckFatal(t, err)
+		mtime, err := time.Parse(time.RFC3339Nano, row[3])
+		tassert.CheckFatal(t, err)
+
+		add("name", parquet.ByteArrayValue([]byte(row[0])), false, false)
+		add("size", parquet.Int64Value(size), false, !v.required)
+		add("etag", parquet.ByteArrayValue([]byte(row[2])), row[2] == "", true)
+		add("mtime", parquet.Int64Value(mtime.UnixNano()/div), false, !v.required)
+		prow = sortRow(prow)
+		_, err = w.WriteRows([]parquet.Row{prow})
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, w.Close())
+	return buf.Bytes()
+}
+
+// reference rows are column-ordered
+func sortRow(row parquet.Row) parquet.Row {
+	out := make(parquet.Row, len(row))
+	for _, val := range row {
+		out[val.Column()] = val
+	}
+	return out
+}
+
+func TestParquetReference(t *testing.T) {
+	for _, num := range []int{0, 1000, 300_000} {
+		t.Run(strconv.Itoa(num), func(t *testing.T) {
+			var (
+				rows = tabRows(num)
+				b    = writeTab(t, tabular.Parquet, rows)
+			)
+			f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, f.NumRows() == int64(num), "expected %d rows, got %d", num, f.NumRows())
+
+			// schema
+			schema := f.Schema()
+			idx := make([]int, len(tabCols))
+			for i, c := range tabCols {
+				leaf, ok := schema.Lookup(c.Name)
+				tassert.Fatalf(t, ok, "column %q not found", c.Name)
+				tassert.Errorf(t, leaf.Node.Optional(), "column %q: expected optional", c.Name)
+				var (
+					typ = leaf.Node.Type()
+					lt  = typ.LogicalType()
+				)
+				switch c.Type {
+				case tabular.ColString:
+					tassert.Errorf(t, typ.Kind() == parquet.ByteArray && lt != nil && lt.UTF8 != nil,
+						"column %q: expected UTF8 byte array, got %s", c.Name, typ)
+				case tabular.ColInt64:
+					tassert.Errorf(t, typ.Kind() == parquet.Int64 && lt == nil, "column %q: expected int64, got %s", c.Name, typ)
+				case tabular.ColTime:
+					tassert.Errorf(t, typ.Kind() == parquet.Int64 && lt != nil && lt.Timestamp != nil && lt.Timestamp.Unit.Nanos != nil,
+						"column %q: expected nanosecond timestamp, got %s", c.Name, typ)
+				}
+				idx[i] = leaf.ColumnIndex
+			}
+
+			// rows
+			var (
+				r    = parquet.NewReader(f)
+				prow = make([]parquet.Row, 1)
+			)
+			defer r.Close()
+			for i := range rows {
+				n, err := r.ReadRows(prow)
+				if n == 0 {
+					tassert.Fatalf(t, false, "row %d: %v", i, err)
+				}
+				vals := sortRow(prow[0])
+				for j, c := range tabCols {
+					var (
+						val = vals[idx[j]]
+						s   string
+					)
+					if !val.IsNull() {
+						switch c.Type {
+						case tabular.ColString:
+							s = string(val.ByteArray())
+						case tabular.ColInt64:
+							s = strconv.FormatInt(val.Int64(), 10)
+						case tabular.ColTime:
+							s = time.Unix(0, val.Int64()).UTC().Format(time.RFC3339Nano)
+						}
+					}
+					tassert.Fatalf(t, s == rows[i][j], "row %d, column %q: expected %q, got %q", i, c.Name, rows[i][j], s)
+				}
+			}
+			n, err := r.ReadRows(prow)
+			tassert.Errorf(t, n == 0 && err == io.EOF, "expected EOF, got %d rows (%v)", n, err)
+		})
+	}
+}
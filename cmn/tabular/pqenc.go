@@ -0,0 +1,238 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Parquet encodings: RLE/bit-packed hybrid (levels and dictionary indices),
+// DELTA_BINARY_PACKED, DELTA_LENGTH_BYTE_ARRAY, and DELTA_BYTE_ARRAY
+
+func errEnc(what string) error {
+	return fmt.Errorf("tabular: parquet: invalid %s: %w", what, ErrBadFormat)
+}
+
+// LSB-first bit unpacking
+func unpack(b []byte, bw, n int, dst []uint64) ([]uint64, error) {
+	if bw > 64 {
+		return nil, errEnc("bit width")
+	}
+	if (n*bw+7)/8 > len(b) {
+		return nil, errEnc("bit-packed run")
+	}
+	var (
+		mask = uint64(1)<<bw - 1
+		bit  int
+	)
+	if bw == 64 {
+		mask = ^uint64(0)
+	}
+	for range n {
+		var v uint64
+		for got := 0; got < bw; {
+			var (
+				i     = bit >> 3
+				shift = bit & 7
+				take  = min(8-shift, bw-got)
+			)
+			v |= uint64(b[i]>>shift) & (1<<take - 1) << got
+			got += take
+			bit += take
+		}
+		dst = append(dst, v&mask)
+	}
+	return dst, nil
+}
+
+// RLE/bit-packed hybrid: decode n values
+func rleDecode(b []byte, bw, n int, dst []uint64) ([]uint64, error) {
+	var (
+		vbytes = (bw + 7) / 8
+		off    int
+	)
+	for len(dst) < n {
+		hdr, k := binary.Uvarint(b[off:])
+		if k <= 0 {
+			return nil, errEnc("RLE header")
+		}
+		off += k
+		if hdr&1 == 0 { // RLE run
+			cnt := int(hdr >> 1)
+			if off+vbytes > len(b) {
+				return nil, errEnc("RLE run")
+			}
+			var v uint64
+			for i := range vbytes {
+				v |= uint64(b[off+i]) << (8 * i)
+			}
+			off += vbytes
+			for i := 0; i < cnt && len(dst) < n; i++ {
+				dst = append(dst, v)
+			}
+			continue
+		}
+		// bit-packed groups of 8
+		var (
+			cnt    = int(hdr>>1) * 8
+			nbytes = cnt * bw / 8
+			l      = len(dst)
+			err    error
+		)
+		if off+nbytes > len(b) {
+			nbytes = len(b) - off // (tolerate truncated last run)
+			cnt = nbytes * 8 / max(bw, 1)
+		}
+		if bw == 0 {
+			for i := 0; i < cnt && len(dst) < n; i++ {
+				dst = append(dst, 0)
+			}
+			continue
+		}
+		if dst, err = unpack(b[off:off+nbytes], bw, cnt, dst); err != nil {
+			return nil, err
+		}
+		off += nbytes
+		if len(dst) > n {
+			dst = dst[:n]
+		} else if len(dst) == l {
+			return nil, errEnc("bit-packed run")
+		}
+	}
+	return dst, nil
+}
+
+// RLE runs only (writing definition levels)
+func rleEncode(b []byte, vals []uint64, bw int) []byte {
+	vbytes := (bw + 7) / 8
+	for i := 0; i < len(vals); {
+		j := i + 1
+		for j < len(vals) && vals[j] == vals[i] {
+			j++
+		}
+		b = binary.AppendUvarint(b, uint64(j-i)<<1)
+		for k := range vbytes {
+			b = append(b, byte(vals[i]>>(8*k)))
+		}
+		i = j
+	}
+	return b
+}
+
+// DELTA_BINARY_PACKED: returns decoded values and the number of consumed bytes
+func deltaDecode(b []byte) ([]int64, int, error) {
+	var (
+		off    int
+		hdr    [3]uint64 // block size, miniblocks per block, total count
+		first  int64
+		errHdr = errEnc("DELTA_BINARY_PACKED header")
+	)
+	for i := range hdr {
+		v, k := binary.Uvarint(b[off:])
+		if k <= 0 {
+			return nil, 0, errHdr
+		}
+		hdr[i], off = v, off+k
+	}
+	first, k := binary.Varint(b[off:])
+	if k <= 0 {
+		return nil, 0, errHdr
+	}
+	off += k
+	var (
+		blockSize = int(hdr[0])
+		nmini     = int(hdr[1])
+		total     = int(hdr[2])
+	)
+	if nmini == 0 || blockSize%nmini != 0 || (blockSize/nmini)%8 != 0 || total > len(b)*64+1 {
+		return nil, 0, errHdr
+	}
+	var (
+		miniSize = blockSize / nmini
+		vals     = make([]int64, 0, total)
+		deltas   = make([]uint64, 0, miniSize)
+		prev     = first
+	)
+	if total > 0 {
+		vals = append(vals, first)
+	}
+	for len(vals) < total {
+		minDelta, k := binary.Varint(b[off:])
+		if k <= 0 || off+k+nmini > len(b) {
+			return nil, 0, errEnc("DELTA_BINARY_PACKED block")
+		}
+		off += k
+		widths := b[off : off+nmini]
+		off += nmini
+		for _, bw := range widths {
+			if len(vals) >= total {
+				break
+			}
+			nbytes := miniSize * int(bw) / 8
+			if off+nbytes > len(b) {
+				return nil, 0, errEnc("DELTA_BINARY_PACKED miniblock")
+			}
+			var err error
+			if deltas, err = unpack(b[off:off+nbytes], int(bw), miniSize, deltas[:0]); err != nil {
+				return nil, 0, err
+			}
+			off += nbytes
+			for _, d := range deltas {
+				if len(vals) >= total {
+					break
+				}
+				prev += minDelta + int64(d)
+				vals = append(vals, prev)
+			}
+		}
+	}
+	return vals, off, nil
+}
+
+// DELTA_LENGTH_BYTE_ARRAY
+func deltaLenDecode(b []byte) ([]string, int, error) {
+	lens, off, err := deltaDecode(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	vals := make([]string, len(lens))
+	for i, l := range lens {
+		if l < 0 || off+int(l) > len(b) {
+			return nil, 0, errEnc("DELTA_LENGTH_BYTE_ARRAY")
+		}
+		vals[i] = string(b[off : off+int(l)])
+		off += int(l)
+	}
+	return vals, off, nil
+}
+
+// DELTA_BYTE_ARRAY (incremental, aka front compression)
+func deltaStrDecode(b []byte) ([]string, error) {
+	prefixes, off, err := deltaDecode(b)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, _, err := deltaLenDecode(b[off:])
+	if err != nil {
+		return nil, err
+	}
+	if len(suffixes) != len(prefixes) {
+		return nil, errEnc("DELTA_BYTE_ARRAY")
+	}
+	var prev string
+	for i, p := range prefixes {
+		if p < 0 || int(p) > len(prev) {
+			return nil, errEnc("DELTA_BYTE_ARRAY prefix")
+		}
+		suffixes[i] = prev[:p] + suffixes[i]
+		prev = suffixes[i]
+	}
+	return suffixes, nil
+}
+
+func bitWidth(maxv int) int { return bits.Len(uint(maxv)) }
@@ -0,0 +1,261 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+// Parquet writer: flat schema of OPTIONAL columns (empty string => null),
+// BYTE_ARRAY (UTF8) and INT64 (plain or nanosecond timestamps) physical types,
+// PLAIN-encoded v1 data pages, SNAPPY compression
+
+const (
+	pqRowGroupRows = 256 * 1024
+	pqPageRows     = 16 * 1024
+	pqCreatedBy    = "aistore"
+)
+
+type (
+	pqChunkMeta struct {
+		offset int64
+		csize  int64
+		usize  int64
+	}
+	pqRowGroup struct {
+		chunks []pqChunkMeta
+		nrows  int64
+		size   int64
+	}
+	pqWriter struct {
+		w     io.Writer
+		cols  []Column
+		buf   [][]string // buffered (current row group) values, per column
+		rgs   []pqRowGroup
+		page  []byte
+		zpage []byte
+		off   int64
+		nrows int64
+	}
+)
+
+func newPqWriter(w io.Writer, cols []Column) *pqWriter {
+	return &pqWriter{w: w, cols: cols, buf: make([][]string, len(cols))}
+}
+
+func (pw *pqWriter) Write(vals []string) error {
+	if len(vals) != len(pw.cols) {
+		return fmt.Errorf("tabular: expecting %d values, got %d", len(pw.cols), len(vals))
+	}
+	for i, v := range vals {
+		if v != "" && pw.cols[i].Type != ColString {
+			if _, err := pw.int64(i, v); err != nil {
+				return err
+			}
+		}
+		pw.buf[i] = append(pw.buf[i], v)
+	}
+	if len(pw.buf[0]) >= pqRowGroupRows {
+		return pw.flush()
+	}
+	return nil
+}
+
+func (pw *pqWriter) Close() error {
+	if err := pw.flush(); err != nil {
+		return err
+	}
+	if pw.off == 0 {
+		if err := pw.write([]byte(pqMagic)); err != nil {
+			return err
+		}
+	}
+	e := &tenc{}
+	pw.footer(e)
+	b := binary.LittleEndian.AppendUint32(e.b, uint32(len(e.b)))
+	b = append(b, pqMagic...)
+	return pw.write(b)
+}
+
+func (pw *pqWriter) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.off += int64(n)
+	return err
+}
+
+func (pw *pqWriter) int64(i int, v string) (int64, error) {
+	if pw.cols[i].Type == ColTime {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return 0, fmt.Errorf("tabular: column %q: invalid timestamp %q", pw.cols[i].Name, v)
+		}
+		return t.UnixNano(), nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("tabular: column %q: invalid integer %q", pw.cols[i].Name, v)
+	}
+	return n, nil
+}
+
+// write out buffered rows as a row group
+func (pw *pqWriter) flush() error {
+	nrows := len(pw.buf[0])
+	if nrows == 0 {
+		return nil
+	}
+	if pw.off == 0 {
+		if err := pw.write([]byte(pqMagic)); err != nil {
+			return err
+		}
+	}
+	rg := pqRowGroup{chunks: make([]pqChunkMeta, len(pw.cols)), nrows: int64(nrows)}
+	for i := range pw.cols {
+		cm := &rg.chunks[i]
+		cm.offset = pw.off
+		for j := 0; j < nrows; j += pqPageRows {
+			usize, err := pw.writePage(i, pw.buf[i][j:min(j+pqPageRows, nrows)])
+			if err != nil {
+				return err
+			}
+			cm.usize += usize
+		}
+		cm.csize = pw.off - cm.offset
+		rg.size += cm.usize
+		clear(pw.buf[i])
+		pw.buf[i] = pw.buf[i][:0]
+	}
+	pw.rgs = append(pw.rgs, rg)
+	pw.nrows += int64(nrows)
+	return nil
+}
+
+// returns uncompressed size (including page header)
+func (pw *pqWriter) writePage(i int, vals []string) (int64, error) {
+	// definition levels (with 4-byte length prefix), followed by PLAIN values
+	var (
+		b    = append(pw.page[:0], 0, 0, 0, 0)
+		lvls = make([]uint64, len(vals))
+	)
+	for j, v := range vals {
+		if v != "" {
+			lvls[j] = 1
+		}
+	}
+	b = rleEncode(b, lvls, 1)
+	binary.LittleEndian.PutUint32(b, uint32(len(b)-4))
+	for _, v := range vals {
+		if v == "" {
+			continue
+		}
+		if pw.cols[i].Type == ColString {
+			b = binary.LittleEndian.AppendUint32(b, uint32(len(v)))
+			b = append(b, v...)
+		} else {
+			n, _ := pw.int64(i, v) // (validated)
+			b = binary.LittleEndian.AppendUint64(b, uint64(n))
+		}
+	}
+	pw.page = b
+	pw.zpage = snappy.Encode(pw.zpage[:cap(pw.zpage)], b)
+
+	e := &tenc{}
+	e.begin(0)
+	e.i32(1, pqDataPage)
+	e.i32(2, int32(len(b)))
+	e.i32(3, int32(len(pw.zpage)))
+	e.begin(5)
+	e.i32(1, int32(len(vals)))
+	e.i32(2, pqPlain)
+	e.i32(3, pqRLE)
+	e.i32(4, pqRLE)
+	e.end()
+	e.end()
+	if err := pw.write(e.b); err != nil {
+		return 0, err
+	}
+	return int64(len(e.b) + len(b)), pw.write(pw.zpage)
+}
+
+func (pw *pqWriter) footer(e *tenc) {
+	e.begin(0)
+	e.i32(1, 1) // version
+	// schema
+	e.list(2, tStruct, len(pw.cols)+1)
+	e.begin(0)
+	e.str(4, "schema")
+	e.i32(5, int32(len(pw.cols)))
+	e.end()
+	for _, col := range pw.cols {
+		e.begin(0)
+		switch col.Type {
+		case ColString:
+			e.i32(1, pqByteArray)
+			e.i32(3, pqOptional)
+			e.str(4, col.Name)
+			e.i32(6, pqConvUTF8)
+		case ColInt64:
+			e.i32(1, pqInt64)
+			e.i32(3, pqOptional)
+			e.str(4, col.Name)
+		case ColTime:
+			e.i32(1, pqInt64)
+			e.i32(3, pqOptional)
+			e.str(4, col.Name)
+			e.begin(10) // LogicalType
+			e.begin(pqLogicalTsType)
+			e.bool(1, true) // isAdjustedToUTC
+			e.begin(2)      // unit
+			e.begin(3)      // NANOS
+			e.end()
+			e.end()
+			e.end()
+			e.end()
+		}
+		e.end()
+	}
+	e.i64(3, pw.nrows)
+	// row groups
+	e.list(4, tStruct, len(pw.rgs))
+	for _, rg := range pw.rgs {
+		e.begin(0)
+		e.list(1, tStruct, len(rg.chunks))
+		for i, cm := range rg.chunks {
+			e.begin(0)
+			e.i64(2, cm.offset)
+			e.begin(3) // ColumnMetaData
+			if pw.cols[i].Type == ColString {
+				e.i32(1, pqByteArray)
+			} else {
+				e.i32(1, pqInt64)
+			}
+			e.list(2, tI32, 2)
+			e.varint(pqPlain)
+			e.varint(pqRLE)
+			e.list(3, tBinary, 1)
+			e.uvarint(uint64(len(pw.cols[i].Name)))
+			e.b = append(e.b, pw.cols[i].Name...)
+			e.i32(4, pqSnappy)
+			e.i64(5, rg.nrows)
+			e.i64(6, cm.usize)
+			e.i64(7, cm.csize)
+			e.i64(9, cm.offset)
+			e.end()
+			e.end()
+		}
+		e.i64(2, rg.size)
+		e.i64(3, rg.nrows)
+		e.end()
+	}
+	e.str(6, pqCreatedBy)
+	e.end()
+}
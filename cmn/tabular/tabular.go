@@ -0,0 +1,158 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Reading:
+// - columns are identified by name: CSV header, top-level (or dot-separated) JSON key,
+//   or Parquet leaf column (flat schema);
+// - in addition, CSV columns can be identified by (0-based) index, in which case the
+//   CSV is expected to have no header;
+// - all values are returned as strings: integers in decimal, timestamps in RFC 3339
+//   (nanoseconds), and null (missing) values as empty strings;
+// - Pos() is an opaque position of the next row (byte offset for CSV and JSONL,
+//   row index for Parquet) that can be later used to resume reading.
+
+const (
+	CSV     = "csv"
+	JSONL   = "jsonl"
+	Parquet = "parquet"
+)
+
+// column types (writing)
+const (
+	ColString = iota
+	ColInt64
+	ColTime // RFC 3339 string (Parquet: INT64 nanoseconds timestamp)
+)
+
+var Formats = []string{CSV, JSONL, Parquet}
+
+var ErrBadFormat = errors.New("tabular: invalid format")
+
+type (
+	Reader interface {
+		// returns the next row (values of the requested columns, in order) or io.EOF;
+		// the returned slice is reused by subsequent calls
+		Next() ([]string, error)
+		// position of the next row
+		Pos() int64
+	}
+	Writer interface {
+		Write(vals []string) error
+		// flushes buffered rows and writes trailing metadata, if any
+		// (does not close the underlying writer)
+		Close() error
+	}
+	Column struct {
+		Name string
+		Type int // enum { ColString, ... }
+	}
+)
+
+// FormatByExt returns tabular format given (manifest) object name, e.g.: "a/b/manifest.csv.gz";
+// the second returned value is true when the content is gzip-compressed
+func FormatByExt(name string) (format string, gz bool) {
+	lname := strings.ToLower(name)
+	if strings.HasSuffix(lname, ".gz") {
+		gz = true
+		lname = strings.TrimSuffix(lname, ".gz")
+	}
+	switch {
+	case strings.HasSuffix(lname, ".csv"):
+		format = CSV
+	case strings.HasSuffix(lname, ".jsonl"), strings.HasSuffix(lname, ".ndjson"):
+		format = JSONL
+	case strings.HasSuffix(lname, ".parquet"), strings.HasSuffix(lname, ".pq"):
+		format = Parquet
+	}
+	return format, gz && format != Parquet
+}
+
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid tabular format %q (expecting one of %v)", format, Formats)
+}
+
+// ValidateColumns checks the requested (reading) columns; for CSV, returns true
+// when the columns are indices (and there's no header)
+func ValidateColumns(format string, cols []string) (noHeader bool, err error) {
+	if len(cols) == 0 || cols[0] == "" {
+		return false, errors.New("tabular: missing (first) column")
+	}
+	var nidx int
+	for _, col := range cols {
+		if col == "" {
+			continue // not requested
+		}
+		if _, err := strconv.ParseUint(col, 10, 16); err == nil {
+			nidx++
+		}
+	}
+	if format != CSV || nidx == 0 {
+		return false, nil
+	}
+	for _, col := range cols {
+		if col == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(col, 10, 16); err != nil {
+			return false, fmt.Errorf("tabular: CSV columns must be either all indices or all names, got %q", cols)
+		}
+	}
+	return true, nil
+}
+
+// NewReader returns a reader that starts at the given position (zero to read from the beginning);
+// columns that are empty strings are not read (their values are always empty)
+func NewReader(ra io.ReaderAt, size int64, format string, cols []string, pos int64) (Reader, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	noHeader, err := ValidateColumns(format, cols)
+	if err != nil {
+		return nil, err
+	}
+	if pos < 0 || (pos > size && format != Parquet) {
+		return nil, fmt.Errorf("tabular: invalid position %d (size %d)", pos, size)
+	}
+	switch format {
+	case CSV:
+		return newCSVReader(ra, size, cols, noHeader, pos)
+	case JSONL:
+		return newJSONLReader(ra, size, cols, pos), nil
+	default:
+		return newPqReader(ra, size, cols, pos)
+	}
+}
+
+func NewWriter(w io.Writer, format string, cols []Column) (Writer, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, errors.New("tabular: no columns to write")
+	}
+	switch format {
+	case CSV:
+		return newCSVWriter(w, cols), nil
+	case JSONL:
+		return newJSONLWriter(w, cols), nil
+	default:
+		return newPqWriter(w, cols), nil
+	}
+}
@@ -0,0 +1,176 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/tabular"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var tabCols = []tabular.Column{
+	{Name: "name", Type: tabular.ColString},
+	{Name: "size", Type: tabular.ColInt64},
+	{Name: "etag", Type: tabular.ColString},
+	{Name: "mtime", Type: tabular.ColTime},
+}
+
+func tabRows(num int) [][]string {
+	var (
+		rows = make([][]string, num)
+		t0   = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	)
+	for i := range rows {
+		etag := fmt.Sprintf("\"%x\"", i*7919)
+		if i%5 == 0 {
+			etag = "" // null
+		}
+		rows[i] = []string{
+			fmt.Sprintf("dir-%d/obj,%06d", i%4, i), // (comma to be quoted)
+			strconv.Itoa(i * 1000),
+			etag,
+			t0.Add(time.Duration(i) * time.Millisecond).Format(time.RFC3339Nano),
+		}
+	}
+	return rows
+}
+
+func TestTabularRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		num    int
+	}{
+		{tabular.CSV, 1000},
+		{tabular.JSONL, 1000},
+		{tabular.Parquet, 1000},
+		{tabular.Parquet, 300_000}, // (multiple row groups)
+		{tabular.Parquet, 0},
+	} {
+		t.Run(fmt.Sprintf("%s-%d", test.format, test.num), func(t *testing.T) {
+			rows := tabRows(test.num)
+			b := writeTab(t, test.format, rows)
+
+			// all columns; reordered; not requested
+			cols := []string{"name", "size", "etag", "mtime"}
+			tassert.Fatalf(t, reflect.DeepEqual(readTab(t, b, test.format, cols, 0, -1), rows), "rows mismatch")
+			got := readTab(t, b, test.format, []string{"size", "", "name"}, 0, -1)
+			for i, row := range got {
+				tassert.Fatalf(t, row[0] == rows[i][1] && row[1] == "" && row[2] == rows[i][0], "row %d mismatch: %q", i, row)
+			}
+
+			// resume at positions
+			if test.num == 0 {
+				return
+			}
+			var (
+				pos   int64
+				steps = []int{1, test.num / 3, 7, test.num}
+				i     int
+			)
+			for _, n := range steps {
+				var chunk [][]string
+				chunk, pos = readPos(t, b, test.format, cols, pos, n)
+				for _, row := range chunk {
+					tassert.Fatalf(t, reflect.DeepEqual(row, rows[i]), "resume: row %d mismatch: %q", i, row)
+					i++
+				}
+			}
+			tassert.Errorf(t, i == test.num, "resume: expected %d rows, got %d", test.num, i)
+		})
+	}
+}
+
+func TestTabularCSVNoHeader(t *testing.T) {
+	// (S3 inventory: bucket, key, size, etag)
+	data := "\"bck\",\"a/b.txt\",\"10\",\"e1\"\n\"bck\",\"c d\",\"20\",\"e2\"\n"
+	r, err := tabular.NewReader(bytes.NewReader([]byte(data)), int64(len(data)), tabular.CSV, []string{"1", "2", "", "3"}, 0)
+	tassert.CheckFatal(t, err)
+	row, err := r.Next()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, reflect.DeepEqual(row, []string{"a/b.txt", "10", "", "e1"}), "unexpected %q", row)
+	pos := r.Pos()
+
+	r, err = tabular.NewReader(bytes.NewReader([]byte(data)), int64(len(data)), tabular.CSV, []string{"1", "2", "", "3"}, pos)
+	tassert.CheckFatal(t, err)
+	row, err = r.Next()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, reflect.DeepEqual(row, []string{"c d", "20", "", "e2"}), "unexpected %q", row)
+	_, err = r.Next()
+	tassert.Errorf(t, err == io.EOF, "expected EOF, got %v", err)
+
+	_, err = tabular.ValidateColumns(tabular.CSV, []string{"1", "size"})
+	tassert.Errorf(t, err != nil, "expected mixed CSV columns to fail")
+	_, err = tabular.NewReader(bytes.NewReader([]byte(data)), int64(len(data)), tabular.CSV, []string{"key"}, 0)
+	tassert.Errorf(t, err != nil, "expected missing header column to fail")
+}
+
+func TestTabularJSONLNested(t *testing.T) {
+	data := `{"key":"a","size":1,"meta":{"etag":"x"}}` + "\n\n" + `{"key":"b","size":2.5e1,"meta":{}}`
+	r, err := tabular.NewReader(bytes.NewReader([]byte(data)), int64(len(data)), tabular.JSONL, []string{"key", "size", "meta.etag"}, 0)
+	tassert.CheckFatal(t, err)
+	var got [][]string
+	for {
+		row, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		tassert.CheckFatal(t, err)
+		got = append(got, append([]string{}, row...))
+	}
+	tassert.Errorf(t, reflect.DeepEqual(got, [][]string{{"a", "1", "x"}, {"b", "2.5e1", ""}}), "unexpected %q", got)
+	tassert.Errorf(t, r.Pos() == int64(len(data)), "expected position %d, got %d", len(data), r.Pos())
+}
+
+func TestTabularFormatByExt(t *testing.T) {
+	for name, exp := range map[string]string{
+		"inv/manifest.csv.gz": tabular.CSV, "a.CSV": tabular.CSV, "b.jsonl": tabular.JSONL,
+		"c.ndjson.gz": tabular.JSONL, "d.parquet": tabular.Parquet, "e.txt": "",
+	} {
+		format, _ := tabular.FormatByExt(name)
+		tassert.Errorf(t, format == exp, "%s: expected %q, got %q", name, exp, format)
+	}
+	_, gz := tabular.FormatByExt("x.csv.gz")
+	tassert.Errorf(t, gz, "expected gzip")
+}
+
+func writeTab(t *testing.T, format string, rows [][]string) []byte {
+	var buf bytes.Buffer
+	w, err := tabular.NewWriter(&buf, format, tabCols)
+	tassert.CheckFatal(t, err)
+	for _, row := range rows {
+		tassert.CheckFatal(t, w.Write(row))
+	}
+	tassert.CheckFatal(t, w.Close())
+	return buf.Bytes()
+}
+
+func readTab(t *testing.T, b []byte, format string, cols []string, pos int64, n int) [][]string {
+	rows, _ := readPos(t, b, format, cols, pos, n)
+	return rows
+}
+
+// read up to n rows (all when n < 0) starting at pos; return the next position
+func readPos(t *testing.T, b []byte, format string, cols []string, pos int64, n int) ([][]string, int64) {
+	r, err := tabular.NewReader(bytes.NewReader(b), int64(len(b)), format, cols, pos)
+	tassert.CheckFatal(t, err)
+	rows := make([][]string, 0)
+	for n < 0 || len(rows) < n {
+		row, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		tassert.CheckFatal(t, err)
+		rows = append(rows, append([]string{}, row...))
+	}
+	return rows, r.Pos()
+}
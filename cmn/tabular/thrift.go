@@ -0,0 +1,311 @@
+// Package tabular reads and writes row-oriented listings (manifests) in CSV, JSONL,
+// and Parquet formats - one object per row, selected columns only.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tabular
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Thrift compact protocol - the subset that Parquet metadata (footer and page headers) requires:
+// generic decoding into field-id => value maps, and field-by-field encoding
+
+const (
+	tStop   = 0
+	tTrue   = 1
+	tFalse  = 2
+	tByte   = 3
+	tI16    = 4
+	tI32    = 5
+	tI64    = 6
+	tDouble = 7
+	tBinary = 8
+	tList   = 9
+	tSet    = 10
+	tMap    = 11
+	tStruct = 12
+)
+
+const tMaxDepth = 32
+
+var errShort = errors.New("tabular: thrift: short buffer")
+
+type (
+	// decoded struct: field id => value, where value is one of:
+	// bool, int64 (all integers), float64, []byte, []any (list or set), tstruct, or nil (map)
+	tstruct map[int16]any
+
+	tdec struct {
+		b     []byte
+		off   int
+		depth int
+	}
+	tenc struct {
+		b    []byte
+		fids []int16 // last field IDs (stack)
+		last int16
+	}
+)
+
+//
+// decoding
+//
+
+func (d *tdec) byte1() (byte, error) {
+	if d.off >= len(d.b) {
+		return 0, errShort
+	}
+	c := d.b[d.off]
+	d.off++
+	return c, nil
+}
+
+func (d *tdec) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.b[d.off:])
+	if n <= 0 {
+		if n == 0 {
+			return 0, errShort
+		}
+		return 0, fmt.Errorf("tabular: thrift: varint overflow: %w", ErrBadFormat)
+	}
+	d.off += n
+	return v, nil
+}
+
+func (d *tdec) varint() (int64, error) {
+	u, err := d.uvarint()
+	return int64(u>>1) ^ -int64(u&1), err
+}
+
+func (d *tdec) binary() ([]byte, error) {
+	l, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if l > uint64(len(d.b)-d.off) {
+		return nil, errShort
+	}
+	b := d.b[d.off : d.off+int(l)]
+	d.off += int(l)
+	return b, nil
+}
+
+func (d *tdec) value(typ byte) (any, error) {
+	switch typ {
+	case tTrue:
+		return true, nil
+	case tFalse:
+		return false, nil
+	case tByte:
+		c, err := d.byte1()
+		return int64(int8(c)), err
+	case tI16, tI32, tI64:
+		return d.varint()
+	case tDouble:
+		if d.off+8 > len(d.b) {
+			return nil, errShort
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.b[d.off:]))
+		d.off += 8
+		return v, nil
+	case tBinary:
+		return d.binary()
+	case tList, tSet:
+		return d.list()
+	case tMap:
+		return nil, d.skipMap()
+	case tStruct:
+		return d.structure()
+	default:
+		return nil, fmt.Errorf("tabular: thrift: unknown type %d: %w", typ, ErrBadFormat)
+	}
+}
+
+func (d *tdec) structure() (tstruct, error) {
+	if d.depth++; d.depth > tMaxDepth {
+		return nil, fmt.Errorf("tabular: thrift: max depth exceeded: %w", ErrBadFormat)
+	}
+	var (
+		s    = make(tstruct, 8)
+		last int16
+	)
+	for {
+		c, err := d.byte1()
+		if err != nil {
+			return nil, err
+		}
+		typ := c & 0x0f
+		if typ == tStop {
+			d.depth--
+			return s, nil
+		}
+		fid := last + int16(c>>4)
+		if c>>4 == 0 {
+			v, err := d.varint()
+			if err != nil {
+				return nil, err
+			}
+			fid = int16(v)
+		}
+		last = fid
+		if s[fid], err = d.value(typ); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *tdec) list() ([]any, error) {
+	c, err := d.byte1()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		n   = uint64(c >> 4)
+		typ = c & 0x0f
+	)
+	if n == 15 {
+		if n, err = d.uvarint(); err != nil {
+			return nil, err
+		}
+	}
+	if n > uint64(len(d.b)-d.off) { // (at least one byte per element)
+		return nil, errShort
+	}
+	l := make([]any, n)
+	for i := range l {
+		if typ == tTrue || typ == tFalse { // (bool elements: one byte each)
+			c, err := d.byte1()
+			if err != nil {
+				return nil, err
+			}
+			l[i] = c == tTrue
+			continue
+		}
+		if l[i], err = d.value(typ); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func (d *tdec) skipMap() error {
+	n, err := d.uvarint()
+	if err != nil || n == 0 {
+		return err
+	}
+	c, err := d.byte1()
+	if err != nil {
+		return err
+	}
+	for range n {
+		if _, err := d.value(c >> 4); err != nil {
+			return err
+		}
+		if _, err := d.value(c & 0x0f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tstruct accessors
+
+func (s tstruct) i64(fid int16) int64 {
+	v, _ := s[fid].(int64)
+	return v
+}
+
+func (s tstruct) has(fid int16) bool {
+	_, ok := s[fid]
+	return ok
+}
+
+func (s tstruct) str(fid int16) string {
+	v, _ := s[fid].([]byte)
+	return string(v)
+}
+
+func (s tstruct) st(fid int16) tstruct {
+	v, _ := s[fid].(tstruct)
+	return v
+}
+
+func (s tstruct) list(fid int16) []any {
+	v, _ := s[fid].([]any)
+	return v
+}
+
+func (s tstruct) bool(fid int16, dflt bool) bool {
+	if v, ok := s[fid].(bool); ok {
+		return v
+	}
+	return dflt
+}
+
+//
+// encoding
+//
+
+func (e *tenc) uvarint(v uint64) { e.b = binary.AppendUvarint(e.b, v) }
+func (e *tenc) varint(v int64)   { e.uvarint(uint64(v<<1) ^ uint64(v>>63)) }
+
+func (e *tenc) field(fid int16, typ byte) {
+	if delta := fid - e.last; delta > 0 && delta <= 15 {
+		e.b = append(e.b, byte(delta)<<4|typ)
+	} else {
+		e.b = append(e.b, typ)
+		e.varint(int64(fid))
+	}
+	e.last = fid
+}
+
+func (e *tenc) i32(fid int16, v int32) { e.field(fid, tI32); e.varint(int64(v)) }
+func (e *tenc) i64(fid int16, v int64) { e.field(fid, tI64); e.varint(v) }
+
+func (e *tenc) bool(fid int16, v bool) {
+	if v {
+		e.field(fid, tTrue)
+	} else {
+		e.field(fid, tFalse)
+	}
+}
+
+func (e *tenc) str(fid int16, v string) {
+	e.field(fid, tBinary)
+	e.uvarint(uint64(len(v)))
+	e.b = append(e.b, v...)
+}
+
+func (e *tenc) list(fid int16, typ byte, n int) {
+	e.field(fid, tList)
+	e.listHdr(typ, n)
+}
+
+func (e *tenc) listHdr(typ byte, n int) {
+	if n < 15 {
+		e.b = append(e.b, byte(n)<<4|typ)
+	} else {
+		e.b = append(e.b, 0xf0|typ)
+		e.uvarint(uint64(n))
+	}
+}
+
+// struct-typed field (fid > 0) or list element (fid == 0)
+func (e *tenc) begin(fid int16) {
+	if fid > 0 {
+		e.field(fid, tStruct)
+	}
+	e.fids = append(e.fids, e.last)
+	e.last = 0
+}
+
+func (e *tenc) end() {
+	e.b = append(e.b, tStop)
+	e.last = e.fids[len(e.fids)-1]
+	e.fids = e.fids[:len(e.fids)-1]
+}
@@ -5,13 +5,51 @@
 package tests_test
 
 import (
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
+	"github.com/NVIDIA/aistore/cmn/tabular"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
+var remaisBck = cmn.Bck{Name: "dst", Provider: apc.AIS, Ns: cmn.Ns{UUID: "remais"}}
+
+func testKeys(ids ...uint32) []cmn.DataKey {
+	keys := make([]cmn.DataKey, len(ids))
+	for i, id := range ids {
+		keys[i] = cmn.DataKey{ID: id, Wrapped: []byte{byte(id)}, MasterID: "test"}
+	}
+	return keys
+}
+
+func validateCacheFSP(c cmn.CacheFSPConf) func() error {
+	return func() error { return c.Validate(&cmn.Config{}) }
+}
+
+func validateBck(bck cmn.Bck) func() error { return bck.Validate }
+
+func validateExportList(msg cmn.ExportListMsg) func() error {
+	return func() error { return msg.Validate(&cmn.Bck{Name: "src", Provider: apc.AIS}) }
+}
+
+// (ais:// bucket with checksumming, unless specified otherwise)
+func validateProps(props cmn.Bprops) func() error {
+	if props.Provider == "" {
+		props.Provider = apc.AIS
+	}
+	if props.Cksum.Type == "" {
+		props.Cksum.Type = cos.ChecksumXXHash
+	}
+	return func() error { return props.Validate(1) }
+}
+
 var _ = Describe("API", func() {
 	Describe("Apply", func() {
 		DescribeTable("should successfully apply all the props",
@@ -121,6 +159,44 @@ var _ = Describe("API", func() {
 					Access: 10,
 				},
 			),
+			Entry("tier",
+				cmn.Bprops{
+					Provider: apc.AIS,
+				},
+				cmn.BpropsToSet{
+					Tier: &cmn.TierConfToSet{
+						Bck:     &cmn.BackendBckToSet{Name: apc.Ptr("cold"), Provider: apc.Ptr(apc.AWS)},
+						Age:     apc.Ptr(cos.Duration(72 * time.Hour)),
+						Enabled: apc.Ptr(true),
+					},
+				},
+				cmn.Bprops{
+					Provider: apc.AIS,
+					Tier: cmn.TierConf{
+						Bck:     cmn.Bck{Name: "cold", Provider: apc.AWS},
+						Age:     cos.Duration(72 * time.Hour),
+						Enabled: true,
+					},
+				},
+			),
+			Entry("replication",
+				cmn.Bprops{
+					Provider: apc.AIS,
+				},
+				cmn.BpropsToSet{
+					Replication: &cmn.ReplConfToSet{
+						Bck:     &cmn.ReplBckToSet{Name: apc.Ptr("dst"), Provider: apc.Ptr(apc.AIS), Ns: &cmn.NsToSet{UUID: apc.Ptr("remais")}},
+						Enabled: apc.Ptr(true),
+					},
+				},
+				cmn.Bprops{
+					Provider: apc.AIS,
+					Replication: cmn.ReplConf{
+						Bck:     remaisBck,
+						Enabled: true,
+					},
+				},
+			),
 			Entry("all fields",
 				cmn.Bprops{},
 				cmn.BpropsToSet{
@@ -185,4 +261,221 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("EncryptConf", func() {
+		AfterEach(func() { crypt.SetProvider(nil) })
+
+		It("should fail to generate data key without master key provider", func() {
+			conf := cmn.EncryptConf{Enabled: true}
+			crypt.SetProvider(nil)
+			Expect(conf.NewKey()).To(Equal(crypt.ErrNoProvider))
+			Expect(conf.Keys).To(BeEmpty())
+		})
+
+		It("should generate (rotate) data keys", func() {
+			dir, err := os.MkdirTemp("", "master-key")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			fqn := filepath.Join(dir, "master.key")
+			Expect(os.WriteFile(fqn, []byte(cos.CryptoRandS(crypt.KeySize)), 0o600)).NotTo(HaveOccurred())
+			p, err := crypt.NewFileProvider(fqn)
+			Expect(err).NotTo(HaveOccurred())
+			crypt.SetProvider(p)
+
+			conf := cmn.EncryptConf{Enabled: true}
+			for range 3 {
+				Expect(conf.NewKey()).NotTo(HaveOccurred())
+			}
+			Expect(conf.Keys).To(HaveLen(3))
+			Expect(conf.Current().ID).To(BeEquivalentTo(3))
+
+			props := cmn.Bprops{Provider: apc.AIS, Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, Encrypt: conf}
+			Expect(props.Validate(1)).NotTo(HaveOccurred())
+
+			// previous keys remain usable
+			dk := conf.Key(2)
+			Expect(dk).NotTo(BeNil())
+			dek, err := crypt.UnwrapDataKey(dk.Wrapped, dk.MasterID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dek).To(HaveLen(crypt.KeySize))
+		})
+	})
+
+	Describe("GetBatchIn", func() {
+		bck := &cmn.Bck{Name: "abc", Provider: apc.AIS}
+		DescribeTable("should name entries in the resulting archive",
+			func(in cmn.GetBatchIn, name string) {
+				Expect(in.NameInArch(bck)).To(Equal(name))
+			},
+			Entry("object", cmn.GetBatchIn{ObjName: "a/b"}, "a/b"),
+			Entry("object in the default bucket", cmn.GetBatchIn{Bck: *bck, ObjName: "a"}, "a"),
+			Entry("object in another bucket", cmn.GetBatchIn{Bck: cmn.Bck{Name: "xyz"}, ObjName: "a"}, "xyz/a"),
+			Entry("archived file", cmn.GetBatchIn{ObjName: "shard.tar", ArchPath: "x/y.jpg"}, "shard.tar/x/y.jpg"),
+			Entry("archived file in another bucket",
+				cmn.GetBatchIn{Bck: cmn.Bck{Name: "xyz"}, ObjName: "shard.tar", ArchPath: "y.jpg"}, "xyz/shard.tar/y.jpg"),
+		)
+	})
+
+	Describe("ExportListMsg", func() {
+		It("should select columns in the listing order", func() {
+			msg := &cmn.ExportListMsg{Props: "atime,size,location"}
+			Expect(msg.Columns()).To(Equal([]tabular.Column{
+				{Name: apc.GetPropsName, Type: tabular.ColString},
+				{Name: apc.GetPropsSize, Type: tabular.ColInt64},
+				{Name: apc.GetPropsAtime, Type: tabular.ColTime},
+				{Name: apc.GetPropsLocation, Type: tabular.ColString},
+			}))
+		})
+	})
+
+	Describe("Defaults", func() {
+		It("cache tier", func() {
+			conf := cmn.CacheFSPConf{Paths: []string{"/nvme0"}}
+			Expect(conf.Validate(&cmn.Config{})).NotTo(HaveOccurred())
+			Expect(conf.ObjSize()).To(BeEquivalentTo(cmn.DefaultCacheObjSize))
+			Expect(conf.Hits()).To(BeEquivalentTo(cmn.DefaultCachePromote))
+			Expect(conf.LowWatermark()).To(BeEquivalentTo(cmn.DefaultCacheLowWM))
+			Expect(conf.HighWatermark()).To(BeEquivalentTo(cmn.DefaultCacheHighWM))
+		})
+		It("packing", func() {
+			conf := cmn.PackConf{}
+			Expect(conf.ObjSize()).To(BeEquivalentTo(cmn.DefaultPackObjSize))
+			Expect(conf.PackSize()).To(BeEquivalentTo(cmn.DefaultPackSize))
+			Expect(conf.Compact()).To(BeEquivalentTo(cmn.DefaultPackCompact))
+		})
+		It("chunks", func() {
+			conf := cmn.ChunkConf{}
+			Expect(conf.Size()).To(BeEquivalentTo(cmn.DefaultChunkSize))
+			Expect(conf.ObjSize()).To(BeEquivalentTo(cmn.DefaultChunkObjSize))
+
+			// min. object size defaults to at least chunk size
+			conf.ChunkSize = 8 * cos.GiB
+			Expect(conf.ObjSize()).To(BeEquivalentTo(8 * cos.GiB))
+		})
+		It("export list", func() {
+			msg := &cmn.ExportListMsg{ToBck: cmn.Bck{Name: "dst", Provider: apc.AIS}}
+			Expect(msg.Validate(&cmn.Bck{Name: "src", Provider: apc.AIS})).NotTo(HaveOccurred())
+			Expect(msg.Format).To(Equal(tabular.Parquet))
+			Expect(msg.Props).To(Equal(apc.GetPropsNameSize))
+			Expect(msg.OutName("t1", 0)).To(Equal("src/t1-0.parquet"))
+		})
+	})
+
+	Describe("Validate", func() {
+		DescribeTable("should validate",
+			func(validate func() error, valid bool) {
+				if valid {
+					Expect(validate()).NotTo(HaveOccurred())
+				} else {
+					Expect(validate()).To(HaveOccurred())
+				}
+			},
+			Entry("snapshot bucket", validateBck(cmn.Bck{Name: "data@snap-2024.01_01", Provider: apc.AIS}), true),
+			Entry("snapshot bucket: no origin", validateBck(cmn.Bck{Name: "@snap", Provider: apc.AIS}), false),
+			Entry("snapshot bucket: no snapshot name", validateBck(cmn.Bck{Name: "data@", Provider: apc.AIS}), false),
+			Entry("snapshot bucket: nested", validateBck(cmn.Bck{Name: "data@snap@1", Provider: apc.AIS}), false),
+			Entry("snapshot bucket: invalid name", validateBck(cmn.Bck{Name: "data@sn ap", Provider: apc.AIS}), false),
+			Entry("snapshot bucket: remote", validateBck(cmn.Bck{Name: "data@snap", Provider: apc.AWS}), false),
+			Entry("tier", validateProps(cmn.Bprops{Tier: cmn.TierConf{Bck: cmn.Bck{Name: "cold", Provider: apc.AWS}, Enabled: true}}), true),
+			Entry("tier: disabled", validateProps(cmn.Bprops{Tier: cmn.TierConf{Age: -1}}), true),
+			Entry("tier: no tier bucket", validateProps(cmn.Bprops{Tier: cmn.TierConf{Enabled: true}}), false),
+			Entry("tier: ais:// tier bucket",
+				validateProps(cmn.Bprops{Tier: cmn.TierConf{Bck: cmn.Bck{Name: "cold", Provider: apc.AIS}, Enabled: true}}), false),
+			Entry("tier: remote bucket",
+				validateProps(cmn.Bprops{Provider: apc.GCP, Tier: cmn.TierConf{Bck: cmn.Bck{Name: "cold", Provider: apc.AWS}, Enabled: true}}), false),
+			Entry("tier: negative age",
+				validateProps(cmn.Bprops{Tier: cmn.TierConf{Bck: cmn.Bck{Name: "cold", Provider: apc.AWS}, Age: -1, Enabled: true}}), false),
+			Entry("replication", validateProps(cmn.Bprops{Replication: cmn.ReplConf{Bck: remaisBck, Enabled: true}}), true),
+			Entry("replication: no destination", validateProps(cmn.Bprops{Replication: cmn.ReplConf{Enabled: true}}), false),
+			Entry("replication: local destination",
+				validateProps(cmn.Bprops{Replication: cmn.ReplConf{Bck: cmn.Bck{Name: "dst", Provider: apc.AIS}, Enabled: true}}), false),
+			Entry("replication: remote bucket",
+				validateProps(cmn.Bprops{Provider: apc.AWS, Replication: cmn.ReplConf{Bck: remaisBck, Enabled: true}}), false),
+			Entry("replication and tier", validateProps(cmn.Bprops{
+				Replication: cmn.ReplConf{Bck: remaisBck, Enabled: true},
+				Tier:        cmn.TierConf{Bck: cmn.Bck{Name: "cold", Provider: apc.AWS}, Enabled: true},
+			}), false),
+			Entry("compression", validateProps(cmn.Bprops{Compress: cmn.CompressConf{Enabled: true}}), true),
+			Entry("compression: zstd",
+				validateProps(cmn.Bprops{Compress: cmn.CompressConf{Algo: "zstd", BlockSize: cos.MiB, Enabled: true}}), true),
+			Entry("compression: unknown algorithm", validateProps(cmn.Bprops{Compress: cmn.CompressConf{Algo: "gzip", Enabled: true}}), false),
+			Entry("compression: block too small", validateProps(cmn.Bprops{Compress: cmn.CompressConf{BlockSize: cos.KiB, Enabled: true}}), false),
+			Entry("compression: block too large", validateProps(cmn.Bprops{Compress: cmn.CompressConf{BlockSize: 64 * cos.MiB}}), false),
+			Entry("encryption", validateProps(cmn.Bprops{Encrypt: cmn.EncryptConf{Keys: testKeys(1, 2), Enabled: true}}), true),
+			Entry("encryption: no data key", validateProps(cmn.Bprops{Encrypt: cmn.EncryptConf{Enabled: true}}), false),
+			Entry("encryption: disabled with data keys", validateProps(cmn.Bprops{Encrypt: cmn.EncryptConf{Keys: testKeys(1)}}), true),
+			Entry("encryption: data key order", validateProps(cmn.Bprops{Encrypt: cmn.EncryptConf{Keys: testKeys(2, 1), Enabled: true}}), false),
+			Entry("encryption: invalid data key",
+				validateProps(cmn.Bprops{Encrypt: cmn.EncryptConf{Keys: []cmn.DataKey{{ID: 1}}, Enabled: true}}), false),
+			Entry("compose", (&cmn.ComposeMsg{Sources: []cmn.ComposeSrc{{ObjName: "a"}}}).Validate, true),
+			Entry("compose: ranges",
+				(&cmn.ComposeMsg{Sources: []cmn.ComposeSrc{{ObjName: "a"}, {ObjName: "b", Offset: 10, Length: 100}}}).Validate, true),
+			Entry("compose: no sources", (&cmn.ComposeMsg{}).Validate, false),
+			Entry("compose: no source name", (&cmn.ComposeMsg{Sources: []cmn.ComposeSrc{{ObjName: "a"}, {}}}).Validate, false),
+			Entry("compose: negative offset", (&cmn.ComposeMsg{Sources: []cmn.ComposeSrc{{ObjName: "a", Offset: -1}}}).Validate, false),
+			Entry("compose: negative length", (&cmn.ComposeMsg{Sources: []cmn.ComposeSrc{{ObjName: "a", Length: -1}}}).Validate, false),
+			Entry("rename prefix", (&cmn.RenamePrefixMsg{From: "tmp/run42/", To: "final/run42/"}).Validate, true),
+			Entry("rename prefix: sibling", (&cmn.RenamePrefixMsg{From: "tmp/run42/", To: "tmp/run43/"}).Validate, true),
+			Entry("rename prefix: no source", (&cmn.RenamePrefixMsg{To: "final/"}).Validate, false),
+			Entry("rename prefix: no destination", (&cmn.RenamePrefixMsg{From: "tmp/"}).Validate, false),
+			Entry("rename prefix: same", (&cmn.RenamePrefixMsg{From: "tmp/", To: "tmp/"}).Validate, false),
+			Entry("rename prefix: nested destination", (&cmn.RenamePrefixMsg{From: "tmp/", To: "tmp/run42/"}).Validate, false),
+			Entry("rename prefix: nested source", (&cmn.RenamePrefixMsg{From: "tmp/run42/", To: "tmp/"}).Validate, false),
+			Entry("rename prefix: invalid destination", (&cmn.RenamePrefixMsg{From: "tmp/", To: "../final/"}).Validate, false),
+			Entry("packing", validateProps(cmn.Bprops{Pack: cmn.PackConf{Enabled: true}}), true),
+			Entry("packing: sizes", validateProps(cmn.Bprops{
+				Pack: cmn.PackConf{MaxObjSize: 64 * cos.KiB, MaxPackSize: 16 * cos.MiB, CompactPct: 30, Enabled: true},
+			}), true),
+			Entry("packing: object too large", validateProps(cmn.Bprops{Pack: cmn.PackConf{MaxObjSize: 2 * cos.MiB, Enabled: true}}), false),
+			Entry("packing: pack too small", validateProps(cmn.Bprops{Pack: cmn.PackConf{MaxPackSize: cos.KiB}}), false),
+			Entry("packing: compaction percentage", validateProps(cmn.Bprops{Pack: cmn.PackConf{CompactPct: 101}}), false),
+			Entry("packing and compression", validateProps(cmn.Bprops{
+				Pack:     cmn.PackConf{Enabled: true},
+				Compress: cmn.CompressConf{Enabled: true},
+			}), false),
+			Entry("chunks", validateProps(cmn.Bprops{Chunks: cmn.ChunkConf{Enabled: true}}), true),
+			Entry("chunks: sizes", validateProps(cmn.Bprops{Chunks: cmn.ChunkConf{ChunkSize: 64 * cos.MiB, MinObjSize: 64 * cos.MiB, Enabled: true}}), true),
+			Entry("chunks: chunk too small", validateProps(cmn.Bprops{Chunks: cmn.ChunkConf{ChunkSize: cos.KiB, Enabled: true}}), false),
+			Entry("chunks: chunk too large", validateProps(cmn.Bprops{Chunks: cmn.ChunkConf{ChunkSize: 128 * cos.GiB}}), false),
+			Entry("chunks: object smaller than chunk",
+				validateProps(cmn.Bprops{Chunks: cmn.ChunkConf{ChunkSize: 64 * cos.MiB, MinObjSize: 32 * cos.MiB}}), false),
+			Entry("chunks and mirroring", validateProps(cmn.Bprops{
+				Chunks: cmn.ChunkConf{Enabled: true},
+				Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
+			}), false),
+			Entry("chunks and packing", validateProps(cmn.Bprops{
+				Chunks: cmn.ChunkConf{Enabled: true},
+				Pack:   cmn.PackConf{Enabled: true},
+			}), false),
+			Entry("cache tier: none", validateCacheFSP(cmn.CacheFSPConf{}), true),
+			Entry("cache tier", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, MaxObjSize: cos.MiB, PromoteHits: 1}), true),
+			Entry("cache tier: watermarks", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, LowWM: 50, HighWM: 95}), true),
+			Entry("cache tier: low watermark", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, LowWM: 80}), true),
+			Entry("cache tier: negative size", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, MaxObjSize: -1}), false),
+			Entry("cache tier: negative hits", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, PromoteHits: -1}), false),
+			Entry("cache tier: too many hits", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, PromoteHits: 1001}), false),
+			Entry("cache tier: high watermark", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, HighWM: 101}), false),
+			Entry("cache tier: watermarks too close", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, LowWM: 90, HighWM: 92}), false),
+			Entry("cache tier: low above default high", validateCacheFSP(cmn.CacheFSPConf{Paths: []string{"/nvme0"}, LowWM: 95}), false),
+			Entry("batch get", (&cmn.GetBatchMsg{In: []cmn.GetBatchIn{{ObjName: "a"}}}).Validate, true),
+			Entry("batch get: buckets and archived files", (&cmn.GetBatchMsg{In: []cmn.GetBatchIn{
+				{ObjName: "a"},
+				{Bck: cmn.Bck{Name: "b", Provider: apc.AIS}, ObjName: "b", ArchPath: "c"},
+			}}).Validate, true),
+			Entry("batch get: empty", (&cmn.GetBatchMsg{}).Validate, false),
+			Entry("batch get: no object name", (&cmn.GetBatchMsg{In: []cmn.GetBatchIn{{ObjName: "a"}, {}}}).Validate, false),
+			Entry("batch get: invalid bucket", (&cmn.GetBatchMsg{In: []cmn.GetBatchIn{{Bck: cmn.Bck{Name: "b/c"}, ObjName: "a"}}}).Validate, false),
+			Entry("export list", validateExportList(cmn.ExportListMsg{ToBck: cmn.Bck{Name: "dst"}}), true),
+			Entry("export list: csv", validateExportList(cmn.ExportListMsg{
+				ToBck: cmn.Bck{Name: "dst"}, Format: tabular.CSV, Props: "size,name,atime", MaxRows: 1000,
+			}), true),
+			Entry("export list: props", validateExportList(cmn.ExportListMsg{
+				ToBck: cmn.Bck{Name: "dst"}, Props: "name, checksum, version, custom, location",
+			}), true),
+			Entry("export list: no destination", validateExportList(cmn.ExportListMsg{}), false),
+			Entry("export list: format", validateExportList(cmn.ExportListMsg{ToBck: cmn.Bck{Name: "dst"}, Format: "xml"}), false),
+			Entry("export list: unsupported prop", validateExportList(cmn.ExportListMsg{ToBck: cmn.Bck{Name: "dst"}, Props: "name,copies"}), false),
+			Entry("export list: negative rows", validateExportList(cmn.ExportListMsg{ToBck: cmn.Bck{Name: "dst"}, MaxRows: -1}), false),
+		)
+	})
 })
@@ -5,6 +5,8 @@
 package tests_test
 
 import (
+	"time"
+
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -91,6 +93,51 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           false,
 					"lru.dont_evict_time":   cos.Duration(0),
 					"lru.capacity_upd_time": cos.Duration(0),
+					"lru.policy":            "",
+					"lru.priority":          0,
+
+					"tier.bck.name":     "",
+					"tier.bck.provider": "",
+					"tier.age":          cos.Duration(0),
+					"tier.min_size":     cos.SizeIEC(0),
+					"tier.enabled":      false,
+
+					"replication.bck.name":     "",
+					"replication.bck.provider": "",
+					"replication.enabled":      false,
+
+					"events.rules":   []cmn.EventRule(nil),
+					"events.enabled": false,
+
+					"compression.algo":       "",
+					"compression.block_size": cos.SizeIEC(0),
+					"compression.enabled":    false,
+
+					"encryption.keys":    []cmn.DataKey(nil),
+					"encryption.enabled": false,
+
+					"packing.max_obj_size":  cos.SizeIEC(0),
+					"packing.max_pack_size": cos.SizeIEC(0),
+					"packing.compact_pct":   int64(0),
+					"packing.enabled":       false,
+
+					"chunks.chunk_size":   cos.SizeIEC(0),
+					"chunks.min_obj_size": cos.SizeIEC(0),
+					"chunks.enabled":      false,
+
+					"name_index.enabled": false,
+
+					"inventory.bck.name":         "",
+					"inventory.bck.provider":     "",
+					"inventory.name":             "",
+					"inventory.format":           "",
+					"inventory.columns.name":     "",
+					"inventory.columns.size":     "",
+					"inventory.columns.checksum": "",
+					"inventory.columns.version":  "",
+					"inventory.columns.etag":     "",
+					"inventory.columns.mtime":    "",
+					"inventory.enabled":          false,
 
 					"extra.aws.cloud_region": "us-central",
 					"extra.aws.endpoint":     "",
@@ -150,6 +197,52 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           (*bool)(nil),
 					"lru.dont_evict_time":   (*cos.Duration)(nil),
 					"lru.capacity_upd_time": (*cos.Duration)(nil),
+					"lru.policy":            (*string)(nil),
+					"lru.priority":          (*int)(nil),
+
+					"tier.bck.name":     (*string)(nil),
+					"tier.bck.provider": (*string)(nil),
+					"tier.age":          (*cos.Duration)(nil),
+					"tier.min_size":     (*cos.SizeIEC)(nil),
+					"tier.enabled":      (*bool)(nil),
+
+					"replication.bck.name":           (*string)(nil),
+					"replication.bck.provider":       (*string)(nil),
+					"replication.bck.namespace.uuid": (*string)(nil),
+					"replication.bck.namespace.name": (*string)(nil),
+					"replication.enabled":            (*bool)(nil),
+
+					"events.rules":   (*[]cmn.EventRule)(nil),
+					"events.enabled": (*bool)(nil),
+
+					"compression.algo":       (*string)(nil),
+					"compression.block_size": (*cos.SizeIEC)(nil),
+					"compression.enabled":    (*bool)(nil),
+
+					"encryption.enabled": (*bool)(nil),
+
+					"packing.max_obj_size":  (*cos.SizeIEC)(nil),
+					"packing.max_pack_size": (*cos.SizeIEC)(nil),
+					"packing.compact_pct":   (*int64)(nil),
+					"packing.enabled":       (*bool)(nil),
+
+					"chunks.chunk_size":   (*cos.SizeIEC)(nil),
+					"chunks.min_obj_size": (*cos.SizeIEC)(nil),
+					"chunks.enabled":      (*bool)(nil),
+
+					"name_index.enabled": (*bool)(nil),
+
+					"inventory.bck.name":         (*string)(nil),
+					"inventory.bck.provider":     (*string)(nil),
+					"inventory.name":             (*string)(nil),
+					"inventory.format":           (*string)(nil),
+					"inventory.columns.name":     (*string)(nil),
+					"inventory.columns.size":     (*string)(nil),
+					"inventory.columns.checksum": (*string)(nil),
+					"inventory.columns.version":  (*string)(nil),
+					"inventory.columns.etag":     (*string)(nil),
+					"inventory.columns.mtime":    (*string)(nil),
+					"inventory.enabled":          (*bool)(nil),
 
 					"access":   apc.Ptr[apc.AccessAttrs](1024),
 					"features": apc.Ptr[feat.Flags](1024),
@@ -310,6 +403,39 @@ var _ = Describe("IterFields", func() {
 					},
 				},
 			),
+			Entry("update tier BpropsToSet",
+				&cmn.BpropsToSet{},
+				map[string]any{
+					cmn.PropTierBckName:     "cold",
+					cmn.PropTierBckProvider: apc.AWS,
+					"tier.age":              "72h",
+					"tier.min_size":         "1MiB",
+					"tier.enabled":          "true",
+				},
+				&cmn.BpropsToSet{
+					Tier: &cmn.TierConfToSet{
+						Bck:     &cmn.BackendBckToSet{Name: apc.Ptr("cold"), Provider: apc.Ptr(apc.AWS)},
+						Age:     apc.Ptr(cos.Duration(72 * time.Hour)),
+						MinSize: apc.Ptr(cos.SizeIEC(cos.MiB)),
+						Enabled: apc.Ptr(true),
+					},
+				},
+			),
+			Entry("update replication BpropsToSet",
+				&cmn.BpropsToSet{},
+				map[string]any{
+					cmn.PropReplBckName:     "dst",
+					cmn.PropReplBckProvider: apc.AIS,
+					cmn.PropReplBckNsUUID:   "remais",
+					"replication.enabled":   "true",
+				},
+				&cmn.BpropsToSet{
+					Replication: &cmn.ReplConfToSet{
+						Bck:     &cmn.ReplBckToSet{Name: apc.Ptr("dst"), Provider: apc.Ptr(apc.AIS), Ns: &cmn.NsToSet{UUID: apc.Ptr("remais")}},
+						Enabled: apc.Ptr(true),
+					},
+				},
+			),
 		)
 
 		DescribeTable("should error on update",
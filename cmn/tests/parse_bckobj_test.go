@@ -112,6 +112,11 @@ func TestParseBckObjectURI(t *testing.T) {
 			uri:         "ais://bucket",
 			expectedBck: cmn.Bck{Provider: apc.AIS, Name: "bucket"},
 		},
+		{
+			uri:         "ais://bucket@snap/objname",
+			expectedBck: cmn.Bck{Provider: apc.AIS, Name: "bucket@snap"},
+			expectedObj: "objname",
+		},
 		{
 			uri:         "aws://",
 			expectedBck: cmn.Bck{Provider: apc.AWS},
@@ -0,0 +1,176 @@
+// Package zblk implements block-indexed compressed format that provides for random access
+// (range reads) into the original (uncompressed) content.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package zblk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Reader provides for reading (io.Reader, io.Seeker) and random access (io.ReaderAt)
+// to the original content; the last decompressed block is cached, which makes
+// sequential reads efficient
+type Reader struct {
+	r     io.ReaderAt
+	index []uint64
+	blk   []byte // cached block (decompressed)
+	zbuf  []byte
+	f     footer
+	bidx  int64 // cached block index
+	off   int64 // Read and Seek
+	mu    sync.Mutex
+}
+
+// interface guard
+var (
+	_ io.ReadSeeker = (*Reader)(nil)
+	_ io.ReaderAt   = (*Reader)(nil)
+)
+
+// given physical (compressed) size, read and validate footer and index
+func NewReader(r io.ReaderAt, physSize int64) (*Reader, error) {
+	zr := &Reader{r: r, bidx: -1}
+	if physSize < FooterSize {
+		return nil, ErrBadFormat
+	}
+	b := make([]byte, FooterSize)
+	if _, err := r.ReadAt(b, physSize-FooterSize); err != nil {
+		return nil, err
+	}
+	if err := zr.f.unpack(b, physSize); err != nil {
+		return nil, err
+	}
+	if zr.f.nblocks > 0 {
+		b = make([]byte, int(zr.f.nblocks)*8)
+		if _, err := r.ReadAt(b, zr.f.ioff); err != nil {
+			return nil, err
+		}
+		zr.index = make([]uint64, zr.f.nblocks)
+		var prev int64
+		for i := range zr.index {
+			v := binary.LittleEndian.Uint64(b[i*8:])
+			if end := int64(v &^ rawFlag); end <= prev || end > zr.f.ioff {
+				return nil, ErrBadFormat
+			} else {
+				prev = end
+			}
+			zr.index[i] = v
+		}
+	}
+	return zr, nil
+}
+
+// original (uncompressed) size
+func (zr *Reader) Size() int64 { return zr.f.size }
+
+func (zr *Reader) Read(p []byte) (int, error) {
+	n, err := zr.ReadAt(p, zr.off)
+	zr.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (zr *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += zr.off
+	case io.SeekEnd:
+		offset += zr.f.size
+	default:
+		return 0, errors.New("zblk: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("zblk: negative position")
+	}
+	zr.off = offset
+	return offset, nil
+}
+
+func (zr *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("zblk: negative offset")
+	}
+	if off >= zr.f.size {
+		return 0, io.EOF
+	}
+	zr.mu.Lock()
+	for n < len(p) && off < zr.f.size {
+		bsize := int64(zr.f.blockSize)
+		if err = zr.load(off / bsize); err != nil {
+			break
+		}
+		l := copy(p[n:], zr.blk[off%bsize:])
+		n += l
+		off += int64(l)
+	}
+	zr.mu.Unlock()
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// read and decompress (ie., cache) a given block
+func (zr *Reader) load(bidx int64) error {
+	if bidx == zr.bidx {
+		return nil
+	}
+	var (
+		start int64
+		bsize = int(zr.f.blockSize)
+		v     = zr.index[bidx]
+		end   = int64(v &^ rawFlag)
+		size  = min(int64(bsize), zr.f.size-bidx*int64(bsize)) // (the last one may be shorter)
+	)
+	if bidx > 0 {
+		start = int64(zr.index[bidx-1] &^ rawFlag)
+	}
+	if zr.blk == nil {
+		zr.blk = make([]byte, bsize)
+		zr.zbuf = make([]byte, bsize)
+	}
+	zr.bidx = -1
+	if v&rawFlag != 0 {
+		if end-start != size {
+			return ErrBadFormat
+		}
+		if _, err := zr.r.ReadAt(zr.blk[:size], start); err != nil {
+			return _eof(err)
+		}
+	} else {
+		if end-start >= size {
+			return ErrBadFormat
+		}
+		zbuf := zr.zbuf[:end-start]
+		if _, err := zr.r.ReadAt(zbuf, start); err != nil {
+			return _eof(err)
+		}
+		n, err := decompress(zr.f.algo, zbuf, zr.blk[:size])
+		if err != nil {
+			return fmt.Errorf("%w: block %d: %v", ErrBadFormat, bidx, err)
+		}
+		if int64(n) != size {
+			return ErrBadFormat
+		}
+	}
+	zr.blk = zr.blk[:size]
+	zr.bidx = bidx
+	return nil
+}
+
+// (truncated)
+func _eof(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
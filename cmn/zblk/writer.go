@@ -0,0 +1,128 @@
+// Package zblk implements block-indexed compressed format that provides for random access
+// (range reads) into the original (uncompressed) content.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package zblk
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/debug"
+)
+
+// Writer compresses and writes the content to the underlying writer;
+// Close must be called to write out the remaining block, the index, and the footer
+// (the underlying writer is not closed)
+type Writer struct {
+	w     io.Writer
+	blk   []byte // current block (uncompressed)
+	zbuf  []byte // compressed
+	index []uint64
+	off   int64 // written so far (compressed)
+	size  int64 // original size
+	algo  byte
+}
+
+// interface guard
+var _ io.WriteCloser = (*Writer)(nil)
+
+func NewWriter(w io.Writer, algo string, blockSize int64) (*Writer, error) {
+	id, err := algoID(algo)
+	if err != nil {
+		return nil, err
+	}
+	if blockSize == 0 {
+		blockSize = DefaultBlockSize
+	}
+	if err := ValidateBlockSize(blockSize); err != nil {
+		return nil, err
+	}
+	if id == algoZstd {
+		if _initZstd(); zstdc.err != nil {
+			return nil, zstdc.err
+		}
+	}
+	zw := &Writer{
+		w:    w,
+		algo: id,
+		blk:  make([]byte, 0, blockSize),
+		zbuf: make([]byte, blockSize),
+	}
+	return zw, nil
+}
+
+func (zw *Writer) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		l := min(cap(zw.blk)-len(zw.blk), len(p))
+		zw.blk = append(zw.blk, p[:l]...)
+		n += l
+		p = p[l:]
+		if len(zw.blk) == cap(zw.blk) {
+			if err = zw.flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (zw *Writer) flush() error {
+	var (
+		blk    = zw.blk
+		n, err = compress(zw.algo, zw.blk, zw.zbuf)
+		raw    uint64
+	)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		blk = zw.zbuf[:n]
+	} else {
+		raw = rawFlag // incompressible
+	}
+	if _, err := zw.w.Write(blk); err != nil {
+		return err
+	}
+	zw.off += int64(len(blk))
+	zw.size += int64(len(zw.blk))
+	zw.index = append(zw.index, uint64(zw.off)|raw)
+	zw.blk = zw.blk[:0]
+	return nil
+}
+
+func (zw *Writer) Close() error {
+	if len(zw.blk) > 0 {
+		if err := zw.flush(); err != nil {
+			return err
+		}
+	}
+	var (
+		l = len(zw.index)*8 + FooterSize
+		b = make([]byte, l)
+		f = footer{
+			algo:      zw.algo,
+			blockSize: uint32(cap(zw.blk)),
+			nblocks:   uint32(len(zw.index)),
+			size:      zw.size,
+			ioff:      zw.off,
+		}
+	)
+	for i, v := range zw.index {
+		binary.LittleEndian.PutUint64(b[i*8:], v)
+	}
+	f.pack(b[l-FooterSize:])
+	if _, err := zw.w.Write(b); err != nil {
+		return err
+	}
+	zw.off += int64(l)
+	debug.Assert(f.ioff+int64(len(zw.index))*8+FooterSize == zw.off)
+	return nil
+}
+
+// original (uncompressed) size written so far
+func (zw *Writer) Size() int64 { return zw.size + int64(len(zw.blk)) }
+
+// total size of the compressed output (valid upon Close)
+func (zw *Writer) PhysSize() int64 { return zw.off }
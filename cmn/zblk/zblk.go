@@ -0,0 +1,182 @@
+// Package zblk implements block-indexed compressed format that provides for random access
+// (range reads) into the original (uncompressed) content.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package zblk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+)
+
+// Format:
+//
+//	| block 0 | block 1 | ... | block N-1 | index | footer |
+//
+// - the original content is split into fixed-size blocks (except the last one),
+//   and each block is compressed independently
+// - index: N (little-endian) uint64 end offsets of the compressed blocks;
+//   the high bit indicates a block that is stored uncompressed (incompressible)
+// - footer (fixed size): magic, version, algorithm, block size, number of blocks,
+//   original size, and index offset
+
+const (
+	AlgoLZ4  = "lz4"
+	AlgoZstd = "zstd"
+
+	MinBlockSize     = 4 * cos.KiB
+	MaxBlockSize     = 4 * cos.MiB
+	DefaultBlockSize = 64 * cos.KiB
+
+	FooterSize = 32
+)
+
+const (
+	version = 1
+	rawFlag = uint64(1) << 63
+
+	algoLZ4  = 1
+	algoZstd = 2
+)
+
+var magic = [4]byte{'a', 'i', 's', 'z'}
+
+var (
+	SupportedAlgos = []string{AlgoLZ4, AlgoZstd}
+
+	ErrBadFormat = errors.New("zblk: invalid format")
+)
+
+type footer struct {
+	algo      byte
+	blockSize uint32
+	nblocks   uint32
+	size      int64 // original (uncompressed)
+	ioff      int64 // index offset
+}
+
+func IsValidAlgo(algo string) bool { return cos.StringInSlice(algo, SupportedAlgos) }
+
+func ValidateBlockSize(size int64) error {
+	if size != 0 && (size < MinBlockSize || size > MaxBlockSize) {
+		return fmt.Errorf("invalid block size %s (expecting range [%s, %s])",
+			cos.ToSizeIEC(size, 0), cos.ToSizeIEC(MinBlockSize, 0), cos.ToSizeIEC(MaxBlockSize, 0))
+	}
+	return nil
+}
+
+func algoID(algo string) (byte, error) {
+	switch algo {
+	case AlgoLZ4, "":
+		return algoLZ4, nil
+	case AlgoZstd:
+		return algoZstd, nil
+	default:
+		return 0, fmt.Errorf("zblk: unsupported compression %q (expecting one of: %v)", algo, SupportedAlgos)
+	}
+}
+
+// (zstd encoder and decoder are safe for concurrent use via EncodeAll and DecodeAll)
+var zstdc struct {
+	enc  *zstd.Encoder
+	dec  *zstd.Decoder
+	once sync.Once
+	err  error
+}
+
+func _initZstd() {
+	zstdc.once.Do(func() {
+		zstdc.enc, zstdc.err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if zstdc.err == nil {
+			zstdc.dec, zstdc.err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+		}
+	})
+}
+
+// returns compressed size or zero when incompressible
+func compress(algo byte, src, dst []byte) (int, error) {
+	switch algo {
+	case algoLZ4:
+		n, err := lz4.CompressBlock(src, dst[:len(src)-1], nil)
+		if err != nil { // (short destination)
+			return 0, nil
+		}
+		return n, nil
+	default:
+		out := zstdc.enc.EncodeAll(src, dst[:0])
+		if len(out) >= len(src) {
+			return 0, nil
+		}
+		if &out[0] != &dst[0] { // (reallocated)
+			copy(dst, out)
+		}
+		return len(out), nil
+	}
+}
+
+func decompress(algo byte, src, dst []byte) (int, error) {
+	switch algo {
+	case algoLZ4:
+		return lz4.UncompressBlock(src, dst)
+	default:
+		out, err := zstdc.dec.DecodeAll(src, dst[:0])
+		if err != nil {
+			return 0, err
+		}
+		if len(out) > len(dst) {
+			return 0, ErrBadFormat
+		}
+		return len(out), nil
+	}
+}
+
+////////////
+// footer //
+////////////
+
+func (f *footer) pack(b []byte) {
+	copy(b, magic[:])
+	b[4] = version
+	b[5] = f.algo
+	binary.LittleEndian.PutUint32(b[8:], f.blockSize)
+	binary.LittleEndian.PutUint32(b[12:], f.nblocks)
+	binary.LittleEndian.PutUint64(b[16:], uint64(f.size))
+	binary.LittleEndian.PutUint64(b[24:], uint64(f.ioff))
+}
+
+func (f *footer) unpack(b []byte, physSize int64) error {
+	if len(b) != FooterSize || [4]byte(b[:4]) != magic {
+		return ErrBadFormat
+	}
+	if b[4] != version {
+		return fmt.Errorf("zblk: unsupported version %d", b[4])
+	}
+	f.algo = b[5]
+	f.blockSize = binary.LittleEndian.Uint32(b[8:])
+	f.nblocks = binary.LittleEndian.Uint32(b[12:])
+	f.size = int64(binary.LittleEndian.Uint64(b[16:]))
+	f.ioff = int64(binary.LittleEndian.Uint64(b[24:]))
+
+	switch {
+	case f.algo != algoLZ4 && f.algo != algoZstd:
+		return ErrBadFormat
+	case f.blockSize < MinBlockSize || f.blockSize > MaxBlockSize:
+		return ErrBadFormat
+	case f.ioff+int64(f.nblocks)*8+FooterSize != physSize:
+		return ErrBadFormat
+	case f.size > int64(f.nblocks)*int64(f.blockSize) || (f.nblocks > 0 && f.size <= int64(f.nblocks-1)*int64(f.blockSize)):
+		return ErrBadFormat
+	}
+	if f.algo == algoZstd {
+		_initZstd()
+		return zstdc.err
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package zblk implements block-indexed compressed format that provides for random access
+// (range reads) into the original (uncompressed) content.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package zblk_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/zblk"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func genContent(size int) []byte {
+	var (
+		sb    strings.Builder
+		words = []string{"alpha", "beta", "gamma", "delta", "{\"key\": 12345}", "\n"}
+	)
+	for sb.Len() < size {
+		sb.WriteString(words[rand.Intn(len(words))])
+	}
+	b := []byte(sb.String())[:size]
+	// incompressible tail
+	if size > 3*zblk.MinBlockSize {
+		rand.Read(b[size-2*zblk.MinBlockSize:])
+	}
+	return b
+}
+
+func compress(t *testing.T, content []byte, algo string, blockSize int64) []byte {
+	var (
+		out    bytes.Buffer
+		zw, er = zblk.NewWriter(&out, algo, blockSize)
+	)
+	tassert.CheckFatal(t, er)
+	// write in odd-size chunks
+	for off := 0; off < len(content); off += 1000 {
+		_, err := zw.Write(content[off:min(off+1000, len(content))])
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, zw.Close())
+	tassert.Fatalf(t, zw.PhysSize() == int64(out.Len()), "phys size %d != %d", zw.PhysSize(), out.Len())
+	tassert.Fatalf(t, zw.Size() == int64(len(content)), "size %d != %d", zw.Size(), len(content))
+	return out.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, algo := range zblk.SupportedAlgos {
+		for _, size := range []int{0, 1, zblk.MinBlockSize, 100 * cos.KiB, cos.MiB + 7} {
+			content := genContent(size)
+			z := compress(t, content, algo, zblk.MinBlockSize)
+			if size > cos.MiB {
+				tassert.Errorf(t, len(z) < size/2, "%s: expected compression (%d => %d)", algo, size, len(z))
+			}
+			zr, err := zblk.NewReader(bytes.NewReader(z), int64(len(z)))
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, zr.Size() == int64(size), "%s: size %d != %d", algo, zr.Size(), size)
+			b, err := io.ReadAll(zr)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(b, content), "%s: content mismatch (size %d)", algo, size)
+		}
+	}
+}
+
+func TestRangeRead(t *testing.T) {
+	const size = 300*cos.KiB + 123
+	for _, algo := range zblk.SupportedAlgos {
+		content := genContent(size)
+		z := compress(t, content, algo, 0 /*default*/)
+		zr, err := zblk.NewReader(bytes.NewReader(z), int64(len(z)))
+		tassert.CheckFatal(t, err)
+		for range 100 {
+			var (
+				off = rand.Int63n(size)
+				l   = rand.Int63n(size - off + 1)
+				b   = make([]byte, l)
+			)
+			n, err := io.ReadFull(io.NewSectionReader(zr, off, l), b)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(b[:n], content[off:off+l]), "%s: range [%d, %d) mismatch", algo, off, off+l)
+		}
+		// seek
+		_, err = zr.Seek(-10, io.SeekEnd)
+		tassert.CheckFatal(t, err)
+		b, err := io.ReadAll(zr)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b, content[size-10:]), "%s: seek-end mismatch", algo)
+	}
+}
+
+func TestCorrupted(t *testing.T) {
+	content := genContent(100 * cos.KiB)
+	z := compress(t, content, zblk.AlgoLZ4, 0)
+	for _, b := range [][]byte{z[:len(z)-1], z[1:], z[:zblk.FooterSize-1]} {
+		if _, err := zblk.NewReader(bytes.NewReader(b), int64(len(b))); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	// corrupted block content
+	z[10] ^= 0xff
+	z[11] ^= 0xff
+	zr, err := zblk.NewReader(bytes.NewReader(z), int64(len(z)))
+	tassert.CheckFatal(t, err)
+	b, err := io.ReadAll(zr)
+	if err == nil && bytes.Equal(b, content) {
+		t.Fatal("expected error or different content")
+	}
+}
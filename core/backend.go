@@ -24,6 +24,7 @@ type (
 		ErrCode  int
 	}
 	LsoInventoryCtx struct {
+		FQN    string // local copy of the bucket's manifest (see cmn.InvConf)
 		Offset int64
 		Size   int64
 	}
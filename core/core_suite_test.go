@@ -9,12 +9,16 @@ import (
 	"testing"
 
 	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
 func init() {
 	hk.TestInit()
+	xreg.Init()
+	xs.Xreg(false)
 }
 
 func TestCluster(t *testing.T) {
@@ -6,7 +6,9 @@ package core
 
 import (
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
 )
 
 func ResolveFQN(fqn string) (parsedFQN fs.ParsedFQN, hrwFQN string, err error) {
@@ -21,13 +23,20 @@ func ResolveFQN(fqn string) (parsedFQN fs.ParsedFQN, hrwFQN string, err error) {
 		return
 	}
 	parsedFQN.Digest = digest
+	if parsedFQN.ContentType == fs.ChunkType {
+		// chunks belong to the target of their object (see core/lchunk.go)
+		if objName, _, ok := fs.CSM.Resolver(fs.ChunkType).ParseUniqueFQN(parsedFQN.ObjName); ok {
+			uname := parsedFQN.Bck.HrwUname(objName)
+			parsedFQN.Digest = xxhash.Checksum64S(cos.UnsafeB(uname), cos.MLCG32)
+		}
+	}
 	return
 }
 
 func HrwFQN(bck *cmn.Bck, contentType, objName string) (fqn string, digest uint64, err error) {
 	var (
 		mi    *fs.Mountpath
-		uname = bck.MakeUname(objName)
+		uname = bck.HrwUname(objName) // (snapshots are co-located with their origin buckets)
 	)
 	if mi, digest, err = fs.Hrw(uname); err == nil {
 		fqn = mi.MakePathFQN(bck, contentType, objName)
@@ -0,0 +1,277 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// journal: per-mountpath, append-only (and synced) log of records - one line per object
+// that needs to be acted upon (written back, replicated) - in addition to the in-memory
+// queue of the respective xaction; records are opaque to the journal (see WbackLog, ReplLog)
+// - each logged record is accounted as pending until done
+// - mountpath journals are independent (separate locks); concurrently logged records
+//   get synced together - one fsync per group (group commit)
+// - removed when there are no pending records; otherwise, compacted (rewritten to contain
+//   only pending records) once the number of done records becomes large enough
+// - replayed (and removed) upon target restart; records done since the last compaction
+//   may get replayed as well (and must be handled idempotently)
+// - the number of pending records is reported via target stats (gauge)
+
+const (
+	jcompactMin    = 1024 // min number of records in a journal to consider compaction
+	jcompactFactor = 4    // compact when records > factor * pending
+)
+
+type (
+	journal struct {
+		mpjs  map[string]*mpjournal // by mountpath
+		fname string
+		tag   string // (logging)
+		stat  string // gauge
+		mu    sync.RWMutex
+	}
+	mpjournal struct {
+		j       *journal
+		fh      *os.File
+		cond    sync.Cond
+		fpath   string
+		pending map[string]*jrec
+		nrecs   int   // records in the file
+		written int64 // group commit: written so far
+		synced  int64 // ditto, synced
+		syncing bool  // ditto, fsync in progress
+		mu      sync.Mutex
+	}
+	jrec struct {
+		n   int   // number of times logged (and not done)
+		seq int64 // last logged (see compact)
+	}
+)
+
+func newJournal(fname, tag, stat string) *journal {
+	return &journal{mpjs: make(map[string]*mpjournal, 4), fname: fname, tag: tag, stat: stat}
+}
+
+func (j *journal) log(lom *LOM, rec string) error {
+	mi := lom.Mountpath()
+	j.mu.RLock()
+	mpj, ok := j.mpjs[mi.Path]
+	j.mu.RUnlock()
+	if !ok {
+		j.mu.Lock()
+		if mpj, ok = j.mpjs[mi.Path]; !ok {
+			mpj = &mpjournal{j: j, fpath: filepath.Join(mi.Path, j.fname), pending: make(map[string]*jrec)}
+			mpj.cond.L = &mpj.mu
+			j.mpjs[mi.Path] = mpj
+		}
+		j.mu.Unlock()
+	}
+	if err := mpj.log(rec); err != nil {
+		return err
+	}
+	g.tstats.Add(j.stat, 1)
+	return nil
+}
+
+func (j *journal) done(rec string) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	for _, mpj := range j.mpjs {
+		if mpj.done(rec) {
+			g.tstats.Add(j.stat, -1)
+			return
+		}
+	}
+}
+
+// read all journals and return all records (per mountpath, in the order of logging)
+func (j *journal) replay() (recs []string) {
+	avail, _ := fs.Get()
+	j.mu.Lock()
+	for _, mpj := range j.mpjs {
+		mpj.mu.Lock()
+		mpj.close()
+		mpj.mu.Unlock()
+	}
+	clear(j.mpjs)
+	for _, mi := range avail {
+		fpath := filepath.Join(mi.Path, j.fname)
+		fh, err := os.Open(fpath)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Errorln("failed to open", j.tag, "journal:", err)
+			}
+			continue
+		}
+		scanner := bufio.NewScanner(fh)
+		for scanner.Scan() {
+			if rec := scanner.Text(); rec != "" {
+				recs = append(recs, rec)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			nlog.Errorln("failed to read", j.tag, "journal", fpath, "err:", err)
+		}
+		cos.Close(fh)
+		if err := cos.RemoveFile(fpath); err != nil {
+			nlog.Errorln("failed to remove", j.tag, "journal:", err)
+		}
+	}
+	j.mu.Unlock()
+	return recs
+}
+
+///////////////
+// mpjournal //
+///////////////
+
+func (mpj *mpjournal) log(rec string) (err error) {
+	mpj.mu.Lock()
+	defer mpj.mu.Unlock()
+	if mpj.fh == nil {
+		mpj.fh, err = os.OpenFile(mpj.fpath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+		if err != nil {
+			return err
+		}
+	}
+	if mpj.nrecs >= jcompactMin && mpj.nrecs >= jcompactFactor*len(mpj.pending) && !mpj.syncing {
+		if err = mpj.compact(); err != nil {
+			nlog.Errorln("failed to compact", mpj.j.tag, "journal", mpj.fpath, "err:", err)
+			if mpj.fh == nil {
+				return err
+			}
+			err = nil // (proceeding anyway)
+		}
+	}
+	if _, err = mpj.fh.WriteString(rec + "\n"); err != nil {
+		return err
+	}
+	mpj.nrecs++
+	mpj.written++
+	if jr, ok := mpj.pending[rec]; ok { // (before syncing - see compact)
+		jr.n++
+		jr.seq = mpj.written
+	} else {
+		mpj.pending[rec] = &jrec{n: 1, seq: mpj.written}
+	}
+
+	// group commit: wait for the sync that covers this record, or sync on behalf of all
+	// the records written so far
+	seq := mpj.written
+	for mpj.synced < seq {
+		if mpj.syncing {
+			mpj.cond.Wait()
+			continue
+		}
+		mpj.syncing = true
+		fh, upto := mpj.fh, mpj.written
+		mpj.mu.Unlock()
+		err = fh.Sync()
+		mpj.mu.Lock()
+		mpj.syncing = false
+		mpj.cond.Broadcast()
+		if err != nil {
+			mpj._done(rec)
+			return err
+		}
+		mpj.synced = max(mpj.synced, upto)
+	}
+	return nil
+}
+
+func (mpj *mpjournal) done(rec string) (ok bool) {
+	mpj.mu.Lock()
+	ok = mpj._done(rec)
+	if len(mpj.pending) == 0 && !mpj.syncing && mpj.fh != nil {
+		mpj.close()
+		if err := cos.RemoveFile(mpj.fpath); err != nil {
+			nlog.Errorln("failed to remove", mpj.j.tag, "journal:", err)
+		}
+	}
+	mpj.mu.Unlock()
+	return ok
+}
+
+func (mpj *mpjournal) _done(rec string) bool {
+	jr, ok := mpj.pending[rec]
+	if !ok {
+		return false
+	}
+	if jr.n--; jr.n == 0 {
+		delete(mpj.pending, rec)
+	}
+	return true
+}
+
+// rewrite the journal to contain only pending records - one per record, in the order of logging
+// - pending includes written but not yet synced records (compare w/ log)
+func (mpj *mpjournal) compact() error {
+	recs := make([]string, 0, len(mpj.pending))
+	for rec := range mpj.pending {
+		recs = append(recs, rec)
+	}
+	sort.Slice(recs, func(i, k int) bool { return mpj.pending[recs[i]].seq < mpj.pending[recs[k]].seq })
+
+	tmp := mpj.fpath + ".tmp"
+	fh, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(fh)
+	for _, rec := range recs {
+		if _, err = bw.WriteString(rec + "\n"); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err == nil {
+		err = fh.Sync()
+	}
+	cos.Close(fh)
+	if err == nil {
+		err = os.Rename(tmp, mpj.fpath)
+	}
+	if err != nil {
+		cos.RemoveFile(tmp)
+		return err
+	}
+	if err := fsyncDir(filepath.Dir(mpj.fpath)); err != nil {
+		nlog.Errorln("failed to fsync", mpj.j.tag, "journal dir:", err)
+	}
+	mpj.close()
+	mpj.nrecs = len(mpj.pending)
+	mpj.fh, err = os.OpenFile(mpj.fpath, os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+	return err
+}
+
+func (mpj *mpjournal) close() {
+	if mpj.fh != nil {
+		cos.Close(mpj.fh)
+		mpj.fh = nil
+	}
+	mpj.nrecs = 0
+	mpj.synced = mpj.written
+}
+
+func fsyncDir(dir string) error {
+	fh, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = fh.Sync()
+	cos.Close(fh)
+	return err
+}
@@ -0,0 +1,696 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Chunked storage of large objects (see cmn.ChunkConf)
+// - the object's file contains the manifest: chunk size, object size, and the list of chunks;
+//   the content itself is stored in fixed-size chunks (content type fs.ChunkType)
+// - each chunk is placed on the mountpath selected by HRW over the chunk's name - large objects
+//   get spread across all mountpaths, and resilver moves individual chunks
+// - chunked objects carry custom key `cmn.ChunkObjMD` (the value is the size of the manifest)
+// - to read, use lom.Open (ranged reads open only the chunks they need); to write - lom.NewChunkWriter
+//   (PUT, cold GET, blob download) and lom.ChunkAppend/lom.OpenChunkWriter (APPEND)
+// - overwriting or removing the object removes its chunks; chunks that are not referenced by any
+//   manifest (e.g., upon crash) are removed by space cleanup
+
+const (
+	chunkMagic   = 0x61697363 // "aisc"
+	chunkVersion = 1
+	chunkHdrSize = 32 // magic(4) | version(4) | chunk size(8) | object size(8) | num chunks(4) | reserved(4)
+
+	maxManifestSize = 64 * cos.MiB
+)
+
+type (
+	chunkManifest struct {
+		tags      []string // chunk name = object name + "." + tag (see fs.ChunkContentResolver)
+		chunkSize int64
+		size      int64 // object size
+	}
+
+	// writes object's content directly into chunks (see lom.NewChunkWriter)
+	ChunkWriter struct {
+		lom     *LOM
+		fh      *os.File // current (last) chunk
+		workfqn string   // manifest
+		created []string // chunks created by this writer
+		mf      chunkManifest
+		coff    int64  // offset in the current chunk
+		tag     uint64 // next chunk tag
+	}
+
+	chunkReader struct {
+		mf  *chunkManifest
+		fh  *os.File // current chunk (sequential reading)
+		bck cmn.Bck
+		obj string
+		fqn string // manifest
+		idx int    // index of the current chunk
+		off int64  // current offset
+	}
+)
+
+var errBadChunks = errors.New("invalid chunk manifest or missing chunk")
+
+// interface guard
+var (
+	_ LomReader = (*chunkReader)(nil)
+	_ io.Writer = (*ChunkWriter)(nil)
+)
+
+// (bucket property)
+func (lom *LOM) ChunkEnabled() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.Chunks.Enabled
+}
+
+func (lom *LOM) IsChunked() bool {
+	_, ok := lom.GetCustomKey(cmn.ChunkObjMD)
+	return ok
+}
+
+// size of the manifest
+func (lom *LOM) msize() int64 {
+	v, _ := lom.GetCustomKey(cmn.ChunkObjMD)
+	size, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return -1 // (=> errsize)
+	}
+	return size
+}
+
+///////////////////
+// chunkManifest //
+///////////////////
+
+func (mf *chunkManifest) marshal() []byte {
+	size := chunkHdrSize + cos.SizeofI64
+	for _, tag := range mf.tags {
+		size += cos.SizeofI16 + len(tag)
+	}
+	buf := make([]byte, size)
+	binary.LittleEndian.PutUint32(buf, chunkMagic)
+	binary.LittleEndian.PutUint32(buf[4:], chunkVersion)
+	binary.LittleEndian.PutUint64(buf[8:], uint64(mf.chunkSize))
+	binary.LittleEndian.PutUint64(buf[16:], uint64(mf.size))
+	binary.LittleEndian.PutUint32(buf[24:], uint32(len(mf.tags)))
+	off := chunkHdrSize
+	for _, tag := range mf.tags {
+		binary.LittleEndian.PutUint16(buf[off:], uint16(len(tag)))
+		off += cos.SizeofI16
+		off += copy(buf[off:], tag)
+	}
+	binary.LittleEndian.PutUint64(buf[off:], xxhash.Checksum64S(buf[:off], cos.MLCG32))
+	return buf
+}
+
+func (mf *chunkManifest) unmarshal(buf []byte, fqn string) error {
+	if len(buf) < chunkHdrSize+cos.SizeofI64 || binary.LittleEndian.Uint32(buf) != chunkMagic {
+		return fmt.Errorf("%w: %s (bad header)", errBadChunks, fqn)
+	}
+	if v := binary.LittleEndian.Uint32(buf[4:]); v != chunkVersion {
+		return fmt.Errorf("%w: %s (unsupported version %d)", errBadChunks, fqn, v)
+	}
+	end := len(buf) - cos.SizeofI64
+	if binary.LittleEndian.Uint64(buf[end:]) != xxhash.Checksum64S(buf[:end], cos.MLCG32) {
+		return fmt.Errorf("%w: %s (checksum mismatch)", errBadChunks, fqn)
+	}
+	mf.chunkSize = int64(binary.LittleEndian.Uint64(buf[8:]))
+	mf.size = int64(binary.LittleEndian.Uint64(buf[16:]))
+	num := int(binary.LittleEndian.Uint32(buf[24:]))
+	if mf.chunkSize <= 0 || mf.size < 0 || int64(num) != (mf.size+mf.chunkSize-1)/mf.chunkSize {
+		return fmt.Errorf("%w: %s (chunk size %d, size %d, num %d)", errBadChunks, fqn, mf.chunkSize, mf.size, num)
+	}
+	mf.tags = make([]string, 0, num)
+	for off := chunkHdrSize; len(mf.tags) < num; {
+		if off+cos.SizeofI16 > end {
+			return fmt.Errorf("%w: %s (truncated)", errBadChunks, fqn)
+		}
+		l := int(binary.LittleEndian.Uint16(buf[off:]))
+		off += cos.SizeofI16
+		if off+l > end {
+			return fmt.Errorf("%w: %s (truncated)", errBadChunks, fqn)
+		}
+		mf.tags = append(mf.tags, string(buf[off:off+l]))
+		off += l
+	}
+	return nil
+}
+
+// size of a given chunk
+func (mf *chunkManifest) csize(idx int) int64 {
+	return min(mf.chunkSize, mf.size-int64(idx)*mf.chunkSize)
+}
+
+func (mf *chunkManifest) has(tag string) bool {
+	for _, t := range mf.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// (the file may as well be the object's content - check the header first)
+func loadManifest(fqn string) (*chunkManifest, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, chunkHdrSize, chunkHdrSize+cos.KiB)
+	if _, err = io.ReadFull(fh, buf); err == nil && !isManifestHdr(buf) {
+		err = fmt.Errorf("%w: %s (bad header)", errBadChunks, fqn)
+	}
+	if err == nil {
+		var rest []byte
+		rest, err = io.ReadAll(io.LimitReader(fh, maxManifestSize))
+		buf = append(buf, rest...)
+	}
+	cos.Close(fh)
+	if err != nil {
+		return nil, err
+	}
+	mf := &chunkManifest{}
+	if err := mf.unmarshal(buf, fqn); err != nil {
+		return nil, err
+	}
+	return mf, nil
+}
+
+// whether a given (work) file contains chunk manifest
+func IsChunkManifest(fqn string) bool {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return false
+	}
+	var hdr [chunkHdrSize]byte
+	_, err = io.ReadFull(fh, hdr[:])
+	cos.Close(fh)
+	return err == nil && isManifestHdr(hdr[:])
+}
+
+func isManifestHdr(hdr []byte) bool {
+	return binary.LittleEndian.Uint32(hdr) == chunkMagic && binary.LittleEndian.Uint32(hdr[4:]) == chunkVersion
+}
+
+////////////
+// chunks //
+////////////
+
+// the chunk is placed on the mountpath selected by HRW over the chunk's name
+func chunkFQN(bck *cmn.Bck, name string) (string, error) {
+	fqn, _, err := HrwFQN(bck, fs.ChunkType, name)
+	return fqn, err
+}
+
+// HRW location or, if not found there (e.g., not yet resilvered), any other mountpath
+func findChunk(bck *cmn.Bck, name string) (string, error) {
+	fqn, err := chunkFQN(bck, name)
+	if err != nil {
+		return "", err
+	}
+	if err = cos.Stat(fqn); err == nil || !os.IsNotExist(err) {
+		return fqn, err
+	}
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		if other := mi.MakePathFQN(bck, fs.ChunkType, name); other != fqn && cos.Stat(other) == nil {
+			return other, nil
+		}
+	}
+	return "", fmt.Errorf("%w: chunk %q not found", errBadChunks, name)
+}
+
+// remove chunks of a given manifest except those that are still referenced by `keep`
+func rmChunks(bck *cmn.Bck, objName string, mf, keep *chunkManifest) {
+	for _, tag := range mf.tags {
+		if keep != nil && keep.has(tag) {
+			continue
+		}
+		fqn, err := findChunk(bck, objName+"."+tag)
+		if err == nil {
+			err = cos.RemoveFile(fqn)
+		}
+		if err != nil {
+			nlog.Warningln("failed to remove chunk", objName+"."+tag, "err:", err)
+		}
+	}
+}
+
+// upon removing the object
+func (lom *LOM) rmChunks() {
+	mf, err := loadManifest(lom.FQN)
+	if err != nil {
+		nlog.Warningln(lom.String(), "failed to load chunk manifest:", err)
+		return
+	}
+	rmChunks(lom.Bucket(), lom.ObjName, mf, nil)
+}
+
+// upon overwriting the object: the chunks that were replaced (compare w/ lom.rmChunks)
+func (lom *LOM) prevChunks() *chunkManifest {
+	mf, err := loadManifest(lom.FQN)
+	if err != nil {
+		return nil
+	}
+	return mf
+}
+
+func (lom *LOM) dropChunks(prev *chunkManifest) {
+	keep, _ := loadManifest(lom.FQN) // (nil when not chunked)
+	rmChunks(lom.Bucket(), lom.ObjName, prev, keep)
+}
+
+// link (or copy) chunks of a given manifest from `src` to `dst` bucket
+// (bucket snapshots - see xact/xs/snap.go)
+func LinkChunks(src, dst *cmn.Bck, objName, fqn string, buf []byte) error {
+	mf, err := loadManifest(fqn)
+	if err != nil {
+		return err
+	}
+	for _, tag := range mf.tags {
+		name := objName + "." + tag
+		srcFQN, err := findChunk(src, name)
+		if err != nil {
+			return err
+		}
+		dstFQN, err := chunkFQN(dst, name)
+		if err != nil {
+			return err
+		}
+		if err := LinkFile(srcFQN, dstFQN, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// whether a given chunk is (or may be) referenced by its object's manifest
+// (see space/cleanup.go)
+func ChunkInUse(bck *cmn.Bck, name string) bool {
+	objName, _, ok := fs.CSM.Resolver(fs.ChunkType).ParseUniqueFQN(name)
+	if !ok || objName == "" {
+		return false
+	}
+	tag := name[len(objName)+1:]
+	lom := AllocLOM(objName)
+	defer FreeLOM(lom)
+	if err := lom.InitBck(bck); err != nil {
+		return !cmn.IsErrBckNotFound(err)
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		if !cos.IsNotExist(err, 0) {
+			return true // when in doubt
+		}
+		// misplaced (e.g., pending resilver)
+		avail := fs.GetAvail()
+		for _, mi := range avail {
+			if cos.Stat(mi.MakePathFQN(bck, fs.ObjectType, objName)) == nil {
+				return true
+			}
+		}
+		return false
+	}
+	if !lom.IsChunked() {
+		return false
+	}
+	mf, err := loadManifest(lom.FQN)
+	if err != nil {
+		return !errors.Is(err, errBadChunks)
+	}
+	return mf.has(tag)
+}
+
+/////////////////
+// ChunkWriter //
+/////////////////
+
+// returns nil unless the bucket is configured to store objects of this size in chunks
+// (the caller must write the content and then call cw.Finish or, on error, cw.Abort)
+func (lom *LOM) NewChunkWriter(workfqn string, size int64) *ChunkWriter {
+	if !lom.ChunkEnabled() {
+		return nil
+	}
+	conf := &lom.Bprops().Chunks
+	if size < conf.ObjSize() {
+		return nil
+	}
+	return lom.newCW(workfqn, conf.Size())
+}
+
+func (lom *LOM) newCW(workfqn string, chunkSize int64) *ChunkWriter {
+	return &ChunkWriter{
+		lom:     lom,
+		workfqn: workfqn,
+		mf:      chunkManifest{chunkSize: chunkSize},
+		tag:     cos.NowRand().Uint64(),
+	}
+}
+
+// first APPEND: start with the object's current content, if exists (and is loaded)
+// - full chunks of a chunked object are shared with the new one, the rest gets copied
+// - `cksum` is updated with the entire (current) content
+func (lom *LOM) ChunkAppend(workfqn string, cksum *cos.CksumHash, buf []byte, exists bool) (*ChunkWriter, error) {
+	if !exists {
+		return lom.newCW(workfqn, lom.Bprops().Chunks.Size()), nil
+	}
+	var (
+		cw    *ChunkWriter
+		nfull int64
+	)
+	if lom.IsChunked() {
+		mf, err := loadManifest(lom.FQN)
+		if err != nil {
+			return nil, err
+		}
+		cw = lom.newCW(workfqn, mf.chunkSize)
+		num := int(mf.size / mf.chunkSize)
+		cw.mf.tags = append(cw.mf.tags, mf.tags[:num]...)
+		nfull = int64(num) * mf.chunkSize
+		cw.mf.size = nfull
+		cw.coff = mf.chunkSize // (next write starts new chunk)
+	} else {
+		cw = lom.newCW(workfqn, lom.Bprops().Chunks.Size())
+	}
+	lmfh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	if nfull > 0 {
+		_, err = io.CopyBuffer(cksum.H, io.LimitReader(lmfh, nfull), buf)
+	}
+	if err == nil {
+		_, err = io.CopyBuffer(cos.NewWriterMulti(cw, cksum.H), lmfh, buf)
+	}
+	cos.Close(lmfh)
+	if err != nil {
+		cw.Abort()
+		return nil, err
+	}
+	return cw, nil
+}
+
+// subsequent APPEND: resume writing given the manifest that was written by the previous one
+func (lom *LOM) OpenChunkWriter(workfqn string) (*ChunkWriter, error) {
+	mf, err := loadManifest(workfqn)
+	if err != nil {
+		return nil, err
+	}
+	cw := lom.newCW(workfqn, mf.chunkSize)
+	cw.mf = *mf
+	num := len(mf.tags)
+	if num == 0 {
+		return cw, nil
+	}
+	cw.coff = mf.csize(num - 1)
+	if cw.coff == mf.chunkSize {
+		return cw, nil
+	}
+	// the last (partial) chunk was written by this same APPEND sequence - append to it,
+	// discarding whatever may have been written by a failed APPEND
+	fqn, err := findChunk(lom.Bucket(), lom.ObjName+"."+mf.tags[num-1])
+	if err != nil {
+		return nil, err
+	}
+	if cw.fh, err = os.OpenFile(fqn, os.O_WRONLY, cos.PermRWR); err != nil {
+		return nil, err
+	}
+	if err = cw.fh.Truncate(cw.coff); err == nil {
+		_, err = cw.fh.Seek(cw.coff, io.SeekStart)
+	}
+	if err != nil {
+		cos.Close(cw.fh)
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *ChunkWriter) Write(b []byte) (written int, err error) {
+	for len(b) > 0 {
+		if cw.fh == nil || cw.coff == cw.mf.chunkSize {
+			if err = cw.next(); err != nil {
+				return written, err
+			}
+		}
+		var (
+			m int
+			n = min(int64(len(b)), cw.mf.chunkSize-cw.coff)
+		)
+		m, err = cw.fh.Write(b[:n])
+		written += m
+		cw.coff += int64(m)
+		cw.mf.size += int64(m)
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
+
+// close the current chunk and start the next one
+func (cw *ChunkWriter) next() error {
+	if err := cw.closeChunk(); err != nil {
+		return err
+	}
+	var (
+		lom  = cw.lom
+		tag  = strconv.FormatUint(cw.tag, 16)
+		name = fs.CSM.Resolver(fs.ChunkType).GenUniqueFQN(lom.ObjName, tag)
+	)
+	cw.tag++
+	fqn, err := chunkFQN(lom.Bucket(), name)
+	if err != nil {
+		return err
+	}
+	fh, err := lom.CreateFile(fqn)
+	if err != nil {
+		return err
+	}
+	cw.created = append(cw.created, fqn)
+	cw.mf.tags = append(cw.mf.tags, name[len(lom.ObjName)+1:])
+	cw.fh, cw.coff = fh, 0
+	return nil
+}
+
+func (cw *ChunkWriter) closeChunk() (err error) {
+	if cw.fh == nil {
+		return nil
+	}
+	if cw.lom.IsFeatureSet(feat.FsyncPUT) {
+		err = cw.fh.Sync()
+	}
+	if errC := cw.fh.Close(); err == nil {
+		err = errC
+	}
+	cw.fh = nil
+	return err
+}
+
+// close the last chunk, write the manifest into the work file, and update object metadata
+func (cw *ChunkWriter) Finish() error {
+	if err := cw.closeChunk(); err != nil {
+		return err
+	}
+	buf := cw.mf.marshal()
+	fh, err := cw.lom.CreateFile(cw.workfqn)
+	if err != nil {
+		return err
+	}
+	if _, err = fh.Write(buf); err != nil {
+		cos.Close(fh)
+		return err
+	}
+	if err = cos.FlushClose(fh); err != nil {
+		return err
+	}
+	cw.lom.ClrEncoded()
+	cw.lom.SetCustomKey(cmn.ChunkObjMD, strconv.Itoa(len(buf)))
+	cw.lom.SetSize(cw.mf.size)
+	return nil
+}
+
+// remove the chunks created by this writer (the caller removes the work file)
+func (cw *ChunkWriter) Abort() {
+	if cw.fh != nil {
+		cos.Close(cw.fh)
+		cw.fh = nil
+	}
+	for _, fqn := range cw.created {
+		if err := cos.RemoveFile(fqn); err != nil {
+			nlog.Warningln("failed to remove chunk", fqn, "err:", err)
+		}
+	}
+	cw.created = nil
+}
+
+// copy (logical) content to another object that gets chunked or not, depending on
+// its bucket and size (see lom.copy2fqn)
+func (lom *LOM) copyChunked(dst *LOM, workfqn string, buf []byte, cksumType string) (*cos.CksumHash, error) {
+	dst.ClrEncoded()
+	cw := dst.NewChunkWriter(workfqn, lom.SizeBytes())
+	if cw == nil {
+		return lom.CopyContent(workfqn, buf, cksumType)
+	}
+	lmfh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	_, cksum, err := cos.CopyAndChecksum(cw, lmfh, buf, cksumType)
+	cos.Close(lmfh)
+	if err == nil {
+		err = cw.Finish()
+	}
+	if err != nil {
+		cw.Abort()
+		return nil, err
+	}
+	return cksum, nil
+}
+
+/////////////////
+// chunkReader //
+/////////////////
+
+// given the manifest: main replica or work file
+func (lom *LOM) openChunked(fqn string) (*chunkReader, error) {
+	mf, err := loadManifest(fqn)
+	if err != nil {
+		return nil, err
+	}
+	if mf.size != lom.SizeBytes() {
+		return nil, fmt.Errorf("%w: %s size %d != %d", errBadChunks, fqn, mf.size, lom.SizeBytes())
+	}
+	return &chunkReader{mf: mf, bck: *lom.Bucket(), obj: lom.ObjName, fqn: fqn}, nil
+}
+
+func (cr *chunkReader) Read(b []byte) (n int, err error) {
+	if cr.off >= cr.mf.size {
+		return 0, io.EOF
+	}
+	idx := int(cr.off / cr.mf.chunkSize)
+	if cr.fh == nil || idx != cr.idx {
+		if err = cr.open(idx); err != nil {
+			return 0, err
+		}
+	}
+	coff := cr.off - int64(idx)*cr.mf.chunkSize
+	if rem := cr.mf.csize(idx) - coff; int64(len(b)) > rem {
+		b = b[:rem]
+	}
+	n, err = cr.fh.Read(b)
+	cr.off += int64(n)
+	if err == io.EOF {
+		err = nil
+		if n == 0 {
+			err = io.ErrUnexpectedEOF // (truncated chunk)
+		}
+	}
+	return n, err
+}
+
+func (cr *chunkReader) open(idx int) error {
+	cr.closeChunk()
+	fqn, err := findChunk(&cr.bck, cr.obj+"."+cr.mf.tags[idx])
+	if err != nil {
+		return err
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	if _, err = fh.Seek(cr.off-int64(idx)*cr.mf.chunkSize, io.SeekStart); err != nil {
+		cos.Close(fh)
+		return err
+	}
+	cr.fh, cr.idx = fh, idx
+	return nil
+}
+
+func (cr *chunkReader) closeChunk() {
+	if cr.fh != nil {
+		cos.Close(cr.fh)
+		cr.fh = nil
+	}
+}
+
+// reads only the chunks that contain the requested range
+func (cr *chunkReader) ReadAt(b []byte, off int64) (n int, err error) {
+	for n < len(b) {
+		if off >= cr.mf.size {
+			return n, io.EOF
+		}
+		var (
+			fqn  string
+			fh   *os.File
+			m    int
+			idx  = int(off / cr.mf.chunkSize)
+			coff = off - int64(idx)*cr.mf.chunkSize
+			l    = min(int64(len(b)-n), cr.mf.csize(idx)-coff)
+		)
+		if fqn, err = findChunk(&cr.bck, cr.obj+"."+cr.mf.tags[idx]); err != nil {
+			return n, err
+		}
+		if fh, err = os.Open(fqn); err != nil {
+			return n, err
+		}
+		m, err = fh.ReadAt(b[n:n+int(l)], coff)
+		cos.Close(fh)
+		n += m
+		off += int64(m)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF // (ditto)
+			}
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (cr *chunkReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += cr.off
+	case io.SeekEnd:
+		offset += cr.mf.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != cr.off {
+		cr.closeChunk()
+		cr.off = offset
+	}
+	return offset, nil
+}
+
+func (cr *chunkReader) Open() (cos.ReadOpenCloser, error) {
+	return &chunkReader{mf: cr.mf, bck: cr.bck, obj: cr.obj, fqn: cr.fqn}, nil
+}
+
+func (cr *chunkReader) Close() error {
+	cr.closeChunk()
+	return nil
+}
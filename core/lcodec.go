@@ -0,0 +1,347 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/zblk"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Content encoding at rest: compression (core/lzblk.go) and/or encryption (core/lcrypt.go)
+// - the content is first compressed and then encrypted
+// - object size (lom.SizeBytes) and checksum always refer to the original (logical) content
+// - to read the content, use lom.Open (or lom.OpenFQN) rather than opening the file directly
+// - to write, use lom.NewEncoder; content written as is (e.g., promoted) is encoded via lom.EncodeFile
+// - mirror copies are byte-for-byte replicas of the main one (and share its metadata)
+
+type (
+	// object's (logical) content - decoded on the fly when stored compressed and/or encrypted
+	LomReader interface {
+		cos.ReadOpenCloser
+		io.Seeker
+		io.ReaderAt
+	}
+
+	// compresses and/or encrypts (see NewEncoder)
+	Encoder struct {
+		io.Writer // top of the chain
+		zw        *zblk.Writer
+		ew        *crypt.Writer
+		keyID     uint32
+	}
+
+	xreader interface {
+		io.ReadSeeker
+		io.ReaderAt
+	}
+	xfile struct {
+		xreader
+		fh *os.File
+		xargs
+	}
+	xargs struct {
+		key   crypt.KeyFunc // nil unless encrypted
+		fqn   string
+		size  int64 // original
+		zsize int64 // compressed (-1 when not)
+	}
+)
+
+// interface guard
+var (
+	_ LomReader = (*xfile)(nil)
+	_ LomReader = (*cos.FileHandle)(nil)
+	_ LomReader = (*cos.ByteHandle)(nil)
+)
+
+// stored compressed and/or encrypted
+func (lom *LOM) IsEncoded() bool { return lom.IsCompressed() || lom.IsEncrypted() }
+
+// stored as is, in a single (regular) file
+func (lom *LOM) IsRaw() bool { return !lom.IsEncoded() && !lom.IsPacked() && !lom.IsChunked() }
+
+// (content written as is - see also ChunkWriter.Finish)
+func (lom *LOM) ClrEncoded() {
+	lom.ObjAttrs().DelCustomKeys(cmn.CompressObjMD, cmn.EncryptObjMD, cmn.ChunkObjMD)
+}
+
+// physical size on disk (same as lom.SizeBytes() unless encoded)
+func (lom *LOM) PhysSize() int64 {
+	size := lom.zsize()
+	if size >= 0 && lom.IsEncrypted() {
+		size = crypt.PhysSize(size)
+	}
+	return size
+}
+
+// size of the object's file (compare w/ PhysSize)
+func (lom *LOM) fsize() int64 {
+	if lom.IsChunked() {
+		return lom.msize()
+	}
+	return lom.PhysSize()
+}
+
+func (lom *LOM) Open() (LomReader, error) { return lom.OpenFQN(lom.FQN) }
+
+// given the object's file: main replica, mirror copy, or PUT workfile
+// (compare w/ cos.NewFileHandle)
+func (lom *LOM) OpenFQN(fqn string) (LomReader, error) {
+	if lom.IsPacked() {
+		return openPacked(fqn) // (never encoded)
+	}
+	if lom.IsChunked() {
+		return lom.openChunked(fqn) // (ditto)
+	}
+	if !lom.IsEncoded() {
+		fh, err := cos.NewFileHandle(fqn)
+		if err != nil {
+			return nil, err
+		}
+		return fh, nil
+	}
+	a := xargs{fqn: fqn, size: lom.SizeBytes(), zsize: -1}
+	if lom.IsCompressed() {
+		a.zsize = lom.zsize()
+	}
+	if lom.IsEncrypted() {
+		a.key = DataKeys(lom.Bucket(), lom.Bprops())
+	}
+	return openX(&a)
+}
+
+// copy (logical) content to a given destination (compare w/ cos.CopyFile)
+func (lom *LOM) CopyContent(dst string, buf []byte, cksumType string) (*cos.CksumHash, error) {
+	if lom.IsRaw() {
+		_, cksum, err := cos.CopyFile(lom.FQN, dst, buf, cksumType)
+		return cksum, err
+	}
+	lmfh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	cksum, err := cos.SaveReader(dst, lmfh, buf, cksumType, lom.SizeBytes())
+	cos.Close(lmfh)
+	return cksum, err
+}
+
+// corrupted, truncated, or failed to authenticate
+func isBadEncoding(err error) bool {
+	return errors.Is(err, zblk.ErrBadFormat) || errors.Is(err, crypt.ErrBadFormat) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, errBadChunks)
+}
+
+/////////////
+// Encoder //
+/////////////
+
+// returns nil when the bucket is configured to neither compress nor encrypt
+// (the caller must write the content and then call enc.Finish)
+func (lom *LOM) NewEncoder(w io.Writer) (*Encoder, error) {
+	var (
+		enc = &Encoder{Writer: w}
+		err error
+	)
+	if enc.ew, enc.keyID, err = lom.newEW(w); err != nil {
+		return nil, err
+	}
+	if enc.ew != nil {
+		enc.Writer = enc.ew
+	}
+	if enc.zw, err = lom.newZW(enc.Writer); err != nil {
+		return nil, err
+	}
+	if enc.zw != nil {
+		enc.Writer = enc.zw
+	}
+	if enc.zw == nil && enc.ew == nil {
+		return nil, nil
+	}
+	return enc, nil
+}
+
+// write out the remaining (compressed and/or encrypted) content and update object metadata
+// (the underlying writer is not closed)
+func (enc *Encoder) Finish(lom *LOM) error {
+	lom.ClrEncoded()
+	if enc.zw != nil {
+		if err := enc.zw.Close(); err != nil {
+			return err
+		}
+		lom.SetCompressed(enc.zw.PhysSize())
+	}
+	if enc.ew != nil {
+		if err := enc.ew.Close(); err != nil {
+			return err
+		}
+		lom.SetEncrypted(enc.keyID)
+	}
+	return nil
+}
+
+// encode (in place) the content that was written as is, e.g. promoted or concatenated
+// (S3 multipart); no-op when the bucket is configured to neither compress nor encrypt
+func (lom *LOM) EncodeFile(fqn string, buf []byte) error {
+	lom.ClrEncoded()
+	if !lom.CompressEnabled() && !lom.EncryptEnabled() {
+		return nil
+	}
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileEncode)
+	err = lom._encode(fh, workFQN, buf)
+	cos.Close(fh)
+	if err == nil {
+		err = cos.Rename(workFQN, fqn)
+	}
+	if err != nil {
+		lom.ClrEncoded()
+		if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+			nlog.Errorln("nested err:", errRemove)
+		}
+	}
+	return err
+}
+
+// rewrite the object (and its mirror copies, if any) as per the current bucket configuration:
+// compress and/or encrypt with the current data key, or decode (see also: re-encrypt xaction)
+// - the caller must hold wlock
+func (lom *LOM) Reencode(buf []byte) error {
+	var (
+		workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileEncode)
+		copies  = lom.GetCopies()
+		prev    *chunkManifest
+	)
+	if lom.IsChunked() {
+		prev = lom.prevChunks() // (no longer chunked once rewritten)
+	}
+	lmfh, err := lom.Open()
+	if err != nil {
+		return err
+	}
+	err = lom._encode(lmfh, workFQN, buf)
+	cos.Close(lmfh)
+	if err == nil {
+		err = cos.Rename(workFQN, lom.FQN)
+	}
+	if err != nil {
+		lom.Uncache()
+		if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+			nlog.Errorln("nested err:", errRemove)
+		}
+		return err
+	}
+	if prev != nil {
+		rmChunks(lom.Bucket(), lom.ObjName, prev, nil)
+	}
+	for fqn, mi := range copies {
+		if fqn == lom.FQN {
+			continue
+		}
+		workFQN := mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, fs.WorkfileEncode+"."+lom.ObjName)
+		if _, _, err = cos.CopyFile(lom.FQN, workFQN, buf, cos.ChecksumNone); err == nil {
+			err = cos.Rename(workFQN, fqn)
+		}
+		if err != nil {
+			// (the copy is stale and must go)
+			nlog.Warningln(lom.String(), "failed to update copy", fqn, "err:", err)
+			if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+				nlog.Errorln("nested err:", errRemove)
+			}
+			if err = lom.DelCopies(fqn); err != nil {
+				return err
+			}
+		}
+	}
+	if err = lom.Persist(); err != nil {
+		return err
+	}
+	return lom.syncMetaWithCopies()
+}
+
+func (lom *LOM) _encode(r io.Reader, workFQN string, buf []byte) error {
+	wfh, err := lom.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	var (
+		w        io.Writer = wfh
+		enc, erx           = lom.NewEncoder(wfh)
+	)
+	if erx != nil {
+		cos.Close(wfh)
+		return erx
+	}
+	if enc != nil {
+		w = enc
+	}
+	if _, err = io.CopyBuffer(w, r, buf); err == nil {
+		if enc != nil {
+			err = enc.Finish(lom)
+		} else {
+			lom.ClrEncoded()
+		}
+	}
+	if err != nil {
+		cos.Close(wfh)
+		return err
+	}
+	return cos.FlushClose(wfh)
+}
+
+///////////
+// xfile //
+///////////
+
+func openX(a *xargs) (*xfile, error) {
+	fh, err := os.Open(a.fqn)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		r    xreader
+		ra   io.ReaderAt = fh
+		size             = a.size
+	)
+	if a.zsize >= 0 {
+		size = a.zsize
+	}
+	if a.key != nil {
+		var er *crypt.Reader
+		if er, err = crypt.NewReader(fh, crypt.PhysSize(size), a.key); err != nil {
+			goto rerr
+		}
+		r, ra = er, er
+	}
+	if a.zsize >= 0 {
+		var zr *zblk.Reader
+		if zr, err = zblk.NewReader(ra, a.zsize); err != nil {
+			goto rerr
+		}
+		if zr.Size() != a.size {
+			err = fmt.Errorf("%w: %s size %d != %d", zblk.ErrBadFormat, a.fqn, zr.Size(), a.size)
+			goto rerr
+		}
+		r = zr
+	}
+	return &xfile{xreader: r, fh: fh, xargs: *a}, nil
+rerr:
+	cos.Close(fh)
+	return nil, err
+}
+
+func (xf *xfile) Open() (cos.ReadOpenCloser, error) { return openX(&xf.xargs) }
+func (xf *xfile) Close() error                      { return xf.fh.Close() }
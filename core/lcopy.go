@@ -6,6 +6,7 @@ package core
 
 import (
 	"fmt"
+	"math"
 	"os"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -95,7 +96,7 @@ func (lom *LOM) DelCopies(copiesFQN ...string) (err error) {
 
 	// 3. Remove the copies
 	for _, copyFQN := range copiesFQN {
-		if err1 := cos.RemoveFile(copyFQN); err1 != nil {
+		if err1 := RemoveFQN(copyFQN); err1 != nil {
 			nlog.Errorln(err1) // TODO: LRU should take care of that later.
 			continue
 		}
@@ -126,7 +127,7 @@ func (lom *LOM) DelExtraCopies(fqn ...string) (removed bool, err error) {
 		if _, ok := lom.md.copies[copyFQN]; ok {
 			continue
 		}
-		if err1 := cos.RemoveFile(copyFQN); err1 != nil {
+		if err1 := RemoveFQN(copyFQN); err1 != nil {
 			err = err1
 			continue
 		}
@@ -159,8 +160,8 @@ func (lom *LOM) syncMetaWithCopies() (err error) {
 			break
 		}
 		lom.delCopyMd(copyFQN)
-		if err1 := cos.Stat(copyFQN); err1 != nil && !os.IsNotExist(err1) {
-			T.FSHC(err, copyFQN) // TODO: notify scrubber
+		if err1 := statFQN(copyFQN); err1 != nil && !os.IsNotExist(err1) {
+			T.FSHC(err1, copyFQN) // TODO: notify scrubber
 		}
 	}
 	return
@@ -176,16 +177,16 @@ func (lom *LOM) RestoreToLocation() (exists bool) {
 		return true // nothing to do
 	}
 	var (
-		saved          = lom.md.pushrt()
-		availablePaths = fs.GetAvail()
-		buf, slab      = g.pmm.Alloc()
+		saved     = lom.md.pushrt()
+		mpaths    = restoreOrder(fs.GetAvail())
+		buf, slab = g.pmm.Alloc()
 	)
-	for path, mi := range availablePaths {
-		if path == lom.mi.Path {
+	for _, mi := range mpaths {
+		if mi.Path == lom.mi.Path {
 			continue
 		}
 		fqn := mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
-		if err := cos.Stat(fqn); err != nil {
+		if err := statFQN(fqn); err != nil {
 			continue
 		}
 		dst, err := lom._restore(fqn, buf)
@@ -205,6 +206,22 @@ func (lom *LOM) RestoreToLocation() (exists bool) {
 	return
 }
 
+// degraded mountpaths go last (that is, prefer restoring from mirror copies)
+func restoreOrder(avail fs.MPI) []*fs.Mountpath {
+	mpaths := make([]*fs.Mountpath, 0, len(avail))
+	for _, mi := range avail {
+		if !mi.IsDegraded() {
+			mpaths = append(mpaths, mi)
+		}
+	}
+	for _, mi := range avail {
+		if mi.IsDegraded() {
+			mpaths = append(mpaths, mi)
+		}
+	}
+	return mpaths
+}
+
 func (lom *LOM) _restore(fqn string, buf []byte) (dst *LOM, err error) {
 	src := lom.CloneMD(fqn)
 	defer FreeLOM(src)
@@ -227,7 +244,7 @@ func (lom *LOM) Copy(mi *fs.Mountpath, buf []byte) (err error) {
 		workFQN = mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, fs.WorkfileCopy+"."+lom.ObjName)
 	)
 	// check if the copy destination exists and then skip copying if it's also identical
-	if errExists := cos.Stat(copyFQN); errExists == nil {
+	if errExists := statFQN(copyFQN); errExists == nil {
 		cplom := AllocLOM(lom.ObjName)
 		defer FreeLOM(cplom)
 		if errExists = cplom.InitFQN(copyFQN, lom.Bucket()); errExists == nil {
@@ -238,6 +255,12 @@ func (lom *LOM) Copy(mi *fs.Mountpath, buf []byte) (err error) {
 	}
 
 	// copy
+	if lom.IsPacked() {
+		if err = lom.copyPacked(lom.FQN, copyFQN); err != nil {
+			return
+		}
+		goto add
+	}
 	_, _, err = cos.CopyFile(lom.FQN, workFQN, buf, cos.ChecksumNone) // TODO: checksumming
 	if err != nil {
 		return
@@ -283,7 +306,7 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 		srcCksum  = lom.Checksum()
 		cksumType = cos.ChecksumNone
 	)
-	if !srcCksum.IsEmpty() {
+	if !srcCksum.IsEmpty() && !lom.IsEncoded() && !lom.IsTiered() { // (encoded: copying as is; tiered: stub)
 		cksumType = srcCksum.Ty()
 	}
 	if dst.isMirror(lom) && lom.md.copies != nil {
@@ -298,12 +321,27 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 	}
 
 	workFQN := fs.CSM.Gen(dst, fs.WorkfileType, fs.WorkfileCopy)
-	_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
+	switch {
+	case lom.IsChunked() || (dst.ChunkEnabled() && lom.IsRaw()):
+		dstCksum, err = lom.copyChunked(dst, workFQN, buf, cksumType) // (never mirrored)
+	case lom.IsPacked():
+		dstCksum, err = lom.CopyContent(workFQN, buf, cksumType)
+	default:
+		_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
+	}
 	if err != nil {
 		return
 	}
 
-	if err = cos.Rename(workFQN, dstFQN); err != nil {
+	switch {
+	case !lom.isMirror(dst):
+		err = dst.RenameFrom(workFQN) // (packing the destination, if need be)
+	case lom.IsPacked():
+		err = dst.packWork(workFQN) // mirror copies are packed iff the main replica is
+	default:
+		err = cos.Rename(workFQN, dstFQN)
+	}
+	if err != nil {
 		if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
 			nlog.Errorln("nested err:", errRemove)
 		}
@@ -327,7 +365,7 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 		lom.md.copies[lom.FQN], dst.md.copies[lom.FQN] = lom.mi, lom.mi
 		if err = lom.syncMetaWithCopies(); err != nil {
 			if _, ok := lom.md.copies[dst.FQN]; !ok {
-				if errRemove := os.Remove(dst.FQN); errRemove != nil && !os.IsNotExist(errRemove) {
+				if errRemove := RemoveFQN(dst.FQN); errRemove != nil && !os.IsNotExist(errRemove) {
 					nlog.Errorln("nested err:", errRemove)
 				}
 			}
@@ -339,7 +377,7 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 		}
 		err = lom.Persist()
 	} else if err = dst.Persist(); err != nil {
-		if errRemove := os.Remove(dst.FQN); errRemove != nil && !os.IsNotExist(errRemove) {
+		if errRemove := RemoveFQN(dst.FQN); errRemove != nil && !os.IsNotExist(errRemove) {
 			nlog.Errorln("nested err:", errRemove)
 		}
 	}
@@ -363,11 +401,15 @@ func (lom *LOM) leastUtilCopy() (fqn string) {
 		copies     = lom.GetCopies()
 	)
 	fqn = lom.FQN
+	if lom.mi.IsDegraded() {
+		minUtil = math.MaxInt64 // prefer any copy
+	}
 	for copyFQN, copyMPI := range copies {
-		if copyFQN != lom.FQN {
-			if util := mpathUtils.Get(copyMPI.Path); util < minUtil {
-				fqn, minUtil = copyFQN, util
-			}
+		if copyFQN == lom.FQN || copyMPI.IsDegraded() {
+			continue
+		}
+		if util := mpathUtils.Get(copyMPI.Path); util < minUtil {
+			fqn, minUtil = copyFQN, util
 		}
 	}
 	return
@@ -382,7 +424,7 @@ func (lom *LOM) LeastUtilNoCopy() (mi *fs.Mountpath) {
 		minUtil        = int64(101) // to motivate the first assignment
 	)
 	for mpath, mpathInfo := range availablePaths {
-		if lom.haveMpath(mpath) || mpathInfo.IsAnySet(fs.FlagWaitingDD) {
+		if lom.haveMpath(mpath) || mpathInfo.IsAnySet(fs.FlagWaitingDD|fs.FlagDegraded) {
 			continue
 		}
 		if util := mpathUtils.Get(mpath); util < minUtil {
@@ -423,7 +465,7 @@ func (lom *LOM) ToMpath() (mi *fs.Mountpath, isHrw bool) {
 		return hrwMi, true
 	}
 	mirror := lom.MirrorConf()
-	if !mirror.Enabled || mirror.Copies < 2 {
+	if !mirror.Enabled || mirror.Copies < 2 || lom.IsTiered() { // (stubs are never mirrored - see core/ltier.go)
 		return
 	}
 	// count copies vs. configuration
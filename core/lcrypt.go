@@ -0,0 +1,174 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Encryption at rest (see cmn.EncryptConf and cmn/crypt)
+// - PUT into a bucket with `encryption.enabled` stores the object encrypted with the bucket's
+//   current data key; the key's ID is kept in custom key `cmn.EncryptObjMD`
+// - data keys are stored in the BMD wrapped by the master key, and get unwrapped (and cached) on demand
+// - re-encrypt (xaction) rewrites the objects that were encrypted with older data keys
+//   (or were written prior to enabling encryption, or after disabling it)
+// - see also core/lcodec.go
+
+var dkeys sync.Map // wrapped data key => unwrapped
+
+// (bucket property)
+func (lom *LOM) EncryptEnabled() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.Encrypt.Enabled
+}
+
+func (lom *LOM) IsEncrypted() bool {
+	_, ok := lom.GetCustomKey(cmn.EncryptObjMD)
+	return ok
+}
+
+// ID of the data key that was used to encrypt the object (zero if not encrypted)
+func (lom *LOM) KeyID() uint32 {
+	v, ok := lom.GetCustomKey(cmn.EncryptObjMD)
+	if !ok {
+		return 0
+	}
+	id, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(id)
+}
+
+func (lom *LOM) SetEncrypted(keyID uint32) {
+	lom.SetCustomKey(cmn.EncryptObjMD, strconv.FormatUint(uint64(keyID), 10))
+}
+
+func (lom *LOM) ClrEncrypted() { lom.ObjAttrs().DelCustomKeys(cmn.EncryptObjMD) }
+
+// data keys of a given bucket (to decrypt objects and EC slices)
+func DataKeys(bck *cmn.Bck, bprops *cmn.Bprops) crypt.KeyFunc {
+	return func(id uint32) ([]byte, error) {
+		dk := bprops.Encrypt.Key(id)
+		if dk == nil {
+			return nil, fmt.Errorf("%s: data key %d not found", bck.Cname(""), id)
+		}
+		return dataKey(dk)
+	}
+}
+
+func dataKey(dk *cmn.DataKey) ([]byte, error) {
+	if v, ok := dkeys.Load(string(dk.Wrapped)); ok {
+		return v.([]byte), nil
+	}
+	key, err := crypt.UnwrapDataKey(dk.Wrapped, dk.MasterID)
+	if err != nil {
+		return nil, err
+	}
+	dkeys.Store(string(dk.Wrapped), key)
+	return key, nil
+}
+
+// returns nil when the bucket is not configured to encrypt
+func (lom *LOM) newEW(w io.Writer) (*crypt.Writer, uint32, error) {
+	bprops := lom.Bprops()
+	if bprops == nil || !bprops.Encrypt.Enabled {
+		return nil, 0, nil
+	}
+	dk := bprops.Encrypt.Current()
+	if dk == nil {
+		return nil, 0, fmt.Errorf("%s: no data key", lom.Cname())
+	}
+	key, err := dataKey(dk)
+	if err != nil {
+		return nil, 0, err
+	}
+	ew, err := crypt.NewWriter(w, dk.ID, key)
+	return ew, dk.ID, err
+}
+
+//
+// EC slices (see ec.WriteSliceAndMeta)
+// - stored encrypted when the bucket is configured to encrypt, and always sent (received) decrypted
+// - encrypted slice is told apart by its physical size (that differs from the expected slice size)
+//
+
+func (ct *CT) WriteSlice(r io.Reader, size int64, workFQN string) (err error) {
+	bprops := ct.bck.Props
+	if bprops == nil || !bprops.Encrypt.Enabled {
+		return ct.Write(r, size, workFQN)
+	}
+	var (
+		wfh     *os.File
+		ew      *crypt.Writer
+		key     []byte
+		written int64
+		dk      = bprops.Encrypt.Current()
+	)
+	if dk == nil {
+		return fmt.Errorf("%s: no data key", ct.bck.Cname(""))
+	}
+	if key, err = dataKey(dk); err != nil {
+		return err
+	}
+	if wfh, err = cos.CreateFile(workFQN); err != nil {
+		return err
+	}
+	buf, slab := g.pmm.Alloc()
+	if ew, err = crypt.NewWriter(wfh, dk.ID, key); err == nil {
+		if written, err = io.CopyBuffer(ew, io.LimitReader(r, size), buf); err == nil {
+			if written != size {
+				err = fmt.Errorf("wrong size when saving %s: expected %d, got %d", ct.fqn, size, written)
+			} else {
+				err = ew.Close()
+			}
+		}
+	}
+	slab.Free(buf)
+	if err == nil {
+		err = cos.FlushClose(wfh)
+	} else {
+		cos.Close(wfh)
+	}
+	if err == nil {
+		err = cos.Rename(workFQN, ct.fqn)
+	}
+	if err != nil {
+		if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+			nlog.Errorln("nested err:", errRemove)
+		}
+	}
+	return err
+}
+
+// open EC slice given its expected (logical) size, e.g. ec.SliceSize(md.Size, md.Data)
+func OpenSlice(bck *meta.Bck, fqn string, size int64) (LomReader, error) {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return nil, err
+	}
+	switch finfo.Size() {
+	case size:
+		fh, err := cos.NewFileHandle(fqn)
+		if err != nil {
+			return nil, err
+		}
+		return fh, nil
+	case crypt.PhysSize(size):
+		return openX(&xargs{key: DataKeys(bck.Bucket(), bck.Props), fqn: fqn, size: size, zsize: -1})
+	default:
+		return nil, fmt.Errorf("%w: %s size %d (expecting %d)", crypt.ErrBadFormat, fqn, finfo.Size(), size)
+	}
+}
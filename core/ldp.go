@@ -49,7 +49,7 @@ func (r *deferROC) Close() (err error) {
 
 // is called under rlock; unlocks on fail
 func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) {
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open()
 	if err == nil {
 		return &deferROC{fh, lom.LIF()}, nil
 	}
@@ -62,6 +62,11 @@ func (*LDP) Reader(lom *LOM, latestVer, sync bool) (cos.ReadOpenCloser, cos.OAH,
 	lom.Lock(false)
 	loadErr := lom.Load(false /*cache it*/, true /*locked*/)
 	if loadErr == nil {
+		if lom.IsTiered() {
+			// read-through (without re-hydrating) - see core/ltier.go
+			lom.Unlock(false)
+			goto remote
+		}
 		if latestVer || sync {
 			debug.Assert(lom.Bck().IsRemote(), lom.Bck().String()) // caller's responsibility
 			res := lom.CheckRemoteMD(true /* rlocked*/, sync)
@@ -100,7 +105,12 @@ remote:
 		Cksum: cos.NoneCksum, // will likely reassign (below)
 		Atime: lom.AtimeUnix(),
 	}
-	res := T.Backend(lom.Bck()).GetObjReader(context.Background(), lom, 0, 0)
+	var res GetReaderResult
+	if tier := lom.TierBck(); tier != nil {
+		res = lom.TierReader(context.Background(), tier)
+	} else {
+		res = T.Backend(lom.Bck()).GetObjReader(context.Background(), lom, 0, 0)
+	}
 
 	if lom.Checksum() != nil {
 		oah.Cksum = lom.Checksum()
@@ -124,6 +134,10 @@ func (lom *LOM) CheckRemoteMD(locked, sync bool) (res CRMD) {
 		// that doesn't provide any versioning metadata
 		return CRMD{Eq: true}
 	}
+	if lom.IsWbackPending() {
+		// local copy is the latest (not yet written back - see lwback.go)
+		return CRMD{Eq: true}
+	}
 
 	oa, errCode, err := T.Backend(bck).HeadObj(context.Background(), lom)
 	if err == nil {
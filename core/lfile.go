@@ -5,13 +5,17 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 // (compare with cos.CreateFile)
@@ -52,12 +56,19 @@ func (lom *LOM) Remove(force ...bool) (err error) {
 		return exclusive || (len(force) > 0 && force[0] && rc > 0)
 	})
 	lom.Uncache()
-	err = cos.RemoveFile(lom.FQN)
+	lom.HotDrop()
+	lom.dropShardIdx()
+	lom.dropSnapMD()
+	lom.nidxDel()
+	if lom.IsChunked() {
+		lom.rmChunks()
+	}
+	err = RemoveFQN(lom.FQN)
 	if os.IsNotExist(err) {
 		err = nil
 	}
 	for copyFQN := range lom.md.copies {
-		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) {
+		if erc := RemoveFQN(copyFQN); erc != nil && !os.IsNotExist(erc) {
 			err = erc
 		}
 	}
@@ -71,8 +82,71 @@ func (lom *LOM) RenameFrom(workfqn string) error {
 	if err := cos.Stat(bdir); err != nil {
 		return fmt.Errorf("%s(bdir: %s): %w", lom, bdir, err)
 	}
+	lom.HotDrop() // (overwriting)
+	lom.dropShardIdx()
+	if lom.PackEnabled() {
+		if packed, err := lom.packFrom(workfqn); packed || err != nil {
+			if err == nil {
+				lom.nidxAdd()
+			}
+			return err
+		}
+	}
+	var prev *chunkManifest
+	if lom.ChunkEnabled() {
+		prev = lom.prevChunks()
+	}
 	if err := cos.Rename(workfqn, lom.FQN); err != nil {
 		return cmn.NewErrFailedTo(T, "finalize", lom, err)
 	}
+	lom.unpack()
+	if prev != nil {
+		lom.dropChunks(prev) // overwritten
+	}
+	lom.nidxAdd()
 	return nil
 }
+
+// LinkFile hard-links object's file (content and on-disk metadata, as is) when both
+// `src` and `dst` reside on the same mountpath, and copies it otherwise;
+// creates destination directory if doesn't exist and replaces existing destination
+// (used with bucket snapshots - see cmn/snap.go)
+func LinkFile(src, dst string, buf []byte) (err error) {
+	err = os.Link(src, dst)
+	if err == nil {
+		return
+	}
+	switch {
+	case os.IsNotExist(err):
+		if err = cos.CreateDir(filepath.Dir(dst)); err == nil {
+			err = os.Link(src, dst)
+		}
+		if os.IsNotExist(err) {
+			if packed, errP := unpackTo(src, dst); packed {
+				return errP // (see core/lpack.go)
+			}
+		}
+	case errors.Is(err, os.ErrExist):
+		if err = os.Remove(dst); err == nil {
+			err = os.Link(src, dst)
+		}
+	}
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return
+	}
+
+	// cross-device (slow path)
+	var md []byte
+	if md, err = fs.GetXattr(src, XattrLOM); err != nil {
+		return
+	}
+	if _, _, err = cos.CopyFile(src, dst, buf, cos.ChecksumNone); err != nil {
+		return
+	}
+	if err = fs.SetXattr(dst, XattrLOM, md); err != nil {
+		if nested := cos.RemoveFile(dst); nested != nil {
+			nlog.Errorln("nested error:", err, nested)
+		}
+	}
+	return
+}
@@ -0,0 +1,281 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Cache tier (see cmn.CacheFSPConf and fs/cachet.go)
+// - warm GETs are counted per object; upon reaching `cache_fspaths.promote_hits` the object
+//   gets copied (promoted) to its HRW cache-tier mountpath in the background
+// - subsequent GETs are served from the cache tier while the object's placement, metadata,
+//   and copies remain authoritative on the capacity tier
+// - overwriting or removing the object drops its cached copy; so does any mismatch
+//   in size, version, or checksum
+// - when a cache-tier mountpath exceeds its high watermark, least recently served copies
+//   get demoted (removed) until the used capacity goes below the low watermark
+// - cache tier is volatile: it is not persisted and gets wiped out upon restart
+
+const (
+	hotMaxHits  = 64 * 1024 // max number of tracked candidates (for promotion)
+	hotQueueLen = 256
+)
+
+type (
+	hotEntry struct {
+		mi    *fs.Mountpath
+		cksum *cos.Cksum
+		uname string
+		fqn   string
+		ver   string
+		size  int64
+		psize int64
+		atime atomic.Int64 // last served (mono)
+	}
+	hotTier struct {
+		entries map[string]*hotEntry // by uname
+		hits    map[string]int
+		workCh  chan LIF
+		mu      sync.RWMutex
+	}
+)
+
+var hot hotTier
+
+// target only; must be called after adding capacity-tier mountpaths
+func InitHot(config *cmn.Config) {
+	for _, mpath := range config.CacheFSP.Paths {
+		mi, err := fs.AddCacheTier(mpath)
+		if err != nil {
+			nlog.Errorln("failed to add cache-tier mountpath:", err)
+			continue
+		}
+		nlog.Infoln("cache-tier:", mi.String())
+	}
+	if len(fs.CacheTier()) == 0 {
+		return
+	}
+	hot.entries = make(map[string]*hotEntry, 1024)
+	hot.hits = make(map[string]int, 1024)
+	hot.workCh = make(chan LIF, hotQueueLen)
+	go hot.run()
+}
+
+func hotEnabled() bool { return len(fs.CacheTier()) > 0 }
+
+// not promoting packed, chunked, and tiered objects (or objects that are too large)
+func (lom *LOM) hotEligible(config *cmn.Config) bool {
+	if lom.IsPacked() || lom.IsChunked() || lom.IsTiered() {
+		return false
+	}
+	return lom.PhysSize() <= config.CacheFSP.ObjSize()
+}
+
+// returns the FQN of the object's cache-tier copy, or empty string if there's none
+// (or if the one that exists is stale)
+func (lom *LOM) HotFQN() string {
+	if !hotEnabled() {
+		return ""
+	}
+	hot.mu.RLock()
+	e, ok := hot.entries[lom.md.uname]
+	hot.mu.RUnlock()
+	if !ok {
+		return ""
+	}
+	if e.size != lom.md.Size || e.ver != lom.md.Version() || !e.sameCksum(lom.md.Cksum) {
+		lom.HotDrop()
+		return ""
+	}
+	e.atime.Store(mono.NanoTime())
+	return e.fqn
+}
+
+// count warm GET; schedule promotion upon reaching the configured number of hits
+func (lom *LOM) HotHit() {
+	if !hotEnabled() {
+		return
+	}
+	config := cmn.GCO.Get()
+	if !lom.hotEligible(config) {
+		return
+	}
+	uname := lom.md.uname
+	hot.mu.Lock()
+	if _, ok := hot.entries[uname]; ok {
+		hot.mu.Unlock()
+		return
+	}
+	n := hot.hits[uname] + 1
+	if n < config.CacheFSP.Hits() {
+		if len(hot.hits) >= hotMaxHits {
+			clear(hot.hits) // start over
+		}
+		hot.hits[uname] = n
+		hot.mu.Unlock()
+		return
+	}
+	delete(hot.hits, uname)
+	hot.mu.Unlock()
+
+	select {
+	case hot.workCh <- lom.LIF():
+	default: // busy promoting - skip
+	}
+}
+
+// remove the object's cache-tier copy, if any
+// (called when the object gets removed or overwritten)
+func (lom *LOM) HotDrop() {
+	if !hotEnabled() {
+		return
+	}
+	hot.mu.Lock()
+	e, ok := hot.entries[lom.md.uname]
+	if ok {
+		delete(hot.entries, lom.md.uname)
+	}
+	hot.mu.Unlock()
+	if ok {
+		e.remove()
+	}
+}
+
+// (objects in buckets without checksumming have no checksum to compare)
+func (e *hotEntry) sameCksum(cksum *cos.Cksum) bool {
+	if e.cksum.IsEmpty() || cksum.IsEmpty() {
+		return e.cksum.IsEmpty() && cksum.IsEmpty()
+	}
+	return e.cksum.Equal(cksum)
+}
+
+func (e *hotEntry) remove() {
+	if err := os.Remove(e.fqn); err != nil && !os.IsNotExist(err) {
+		nlog.Warningln("failed to remove cache-tier copy", e.fqn, err)
+	}
+}
+
+/////////////
+// hotTier //
+/////////////
+
+func (h *hotTier) run() {
+	for lif := range h.workCh {
+		lom, err := lif.LOM()
+		if err != nil {
+			continue // (bucket gone)
+		}
+		mi := fs.HrwCacheTier(lom.md.uname)
+		if mi == nil {
+			FreeLOM(lom)
+			continue
+		}
+		lom.Lock(false)
+		e, err := h.promote(lom, mi)
+		lom.Unlock(false)
+		if err != nil {
+			nlog.Warningln("failed to promote", lom.Cname(), "to", mi.String(), err)
+		} else if e != nil {
+			g.tstats.Inc(HotPromoteCount)
+			g.tstats.Add(HotPromoteSize, e.psize)
+			h.demote(mi)
+		}
+		FreeLOM(lom)
+	}
+}
+
+// copy the object (as is, including compressed and/or encrypted content) to the cache tier;
+// the entry is added while still holding the object's read lock - see also lom.Remove()
+func (h *hotTier) promote(lom *LOM, mi *fs.Mountpath) (*hotEntry, error) {
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			err = nil
+		}
+		return nil, err
+	}
+	uname := lom.md.uname
+	h.mu.RLock()
+	_, ok := h.entries[uname]
+	h.mu.RUnlock()
+	if ok || !lom.hotEligible(cmn.GCO.Get()) {
+		return nil, nil
+	}
+
+	fqn := mi.MakePathFQN(lom.Bucket(), fs.CacheType, lom.ObjName)
+	buf, slab := g.pmm.AllocSize(lom.PhysSize())
+	psize, _, err := cos.CopyFile(lom.FQN, fqn, buf, cos.ChecksumNone)
+	slab.Free(buf)
+	if err != nil {
+		return nil, err
+	}
+	e := &hotEntry{
+		mi:    mi,
+		cksum: lom.md.Cksum,
+		uname: uname,
+		fqn:   fqn,
+		ver:   lom.md.Version(),
+		size:  lom.md.Size,
+		psize: psize,
+	}
+	e.atime.Store(mono.NanoTime())
+	h.mu.Lock()
+	h.entries[uname] = e
+	h.mu.Unlock()
+	return e, nil
+}
+
+// demote least recently served copies when above high watermark
+func (h *hotTier) demote(mi *fs.Mountpath) {
+	config := cmn.GCO.Get()
+	cs, err := fs.CacheTierCap(mi, config)
+	if err != nil {
+		nlog.Errorln(mi.String(), err)
+		return
+	}
+	if int64(cs.PctUsed) <= config.CacheFSP.HighWatermark() {
+		return
+	}
+	var (
+		total  = cs.Used + cs.Avail
+		toFree = int64(total) * (int64(cs.PctUsed) - config.CacheFSP.LowWatermark()) / 100
+		cands  = make([]*hotEntry, 0, 64)
+	)
+	h.mu.RLock()
+	for _, e := range h.entries {
+		if e.mi == mi {
+			cands = append(cands, e)
+		}
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(cands, func(i, j int) bool { return cands[i].atime.Load() < cands[j].atime.Load() })
+	for _, e := range cands {
+		if toFree <= 0 {
+			break
+		}
+		h.mu.Lock()
+		current := h.entries[e.uname] == e
+		if current {
+			delete(h.entries, e.uname)
+		}
+		h.mu.Unlock()
+		if !current {
+			continue // dropped in the meantime
+		}
+		e.remove()
+		toFree -= e.psize
+		g.tstats.Inc(HotDemoteCount)
+	}
+}
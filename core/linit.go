@@ -82,7 +82,7 @@ func (lom *LOM) InitBck(bck *cmn.Bck) (err error) {
 		return
 	}
 	lom.md.uname = lom.bck.MakeUname(lom.ObjName)
-	lom.mi, lom.digest, err = fs.Hrw(lom.md.uname)
+	lom.mi, lom.digest, err = fs.Hrw(lom.bck.Bucket().HrwUname(lom.ObjName))
 	if err != nil {
 		return
 	}
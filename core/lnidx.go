@@ -0,0 +1,38 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Name index (see cmn.NameIdxConf and fs/nameidx.go)
+// - gets updated when the object's main replica gets created (PUT, copy, rebalance, etc. - see
+//   RenameFrom) and removed;
+// - updates are dropped when there's no index - the one that is being built (xs/nidx.go) is,
+//   on the other hand, updated as well
+
+func (lom *LOM) NameIdxEnabled() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.NameIdx.Enabled
+}
+
+func (lom *LOM) nidxAdd() {
+	if !lom.NameIdxEnabled() {
+		return
+	}
+	if ni := fs.NameIdxOf(lom.Bucket()); ni != nil {
+		ni.Add(lom.ObjName)
+	}
+}
+
+func (lom *LOM) nidxDel() {
+	if !lom.NameIdxEnabled() || !lom.IsHRW() {
+		return
+	}
+	if ni := fs.NameIdxOf(lom.Bucket()); ni != nil {
+		ni.Del(lom.ObjName)
+	}
+}
@@ -44,6 +44,40 @@ const (
 	LcacheCollisionCount = "lcache.collision.n"
 	LcacheEvictedCount   = "lcache.evicted.n"
 	LcacheFlushColdCount = "lcache.flush.cold.n"
+
+	// number of objects pending write-back (gauge)
+	WbackPendingCount = "wback.pending"
+
+	// replication: number of changes yet to be shipped (gauge), replication lag
+	// (the time between logging and shipping a change), and the number of shipped changes
+	ReplPendingCount = "repl.pending"
+	ReplLagLatency   = "repl.lag.ns"
+	ReplCount        = "repl.n"
+	ReplErrCount     = "err.repl.n"
+
+	// scrub: number of objects found corrupted, repaired (from mirror copies or EC),
+	// and unrecoverable
+	ScrubCorruptedCount     = "scrub.corrupted.n"
+	ScrubRepairedCount      = "scrub.repaired.n"
+	ScrubUnrecoverableCount = "scrub.unrecoverable.n"
+
+	// cache tier: GETs served from the cache tier, promoted (count and size), and demoted objects
+	HotGetCount     = "hot.get.n"
+	HotPromoteCount = "hot.promote.n"
+	HotPromoteSize  = "hot.promote.size"
+	HotDemoteCount  = "hot.demote.n"
+
+	// read-ahead: GETs served from read-ahead buffers (count and size), GETs of sequentially
+	// accessed ranges (objects) that were not, bytes read ahead, and buffers evicted unused
+	RaHitCount   = "ra.hit.n"
+	RaHitSize    = "ra.hit.size"
+	RaMissCount  = "ra.miss.n"
+	RaReadSize   = "ra.read.size"
+	RaEvictCount = "ra.evict.n"
+
+	// shard index: archived files read via index, and indexes built (see lshard.go)
+	ShardIdxGetCount   = "shard.idx.get.n"
+	ShardIdxBuildCount = "shard.idx.build.n"
 )
 
 type (
@@ -113,6 +147,7 @@ func Term() {
 		time.Sleep(sleep)
 	}
 	g.lchk.evictAll(termDuration)
+	fs.TermNameIdx()
 }
 
 /////////
@@ -175,6 +210,30 @@ func (lom *LOM) SetCustomMD(md cos.StrKVs) { lom.md.SetCustomMD(md) }
 func (lom *LOM) GetCustomKey(key string) (string, bool) { return lom.md.GetCustomKey(key) }
 func (lom *LOM) SetCustomKey(key, value string)         { lom.md.SetCustomKey(key, value) }
 
+// access counter (LFU eviction)
+func (lom *LOM) AccessCnt() (n int64) {
+	if v, ok := lom.md.GetCustomKey(cmn.AccessCntObjMD); ok {
+		n, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return n
+}
+
+// IncAccess increments the access counter iff the bucket is configured with LFU eviction;
+// the counter gets persisted lazily, along with atime (see lcache.go)
+// NOTE: cached custom metadata is shared by readers - copy-on-write
+func (lom *LOM) IncAccess() {
+	if lom.Bprops().LRU.Policy != cmn.EvictLFU {
+		return
+	}
+	custom := make(cos.StrKVs, len(lom.md.CustomMD)+1)
+	for k, v := range lom.md.CustomMD {
+		custom[k] = v
+	}
+	custom[cmn.AccessCntObjMD] = strconv.FormatInt(lom.AccessCnt()+1, 10)
+	lom.md.CustomMD = custom
+	lom.md.makeDirty()
+}
+
 // lom <= transport.ObjHdr (NOTE: caller must call freeLOM)
 func AllocLomFromHdr(hdr *transport.ObjHdr) (lom *LOM, err error) {
 	lom = AllocLOM(hdr.ObjName)
@@ -376,11 +435,11 @@ func (lom *LOM) ComputeSetCksum() (*cos.Cksum, error) {
 }
 
 func (lom *LOM) ComputeCksum(cksumType string) (cksum *cos.CksumHash, err error) {
-	var file *os.File
+	var file LomReader
 	if cksumType == cos.ChecksumNone {
 		return
 	}
-	if file, err = os.Open(lom.FQN); err != nil {
+	if file, err = lom.Open(); err != nil {
 		return
 	}
 	// No need to allocate `buf` as `io.Discard` has efficient `io.ReaderFrom` implementation.
@@ -482,6 +541,9 @@ func (lom *LOM) LoadUnsafe() (err error) {
 // store new or refresh existing
 func (lom *LOM) Recache() {
 	debug.Assert(!lom.IsCopy())
+	if lom.bck.IsSnap() {
+		return
+	}
 	md := lom.md
 	bid := lom.Bprops().BID
 	debug.Assert(bid != 0)
@@ -502,6 +564,9 @@ func (lom *LOM) Recache() {
 }
 
 func (lom *LOM) Uncache() {
+	if lom.bck.IsSnap() {
+		return
+	}
 	lcache := lom.lcache()
 	md, ok := lcache.LoadAndDelete(lom.digest)
 	if !ok {
@@ -530,7 +595,12 @@ func (lom *LOM) UncacheUnless() {
 func (lom *LOM) CacheIdx() int     { return fs.LcacheIdx(lom.digest) } // (lif.CacheIdx())
 func (lom *LOM) lcache() *sync.Map { return lom.mi.LomCache(lom.CacheIdx()) }
 
+// NOTE: snapshot objects (see cmn/snap.go) share HRW digest with their respective
+// origins and are, therefore, never cached
 func (lom *LOM) fromCache() (lcache *sync.Map, lmd *lmeta) {
+	if lom.bck.IsSnap() {
+		return
+	}
 	lcache = lom.lcache()
 	if md, ok := lcache.Load(lom.digest); ok {
 		lmd = md.(*lmeta)
@@ -547,6 +617,11 @@ func (lom *LOM) FromFS() error {
 		if !os.IsNotExist(err) {
 			err = os.NewSyscallError("stat", err)
 			T.FSHC(err, lom.FQN)
+			return err
+		}
+		// packed? (see core/lpack.go)
+		if _, errP := lom.lmpack(true); errP == nil || !os.IsNotExist(errP) {
+			return errP
 		}
 		return err
 	}
@@ -564,8 +639,10 @@ func (lom *LOM) FromFS() error {
 		return err
 	}
 	// fstat & atime
-	if lom.md.Size != finfo.Size() { // corruption or tampering
-		return cmn.NewErrLmetaCorrupted(lom.whingeSize(finfo.Size()))
+	if lom.fsize() != finfo.Size() { // corruption or tampering
+		if finfo.Size() != 0 || !lom.IsTiered() { // (tiered => zero-size stub)
+			return cmn.NewErrLmetaCorrupted(lom.whingeSize(finfo.Size()))
+		}
 	}
 	lom.md.Atime = atimefs
 	lom.md.atimefs = uint64(atimefs)
@@ -573,7 +650,7 @@ func (lom *LOM) FromFS() error {
 }
 
 func (lom *LOM) whingeSize(size int64) error {
-	return fmt.Errorf("errsize (%d != %d)", lom.md.Size, size)
+	return fmt.Errorf("errsize (%d != %d)", lom.fsize(), size)
 }
 
 func lomCaches() []*sync.Map {
@@ -5,21 +5,31 @@
 package core_test
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/crypt"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/zblk"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/tools/cryptorand"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -30,9 +40,17 @@ var _ = Describe("LOM", func() {
 		tmpDir    = "/tmp/lom_test"
 		numMpaths = 3
 
-		bucketLocalA = "LOM_TEST_Local_A"
-		bucketLocalB = "LOM_TEST_Local_B"
-		bucketLocalC = "LOM_TEST_Local_C"
+		bucketLocalA  = "LOM_TEST_Local_A"
+		bucketLocalB  = "LOM_TEST_Local_B"
+		bucketLocalC  = "LOM_TEST_Local_C"
+		bucketLocalZ  = "LOM_TEST_Local_Z"
+		bucketLocalE  = "LOM_TEST_Local_E"
+		bucketLocalZE = "LOM_TEST_Local_ZE"
+		bucketLocalP  = "LOM_TEST_Local_P"
+		bucketLocalCh = "LOM_TEST_Local_Ch"
+		bucketLocalR  = "LOM_TEST_Local_R"
+		bucketLocalT  = "LOM_TEST_Local_T"
+		bucketSnapA   = bucketLocalA + "@snap1"
 
 		bucketCloudA = "LOM_TEST_Cloud_A"
 		bucketCloudB = "LOM_TEST_Cloud_B"
@@ -41,9 +59,17 @@ var _ = Describe("LOM", func() {
 	)
 
 	var (
-		localBckA = cmn.Bck{Name: bucketLocalA, Provider: apc.AIS, Ns: cmn.NsGlobal}
-		localBckB = cmn.Bck{Name: bucketLocalB, Provider: apc.AIS, Ns: cmn.NsGlobal}
-		cloudBckA = cmn.Bck{Name: bucketCloudA, Provider: apc.AWS, Ns: cmn.NsGlobal}
+		localBckA  = cmn.Bck{Name: bucketLocalA, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckB  = cmn.Bck{Name: bucketLocalB, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckZ  = cmn.Bck{Name: bucketLocalZ, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckE  = cmn.Bck{Name: bucketLocalE, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckZE = cmn.Bck{Name: bucketLocalZE, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckP  = cmn.Bck{Name: bucketLocalP, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckCh = cmn.Bck{Name: bucketLocalCh, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckR  = cmn.Bck{Name: bucketLocalR, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckT  = cmn.Bck{Name: bucketLocalT, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		snapBckA   = cmn.Bck{Name: bucketSnapA, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		cloudBckA  = cmn.Bck{Name: bucketCloudA, Provider: apc.AWS, Ns: cmn.NsGlobal}
 	)
 
 	var (
@@ -72,6 +98,10 @@ var _ = Describe("LOM", func() {
 
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{}, true)
+	fs.CSM.Reg(fs.ShardIdxType, &fs.ShardIdxContentResolver{}, true)
+	fs.CSM.Reg(fs.NameIdxType, &fs.NameIdxContentResolver{}, true)
+	fs.CSM.Reg(fs.SnapMetaType, &fs.SnapMetaContentResolver{}, true)
 
 	bmd := mock.NewBaseBownerMock(
 		meta.NewBck(
@@ -95,6 +125,67 @@ var _ = Describe("LOM", func() {
 		meta.NewBck(bucketCloudA, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 5}),
 		meta.NewBck(bucketCloudB, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 6}),
 		meta.NewBck(sameBucketName, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 7}),
+		meta.NewBck(
+			bucketLocalZ, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:    cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Compress: cmn.CompressConf{Algo: zblk.AlgoLZ4, BlockSize: 4 * cos.KiB, Enabled: true},
+				BID:      8,
+			},
+		),
+		meta.NewBck(
+			bucketLocalE, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:   cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Encrypt: cmn.EncryptConf{Keys: testDataKeys, Enabled: true},
+				BID:     9,
+			},
+		),
+		meta.NewBck(
+			bucketLocalZE, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:    cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Compress: cmn.CompressConf{Algo: zblk.AlgoZstd, Enabled: true},
+				Encrypt:  cmn.EncryptConf{Keys: testDataKeys, Enabled: true},
+				BID:      10,
+			},
+		),
+		meta.NewBck(
+			bucketLocalP, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
+				Pack:   cmn.PackConf{MaxObjSize: 4 * cos.KiB, Enabled: true},
+				BID:    11,
+			},
+		),
+		meta.NewBck(
+			bucketLocalCh, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Chunks: cmn.ChunkConf{ChunkSize: 4 * cos.KiB, MinObjSize: 8 * cos.KiB, Enabled: true},
+				BID:    12,
+			},
+		),
+		meta.NewBck(bucketSnapA, apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 1}),
+		meta.NewBck(
+			bucketLocalR, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash},
+				// (destination's backend is mocked - see BackendMock)
+				Replication: cmn.ReplConf{Bck: cmn.Bck{Name: bucketCloudB, Provider: apc.AWS, Ns: cmn.NsGlobal}, Enabled: true},
+				BID:         13,
+			},
+		),
+		meta.NewBck(
+			bucketLocalT, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash},
+				// (ditto)
+				Tier: cmn.TierConf{Bck: cmn.Bck{Name: bucketCloudB, Provider: apc.AWS, Ns: cmn.NsGlobal}, Age: cos.Duration(time.Hour), Enabled: true},
+				BID:  14,
+			},
+		),
 	)
 
 	BeforeEach(func() {
@@ -110,6 +201,7 @@ var _ = Describe("LOM", func() {
 			_, _ = fs.Add(mpath, "daeID")
 		}
 		_ = mock.NewTarget(bmd)
+		crypt.SetProvider(testKeyProvider{})
 	})
 
 	AfterEach(func() {
@@ -582,6 +674,1033 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("Stub", func() {
+		testObject := "foldr/test-obj-tiered.ext"
+		testFileSize := 789
+		localFQN := mis[0].MakePathFQN(&localBckB, fs.ObjectType, testObject)
+
+		It("should replace object with metadata-only stub", func() {
+			lom := filePut(localFQN, testFileSize)
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			cksum := lom.Checksum().Clone()
+			Expect(cksum.IsEmpty()).To(BeFalse())
+
+			// the stub must not affect hard links (see cmn/snap.go)
+			linkFQN := localFQN + ".link"
+			Expect(os.Link(localFQN, linkFQN)).NotTo(HaveOccurred())
+
+			lom.Lock(true)
+			err := lom.Stub(meta.CloneBck(&cloudBckA))
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			finfo, err := os.Stat(localFQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(0))
+			finfo, err = os.Stat(linkFQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(testFileSize))
+
+			stub := NewBasicLom(localFQN)
+			Expect(stub.Load(false, false)).NotTo(HaveOccurred())
+			Expect(stub.IsTiered()).To(BeTrue())
+			Expect(stub.SizeBytes()).To(BeEquivalentTo(testFileSize))
+			Expect(stub.Checksum().Equal(cksum)).To(BeTrue())
+			tier := stub.TierBck()
+			Expect(tier).NotTo(BeNil())
+			Expect(tier.Equal(meta.CloneBck(&cloudBckA), false, false)).To(BeTrue())
+		})
+
+		It("should move and rebuild stub as metadata only", func() {
+			lom := filePut(localFQN, testFileSize)
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			lom.Lock(true)
+			err := lom.Stub(meta.CloneBck(&cloudBckA))
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			stub := NewBasicLom(localFQN)
+			Expect(stub.Load(false, false)).NotTo(HaveOccurred())
+			cksum := stub.Checksum().Clone()
+
+			// resilver: copying zero-size stub must not validate checksum
+			copyFQN := mis[1].MakePathFQN(&localBckB, fs.ObjectType, testObject)
+			stub.Lock(true)
+			dst, err := stub.Copy2FQN(copyFQN, make([]byte, cos.KiB))
+			stub.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dst.IsTiered()).To(BeTrue())
+			Expect(dst.SizeBytes()).To(BeEquivalentTo(testFileSize))
+			core.FreeLOM(dst)
+
+			// rebalance: header-only send (compare w/ reb/globrun.go doSend)
+			var oa cmn.ObjAttrs
+			oa.CopyFrom(stub.ObjAttrs(), false)
+			oa.Size = 0
+			rcvFQN := mis[2].MakePathFQN(&localBckB, fs.ObjectType, testObject)
+			Expect(cos.CreateDir(mis[2].MakePathBck(&localBckB))).NotTo(HaveOccurred())
+			rcv := NewBasicLom(rcvFQN)
+			Expect(rcv.StubFrom(&oa, stub.SizeBytes())).NotTo(HaveOccurred())
+
+			rcv = NewBasicLom(rcvFQN)
+			Expect(rcv.Load(false, false)).NotTo(HaveOccurred())
+			Expect(rcv.IsTiered()).To(BeTrue())
+			Expect(rcv.SizeBytes()).To(BeEquivalentTo(testFileSize))
+			Expect(rcv.Checksum().Equal(cksum)).To(BeTrue())
+			finfo, err := os.Stat(rcvFQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(0))
+		})
+	})
+
+	Describe("tiering", func() {
+		fqn := func(objName string) string {
+			fqn, _, err := core.HrwFQN(&localBckT, fs.ObjectType, objName)
+			Expect(err).NotTo(HaveOccurred())
+			return fqn
+		}
+		tierUname := func(objName string) string {
+			return meta.NewBck(bucketCloudB, apc.AWS, cmn.NsGlobal).MakeUname(objName)
+		}
+
+		It("should upload under shared lock and only then replace the object with stub", func() {
+			var (
+				tm  = mock.NewTarget(bmd)
+				bp  = mock.NewBackend()
+				lom = filePut(fqn("foldr/test-obj-tier-cold"), 1000)
+			)
+			tm.BP = bp
+			atime := time.Now().Add(-2 * time.Hour)
+			Expect(os.Chtimes(lom.FQN, atime, atime)).NotTo(HaveOccurred())
+			lom.Uncache()
+
+			bck := meta.CloneBck(&localBckT)
+			Expect(bck.Init(tm.Bowner())).NotTo(HaveOccurred())
+
+			lom.Lock(false) // reading
+			rns := xreg.RenewTier(cos.GenUUID(), bck)
+			Expect(rns.Err).NotTo(HaveOccurred())
+			xtier := rns.Entry.Get()
+
+			Eventually(func() bool {
+				_, ok := bp.Get(tierUname(lom.ObjName))
+				return ok
+			}, 10*time.Second, 10*time.Millisecond).Should(BeTrue())
+			finfo, err := os.Stat(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(1000)) // not yet
+
+			lom.Unlock(false)
+			Eventually(xtier.Finished, 10*time.Second, 10*time.Millisecond).Should(BeTrue())
+			Expect(xtier.IsAborted()).To(BeFalse())
+
+			stub := NewBasicLom(lom.FQN)
+			Expect(stub.Load(false, false)).NotTo(HaveOccurred())
+			Expect(stub.IsTiered()).To(BeTrue())
+			finfo, err = os.Stat(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeZero())
+			b, _ := bp.Get(tierUname(lom.ObjName))
+			Expect(b).To(HaveLen(1000))
+		})
+
+		It("should re-hydrate retaining version and custom metadata", func() {
+			var (
+				tm  = mock.NewTarget(bmd)
+				bp  = mock.NewBackend()
+				lom = filePut(fqn("foldr/test-obj-tier-rehydrate"), 100)
+			)
+			tm.BP = bp
+			lom.SetCustomKey("user-key", "user-value")
+			lom.SetVersion("5")
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			lom.Lock(true)
+			_, err := lom.TierPut(meta.CloneBck(&cmn.Bck{Name: bucketCloudB, Provider: apc.AWS, Ns: cmn.NsGlobal}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lom.Stub(meta.CloneBck(&cmn.Bck{Name: bucketCloudB, Provider: apc.AWS, Ns: cmn.NsGlobal}))).NotTo(HaveOccurred())
+			lom.Unlock(true)
+
+			stub := NewBasicLom(lom.FQN)
+			stub.Lock(true)
+			_, err = stub.Rehydrate(context.Background()) // (mock target: PutObject is no-op)
+			stub.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stub.IsTiered()).To(BeFalse())
+			Expect(stub.Version()).To(Equal("5"))
+			v, ok := stub.GetCustomKey("user-key")
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal("user-value"))
+			_, ok = stub.GetCustomKey(cmn.SourceObjMD) // (the tier's, not the object's)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("write-back", func() {
+		testObject := "foldr/test-obj-wback.ext"
+		cloudFQN := mis[0].MakePathFQN(&cloudBckA, fs.ObjectType, testObject)
+
+		It("should persist pending state and replay journal", func() {
+			lom := filePut(cloudFQN, 0)
+			lom.SetWbackPending()
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(core.WbackLog(lom)).NotTo(HaveOccurred())
+			Expect(core.WbackLog(lom)).NotTo(HaveOccurred()) // (dup)
+
+			pending := NewBasicLom(cloudFQN)
+			Expect(pending.Load(false, false)).NotTo(HaveOccurred())
+			Expect(pending.IsWbackPending()).To(BeTrue())
+
+			unames := core.WbackReplay()
+			Expect(unames).To(Equal([]string{lom.Uname()}))
+			Expect(core.WbackReplay()).To(BeEmpty())
+		})
+
+		It("should compact journal to contain only pending objects", func() {
+			var (
+				pending = filePut(cloudFQN, 0)
+				done    = filePut(mis[0].MakePathFQN(&cloudBckA, fs.ObjectType, "foldr/test-obj-wback-done"), 0)
+				jpath   = filepath.Join(mis[0].Path, fname.WbackJournal)
+			)
+			Expect(core.WbackLog(pending)).NotTo(HaveOccurred())
+			for range 3000 {
+				Expect(core.WbackLog(done)).NotTo(HaveOccurred())
+				core.WbackDone(done.Uname())
+			}
+			b, err := os.ReadFile(jpath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bytes.Count(b, []byte{'\n'})).To(BeNumerically("<=", 1024+1))
+
+			// (records done since the last compaction may get replayed as well)
+			unames := core.WbackReplay()
+			Expect(unames).To(HaveLen(2))
+			Expect(unames[0]).To(Equal(pending.Uname()))
+		})
+
+		It("should sync concurrently logged objects and remove journal when done", func() {
+			var (
+				wg     sync.WaitGroup
+				unames = make([]string, 32)
+				jpath  = filepath.Join(mis[0].Path, fname.WbackJournal)
+			)
+			for i := range unames {
+				lom := filePut(mis[0].MakePathFQN(&cloudBckA, fs.ObjectType, fmt.Sprintf("foldr/test-obj-wback-%d", i)), 0)
+				unames[i] = lom.Uname()
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					Expect(core.WbackLog(lom)).NotTo(HaveOccurred())
+				}()
+			}
+			wg.Wait()
+			Expect(jpath).To(BeAnExistingFile())
+			for _, uname := range unames[1:] {
+				core.WbackDone(uname)
+			}
+			Expect(jpath).To(BeAnExistingFile())
+			core.WbackDone(unames[0])
+			Expect(jpath).NotTo(BeAnExistingFile())
+
+			for i := range 2 {
+				lom := NewBasicLom(mis[0].MakePathFQN(&cloudBckA, fs.ObjectType, fmt.Sprintf("foldr/test-obj-wback-%d", i)))
+				Expect(core.WbackLog(lom)).NotTo(HaveOccurred())
+			}
+			Expect(core.WbackReplay()).To(ConsistOf(unames[:2]))
+		})
+
+		It("should write back replayed pending objects and retry failed uploads", func() {
+			var (
+				tm = mock.NewTarget(bmd)
+				bp = mock.NewBackend()
+			)
+			tm.BP = bp
+			bp.Fail = 1
+
+			lom := filePut(cloudFQN, 100)
+			lom.SetWbackPending()
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(core.WbackLog(lom)).NotTo(HaveOccurred())
+
+			// restart
+			unames := core.WbackReplay()
+			Expect(unames).To(Equal([]string{lom.Uname()}))
+			rns := xreg.RenewWback(lom)
+			Expect(rns.Err).NotTo(HaveOccurred())
+			xwb := rns.Entry.Get().(*xs.XactWback)
+			for _, uname := range unames {
+				xwb.Enqueue(uname)
+			}
+
+			Eventually(func() bool {
+				_, ok := bp.Get(lom.Uname())
+				return ok
+			}, 10*time.Second, 100*time.Millisecond).Should(BeTrue())
+			Eventually(func() bool {
+				written := NewBasicLom(cloudFQN)
+				Expect(written.Load(false, false)).NotTo(HaveOccurred())
+				return written.IsWbackPending()
+			}, 10*time.Second, 100*time.Millisecond).Should(BeFalse())
+
+			b, _ := bp.Get(lom.Uname())
+			Expect(b).To(HaveLen(100))
+			Expect(bp.Fail).To(BeZero())
+			Expect(core.WbackReplay()).To(BeEmpty())
+			xwb.Abort(nil)
+		})
+	})
+
+	Describe("replication", func() {
+		It("should replay change log in the order of logging", func() {
+			var (
+				put = filePut(mis[0].MakePathFQN(&localBckR, fs.ObjectType, "foldr/test-obj-repl-put"), 10)
+				del = filePut(mis[0].MakePathFQN(&localBckR, fs.ObjectType, "foldr/test-obj-repl-del"), 10)
+			)
+			Expect(core.ReplLog(del, core.ReplPut)).NotTo(HaveOccurred())
+			Expect(core.ReplLog(del, core.ReplDel)).NotTo(HaveOccurred())
+			Expect(del.Remove()).NotTo(HaveOccurred())
+			Expect(core.ReplLog(put, core.ReplPut)).NotTo(HaveOccurred())
+			Expect(core.ReplLog(del, core.ReplPut)).NotTo(HaveOccurred()) // (deletion takes precedence)
+
+			changes := core.ReplReplay()
+			Expect(changes).To(Equal([]core.ReplChange{
+				{Uname: del.Uname(), Op: core.ReplDel},
+				{Uname: put.Uname(), Op: core.ReplPut},
+			}))
+			Expect(core.ReplReplay()).To(BeEmpty())
+		})
+
+		It("should ship replayed changes and delete only deleted objects", func() {
+			fqn := func(objName string) string {
+				fqn, _, err := core.HrwFQN(&localBckR, fs.ObjectType, objName)
+				Expect(err).NotTo(HaveOccurred())
+				return fqn
+			}
+			var (
+				tm   = mock.NewTarget(bmd)
+				bp   = mock.NewBackend()
+				put  = filePut(fqn("foldr/test-obj-repl-put"), 10)
+				del  = filePut(fqn("foldr/test-obj-repl-del"), 10)
+				gone = NewBasicLom(fqn("foldr/test-obj-repl-gone")) // e.g., failed PUT or migrated
+				dst  = func(lom *core.LOM) string {
+					return meta.NewBck(bucketCloudB, apc.AWS, cmn.NsGlobal).MakeUname(lom.ObjName)
+				}
+			)
+			tm.BP = bp
+			bp.Objs[dst(del)] = []byte("del")
+			bp.Objs[dst(gone)] = []byte("gone")
+
+			Expect(core.ReplLog(put, core.ReplPut)).NotTo(HaveOccurred())
+			Expect(core.ReplLog(gone, core.ReplPut)).NotTo(HaveOccurred())
+			Expect(core.ReplLog(del, core.ReplDel)).NotTo(HaveOccurred())
+			Expect(del.Remove()).NotTo(HaveOccurred())
+
+			// restart
+			changes := core.ReplReplay()
+			Expect(changes).To(HaveLen(3))
+			rns := xreg.RenewRepl(put)
+			Expect(rns.Err).NotTo(HaveOccurred())
+			xrepl := rns.Entry.Get().(*xs.XactRepl)
+			for _, change := range changes {
+				xrepl.Enqueue(change.Uname, change.Op)
+			}
+			Eventually(xrepl.Pending, 10*time.Second, 100*time.Millisecond).Should(BeZero())
+
+			b, ok := bp.Get(dst(put))
+			Expect(ok).To(BeTrue())
+			Expect(b).To(HaveLen(10))
+			_, ok = bp.Get(dst(del))
+			Expect(ok).To(BeFalse())
+			b, ok = bp.Get(dst(gone))
+			Expect(ok).To(BeTrue())
+			Expect(string(b)).To(Equal("gone"))
+			Expect(bp.Deleted).To(Equal([]string{dst(del)}))
+			xrepl.Abort(nil)
+		})
+	})
+
+	Describe("bucket snapshot", func() {
+		const testObject = "foldr/test-obj-snap.ext"
+		var (
+			localFQN = mis[0].MakePathFQN(&localBckA, fs.ObjectType, testObject)
+			snapFQN  = mis[0].MakePathFQN(&snapBckA, fs.ObjectType, testObject)
+			mdFQN    = mis[0].MakePathFQN(&snapBckA, fs.SnapMetaType, testObject)
+		)
+		customValue := func(lom *core.LOM) string {
+			v, _ := lom.GetCustomKey("k")
+			return v
+		}
+
+		It("should co-locate snapshot objects with their origin", func() {
+			base, snap, ok := snapBckA.SnapBase()
+			Expect(ok).To(BeTrue())
+			Expect(base).To(Equal(localBckA))
+			Expect(localBckA.SnapBck(snap)).To(Equal(snapBckA))
+			for i := range 32 {
+				objName := fmt.Sprintf("dir/obj-%d", i)
+				lom, slom := &core.LOM{ObjName: objName}, &core.LOM{ObjName: objName}
+				Expect(lom.InitBck(&localBckA)).NotTo(HaveOccurred())
+				Expect(slom.InitBck(&snapBckA)).NotTo(HaveOccurred())
+				Expect(slom.Mountpath()).To(Equal(lom.Mountpath()))
+				Expect(slom.FQN).NotTo(Equal(lom.FQN))
+				Expect(slom.Uname()).NotTo(Equal(lom.Uname()))
+			}
+		})
+
+		It("should keep snapshot metadata intact and restore it", func() {
+			lom := filePut(localFQN, 100)
+			lom.SetCustomKey("k", "v1")
+			Expect(persist(lom)).NotTo(HaveOccurred())
+
+			lom.Lock(false)
+			err := core.LinkSnap(lom, &snapBckA, nil)
+			lom.Unlock(false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mdFQN).To(BeAnExistingFile())
+
+			// origin changes, snapshot does not
+			lom.SetCustomKey("k", "v2")
+			lom.SetAtimeUnix(time.Now().UnixNano())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+
+			slom := NewBasicLom(snapFQN)
+			Expect(slom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(customValue(slom)).To(Equal("v1"))
+			Expect(slom.SizeBytes()).To(BeEquivalentTo(100))
+
+			lom = NewBasicLom(localFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(customValue(lom)).To(Equal("v2"))
+
+			// restore (same content)
+			lom.Lock(true)
+			err = lom.RestoreSnapMD(slom)
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+			lom = NewBasicLom(localFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(customValue(lom)).To(Equal("v1"))
+
+			// removing snapshot object removes its metadata
+			slom.Lock(true)
+			Expect(slom.Remove()).NotTo(HaveOccurred())
+			slom.Unlock(true)
+			Expect(mdFQN).NotTo(BeAnExistingFile())
+			Expect(localFQN).To(BeAnExistingFile())
+		})
+
+		It("should store metadata of received (rebalanced) snapshot objects separately", func() {
+			// (compare w/ ais/tgtobj.go putOI.finalize)
+			workFQN := mis[0].MakePathFQN(&snapBckA, fs.WorkfileType, testObject)
+			createTestFile(workFQN, 200)
+			slom := NewBasicLom(snapFQN)
+			slom.SetSize(200)
+			slom.SetCustomKey("k", "v3")
+			Expect(slom.RenameFrom(workFQN)).NotTo(HaveOccurred())
+			Expect(persist(slom)).NotTo(HaveOccurred())
+			Expect(mdFQN).To(BeAnExistingFile())
+
+			_, err := fs.GetXattr(snapFQN, core.XattrLOM)
+			Expect(err).To(HaveOccurred())
+
+			slom = NewBasicLom(snapFQN)
+			Expect(slom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(customValue(slom)).To(Equal("v3"))
+			Expect(slom.SizeBytes()).To(BeEquivalentTo(200))
+
+			// metadata alone is not an object
+			Expect(os.Remove(snapFQN)).NotTo(HaveOccurred())
+			slom = NewBasicLom(snapFQN)
+			Expect(os.IsNotExist(slom.Load(false, false))).To(BeTrue())
+		})
+
+		It("should fence writes", func() {
+			lom := filePut(localFQN, 10)
+			Expect(core.FenceWrites(&localBckA, "xid1")).To(BeTrue())
+			Expect(core.FenceWrites(&localBckA, "xid2")).To(BeFalse())
+			Expect(lom.Fenced()).To(HaveOccurred())
+			Expect(NewBasicLom(mis[0].MakePathFQN(&localBckB, fs.ObjectType, testObject)).Fenced()).NotTo(HaveOccurred())
+
+			core.UnfenceWrites(&localBckA, "xid2") // (not fenced by)
+			Expect(lom.Fenced()).To(HaveOccurred())
+			core.UnfenceWrites(&localBckA, "xid1")
+			Expect(lom.Fenced()).NotTo(HaveOccurred())
+			core.UnfenceWrites(&localBckA, "xid1")
+		})
+
+		It("should re-link rebalanced snapshot object and origin with identical content", func() {
+			put := func(fqn, value string, content []byte) {
+				Expect(cos.CreateDir(filepath.Dir(fqn))).NotTo(HaveOccurred())
+				os.Remove(fqn) // (new inode, same as PUT via work file)
+				Expect(os.WriteFile(fqn, content, cos.PermRWR)).NotTo(HaveOccurred())
+				cksum := cos.NewCksumHash(cos.ChecksumXXHash)
+				cksum.H.Write(content)
+				cksum.Finalize()
+				lom := NewBasicLom(fqn)
+				lom.SetSize(int64(len(content)))
+				lom.SetCksum(cksum.Clone())
+				lom.SetCustomKey("k", value)
+				Expect(persist(lom)).NotTo(HaveOccurred())
+				lom.UncacheUnless()
+			}
+			reshare := func(fqn string) bool {
+				lom := NewBasicLom(fqn)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+				return lom.Reshare()
+			}
+			shared := func() bool {
+				fa, err := os.Stat(localFQN)
+				Expect(err).NotTo(HaveOccurred())
+				fb, err := os.Stat(snapFQN)
+				Expect(err).NotTo(HaveOccurred())
+				return os.SameFile(fa, fb)
+			}
+			loadValue := func(fqn string) string {
+				lom := NewBasicLom(fqn)
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				return customValue(lom)
+			}
+			content := []byte("the same content")
+
+			// snapshot object arrives last
+			put(localFQN, "origin", content)
+			put(snapFQN, "snap", content)
+			Expect(shared()).To(BeFalse())
+			Expect(reshare(snapFQN)).To(BeTrue())
+			Expect(shared()).To(BeTrue())
+			Expect(loadValue(localFQN)).To(Equal("origin"))
+			Expect(loadValue(snapFQN)).To(Equal("snap"))
+
+			// origin arrives last
+			put(localFQN, "origin2", content)
+			Expect(shared()).To(BeFalse())
+			Expect(reshare(localFQN)).To(BeTrue())
+			Expect(shared()).To(BeTrue())
+			Expect(loadValue(localFQN)).To(Equal("origin2"))
+			Expect(loadValue(snapFQN)).To(Equal("snap"))
+			Expect(reshare(localFQN)).To(BeFalse()) // (nothing to do)
+
+			// different content
+			put(localFQN, "origin3", []byte("different content"))
+			Expect(reshare(localFQN)).To(BeFalse())
+			Expect(reshare(snapFQN)).To(BeFalse())
+			Expect(shared()).To(BeFalse())
+
+			Expect(os.Remove(snapFQN)).NotTo(HaveOccurred())
+			Expect(os.Remove(mis[0].MakePathFQN(&snapBckA, fs.SnapMetaType, testObject))).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("content encoding at rest", func() {
+		testObject := "foldr/test-obj-encoded.ext"
+
+		// (compare w/ ais/tgtobj.go putOI.write)
+		put := func(fqn string, content []byte) *core.LOM {
+			lom := NewBasicLom(fqn)
+			_ = os.Remove(fqn)
+			fh, err := cos.CreateFile(fqn)
+			Expect(err).NotTo(HaveOccurred())
+			enc, err := lom.NewEncoder(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enc).NotTo(BeNil())
+			_, cksum, err := cos.CopyAndChecksum(enc, bytes.NewReader(content), nil, cos.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(enc.Finish(lom)).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+
+			lom.SetSize(int64(len(content)))
+			lom.SetCksum(cksum.Clone())
+			lom.IncVersion()
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.UncacheUnless()
+			return lom
+		}
+		content := func(size int) []byte {
+			b := make([]byte, size)
+			for i := range b {
+				b[i] = byte('a' + i%7)
+			}
+			_, _ = cryptorand.Read(b[size/2 : size/2+1000]) // (some incompressible blocks)
+			return b
+		}
+
+		for _, bck := range []cmn.Bck{localBckZ, localBckE, localBckZE} {
+			bck := bck
+			fqn := mis[0].MakePathFQN(&bck, fs.ObjectType, testObject)
+
+			It(bck.Name+": should read logical content and ranges, and validate checksum", func() {
+				b := content(150*cos.KiB + 123)
+				_ = put(fqn, b)
+
+				lom := NewBasicLom(fqn)
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				Expect(lom.IsCompressed()).To(Equal(lom.CompressEnabled()))
+				Expect(lom.IsEncrypted()).To(Equal(lom.EncryptEnabled()))
+				Expect(lom.SizeBytes()).To(BeEquivalentTo(len(b)))
+				finfo, err := os.Stat(fqn)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lom.PhysSize()).To(Equal(finfo.Size()))
+				if lom.IsCompressed() {
+					Expect(lom.PhysSize()).To(BeNumerically("<", lom.SizeBytes()))
+				}
+				Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+				fh, err := lom.Open()
+				Expect(err).NotTo(HaveOccurred())
+				all, err := io.ReadAll(fh)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(all).To(Equal(b))
+
+				rng := make([]byte, 70*cos.KiB)
+				off := int64(len(b)/2 - 35*cos.KiB)
+				n, err := fh.ReadAt(rng, off)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(len(rng)))
+				Expect(rng).To(Equal(b[off : off+int64(len(rng))]))
+				Expect(fh.Close()).NotTo(HaveOccurred())
+
+				dst := fqn + ".copy"
+				cksum, err := lom.CopyContent(dst, nil, cos.ChecksumXXHash)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cksum.Equal(lom.Checksum())).To(BeTrue())
+				Expect(getTestFileHash(dst)).To(Equal(lom.Checksum().Value()))
+			})
+
+			It(bck.Name+": should detect corrupted content", func() {
+				_ = put(fqn, content(20*cos.KiB))
+
+				f, err := os.OpenFile(fqn, os.O_WRONLY, 0)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = f.WriteAt([]byte("garbage"), 100)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(f.Close()).NotTo(HaveOccurred())
+
+				lom := NewBasicLom(fqn)
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				Expect(lom.ValidateContentChecksum()).To(HaveOccurred())
+
+				lom.Lock(false)
+				nbad, err := lom.Scrub(nil)
+				lom.Unlock(true) // (upgraded)
+				Expect(err).To(Equal(core.ErrNoHealthyReplica))
+				Expect(nbad).To(Equal(1))
+			})
+		}
+
+		It("should encode content written as is, and re-encode", func() {
+			var (
+				b       = content(100 * cos.KiB)
+				fqn     = mis[0].MakePathFQN(&localBckZE, fs.ObjectType, testObject)
+				workFQN = fqn + ".work"
+			)
+			Expect(cos.CreateDir(mis[0].MakePathBck(&localBckZE))).NotTo(HaveOccurred())
+
+			// e.g., promoted
+			createTestFile(workFQN, 0)
+			Expect(os.WriteFile(workFQN, b, cos.PermRWR)).NotTo(HaveOccurred())
+			lom := NewBasicLom(fqn)
+			lom.SetSize(int64(len(b)))
+			Expect(lom.EncodeFile(workFQN, nil)).NotTo(HaveOccurred())
+			Expect(lom.IsCompressed() && lom.IsEncrypted()).To(BeTrue())
+			Expect(lom.KeyID()).To(BeEquivalentTo(2)) // current
+			fh, err := lom.OpenFQN(workFQN)
+			Expect(err).NotTo(HaveOccurred())
+			all, err := io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+			Expect(all).To(Equal(b))
+
+			// e.g., written prior to enabling encryption
+			createTestFile(fqn, 0)
+			Expect(os.WriteFile(fqn, b, cos.PermRWR)).NotTo(HaveOccurred())
+			lom = NewBasicLom(fqn)
+			lom.SetSize(int64(len(b)))
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(lom.IsEncoded()).To(BeFalse())
+
+			lom.Lock(true)
+			err = lom.Reencode(nil)
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			lom = NewBasicLom(fqn)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsCompressed() && lom.IsEncrypted()).To(BeTrue())
+			Expect(lom.KeyID()).To(BeEquivalentTo(2))
+			fh, err = lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			all, err = io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+			Expect(all).To(Equal(b))
+		})
+	})
+
+	Describe("packing small objects", func() {
+		const testObject = "foldr/test-obj-packed.ext"
+
+		// (compare w/ ais/tgtobj.go putOI.finalize)
+		put := func(bck *cmn.Bck, size int) *core.LOM {
+			fqn := mis[0].MakePathFQN(bck, fs.ObjectType, testObject)
+			Expect(cos.CreateDir(mis[0].MakePathBck(bck))).NotTo(HaveOccurred())
+			workFQN := mis[0].MakePathFQN(bck, fs.WorkfileType, testObject)
+			createTestFile(workFQN, size)
+			lom := NewBasicLom(fqn)
+			lom.SetSize(int64(size))
+			lom.SetCksum(cos.NewCksum(cos.ChecksumXXHash, getTestFileHash(workFQN)))
+			lom.IncVersion()
+			Expect(lom.RenameFrom(workFQN)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.UncacheUnless()
+			return lom
+		}
+
+		It("should pack, read, copy, and remove small objects", func() {
+			lom := put(&localBckP, 3*cos.KiB)
+			Expect(lom.IsPacked()).To(BeTrue())
+			Expect(lom.FQN).NotTo(BeAnExistingFile())
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsPacked()).To(BeTrue())
+			Expect(lom.SizeBytes()).To(BeEquivalentTo(3 * cos.KiB))
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+			fh, err := lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			all, err := io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+			Expect(all).To(HaveLen(3 * cos.KiB))
+
+			// mirror copy is packed as well
+			lom.Lock(true)
+			err = lom.Copy(mis[1], nil)
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+			copyFQN := mis[1].MakePathFQN(&localBckP, fs.ObjectType, testObject)
+			Expect(copyFQN).NotTo(BeAnExistingFile())
+			Expect(lom.GetCopies()).To(HaveKey(copyFQN))
+
+			lom.Lock(true)
+			Expect(lom.Remove()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			lom = NewBasicLom(lom.FQN)
+			Expect(os.IsNotExist(lom.Load(false, false))).To(BeTrue())
+		})
+
+		// (rebalance - see ais/tgtobj_internal_test.go)
+		It("should resilver packed objects", func() {
+			lom := put(&localBckP, 3*cos.KiB)
+			Expect(lom.IsPacked()).To(BeTrue())
+
+			// resilver (compare w/ res/resilver.go fixHrw)
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			err := lom.Copy(mis[2], nil)
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+			hlom := NewBasicLom(mis[2].MakePathFQN(&localBckP, fs.ObjectType, testObject))
+			Expect(hlom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(hlom.IsPacked()).To(BeTrue())
+			Expect(hlom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+
+		It("should not pack large objects and should unpack when overwritten", func() {
+			lom := put(&localBckP, 2*cos.KiB)
+			Expect(lom.IsPacked()).To(BeTrue())
+
+			lom = put(&localBckP, 5*cos.KiB)
+			Expect(lom.IsPacked()).To(BeFalse())
+			Expect(lom.FQN).To(BeAnExistingFile())
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsPacked()).To(BeFalse())
+			Expect(lom.SizeBytes()).To(BeEquivalentTo(5 * cos.KiB))
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("read-ahead", func() {
+		// (read-ahead reloads objects at their HRW locations)
+		hrwPut := func(name string, size int) (*core.LOM, []byte) {
+			lom := core.AllocLOM(name)
+			Expect(lom.InitBck(&localBckA)).NotTo(HaveOccurred())
+			fqn := lom.FQN
+			core.FreeLOM(lom)
+			lom = filePut(fqn, size)
+			b, err := os.ReadFile(fqn)
+			Expect(err).NotTo(HaveOccurred())
+			return lom, b
+		}
+		read := func(sgl *memsys.SGL) []byte {
+			defer sgl.Free()
+			return sgl.ReadAll()
+		}
+
+		It("should read ahead sequential ranges and objects", func() {
+			if memsys.PageMM().Pressure() >= memsys.PressureHigh {
+				Skip("memory pressure") // (no reading ahead)
+			}
+			config := cmn.GCO.BeginUpdate()
+			config.ReadAhead = cmn.ReadAheadConf{Enabled: true, Depth: 2}
+			cmn.GCO.CommitUpdate(config)
+			defer func() {
+				config := cmn.GCO.BeginUpdate()
+				config.ReadAhead = cmn.ReadAheadConf{}
+				cmn.GCO.CommitUpdate(config)
+			}()
+			core.InitReadAhead()
+
+			// ranges
+			const rsize = 4 * cos.KiB
+			lom, content := hrwPut("foldr/test-obj-ra-ranges", 16*rsize)
+			Expect(lom.ReadAhead(0, rsize, true)).To(BeNil())
+			Expect(lom.ReadAhead(rsize, rsize, true)).To(BeNil()) // sequential
+			Eventually(core.TestReadAheadLen).Should(Equal(2))
+			sgl := lom.ReadAhead(2*rsize, rsize, true)
+			Expect(sgl).NotTo(BeNil())
+			Expect(read(sgl)).To(Equal(content[2*rsize : 3*rsize]))
+			Eventually(core.TestReadAheadLen).Should(Equal(2))        // (3*rsize, 4*rsize)
+			Expect(lom.ReadAhead(8*rsize, rsize, true)).To(BeNil())   // not sequential
+			Expect(lom.ReadAhead(3*rsize, 2*rsize, true)).To(BeNil()) // size mismatch
+			Expect(core.TestReadAheadLen()).To(Equal(1))
+
+			// objects in name order
+			var (
+				names    = []string{"foldr/ra/obj-a", "foldr/ra/obj-b", "foldr/ra/obj-c", "foldr/ra/obj-d"}
+				loms     = make([]*core.LOM, len(names))
+				contents = make([][]byte, len(names))
+			)
+			for i, name := range names {
+				loms[i], contents[i] = hrwPut(name, 1000+i)
+			}
+			Expect(loms[0].ReadAhead(0, loms[0].SizeBytes(), false)).To(BeNil())
+			Expect(loms[1].ReadAhead(0, loms[1].SizeBytes(), false)).To(BeNil()) // sequential
+			Eventually(core.TestReadAheadLen).Should(Equal(3))
+			for i := 2; i < len(names); i++ {
+				sgl := loms[i].ReadAhead(0, loms[i].SizeBytes(), false)
+				Expect(sgl).NotTo(BeNil())
+				Expect(read(sgl)).To(Equal(contents[i]))
+			}
+		})
+	})
+
+	Describe("cache tier", func() {
+		const testObject = "foldr/test-obj-hot"
+		var cacheDir = filepath.Join(tmpDir, "cache-tier")
+
+		BeforeEach(func() {
+			Expect(cos.CreateDir(cacheDir)).NotTo(HaveOccurred())
+			config := cmn.GCO.BeginUpdate()
+			config.CacheFSP = cmn.CacheFSPConf{Paths: []string{cacheDir}, PromoteHits: 2}
+			cmn.GCO.CommitUpdate(config)
+			core.InitHot(config)
+		})
+		AfterEach(func() {
+			config := cmn.GCO.BeginUpdate()
+			config.CacheFSP = cmn.CacheFSPConf{}
+			cmn.GCO.CommitUpdate(config)
+			fs.TestResetCacheTier()
+		})
+
+		hotPut := func(size int) (*core.LOM, []byte) {
+			lom := core.AllocLOM(testObject)
+			Expect(lom.InitBck(&localBckA)).NotTo(HaveOccurred())
+			fqn := lom.FQN
+			core.FreeLOM(lom)
+			lom = filePut(fqn, size)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			b, err := os.ReadFile(fqn)
+			Expect(err).NotTo(HaveOccurred())
+			return lom, b
+		}
+		promote := func(lom *core.LOM) string {
+			for range cmn.GCO.Get().CacheFSP.Hits() {
+				Expect(lom.HotFQN()).To(BeEmpty())
+				lom.HotHit()
+			}
+			Eventually(lom.HotFQN).ShouldNot(BeEmpty())
+			return lom.HotFQN()
+		}
+
+		It("should promote upon reaching promote-hits and serve the copy", func() {
+			lom, content := hotPut(4 * cos.KiB)
+			hfqn := promote(lom)
+			Expect(strings.HasPrefix(hfqn, cacheDir)).To(BeTrue())
+			Expect(os.ReadFile(hfqn)).To(Equal(content))
+		})
+
+		It("should drop the copy when the object is overwritten or removed", func() {
+			lom, _ := hotPut(4 * cos.KiB)
+			hfqn := promote(lom)
+
+			// overwritten (different size, version, and content)
+			lom, _ = hotPut(8 * cos.KiB)
+			Expect(lom.HotFQN()).To(BeEmpty())
+			Expect(hfqn).NotTo(BeAnExistingFile())
+
+			hfqn = promote(lom)
+			lom.Lock(true)
+			Expect(lom.Remove()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(hfqn).NotTo(BeAnExistingFile())
+		})
+
+		It("should not promote objects larger than max-obj-size", func() {
+			config := cmn.GCO.BeginUpdate()
+			config.CacheFSP.MaxObjSize = cos.KiB
+			cmn.GCO.CommitUpdate(config)
+
+			lom, _ := hotPut(4 * cos.KiB)
+			for range 2 * config.CacheFSP.Hits() {
+				lom.HotHit()
+			}
+			Consistently(lom.HotFQN, 200*time.Millisecond).Should(BeEmpty())
+		})
+	})
+
+	Describe("chunked storage of large objects", func() {
+		const testObject = "foldr/test-obj-chunked.ext"
+
+		numChunks := func(bck *cmn.Bck) (n int) {
+			for _, mi := range mis {
+				names, _ := filepath.Glob(mi.MakePathCT(bck, fs.ChunkType) + "/foldr/*")
+				n += len(names)
+			}
+			return n
+		}
+		randBytes := func(size int) []byte {
+			b := make([]byte, size)
+			_, _ = cryptorand.Read(b)
+			return b
+		}
+		xxsum := func(b []byte) *cos.Cksum {
+			_, cksum, err := cos.CopyAndChecksum(io.Discard, bytes.NewReader(b), nil, cos.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			return cksum.Clone()
+		}
+		// (compare w/ ais/tgtobj.go putOI.write and putOI.finalize)
+		put := func(bck *cmn.Bck, content []byte) *core.LOM {
+			fqn := mis[0].MakePathFQN(bck, fs.ObjectType, testObject)
+			Expect(cos.CreateDir(mis[0].MakePathBck(bck))).NotTo(HaveOccurred())
+			workFQN := mis[0].MakePathFQN(bck, fs.WorkfileType, testObject)
+			lom := NewBasicLom(fqn)
+			if cw := lom.NewChunkWriter(workFQN, int64(len(content))); cw != nil {
+				_, err := cw.Write(content)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cw.Finish()).NotTo(HaveOccurred())
+			} else {
+				Expect(os.WriteFile(workFQN, content, cos.PermRWR)).NotTo(HaveOccurred())
+				lom.ClrEncoded()
+				lom.SetSize(int64(len(content)))
+			}
+			lom.SetCksum(xxsum(content))
+			lom.IncVersion()
+			Expect(lom.RenameFrom(workFQN)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.UncacheUnless()
+			return lom
+		}
+
+		It("should write, read, and remove chunked objects", func() {
+			content := randBytes(10*cos.KiB + 123)
+			lom := put(&localBckCh, content)
+			Expect(lom.IsChunked()).To(BeTrue())
+			Expect(numChunks(&localBckCh)).To(Equal(3))
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsChunked()).To(BeTrue())
+			Expect(lom.SizeBytes()).To(BeEquivalentTo(len(content)))
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+			fh, err := lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			all, err := io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(all).To(Equal(content))
+
+			// range read across chunk boundary
+			buf := make([]byte, 2*cos.KiB)
+			n, err := fh.ReadAt(buf, 3*cos.KiB)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf[:n]).To(Equal(content[3*cos.KiB : 5*cos.KiB]))
+			_, err = fh.Seek(9*cos.KiB, io.SeekStart)
+			Expect(err).NotTo(HaveOccurred())
+			all, err = io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(all).To(Equal(content[9*cos.KiB:]))
+			Expect(fh.Close()).NotTo(HaveOccurred())
+
+			lom.Lock(true)
+			Expect(lom.Remove()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(numChunks(&localBckCh)).To(BeZero())
+		})
+
+		It("should remove chunks when overwritten", func() {
+			lom := put(&localBckCh, randBytes(9*cos.KiB))
+			Expect(lom.IsChunked()).To(BeTrue())
+			Expect(numChunks(&localBckCh)).To(Equal(3))
+
+			lom = put(&localBckCh, randBytes(16*cos.KiB))
+			Expect(numChunks(&localBckCh)).To(Equal(4))
+
+			lom = put(&localBckCh, randBytes(cos.KiB))
+			Expect(lom.IsChunked()).To(BeFalse())
+			Expect(numChunks(&localBckCh)).To(BeZero())
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+
+		It("should append to chunked objects", func() {
+			content := randBytes(9 * cos.KiB)
+			lom := put(&localBckCh, content)
+			prefix := numChunks(&localBckCh)
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			workFQN := mis[0].MakePathFQN(&localBckCh, fs.WorkfileType, testObject+".append")
+			cksum := cos.NewCksumHash(cos.ChecksumXXHash)
+			cw, err := lom.ChunkAppend(workFQN, cksum, nil, true /*exists*/)
+			Expect(err).NotTo(HaveOccurred())
+			more := randBytes(3 * cos.KiB)
+			_, err = cw.Write(more)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cw.Finish()).NotTo(HaveOccurred())
+			Expect(core.IsChunkManifest(workFQN)).To(BeTrue())
+
+			// next append
+			cw, err = lom.OpenChunkWriter(workFQN)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cw.Write(more)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cw.Finish()).NotTo(HaveOccurred())
+
+			content = append(content, more...)
+			content = append(content, more...)
+			lom.SetCksum(xxsum(content))
+			Expect(lom.RenameFrom(workFQN)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			// 2 full chunks shared, the last one (1KiB) replaced by 2 new ones
+			Expect(numChunks(&localBckCh)).To(Equal(prefix + 1))
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.SizeBytes()).To(BeEquivalentTo(len(content)))
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("copy object methods", func() {
 		const (
 			testObjectName = "foldr/test-obj.ext"
@@ -992,6 +2111,94 @@ var _ = Describe("LOM", func() {
 				Expect(lom.GetCopies()).To(BeNil())
 			})
 		})
+
+		Describe("Scrub", func() {
+			corrupt := func(fqn string) {
+				buf := make([]byte, testFileSize)
+				_, _ = cryptorand.Read(buf)
+				Expect(os.WriteFile(fqn, buf, cos.PermRWR)).NotTo(HaveOccurred()) // (retains xattrs)
+			}
+
+			It("should find no corrupted replicas", func() {
+				lom := prepareLOM(mirrorFQNs[0])
+				_ = prepareCopy(lom, mirrorFQNs[1])
+
+				// (verified under shared lock - concurrently with other readers)
+				lom.Lock(false)
+				defer lom.Unlock(false)
+				other := NewBasicLom(mirrorFQNs[0])
+				Expect(other.TryLock(false)).To(BeTrue())
+				defer other.Unlock(false)
+
+				Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+				nbad, err := lom.Scrub(make([]byte, testFileSize))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(nbad).To(BeZero())
+			})
+
+			It("should repair corrupted main replica and copy", func() {
+				lom := prepareLOM(mirrorFQNs[0])
+				_ = prepareCopy(lom, mirrorFQNs[1])
+				_ = prepareCopy(lom, mirrorFQNs[2])
+				expectedHash := getTestFileHash(lom.FQN)
+				corrupt(mirrorFQNs[0])
+				corrupt(mirrorFQNs[2])
+
+				lom.Lock(false)
+				Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+				nbad, err := lom.Scrub(make([]byte, testFileSize))
+				lom.Unlock(true) // (upgraded to repair)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(nbad).To(Equal(2))
+
+				lom = NewBasicLom(mirrorFQNs[0])
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+				Expect(lom.NumCopies()).To(Equal(3))
+				for _, fqn := range mirrorFQNs {
+					Expect(getTestFileHash(fqn)).To(Equal(expectedHash))
+				}
+			})
+
+			It("should upgrade to repair once other readers are done", func() {
+				lom := prepareLOM(mirrorFQNs[0])
+				_ = prepareCopy(lom, mirrorFQNs[1])
+				expectedHash := getTestFileHash(lom.FQN)
+				corrupt(mirrorFQNs[1])
+
+				other := NewBasicLom(mirrorFQNs[0])
+				other.Lock(false)
+				lom.Lock(false)
+				Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+				done := make(chan int)
+				go func() {
+					defer GinkgoRecover()
+					nbad, err := lom.Scrub(make([]byte, testFileSize))
+					Expect(err).NotTo(HaveOccurred())
+					lom.Unlock(true)
+					done <- nbad
+				}()
+				Consistently(done, 100*time.Millisecond).ShouldNot(Receive())
+				other.Unlock(false)
+				Eventually(done, 5*time.Second).Should(Receive(Equal(1)))
+				Expect(getTestFileHash(mirrorFQNs[1])).To(Equal(expectedHash))
+			})
+
+			It("should fail to repair when all replicas are corrupted", func() {
+				lom := prepareLOM(mirrorFQNs[0])
+				_ = prepareCopy(lom, mirrorFQNs[1])
+				corrupt(mirrorFQNs[0])
+				corrupt(mirrorFQNs[1])
+
+				lom.Lock(false)
+				defer lom.Unlock(true)
+				Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+				nbad, err := lom.Scrub(make([]byte, testFileSize))
+				Expect(err).To(Equal(core.ErrNoHealthyReplica))
+				Expect(nbad).To(Equal(2))
+				Expect(mirrorFQNs[0]).To(BeARegularFile()) // (left in place)
+			})
+		})
 	})
 
 	Describe("local and cloud bucket with the same name", func() {
@@ -1057,6 +2264,18 @@ func filePut(fqn string, size int) *core.LOM {
 	return lom
 }
 
+// data keys "wrapped" as is
+type testKeyProvider struct{}
+
+var testDataKeys = []cmn.DataKey{
+	{ID: 1, Wrapped: bytes.Repeat([]byte{1}, crypt.KeySize), MasterID: "test"},
+	{ID: 2, Wrapped: bytes.Repeat([]byte{2}, crypt.KeySize), MasterID: "test"},
+}
+
+func (testKeyProvider) Name() string                                    { return "test" }
+func (testKeyProvider) Wrap(dek []byte) ([]byte, string, error)         { return dek, "test", nil }
+func (testKeyProvider) Unwrap(wrapped []byte, _ string) ([]byte, error) { return wrapped, nil }
+
 func createTestFile(fqn string, size int) {
 	_ = os.Remove(fqn)
 	testFile, err := cos.CreateFile(fqn)
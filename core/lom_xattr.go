@@ -114,6 +114,9 @@ func (lom *LOM) lmfsReload(populate bool) (md *lmeta, err error) {
 }
 
 func (lom *LOM) lmfs(populate bool) (md *lmeta, err error) {
+	if lom.bck.IsSnap() {
+		return lom.lmsnap(populate) // hard-linked with the origin - see core/lsnap.go
+	}
 	var (
 		size      int64
 		read      []byte
@@ -123,6 +126,11 @@ func (lom *LOM) lmfs(populate bool) (md *lmeta, err error) {
 	read, err = fs.GetXattrBuf(lom.FQN, XattrLOM, buf)
 	if err != nil {
 		slab.Free(buf)
+		if os.IsNotExist(err) {
+			if md, errP := lom.lmpack(populate); errP == nil || !os.IsNotExist(errP) {
+				return md, errP // packed (see core/lpack.go)
+			}
+		}
 		if err != syscall.ERANGE {
 			return whingeLmeta(err)
 		}
@@ -166,7 +174,7 @@ func (lom *LOM) PersistMain() (err error) {
 	}
 	// write-immediate (default)
 	buf := lom.marshal()
-	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
+	if err = lom.setmd(buf, atime); err != nil {
 		lom.Uncache()
 		T.FSHC(err, lom.FQN)
 	} else {
@@ -194,7 +202,7 @@ func (lom *LOM) Persist() (err error) {
 	}
 
 	buf := lom.marshal()
-	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
+	if err = lom.setmd(buf, atime); err != nil {
 		lom.Uncache()
 		T.FSHC(err, lom.FQN)
 	} else {
@@ -211,13 +219,16 @@ func (lom *LOM) Persist() (err error) {
 }
 
 func (lom *LOM) persistMdOnCopies() (copyFQN string, err error) {
-	buf := lom.marshal()
+	var (
+		buf    = lom.marshal()
+		packed = lom.IsPacked()
+	)
 	// replicate across copies
 	for copyFQN = range lom.md.copies {
 		if copyFQN == lom.FQN {
 			continue
 		}
-		if err = fs.SetXattr(copyFQN, XattrLOM, buf); err != nil {
+		if err = setLmeta(copyFQN, buf, lom.AtimeUnix(), packed); err != nil {
 			break
 		}
 	}
@@ -227,6 +238,10 @@ func (lom *LOM) persistMdOnCopies() (copyFQN string, err error) {
 
 // NOTE: not clearing dirty flag as the caller will uncache anyway
 func (lom *LOM) flushCold(md *lmeta, atime time.Time) {
+	if md.isPacked() {
+		lom.flushPacked(md, atime)
+		return
+	}
 	if err := lom.flushAtime(atime); err != nil {
 		return
 	}
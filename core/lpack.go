@@ -0,0 +1,281 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Packing small objects (see cmn.PackConf and fs/pack.go)
+// - PUT into a bucket with `packing.enabled` appends objects of up to `packing.max_obj_size`
+//   to the pack of the object's mountpath; packed objects carry custom key `cmn.PackObjMD`
+// - there's no file behind a packed object's FQN - the methods that read, write, copy, and
+//   remove object replicas resolve the FQN to its mountpath's pack instead
+// - mirror copies of a packed object are packed as well (each in its own mountpath)
+// - fs.Walk visits packed objects along with regular ones (list-objects, rebalance, LRU, etc.)
+
+const packedVal = "1"
+
+// (bucket property)
+func (lom *LOM) PackEnabled() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.Pack.Enabled
+}
+
+func (lom *LOM) IsPacked() bool {
+	_, ok := lom.GetCustomKey(cmn.PackObjMD)
+	return ok
+}
+
+func (md *lmeta) isPacked() bool {
+	_, ok := md.GetCustomKey(cmn.PackObjMD)
+	return ok
+}
+
+// (when there's no file) load metadata and atime from the pack, if packed
+func (lom *LOM) lmpack(populate bool) (*lmeta, error) {
+	pk, err := lom.mi.Pack(lom.Bucket(), false)
+	if err != nil {
+		return nil, err
+	}
+	if pk == nil {
+		return nil, pkNotExist(lom.FQN)
+	}
+	buf, atime, size, err := pk.MD(lom.ObjName)
+	if err != nil {
+		return nil, err
+	}
+	md := &lom.md
+	if !populate {
+		md = &lmeta{}
+	}
+	if err := md.unmarshal(buf); err != nil {
+		return nil, cmn.NewErrLmetaCorrupted(err)
+	}
+	if md.Size != size {
+		return nil, cmn.NewErrLmetaCorrupted(lom.whingeSize(size))
+	}
+	md.Atime, md.atimefs = atime, uint64(atime)
+	return md, nil
+}
+
+func pkNotExist(fqn string) error {
+	return &os.PathError{Op: "packed", Path: fqn, Err: os.ErrNotExist}
+}
+
+// (compare w/ fs.SetXattr)
+func setLmeta(fqn string, buf []byte, atime int64, packed bool) error {
+	if !packed {
+		return fs.SetXattr(fqn, XattrLOM, buf)
+	}
+	pk, objName, err := fs.PackOf(fqn, false)
+	if err != nil {
+		return err
+	}
+	if pk == nil {
+		return pkNotExist(fqn)
+	}
+	return pk.SetMD(objName, buf, atime)
+}
+
+// (compare w/ flushCold)
+func (lom *LOM) flushPacked(md *lmeta, atime time.Time) {
+	if lom.WritePolicy() == apc.WriteNever {
+		return
+	}
+	lom.md = *md
+	if md.isDirty() {
+		if err := lom.syncMetaWithCopies(); err != nil {
+			return
+		}
+	}
+	buf := lom.marshal()
+	if err := setLmeta(lom.FQN, buf, atime.UnixNano(), true); err != nil {
+		T.FSHC(err, lom.FQN)
+	}
+	g.smm.Free(buf)
+}
+
+// given packed replica (main or mirror copy)
+func openPacked(fqn string) (LomReader, error) {
+	data, err := readPacked(fqn)
+	if err != nil {
+		return nil, err
+	}
+	return cos.NewByteHandle(data), nil
+}
+
+func readPacked(fqn string) ([]byte, error) {
+	pk, objName, err := fs.PackOf(fqn, false)
+	if err != nil {
+		return nil, err
+	}
+	if pk == nil {
+		return nil, pkNotExist(fqn)
+	}
+	return pk.Read(objName)
+}
+
+// put packed replica with the object's (current) metadata
+func (lom *LOM) putPacked(fqn string, data []byte) error {
+	pk, objName, err := fs.PackOf(fqn, true)
+	if err != nil {
+		return err
+	}
+	lom.SetCustomKey(cmn.PackObjMD, packedVal)
+	buf := lom.marshal()
+	err = pk.Put(objName, buf, lom.AtimeUnix(), data, lom.Bprops().Pack.PackSize())
+	g.smm.Free(buf)
+	return err
+}
+
+// when configured and small enough, pack the work file (see RenameFrom)
+func (lom *LOM) packFrom(workfqn string) (bool, error) {
+	finfo, err := os.Stat(workfqn)
+	if err != nil || finfo.Size() > lom.Bprops().Pack.ObjSize() {
+		return false, nil // (rename will handle it)
+	}
+	return true, lom.packWork(workfqn)
+}
+
+// pack and remove the work file, and remove the object's file, if exists;
+// the metadata is taken from the work file if it has one (e.g., restoring
+// from a bucket snapshot) or, otherwise, from the `lom` itself
+func (lom *LOM) packWork(workfqn string) error {
+	data, err := os.ReadFile(workfqn)
+	if err != nil {
+		return err
+	}
+	if buf, errX := fs.GetXattr(workfqn, XattrLOM); errX == nil {
+		saved := lom.md.pushrt()
+		if err := lom.md.unmarshal(buf); err != nil {
+			return cmn.NewErrLmetaCorrupted(err)
+		}
+		lom.md.poprt(saved)
+	}
+	if err := lom.putPacked(lom.FQN, data); err != nil {
+		return cmn.NewErrFailedTo(T, "pack", lom, err)
+	}
+	if err := cos.RemoveFile(workfqn); err != nil {
+		nlog.Warningln(lom.String(), "failed to remove packed", workfqn, "err:", err)
+	}
+	if err := cos.RemoveFile(lom.FQN); err != nil {
+		nlog.Warningln(lom.String(), "failed to remove unpacked", lom.FQN, "err:", err)
+	}
+	return nil
+}
+
+// upon writing the object (as a regular file): remove its packed version, if any
+func (lom *LOM) unpack() {
+	lom.ObjAttrs().DelCustomKeys(cmn.PackObjMD)
+	pk, err := lom.mi.Pack(lom.Bucket(), false)
+	if pk == nil || err != nil {
+		return
+	}
+	if _, err := pk.Delete(lom.ObjName); err != nil {
+		nlog.Warningln(lom.String(), "failed to remove packed:", err)
+	}
+}
+
+// copy packed replica `src` to (packed) `dst`
+func (lom *LOM) copyPacked(src, dst string) error {
+	data, err := readPacked(src)
+	if err == nil {
+		err = lom.putPacked(dst, data)
+	}
+	return err
+}
+
+// file or packed (compare w/ cos.Stat)
+func statFQN(fqn string) error {
+	err := cos.Stat(fqn)
+	if err == nil || !os.IsNotExist(err) {
+		return err
+	}
+	pk, objName, errP := fs.PackOf(fqn, false)
+	if errP != nil || pk == nil || !pk.Has(objName) {
+		return err
+	}
+	return nil
+}
+
+// remove object replica: file or packed (compare w/ cos.RemoveFile)
+func RemoveFQN(fqn string) error {
+	err := os.Remove(fqn)
+	if err == nil || !os.IsNotExist(err) {
+		return err
+	}
+	pk, objName, errP := fs.PackOf(fqn, false)
+	if errP != nil || pk == nil {
+		return nil
+	}
+	_, err = pk.Delete(objName)
+	return err
+}
+
+// materialize packed replica as a regular file (with metadata)
+// (used to snapshot packed objects - see LinkFile)
+func unpackTo(src, dst string) (bool, error) {
+	pk, objName, err := fs.PackOf(src, false)
+	if err != nil || pk == nil || !pk.Has(objName) {
+		return false, err
+	}
+	var (
+		md   lmeta
+		data []byte
+	)
+	buf, _, _, err := pk.MD(objName)
+	if err != nil {
+		return true, err
+	}
+	if err = md.unmarshal(buf); err != nil {
+		return true, cmn.NewErrLmetaCorrupted(err)
+	}
+	md.DelCustomKeys(cmn.PackObjMD)
+	if data, err = pk.Read(objName); err != nil {
+		return true, err
+	}
+	if err = cos.CreateDir(filepath.Dir(dst)); err == nil {
+		err = os.WriteFile(dst, data, cos.PermRWR)
+	}
+	if err == nil {
+		buf = md.marshal(g.maxLmeta.Load())
+		if err = fs.SetXattr(dst, XattrLOM, buf); err != nil {
+			if nested := cos.RemoveFile(dst); nested != nil {
+				nlog.Errorln("nested error:", err, nested)
+			}
+		}
+		g.smm.Free(buf)
+	}
+	return true, err
+}
+
+// reclaim space taken by deleted and overwritten packed objects
+// (see space/cleanup.go and space/lru.go)
+func CompactPack(mi *fs.Mountpath, bck *cmn.Bck) (int64, error) {
+	pk, err := mi.Pack(bck, false)
+	if pk == nil || err != nil {
+		return 0, err
+	}
+	b := meta.CloneBck(bck)
+	if err := b.InitFast(T.Bowner()); err != nil {
+		return 0, err
+	}
+	conf := &b.Props.Pack
+	reclaimed, err := pk.Compact(conf.Compact(), conf.PackSize())
+	if reclaimed > 0 {
+		nlog.Infoln(pk.String(), "compacted, reclaimed", cos.ToSizeIEC(reclaimed, 2))
+	}
+	return reclaimed, err
+}
@@ -0,0 +1,456 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Read-ahead (see cmn.ReadAheadConf)
+// - ranges: a range GET that starts where the previous one (of the same object) has ended
+//   is sequential - the next `depth` ranges (of the same size) get read in the background;
+// - objects: a GET of the object that follows, in name order, the previously read object
+//   in the same virtual directory is sequential - the next `depth` objects get read, in
+//   name order, per the bucket's name index or, if there's none, the local directories;
+// - read-ahead buffers are memsys SGLs; a buffer is used (and freed) by the GET that
+//   reads the corresponding range (object) or else gets evicted - in the order of insertion -
+//   when the total exceeds `max_mem`;
+// - buffers are validated against the object's size, version, and checksum (compare w/ lhot.go)
+
+const (
+	raMaxStreams = 16 * 1024 // max number of tracked objects (ranges) and directories (objects)
+	raQueueLen   = 256
+	raWorkers    = 4
+
+	raEnd = "\xff" // nothing (more) to read ahead in a given directory
+)
+
+type (
+	raTask struct {
+		lif  LIF    // object (ranges) or the last object read (objects)
+		dkey string // objects only
+		off  int64  // ranges: offset of the first range to read
+		size int64  // ranges: range size
+		n    int    // number of ranges (objects) to read
+	}
+	raEntry struct {
+		sgl   *memsys.SGL
+		cksum *cos.Cksum
+		ver   string
+		osize int64 // object size
+	}
+	// sequential ranges of a given object
+	raStream struct {
+		next  int64 // expected offset of the next range
+		ahead int64 // scheduled to read ahead up to
+	}
+	// objects read in name order (in a given virtual directory)
+	raDir struct {
+		last    string // last object read
+		ahead   string // last object read ahead
+		pending bool   // reading ahead
+	}
+	readAhead struct {
+		entries map[string]*raEntry  // by raKey
+		streams map[string]*raStream // by uname
+		dirs    map[string]*raDir    // by bucket's uname + directory
+		fifo    []string             // entries in the order of insertion
+		workCh  chan raTask
+		mem     atomic.Int64 // total size of read-ahead buffers
+		mu      sync.Mutex
+	}
+)
+
+var ra readAhead
+
+// target only
+func InitReadAhead() {
+	ra.entries = make(map[string]*raEntry, 64)
+	ra.streams = make(map[string]*raStream, 64)
+	ra.dirs = make(map[string]*raDir, 64)
+	ra.workCh = make(chan raTask, raQueueLen)
+	for range raWorkers {
+		go ra.run()
+	}
+}
+
+func raKey(uname string, off int64) string { return uname + "@" + strconv.FormatInt(off, 10) }
+
+// GET of a range or (when `ranged` is false) entire object:
+//   - returns read-ahead buffer that contains exactly the requested bytes, if any (the caller
+//     then owns the buffer and must free it);
+//   - detects sequential access and schedules reading ahead
+func (lom *LOM) ReadAhead(off, size int64, ranged bool) (sgl *memsys.SGL) {
+	conf := &cmn.GCO.Get().ReadAhead
+	if !conf.Enabled || ra.workCh == nil {
+		if ra.mem.Load() > 0 {
+			ra.purge()
+		}
+		return nil
+	}
+	if lom.IsTiered() {
+		return nil
+	}
+	var (
+		task raTask
+		seq  bool
+		key  = raKey(lom.md.uname, off)
+	)
+	ra.mu.Lock()
+	e, ok := ra.entries[key]
+	if ok {
+		delete(ra.entries, key)
+		ra.mem.Sub(e.sgl.Size())
+	}
+	if ranged {
+		seq = ra.ranges(lom, off, size, conf, &task)
+	} else {
+		seq = ra.objects(lom, conf, &task)
+	}
+	ra.mu.Unlock()
+
+	if ok {
+		if e.sgl.Size() == size && e.valid(lom) {
+			sgl = e.sgl
+			g.tstats.Inc(RaHitCount)
+			g.tstats.Add(RaHitSize, size)
+		} else {
+			e.sgl.Free()
+		}
+	}
+	if sgl == nil && seq {
+		g.tstats.Inc(RaMissCount)
+	}
+	if task.n > 0 {
+		task.lif = lom.LIF()
+		select {
+		case ra.workCh <- task:
+		default: // busy - skip
+			ra.mu.Lock()
+			ra.unschedule(lom, &task)
+			ra.mu.Unlock()
+		}
+	}
+	return sgl
+}
+
+// (compare w/ HotFQN)
+func (e *raEntry) valid(lom *LOM) bool {
+	if e.osize != lom.md.Size || e.ver != lom.md.Version() {
+		return false
+	}
+	if e.cksum.IsEmpty() && lom.md.Cksum.IsEmpty() {
+		return true // (checksum "none")
+	}
+	return e.cksum.Equal(lom.md.Cksum)
+}
+
+// (under lock) returns true if sequential; keeps `depth` ranges ahead of the current one
+func (ra *readAhead) ranges(lom *LOM, off, size int64, conf *cmn.ReadAheadConf, task *raTask) bool {
+	uname := lom.md.uname
+	st, ok := ra.streams[uname]
+	if !ok {
+		if len(ra.streams) >= raMaxStreams {
+			clear(ra.streams) // start over
+		}
+		ra.streams[uname] = &raStream{next: off + size}
+		return false
+	}
+	seq := off == st.next
+	st.next = off + size
+	if !seq {
+		st.ahead = 0
+		return false
+	}
+	osize := lom.md.Size
+	if st.next >= osize {
+		delete(ra.streams, uname) // done
+		return true
+	}
+	if size <= 0 || size > conf.Size() {
+		return true
+	}
+	from := max(st.next, st.ahead)
+	to := min(st.next+int64(conf.NumAhead())*size, osize)
+	if from < to {
+		task.off, task.size = from, size
+		task.n = int((to - from + size - 1) / size)
+		st.ahead = to
+	}
+	return true
+}
+
+// (under lock) returns true if sequential; reads ahead `depth` objects at a time
+func (ra *readAhead) objects(lom *LOM, conf *cmn.ReadAheadConf, task *raTask) bool {
+	var (
+		name = lom.ObjName
+		dkey = lom.bck.MakeUname(filepath.Dir(name))
+	)
+	d, ok := ra.dirs[dkey]
+	if !ok {
+		if len(ra.dirs) >= raMaxStreams {
+			clear(ra.dirs)
+		}
+		ra.dirs[dkey] = &raDir{last: name}
+		return false
+	}
+	seq := name > d.last
+	d.last = name
+	if !seq {
+		d.ahead = ""
+		return false
+	}
+	if d.pending || name < d.ahead {
+		return true
+	}
+	d.pending = true
+	task.dkey, task.n = dkey, conf.NumAhead()
+	return true
+}
+
+// (under lock) failed to schedule
+func (ra *readAhead) unschedule(lom *LOM, task *raTask) {
+	if task.dkey == "" {
+		if st, ok := ra.streams[lom.md.uname]; ok {
+			st.ahead = 0
+		}
+	} else if d, ok := ra.dirs[task.dkey]; ok {
+		d.pending = false
+	}
+}
+
+// free all buffers (upon disabling read-ahead)
+func (ra *readAhead) purge() {
+	ra.mu.Lock()
+	for _, e := range ra.entries {
+		e.sgl.Free()
+	}
+	clear(ra.entries)
+	clear(ra.streams)
+	clear(ra.dirs)
+	ra.fifo = ra.fifo[:0]
+	ra.mem.Store(0)
+	ra.mu.Unlock()
+}
+
+///////////////
+// readAhead //
+///////////////
+
+func (ra *readAhead) run() {
+	for task := range ra.workCh {
+		lom, err := task.lif.LOM()
+		if err != nil {
+			continue // (bucket gone)
+		}
+		conf := &cmn.GCO.Get().ReadAhead
+		if task.dkey == "" {
+			ra.readRanges(lom, &task, conf)
+		} else {
+			ra.readObjs(lom, &task, conf)
+		}
+		FreeLOM(lom)
+	}
+}
+
+func (ra *readAhead) readRanges(lom *LOM, task *raTask, conf *cmn.ReadAheadConf) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return
+	}
+	defer cos.Close(fh)
+	off, osize := task.off, lom.md.Size
+	for i := 0; i < task.n && off < osize; i++ {
+		size := min(task.size, osize-off)
+		if !ra.read(lom, fh, off, size, conf) {
+			break
+		}
+		off += size
+	}
+}
+
+func (ra *readAhead) readObjs(last *LOM, task *raTask, conf *cmn.ReadAheadConf) {
+	var (
+		ahead string
+		names = raNext(last, task.n)
+	)
+	if len(names) == 0 {
+		ahead = raEnd
+	}
+	for _, name := range names {
+		lom := AllocLOM(name)
+		if err := lom.InitBck(last.Bucket()); err != nil {
+			FreeLOM(lom)
+			break
+		}
+		ok := ra.readObj(lom, conf)
+		FreeLOM(lom)
+		if !ok {
+			break
+		}
+		ahead = name
+	}
+	ra.mu.Lock()
+	if d, ok := ra.dirs[task.dkey]; ok {
+		d.pending = false
+		d.ahead = ahead
+	}
+	ra.mu.Unlock()
+}
+
+func (ra *readAhead) readObj(lom *LOM, conf *cmn.ReadAheadConf) bool {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return !cos.IsNotExist(err, 0) // (skip removed)
+	}
+	if lom.IsTiered() || lom.md.Size > conf.Size() {
+		return true
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return false
+	}
+	ok := ra.read(lom, fh, 0, lom.md.Size, conf)
+	cos.Close(fh)
+	return ok
+}
+
+// read into SGL and add
+func (ra *readAhead) read(lom *LOM, fh LomReader, off, size int64, conf *cmn.ReadAheadConf) bool {
+	if g.pmm.Pressure() >= memsys.PressureHigh {
+		return false
+	}
+	key := raKey(lom.md.uname, off)
+	ra.mu.Lock()
+	_, ok := ra.entries[key]
+	ra.mu.Unlock()
+	if ok {
+		return true
+	}
+	sgl := g.pmm.NewSGL(size)
+	n, err := sgl.ReadFrom(io.NewSectionReader(fh, off, size))
+	if err != nil || n != size {
+		sgl.Free()
+		return false
+	}
+	e := &raEntry{sgl: sgl, cksum: lom.md.Cksum, ver: lom.md.Version(), osize: lom.md.Size}
+	ra.add(key, e, conf.Mem())
+	g.tstats.Add(RaReadSize, size)
+	return true
+}
+
+// add and evict the oldest when over budget
+func (ra *readAhead) add(key string, e *raEntry, maxMem int64) {
+	var evicted int64
+	ra.mu.Lock()
+	if prev, ok := ra.entries[key]; ok {
+		ra.mem.Sub(prev.sgl.Size())
+		prev.sgl.Free()
+	}
+	ra.entries[key] = e
+	ra.fifo = append(ra.fifo, key)
+	ra.mem.Add(e.sgl.Size())
+	for len(ra.fifo) > 0 && ra.mem.Load() > maxMem {
+		k := ra.fifo[0]
+		ra.fifo = ra.fifo[1:]
+		if old, ok := ra.entries[k]; ok {
+			delete(ra.entries, k)
+			ra.mem.Sub(old.sgl.Size())
+			old.sgl.Free()
+			evicted++
+		}
+	}
+	// keys of the entries that have been used (or replaced) remain in the fifo - compact
+	if len(ra.fifo) > 2*len(ra.entries)+raQueueLen {
+		fifo := make([]string, 0, len(ra.entries))
+		for _, k := range ra.fifo {
+			if _, ok := ra.entries[k]; ok {
+				fifo = append(fifo, k)
+			}
+		}
+		ra.fifo = slices.Compact(fifo)
+	}
+	ra.mu.Unlock()
+	if evicted > 0 {
+		g.tstats.Add(RaEvictCount, evicted)
+	}
+}
+
+// next (up to n) objects that follow the given one, in name order, in its virtual directory
+func raNext(lom *LOM, n int) (names []string) {
+	var (
+		name = lom.ObjName
+		dir  string
+	)
+	if i := strings.LastIndexByte(name, filepath.Separator); i >= 0 {
+		dir = name[:i+1]
+	}
+	if ni := fs.NameIdxOf(lom.Bucket()); ni != nil && ni.Ready() {
+		if it, err := ni.Iter(name); err == nil {
+			defer it.Close()
+			for len(names) < n {
+				next, ok, err := it.Next()
+				if err != nil || !ok || !strings.HasPrefix(next, dir) {
+					break
+				}
+				if next != name && !strings.ContainsRune(next[len(dir):], filepath.Separator) {
+					names = append(names, next)
+				}
+			}
+			return names
+		}
+	}
+
+	// otherwise, the local directories
+	var (
+		base  = filepath.Base(name)
+		avail = fs.GetAvail()
+	)
+	for _, mi := range avail {
+		des, err := os.ReadDir(filepath.Dir(mi.MakePathFQN(lom.Bucket(), fs.ObjectType, name)))
+		if err != nil {
+			continue
+		}
+		i := sort.Search(len(des), func(i int) bool { return des[i].Name() > base })
+		for cnt := 0; i < len(des) && cnt < n; i++ {
+			if !des[i].IsDir() {
+				names = append(names, dir+des[i].Name())
+				cnt++
+			}
+		}
+	}
+	sort.Strings(names)
+	names = slices.Compact(names) // (copies)
+	if len(names) > n {
+		names = names[:n]
+	}
+	return names
+}
+
+// NOTE: used only in tests
+func TestReadAheadLen() int {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	return len(ra.entries)
+}
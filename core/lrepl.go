@@ -0,0 +1,134 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Replication (see cmn.ReplConf and xact/xs/repl.go)
+// - PUT, DELETE, and rename of objects in a bucket with replication enabled get logged
+//   in the change log (journal.go) that survives restarts; the same applies to objects
+//   received via rebalance (the new owner takes over)
+// - each change log record is the operation (ReplOp) followed by the object's uname
+// - replication is state-based (and idempotent): it is the current state of a logged object
+//   that gets shipped - the object is uploaded to the destination if exists; otherwise,
+//   it is deleted from the destination only if the logged change is a deletion (ReplDel)
+//   - a PUT that has failed (or an object that has been migrated) must not delete anything;
+//   multiple changes of the same object may therefore collapse into a single one
+// - the number of changes that are yet to be shipped is reported via target stats (ReplPendingCount)
+
+type (
+	ReplOp byte
+
+	// (see ReplReplay)
+	ReplChange struct {
+		Uname string
+		Op    ReplOp
+	}
+)
+
+const (
+	ReplPut ReplOp = 'P' // PUT (including the destination of rename)
+	ReplDel ReplOp = 'D' // DELETE (including the source of rename)
+)
+
+var rplj = newJournal(fname.ReplJournal, "replication", ReplPendingCount)
+
+// returns nil unless the object's bucket is being replicated (bucket property)
+func (lom *LOM) ReplBck() *meta.Bck {
+	bprops := lom.Bprops()
+	if bprops == nil || !bprops.Replication.Enabled {
+		return nil
+	}
+	return meta.CloneBck(&bprops.Replication.Bck)
+}
+
+// log changed object
+func ReplLog(lom *LOM, op ReplOp) error { return rplj.log(lom, replRec(lom.Uname(), op)) }
+
+// done shipping logged change (zero lag when there was nothing to do)
+func ReplDone(uname string, op ReplOp, lag time.Duration) {
+	if lag > 0 {
+		g.tstats.Add(ReplLagLatency, int64(lag))
+		g.tstats.Inc(ReplCount)
+	}
+	rplj.done(replRec(uname, op))
+}
+
+func replRec(uname string, op ReplOp) string { return string(op) + " " + uname }
+
+// failed to ship (the change remains pending)
+func ReplErr() { g.tstats.Inc(ReplErrCount) }
+
+// changes that may still need to be replicated (upon restart) - one per object,
+// in the order of logging; a deletion (ReplDel) takes precedence
+func ReplReplay() (changes []ReplChange) {
+	var (
+		recs  = rplj.replay()
+		dedup = make(map[string]int, len(recs))
+	)
+	for _, rec := range recs {
+		if len(rec) < 3 || rec[1] != ' ' || (ReplOp(rec[0]) != ReplPut && ReplOp(rec[0]) != ReplDel) {
+			nlog.Errorln("invalid replication change log record:", rec)
+			continue
+		}
+		op, uname := ReplOp(rec[0]), rec[2:]
+		if i, ok := dedup[uname]; ok {
+			if op == ReplDel {
+				changes[i].Op = ReplDel
+			}
+			continue
+		}
+		dedup[uname] = len(changes)
+		changes = append(changes, ReplChange{Uname: uname, Op: op})
+	}
+	return changes
+}
+
+// ship the object's current state to a given destination bucket
+// - returns the size uploaded (zero if deleted or if there's nothing to do)
+// - non-existing object gets deleted from the destination only when op == ReplDel
+// - the object is read-locked while being uploaded
+func (lom *LOM) Replicate(dst *meta.Bck, op ReplOp) (int64, int, error) {
+	tlom := AllocLOM(lom.ObjName)
+	defer FreeLOM(tlom)
+	if err := tlom.InitBck(dst.Bucket()); err != nil {
+		return 0, 0, err
+	}
+	backend := T.Backend(tlom.Bck())
+
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if !cos.IsNotExist(err, 0) {
+			return 0, 0, err
+		}
+		if op != ReplDel {
+			return 0, 0, nil // e.g., failed PUT, or migrated (rebalanced) in the meantime
+		}
+		// deleted or renamed
+		errCode, err := backend.DeleteObj(tlom)
+		if err != nil && cos.IsNotExist(err, errCode) {
+			err = nil
+		}
+		return 0, errCode, err
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		lom.Unlock(false)
+		return 0, 0, err
+	}
+	size := lom.SizeBytes()
+	tlom.CopyAttrs(lom.ObjAttrs(), false /*skip cksum*/)
+	errCode, err := backend.PutObj(fh, tlom, nil) // (closes fh)
+	lom.Unlock(false)
+	return size, errCode, err
+}
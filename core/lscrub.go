@@ -0,0 +1,150 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Scrub (see xact/xs/scrub.go)
+// - the content of each replica of a given object (the main replica and its mirror copies,
+//   if any) is verified against the object's stored checksum
+// - corrupted replicas are then repaired from a healthy one, if available
+
+var ErrNoHealthyReplica = errors.New("no healthy replica")
+
+// final outcome for a corrupted object (target stats)
+func ScrubDone(repaired bool) {
+	if repaired {
+		g.tstats.Inc(ScrubRepairedCount)
+	} else {
+		g.tstats.Inc(ScrubUnrecoverableCount)
+	}
+}
+
+// returns the number of corrupted replicas (zero when the object is healthy or has
+// no checksum to verify against) and ErrNoHealthyReplica when none of the replicas is healthy
+//   - caller must r-lock; the replicas get verified under the shared lock, which gets upgraded
+//     only to repair (the replicas are then verified again)
+//   - upon return, the lock is exclusive if (and only if) nbad > 0
+func (lom *LOM) Scrub(buf []byte) (nbad int, err error) {
+	cksum := lom.md.Cksum
+	if cksum.IsEmpty() || cksum.Ty() == cos.ChecksumNone || lom.IsTiered() {
+		return 0, nil
+	}
+	if _, bad, err := lom._scrub(cksum); err != nil || len(bad) == 0 {
+		return 0, err
+	}
+	if lom.UpgradeLock() {
+		return 0, nil // upgraded and possibly modified by another goroutine (e.g., overwritten) - skipping
+	}
+	var (
+		good string
+		bad  []string
+	)
+	lom.Uncache()
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		if good, bad, err = lom._scrub(lom.md.Cksum); err == nil && len(bad) > 0 {
+			return lom._repair(good, bad, buf)
+		}
+	}
+	lom.DowngradeLock()
+	return 0, err
+}
+
+// (all replicas: good one and corrupted ones, if any)
+func (lom *LOM) _scrub(cksum *cos.Cksum) (good string, bad []string, _ error) {
+	fqns := make([]string, 0, max(len(lom.md.copies), 1))
+	fqns = append(fqns, lom.FQN)
+	for fqn := range lom.md.copies {
+		if fqn != lom.FQN {
+			fqns = append(fqns, fqn)
+		}
+	}
+	for _, fqn := range fqns {
+		ok, errV := lom._scrubEq(fqn, cksum)
+		switch {
+		case errV != nil && !os.IsNotExist(errV):
+			return "", nil, errV
+		case ok:
+			if good == "" {
+				good = fqn
+			}
+		default:
+			bad = append(bad, fqn) // (including missing copies)
+		}
+	}
+	return good, bad, nil
+}
+
+// (caller must w-lock)
+func (lom *LOM) _repair(good string, bad []string, buf []byte) (nbad int, err error) {
+	nbad = len(bad)
+	nlog.Warningln(lom.String(), "corrupted replica(s):", bad)
+	g.tstats.Inc(ScrubCorruptedCount)
+	lom.Uncache()
+	if good == "" {
+		return nbad, ErrNoHealthyReplica
+	}
+
+	// repair
+	var mainBad bool
+	for _, fqn := range bad {
+		mi := lom.mi
+		if fqn == lom.FQN {
+			mainBad = true
+		} else {
+			mi = lom.md.copies[fqn]
+		}
+		if lom.IsPacked() {
+			if err = lom.copyPacked(good, fqn); err != nil {
+				return nbad, err
+			}
+			continue
+		}
+		workFQN := mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, fs.WorkfileScrub+"."+lom.ObjName)
+		if _, _, err = cos.CopyFile(good, workFQN, buf, cos.ChecksumNone); err != nil {
+			return nbad, err
+		}
+		if err = cos.Rename(workFQN, fqn); err != nil {
+			if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+				nlog.Errorln("nested err:", errRemove)
+			}
+			return nbad, err
+		}
+	}
+	// restore metadata
+	if mainBad {
+		if err = lom.Persist(); err != nil {
+			return nbad, err
+		}
+	}
+	return nbad, lom.syncMetaWithCopies()
+}
+
+func (lom *LOM) _scrubEq(fqn string, cksum *cos.Cksum) (bool, error) {
+	file, err := lom.OpenFQN(fqn)
+	if err != nil {
+		if isBadEncoding(err) {
+			return false, nil // (corrupted compressed or encrypted replica)
+		}
+		return false, err
+	}
+	_, computed, err := cos.CopyAndChecksum(io.Discard, file, nil, cksum.Ty())
+	cos.Close(file)
+	if err != nil {
+		if isBadEncoding(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return computed.Equal(cksum), nil
+}
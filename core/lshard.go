@@ -0,0 +1,313 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Shard index (see archive.Index)
+// - applies to TAR and ZIP shards as per their respective extensions (".tar", ".zip");
+// - gets built in the background upon the first read of an archived file (GET ?archpath=)
+//   that finds no index, and persisted next to the shard (same mountpath, fs.ShardIdxType);
+// - subsequent reads look up the file and seek directly to its content;
+// - the index is valid as long as the shard's size and modification time remain unchanged;
+//   (fast) APPEND to TAR extends the index, while all other writes remove it, and so does
+//   removing the shard itself (space cleanup takes care of the rest)
+
+const (
+	shardIdxMetaver   = 1
+	shardIdxQueueLen  = 256
+	shardIdxMaxCached = 1024 // max number of loaded (in-memory) indexes
+	shardIdxMaxFailed = 4096
+)
+
+type (
+	shardIndex struct {
+		archive.Index
+		Size  int64 `json:"size"`  // shard size
+		Mtime int64 `json:"mtime"` // shard's file modification time
+	}
+	shardIdxer struct {
+		cache   map[string]*shardIndex // by uname
+		pending map[string]struct{}
+		failed  map[string]int64 // shards that cannot be indexed (uname => mtime)
+		workCh  chan LIF
+		mu      sync.Mutex
+	}
+)
+
+var shx shardIdxer
+
+// target only
+func InitShardIdx() {
+	shx.cache = make(map[string]*shardIndex, 64)
+	shx.pending = make(map[string]struct{}, 16)
+	shx.failed = make(map[string]int64, 16)
+	shx.workCh = make(chan LIF, shardIdxQueueLen)
+	go shx.run()
+}
+
+// returns archive mime type iff the object is an indexable shard
+func (lom *LOM) shardMime() string {
+	if shx.workCh == nil {
+		return "" // (not a target)
+	}
+	mime, err := archive.Mime("", lom.ObjName)
+	if err != nil || !archive.Indexable(mime) {
+		return ""
+	}
+	return mime
+}
+
+func (lom *LOM) shardIdxFQN() string {
+	return lom.mi.MakePathFQN(lom.Bucket(), fs.ShardIdxType, lom.ObjName)
+}
+
+// returns the modification time of the shard's file, or 0 if the shard is not indexable
+func (lom *LOM) shardMtime() int64 {
+	if lom.IsPacked() {
+		return 0
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return 0
+	}
+	return finfo.ModTime().UnixNano()
+}
+
+// LoadShardIdx returns the shard's (valid) index, or nil if there's none;
+// the caller must hold the object's lock
+func (lom *LOM) LoadShardIdx() *archive.Index {
+	if lom.shardMime() == "" {
+		return nil
+	}
+	mtime := lom.shardMtime()
+	if mtime == 0 {
+		return nil
+	}
+	if sidx := lom.loadShardIdx(mtime); sidx != nil {
+		return &sidx.Index
+	}
+	return nil
+}
+
+func (lom *LOM) loadShardIdx(mtime int64) *shardIndex {
+	uname := lom.md.uname
+	shx.mu.Lock()
+	sidx, ok := shx.cache[uname]
+	shx.mu.Unlock()
+	if !ok {
+		var (
+			fqn = lom.shardIdxFQN()
+			err error
+		)
+		sidx = &shardIndex{}
+		if _, err = jsp.Load(fqn, sidx, jsp.CCSign(shardIdxMetaver)); err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Warningln(lom.Cname(), "failed to load shard index:", err)
+				os.Remove(fqn)
+			}
+			return nil
+		}
+	}
+	if sidx.Size != lom.SizeBytes() || sidx.Mtime != mtime || sidx.Entries == nil {
+		lom.dropShardIdx() // stale
+		return nil
+	}
+	if !ok {
+		shx.add(uname, sidx)
+	}
+	return sidx
+}
+
+// OpenIndexed returns reader of the archived file (and its index entry) given the shard's index;
+// returns empty mime when there's no valid index - in which case the caller must scan the shard
+// (and the index gets built in the background);
+// returns nil reader (and the shard's mime) when the file is not present in the shard;
+// the caller must hold the object's lock, and `r` is the shard's (or its mirror copy's) reader
+func (lom *LOM) OpenIndexed(r io.ReaderAt, mime, filename string) (cos.ReadCloseSizer, *archive.IndexEntry, string) {
+	smime := lom.shardMime()
+	if smime == "" || (mime != "" && mime != smime) {
+		return nil, nil, ""
+	}
+	mtime := lom.shardMtime()
+	if mtime == 0 {
+		return nil, nil, ""
+	}
+	sidx := lom.loadShardIdx(mtime)
+	if sidx == nil {
+		shx.schedule(lom, mtime)
+		return nil, nil, ""
+	}
+	e := sidx.Lookup(filename)
+	if e == nil {
+		return nil, nil, smime
+	}
+	g.tstats.Inc(ShardIdxGetCount)
+	return sidx.Open(r, e), e, smime
+}
+
+// ExtendShardIdx indexes files that were (fast) appended to the TAR shard at a given offset,
+// and saves the updated index; `prev` is the shard's index prior to appending (see LoadShardIdx);
+// the caller must hold the object's write lock
+func (lom *LOM) ExtendShardIdx(prev *archive.Index, off int64) {
+	if prev == nil {
+		return
+	}
+	if prev.End != off {
+		if cmn.Rom.FastV(4, cos.SmoduleCluster) {
+			nlog.Infoln(lom.Cname(), "shard index: end", prev.End, "vs append offset", off, "- not extending")
+		}
+		return
+	}
+	mtime := lom.shardMtime()
+	if mtime == 0 {
+		return
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		nlog.Warningln(lom.Cname(), "failed to extend shard index:", err)
+		return
+	}
+	err = prev.Extend(fh, off, lom.SizeBytes())
+	cos.Close(fh)
+	if err != nil {
+		nlog.Warningln(lom.Cname(), "failed to extend shard index:", err)
+		return
+	}
+	lom.saveShardIdx(&shardIndex{Index: *prev, Size: lom.SizeBytes(), Mtime: mtime})
+}
+
+func (lom *LOM) saveShardIdx(sidx *shardIndex) {
+	if err := jsp.Save(lom.shardIdxFQN(), sidx, jsp.CCSign(shardIdxMetaver), nil); err != nil {
+		nlog.Warningln(lom.Cname(), "failed to save shard index:", err)
+		return
+	}
+	shx.add(lom.md.uname, sidx)
+}
+
+// remove the shard's index, if any
+// (called when the shard gets removed or overwritten)
+func (lom *LOM) dropShardIdx() {
+	if lom.shardMime() == "" {
+		return
+	}
+	shx.mu.Lock()
+	delete(shx.cache, lom.md.uname)
+	shx.mu.Unlock()
+	if err := os.Remove(lom.shardIdxFQN()); err != nil && !os.IsNotExist(err) {
+		nlog.Warningln(lom.Cname(), "failed to remove shard index:", err)
+	}
+}
+
+// build the index of an existing shard; the caller must hold the object's lock
+func (lom *LOM) buildShardIdx(mime string) error {
+	mtime := lom.shardMtime()
+	if mtime == 0 {
+		return nil
+	}
+	if lom.loadShardIdx(mtime) != nil {
+		return nil // (built by a previous request)
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return err
+	}
+	idx, err := archive.NewIndex(mime, fh, lom.SizeBytes())
+	cos.Close(fh)
+	if err != nil {
+		shx.fail(lom.md.uname, mtime)
+		return err
+	}
+	lom.saveShardIdx(&shardIndex{Index: *idx, Size: lom.SizeBytes(), Mtime: mtime})
+	g.tstats.Inc(ShardIdxBuildCount)
+	return nil
+}
+
+////////////////
+// shardIdxer //
+////////////////
+
+func (x *shardIdxer) add(uname string, sidx *shardIndex) {
+	x.mu.Lock()
+	if len(x.cache) >= shardIdxMaxCached {
+		for k := range x.cache { // evict random
+			delete(x.cache, k)
+			break
+		}
+	}
+	x.cache[uname] = sidx
+	x.mu.Unlock()
+}
+
+func (x *shardIdxer) fail(uname string, mtime int64) {
+	x.mu.Lock()
+	if len(x.failed) >= shardIdxMaxFailed {
+		clear(x.failed) // start over
+	}
+	x.failed[uname] = mtime
+	x.mu.Unlock()
+}
+
+func (x *shardIdxer) schedule(lom *LOM, mtime int64) {
+	uname := lom.md.uname
+	x.mu.Lock()
+	if _, ok := x.pending[uname]; ok {
+		x.mu.Unlock()
+		return
+	}
+	if m, ok := x.failed[uname]; ok && m == mtime {
+		x.mu.Unlock()
+		return
+	}
+	x.pending[uname] = struct{}{}
+	x.mu.Unlock()
+
+	select {
+	case x.workCh <- lom.LIF():
+	default: // busy indexing - skip
+		x.mu.Lock()
+		delete(x.pending, uname)
+		x.mu.Unlock()
+	}
+}
+
+func (x *shardIdxer) run() {
+	for lif := range x.workCh {
+		lom, err := lif.LOM()
+		if err != nil {
+			x.done(lif.Uname)
+			continue // (bucket gone)
+		}
+		lom.Lock(false)
+		if err = lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+			err = lom.buildShardIdx(lom.shardMime())
+		} else if cos.IsNotExist(err, 0) {
+			err = nil
+		}
+		lom.Unlock(false)
+		if err != nil {
+			nlog.Warningln("failed to index", lom.Cname(), err)
+		}
+		x.done(lif.Uname)
+		FreeLOM(lom)
+	}
+}
+
+func (x *shardIdxer) done(uname string) {
+	x.mu.Lock()
+	delete(x.pending, uname)
+	x.mu.Unlock()
+}
@@ -0,0 +1,299 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Snapshot object's metadata (see cmn/snap.go)
+// - snapshot objects are hard links that share content - and the inode, and therefore
+//   xattrs - with their origins; to keep point-in-time metadata intact while the origin
+//   keeps changing, snapshot's metadata is stored separately, next to the object (same
+//   mountpath, fs.SnapMetaType), and is never written into the (shared) xattr;
+// - create: write metadata first, and then link (LinkSnap); restore: link back and apply
+//   the metadata to the restored object (RestoreSnapMD);
+// - resilver moves the two together (MoveSnap), while rebalance sends snapshot objects
+//   as regular ones - the receiving side stores their metadata separately (see Persist);
+// - either way, a snapshot object and its origin arrive (at their new location) separately,
+//   as two distinct files - the one that arrives last gets re-linked (see Reshare);
+// - orphaned metadata is subject to space cleanup
+//
+// Write fence: creating and restoring a snapshot is atomic bucket-wide - objects cannot be
+// written, deleted, or renamed from the moment the operation begins (txn begin, on all targets)
+// and until it's done; writers check the fence under the object's write lock (see Fenced)
+
+const (
+	wfnameSnapMD      = "snap-md"
+	wfnameSnapReshare = "snap-reshare"
+)
+
+var wfence struct {
+	m  map[string]string // bucket => snapshot xaction (txn) ID
+	mu sync.RWMutex
+	n  atomic.Int32
+}
+
+/////////////////
+// write fence //
+/////////////////
+
+// returns false if the bucket is already fenced by another snapshot operation
+func FenceWrites(bck *cmn.Bck, id string) bool {
+	uname := bck.MakeUname("")
+	wfence.mu.Lock()
+	defer wfence.mu.Unlock()
+	if wfence.m == nil {
+		wfence.m = make(map[string]string, 2)
+	}
+	if xid, ok := wfence.m[uname]; ok {
+		return xid == id
+	}
+	wfence.m[uname] = id
+	wfence.n.Inc()
+	return true
+}
+
+// (idempotent)
+func UnfenceWrites(bck *cmn.Bck, id string) {
+	uname := bck.MakeUname("")
+	wfence.mu.Lock()
+	if xid, ok := wfence.m[uname]; ok && xid == id {
+		delete(wfence.m, uname)
+		wfence.n.Dec()
+	}
+	wfence.mu.Unlock()
+}
+
+// returns ErrBusy when the object's bucket is fenced; the caller must wlock the object
+func (lom *LOM) Fenced() error {
+	if wfence.n.Load() == 0 {
+		return nil
+	}
+	wfence.mu.RLock()
+	_, ok := wfence.m[lom.Bucket().MakeUname("")]
+	wfence.mu.RUnlock()
+	if ok {
+		return cmn.NewErrBusy("bucket", lom.Bck(), "snapshot in progress")
+	}
+	return nil
+}
+
+func snapMetaFQN(mi *fs.Mountpath, bck *cmn.Bck, objName string) string {
+	return mi.MakePathFQN(bck, fs.SnapMetaType, objName)
+}
+
+func (lom *LOM) snapMetaFQN() string { return snapMetaFQN(lom.mi, lom.Bucket(), lom.ObjName) }
+
+// (compare with lmfs)
+func (lom *LOM) lmsnap(populate bool) (*lmeta, error) {
+	read, err := os.ReadFile(lom.snapMetaFQN())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, cmn.NewErrLmetaNotFound(err)
+		}
+		return nil, err
+	}
+	md := &lom.md
+	if !populate {
+		md = &lmeta{}
+	}
+	if err := md.unmarshal(read); err != nil {
+		return nil, cmn.NewErrLmetaCorrupted(err)
+	}
+	md.copies = nil
+	return md, nil
+}
+
+// write snapshot object's metadata via workfile
+func setLmsnap(mi *fs.Mountpath, bck *cmn.Bck, objName string, buf []byte) error {
+	var (
+		fqn  = snapMetaFQN(mi, bck, objName)
+		wfqn = mi.MakePathFQN(bck, fs.WorkfileType, fs.CSM.Resolver(fs.WorkfileType).GenUniqueFQN(objName, wfnameSnapMD))
+	)
+	_, err := cos.SaveReaderSafe(wfqn, fqn, bytes.NewReader(buf), nil, cos.ChecksumNone, int64(len(buf)))
+	return err
+}
+
+// (compare with setLmeta)
+func (lom *LOM) setmd(buf []byte, atime int64) error {
+	if lom.bck.IsSnap() {
+		return setLmsnap(lom.mi, lom.Bucket(), lom.ObjName, buf)
+	}
+	return setLmeta(lom.FQN, buf, atime, lom.IsPacked())
+}
+
+func (lom *LOM) dropSnapMD() {
+	if !lom.bck.IsSnap() {
+		return
+	}
+	if err := os.Remove(lom.snapMetaFQN()); err != nil && !os.IsNotExist(err) {
+		nlog.Warningln(lom.Cname(), "failed to remove snapshot metadata:", err)
+	}
+}
+
+// create: link a given object into the snapshot, on the object's (current) mountpath;
+// replicas and packing are not carried over; the caller must rlock the object
+func LinkSnap(lom *LOM, snap *cmn.Bck, buf []byte) error {
+	var (
+		md lmeta
+		b  = lom.marshal()
+	)
+	err := md.unmarshal(b) // (deep copy)
+	g.smm.Free(b)
+	if err != nil {
+		return cmn.NewErrLmetaCorrupted(err)
+	}
+	md.copies = nil
+	md.DelCustomKeys(cmn.PackObjMD) // (linking a packed object unpacks it - see LinkFile)
+
+	b = md.marshal(g.maxLmeta.Load())
+	err = setLmsnap(lom.mi, snap, lom.ObjName, b)
+	g.smm.Free(b)
+	if err != nil {
+		return err
+	}
+	if err = LinkFile(lom.FQN, lom.mi.MakePathFQN(snap, fs.ObjectType, lom.ObjName), buf); err != nil {
+		return err
+	}
+	if lom.IsChunked() {
+		err = LinkChunks(lom.Bucket(), snap, lom.ObjName, lom.FQN, buf) // (see core/lchunk.go)
+	}
+	return err
+}
+
+// restore: apply snapshot's metadata to the (restored) object that must be wlocked and
+// loaded; replicas, if any, must be removed prior to calling this method
+func (lom *LOM) RestoreSnapMD(snaplom *LOM) error {
+	var (
+		uname          = lom.md.uname
+		saved          = lom.md.pushrt()
+		packed, isPack = lom.GetCustomKey(cmn.PackObjMD)
+	)
+	lom.md = snaplom.md
+	lom.md.uname, lom.md.copies = uname, nil
+	lom.md.poprt(saved)
+	if isPack {
+		lom.SetCustomKey(cmn.PackObjMD, packed) // (restored into the bucket's pack - see RenameFrom)
+	}
+	return lom.Persist()
+}
+
+// resilver: move snapshot object to a given mountpath - hard-linking when possible,
+// to keep sharing content with the origin - and carry over its metadata
+func (lom *LOM) MoveSnap(mi *fs.Mountpath, buf []byte) error {
+	md, err := os.ReadFile(lom.snapMetaFQN())
+	if err != nil {
+		return err
+	}
+	if err := setLmsnap(mi, lom.Bucket(), lom.ObjName, md); err != nil {
+		return err
+	}
+	if err := LinkFile(lom.FQN, mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName), buf); err != nil {
+		return err
+	}
+	if err := cos.RemoveFile(lom.FQN); err != nil {
+		nlog.Warningln(lom.Cname(), "failed to remove", lom.FQN, "[", err, "]")
+	}
+	lom.dropSnapMD()
+	return nil
+}
+
+// re-link a given (rebalanced or resilvered) object with the first counterpart on the same
+// mountpath - the origin, if the object is a snapshot object, and the other way around -
+// that has identical content; returns true if re-linked
+// - identical content: same size and (non-empty) checksum; neither chunked, packed, nor encoded
+// - the object must be wlocked and loaded; counterparts that are busy get skipped
+func (lom *LOM) Reshare() bool {
+	if !lom.canReshare() {
+		return false
+	}
+	var (
+		base       = *lom.Bucket()
+		isSnap     = lom.bck.IsSnap()
+		candidates []cmn.Bck
+	)
+	if isSnap {
+		base, _, _ = lom.Bucket().SnapBase()
+		candidates = append(candidates, base)
+	}
+	T.Bowner().Get().Range(&base.Provider, &base.Ns, func(bck *meta.Bck) bool {
+		if b, _, ok := bck.Bucket().SnapBase(); ok && b.Equal(&base) && !bck.Bucket().Equal(lom.Bucket()) {
+			candidates = append(candidates, *bck.Bucket())
+		}
+		return false
+	})
+	for i := range candidates {
+		if lom.reshare(&candidates[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (lom *LOM) canReshare() bool {
+	return !lom.IsChunked() && !lom.IsPacked() && !lom.IsEncoded() && !lom.Checksum().IsEmpty()
+}
+
+func (lom *LOM) reshare(bck *cmn.Bck) bool {
+	other := AllocLOM(lom.ObjName)
+	defer FreeLOM(other)
+	if err := other.InitFQN(lom.mi.MakePathFQN(bck, fs.ObjectType, lom.ObjName), bck); err != nil {
+		return false
+	}
+	if !other.TryLock(false) {
+		return false
+	}
+	defer other.Unlock(false)
+	if err := other.Load(false /*cache it*/, true /*locked*/); err != nil || !other.canReshare() {
+		return false
+	}
+	if other.SizeBytes() != lom.SizeBytes() || !other.Checksum().Equal(lom.Checksum()) {
+		return false
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return false
+	}
+	ofinfo, err := os.Stat(other.FQN)
+	if err != nil || ofinfo.Size() != finfo.Size() || os.SameFile(finfo, ofinfo) {
+		return false
+	}
+
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, wfnameSnapReshare)
+	if err := cos.CreateDir(filepath.Dir(workFQN)); err != nil {
+		return false
+	}
+	if err := os.Link(other.FQN, workFQN); err != nil {
+		return false // (e.g., EXDEV)
+	}
+	if !lom.bck.IsSnap() {
+		// the origin's metadata goes into the (new) inode's xattr prior to renaming
+		// (and is ignored by the snapshot object that keeps its own - see above)
+		buf := lom.marshal()
+		err = fs.SetXattr(workFQN, XattrLOM, buf)
+		g.smm.Free(buf)
+	}
+	if err == nil {
+		err = os.Rename(workFQN, lom.FQN)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			nlog.Errorln("nested error:", err, errRm)
+		}
+		return false
+	}
+	return true
+}
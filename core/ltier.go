@@ -0,0 +1,167 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Tiered objects (see cmn.TierConf and xact/xs/tier.go)
+// - object that was uploaded to a (remote) tier bucket is locally replaced
+//   with a zero-size file that retains the object's metadata (a.k.a. stub)
+// - stub metadata includes the tier bucket (custom key `cmn.TierObjMD`)
+// - GET, copy, and friends re-hydrate (or read through) the stub; re-hydrated object
+//   retains its version and custom metadata - all but the tier bucket (see Untier)
+
+func (lom *LOM) IsTiered() bool {
+	_, ok := lom.GetCustomKey(cmn.TierObjMD)
+	return ok
+}
+
+// returns nil if the object is not tiered
+func (lom *LOM) TierBck() *meta.Bck {
+	v, ok := lom.GetCustomKey(cmn.TierObjMD)
+	if !ok {
+		return nil
+	}
+	bck, _, err := cmn.ParseBckObjectURI(v, cmn.ParseURIOpts{})
+	if err != nil {
+		nlog.Errorln(lom.String(), "invalid tier bucket", v, err)
+		return nil
+	}
+	return meta.CloneBck(&bck)
+}
+
+// (compare w/ T.GetCold)
+func (lom *LOM) TierReader(ctx context.Context, tier *meta.Bck) (res GetReaderResult) {
+	tlom := AllocLOM(lom.ObjName)
+	if res.Err = tlom.InitBck(tier.Bucket()); res.Err == nil {
+		res = T.Backend(tlom.Bck()).GetObjReader(ctx, tlom, 0, 0)
+	}
+	FreeLOM(tlom)
+	return
+}
+
+// (re-hydrating) remove the tier bucket, retain all other custom metadata
+// - copy-on-write (cached custom metadata may be shared - see IncAccess)
+func (lom *LOM) Untier() {
+	md := lom.GetCustomMD()
+	nmd := make(cos.StrKVs, len(md))
+	for k, v := range md {
+		if k != cmn.TierObjMD {
+			nmd[k] = v
+		}
+	}
+	lom.SetCustomMD(nmd)
+}
+
+// upload object's content to a given tier bucket (any backend)
+// - must be locked (shared lock suffices - see xact/xs/tier.go)
+func (lom *LOM) TierPut(tier *meta.Bck) (int, error) {
+	fh, err := lom.Open()
+	if err != nil {
+		return 0, err
+	}
+	tlom := AllocLOM(lom.ObjName)
+	defer FreeLOM(tlom)
+	if err := tlom.InitBck(tier.Bucket()); err != nil {
+		cos.Close(fh)
+		return 0, err
+	}
+	tlom.CopyAttrs(lom.ObjAttrs(), false /*skip cksum*/)
+	return T.Backend(tlom.Bck()).PutObj(fh, tlom, nil) // (closes fh)
+}
+
+// replace the object with metadata-only stub
+// - must be wlocked
+// - never modifies the file in place (it may be hard-linked - see cmn/snap.go)
+func (lom *LOM) Stub(tier *meta.Bck) error {
+	debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
+	if lom.HasCopies() {
+		if err := lom.DelAllCopies(); err != nil {
+			return err
+		}
+	}
+	lom.SetCustomKey(cmn.TierObjMD, tier.Cname(""))
+	lom.ClrEncoded()
+
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileTier)
+	fh, err := lom.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	cos.Close(fh)
+	buf := lom.marshal()
+	err = fs.SetXattr(workFQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	if err == nil {
+		err = lom.RenameFrom(workFQN)
+	}
+	if err != nil {
+		if nested := cos.RemoveFile(workFQN); nested != nil {
+			nlog.Errorln("nested error:", err, nested)
+		}
+		return err
+	}
+	lom.Uncache()
+	return nil
+}
+
+// (rebalance) rebuild metadata-only stub from the received attributes
+// - stubs are sent header-only, with the object's size passed separately
+// - must not be locked
+func (lom *LOM) StubFrom(oah cos.OAH, size int64) error {
+	lom.CopyAttrs(oah, false /*skip cksum*/)
+	lom.SetSize(size)
+	tier := lom.TierBck()
+	if tier == nil {
+		return fmt.Errorf("%s: missing or invalid tier bucket in %s", lom, cmn.TierObjMD)
+	}
+	lom.Lock(true)
+	err := lom.Stub(tier)
+	lom.Unlock(true)
+	return err
+}
+
+// re-hydrate tiered object via cold GET from the tier bucket
+// - must be wlocked
+// - no-op if the object is not tiered (e.g., re-hydrated by another goroutine)
+func (lom *LOM) Rehydrate(ctx context.Context) (int, error) {
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return 0, err
+	}
+	tier := lom.TierBck()
+	if tier == nil {
+		return 0, nil
+	}
+	res := lom.TierReader(ctx, tier)
+	if res.Err != nil {
+		return res.ErrCode, res.Err
+	}
+	lom.Untier()
+
+	params := AllocPutParams()
+	{
+		params.WorkTag = fs.WorkfileColdget
+		params.Reader = res.R
+		params.OWT = cmn.OwtGetLock
+		params.Cksum = res.ExpCksum
+		params.Size = res.Size
+		params.Atime = time.Now()
+		params.ColdGET = true
+	}
+	err := T.PutObject(lom, params)
+	FreePutParams(params)
+	return 0, err
+}
@@ -0,0 +1,139 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+)
+
+// Write-back (see apc.WriteDelayed and xact/xs/wback.go)
+// - PUT to a remote bucket with `write_policy.data = delayed` completes upon writing locally;
+//   the object is marked pending (custom key `cmn.WbackObjMD`) and gets logged in the journal
+// - journal: per-mountpath, append-only; replayed (and removed) upon target restart;
+//   removed as well when there are no pending objects
+// - pending objects are not evicted; remote (versioning) metadata is not checked on GET
+// - the number of pending objects is reported via target stats (WbackPendingCount)
+
+var wbj = newJournal(fname.WbackJournal, "write-back", WbackPendingCount)
+
+// (bucket property)
+func (lom *LOM) WriteBack() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.WritePolicy.Data == apc.WriteDelayed && lom.Bck().IsRemote()
+}
+
+func (lom *LOM) IsWbackPending() bool {
+	_, ok := lom.GetCustomKey(cmn.WbackObjMD)
+	return ok
+}
+
+// mark pending prior to writing (persisting) metadata;
+// remove remote metadata that'll be updated when actually written back (compare w/ putOI.putRemote)
+func (lom *LOM) SetWbackPending() {
+	if !lom.Bck().IsRemoteAIS() {
+		lom.ObjAttrs().DelCustomKeys(cmn.SourceObjMD, cmn.CRC32CObjMD, cmn.ETag, cmn.MD5ObjMD, cmn.VersionObjMD)
+	}
+	lom.SetCustomKey(cmn.WbackObjMD, strconv.FormatInt(time.Now().UnixNano(), 36))
+}
+
+// write back (upload) pending object to its remote bucket and update its metadata
+// - returns the size written (zero if the object is not pending or does not exist)
+// - the object is read-locked while being uploaded
+func (lom *LOM) Wback() (int64, int, error) {
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		return 0, 0, _wbackErr(err)
+	}
+	token, ok := lom.GetCustomKey(cmn.WbackObjMD)
+	if !ok {
+		lom.Unlock(false)
+		return 0, 0, nil
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		lom.Unlock(false)
+		return 0, 0, _wbackErr(err)
+	}
+	// (not to modify custom metadata shared with the cached lom - a failed upload gets retried)
+	pending := lom.GetCustomMD()
+	custom := make(cos.StrKVs, len(pending))
+	for k, v := range pending {
+		if k != cmn.WbackObjMD {
+			custom[k] = v
+		}
+	}
+	lom.SetCustomMD(custom)
+	backend := T.Backend(lom.Bck())
+	errCode, err := backend.PutObj(fh, lom, nil) // (closes fh)
+	lom.Unlock(false)
+	if err != nil {
+		return 0, errCode, err
+	}
+
+	// update metadata - unless the object has been overwritten or deleted in the meantime
+	var (
+		ver  = lom.Version()
+		size = lom.SizeBytes()
+	)
+	custom = make(cos.StrKVs, len(lom.GetCustomMD()))
+	for k, v := range lom.GetCustomMD() {
+		custom[k] = v
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return 0, 0, _wbackErr(err)
+	}
+	if v, ok := lom.GetCustomKey(cmn.WbackObjMD); !ok || v != token {
+		return 0, 0, nil
+	}
+	lom.SetCustomMD(custom)
+	if !lom.Bck().IsRemoteAIS() {
+		lom.SetCustomKey(cmn.SourceObjMD, backend.Provider())
+	}
+	if ver != "" {
+		lom.SetVersion(ver)
+	}
+	return size, 0, lom.Persist()
+}
+
+// deleted in the meantime is not an error
+func _wbackErr(err error) error {
+	if cos.IsNotExist(err, 0) {
+		return nil
+	}
+	return err
+}
+
+/////////////
+// journal //
+/////////////
+
+// log pending object
+func WbackLog(lom *LOM) error { return wbj.log(lom, lom.Uname()) }
+
+// done writing back (or nothing to do)
+func WbackDone(uname string) { wbj.done(uname) }
+
+// unique unames of the objects that may still be pending, in the order of logging
+// (upon restart, and prior to logging new pending objects)
+func WbackReplay() (unames []string) {
+	recs := wbj.replay()
+	dedup := make(map[string]struct{}, len(recs))
+	for _, uname := range recs {
+		if _, ok := dedup[uname]; !ok {
+			dedup[uname] = struct{}{}
+			unames = append(unames, uname)
+		}
+	}
+	return unames
+}
@@ -0,0 +1,58 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/zblk"
+)
+
+// Compression at rest (see cmn.CompressConf and cmn/zblk)
+// - PUT into a bucket with `compression.enabled` stores the object in the block-indexed
+//   compressed format; the compressed size is kept in custom key `cmn.CompressObjMD`
+// - object size (lom.SizeBytes) and checksum always refer to the original (logical) content
+// - see also core/lcodec.go
+
+// (bucket property)
+func (lom *LOM) CompressEnabled() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.Compress.Enabled
+}
+
+func (lom *LOM) IsCompressed() bool {
+	_, ok := lom.GetCustomKey(cmn.CompressObjMD)
+	return ok
+}
+
+func (lom *LOM) SetCompressed(zsize int64) {
+	lom.SetCustomKey(cmn.CompressObjMD, strconv.FormatInt(zsize, 10))
+}
+
+func (lom *LOM) ClrCompressed() { lom.ObjAttrs().DelCustomKeys(cmn.CompressObjMD) }
+
+// compressed size (same as lom.SizeBytes() unless compressed)
+func (lom *LOM) zsize() int64 {
+	v, ok := lom.GetCustomKey(cmn.CompressObjMD)
+	if !ok {
+		return lom.SizeBytes()
+	}
+	size, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return -1 // (=> errsize)
+	}
+	return size
+}
+
+// returns nil when the bucket is not configured to compress
+func (lom *LOM) newZW(w io.Writer) (*zblk.Writer, error) {
+	bprops := lom.Bprops()
+	if bprops == nil || !bprops.Compress.Enabled {
+		return nil, nil
+	}
+	return zblk.NewWriter(w, bprops.Compress.Algo, int64(bprops.Compress.BlockSize))
+}
@@ -54,9 +54,11 @@ func (b *Bck) IsCloud() bool                { return (*cmn.Bck)(b).IsCloud() }
 func (b *Bck) IsRemote() bool               { return (*cmn.Bck)(b).IsRemote() }
 func (b *Bck) IsRemoteAIS() bool            { return (*cmn.Bck)(b).IsRemoteAIS() }
 func (b *Bck) IsQuery() bool                { return (*cmn.Bck)(b).IsQuery() }
+func (b *Bck) IsSnap() bool                 { return (*cmn.Bck)(b).IsSnap() }
 func (b *Bck) RemoteBck() *cmn.Bck          { return (*cmn.Bck)(b).RemoteBck() }
 func (b *Bck) Validate() error              { return (*cmn.Bck)(b).Validate() }
 func (b *Bck) MakeUname(name string) string { return (*cmn.Bck)(b).MakeUname(name) }
+func (b *Bck) HrwUname(name string) string  { return (*cmn.Bck)(b).HrwUname(name) }
 func (b *Bck) Cname(name string) string     { return (*cmn.Bck)(b).Cname(name) }
 func (b *Bck) IsEmpty() bool                { return (*cmn.Bck)(b).IsEmpty() }
 func (b *Bck) HasVersioningMD() bool        { return (*cmn.Bck)(b).HasVersioningMD() }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -177,6 +178,18 @@ func (m *BMD) Select(qbck *cmn.QueryBcks) cmn.Bcks {
 	return bcks
 }
 
+// returns all snapshots of a given bucket (see cmn/snap.go)
+func (m *BMD) Snaps(bck *Bck) (snaps []*Bck) {
+	buckets := m.getBuckets(bck)
+	prefix := bck.Name + string(cmn.SnapSepa)
+	for name, props := range buckets {
+		if strings.HasPrefix(name, prefix) {
+			snaps = append(snaps, NewBck(name, bck.Provider, bck.Ns, props))
+		}
+	}
+	return
+}
+
 //
 // private methods
 //
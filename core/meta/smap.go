@@ -70,6 +70,7 @@ type (
 		ControlNet NetInfo    `json:"intra_control_net"` // cmn.NetIntraControl
 		DaeType    string     `json:"daemon_type"`       // "target" or "proxy"
 		DaeID      string     `json:"daemon_id"`
+		PortGRPC   string     `json:"port_grpc,omitempty"` // optional gRPC API (see cmn.LocalNetConfig.PortGRPC)
 		name       string
 		Flags      cos.BitFlags `json:"flags"` // enum { SnodeNonElectable, SnodeIC, ... }
 		idDigest   uint64
@@ -154,6 +155,14 @@ func (d *Snode) nameNets() string {
 	return fmt.Sprintf("%s(%s)", d.Name(), d.PubNet.URL)
 }
 
+// gRPC endpoint (on the public network) or empty string if the node does not serve gRPC
+func (d *Snode) GRPCEndpoint() string {
+	if d.PortGRPC == "" {
+		return ""
+	}
+	return _ep(d.PubNet.Hostname, d.PortGRPC)
+}
+
 func (d *Snode) URL(network string) (u string) {
 	switch network {
 	case cmn.NetPublic:
@@ -0,0 +1,106 @@
+// Package mock provides a variety of mock implementations used for testing.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package mock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// BackendMock: in-memory remote backend that keeps PUT objects by uname
+// and can be told to fail a given number of (next) PUTs; GET reads what's been PUT
+type BackendMock struct {
+	Objs    map[string][]byte
+	Deleted []string
+	Fail    int
+	mu      sync.Mutex
+}
+
+// interface guard
+var _ core.BackendProvider = (*BackendMock)(nil)
+
+var errBackendMock = errors.New("backend mock: failed")
+
+func NewBackend() *BackendMock { return &BackendMock{Objs: make(map[string][]byte)} }
+
+func (*BackendMock) Provider() string { return apc.AWS }
+
+func (bp *BackendMock) Get(uname string) ([]byte, bool) {
+	bp.mu.Lock()
+	b, ok := bp.Objs[uname]
+	bp.mu.Unlock()
+	return b, ok
+}
+
+func (bp *BackendMock) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int, error) {
+	b, err := io.ReadAll(r)
+	cos.Close(r)
+	if err != nil {
+		return 0, err
+	}
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	if bp.Fail > 0 {
+		bp.Fail--
+		return http.StatusServiceUnavailable, errBackendMock
+	}
+	bp.Objs[lom.Uname()] = b
+	return 0, nil
+}
+
+func (bp *BackendMock) DeleteObj(lom *core.LOM) (int, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	delete(bp.Objs, lom.Uname())
+	bp.Deleted = append(bp.Deleted, lom.Uname())
+	return 0, nil
+}
+
+func (*BackendMock) CreateBucket(*meta.Bck) (int, error) { return 0, nil }
+
+func (*BackendMock) ListObjects(*meta.Bck, *apc.LsoMsg, *cmn.LsoResult) (int, error) { return 0, nil }
+
+func (*BackendMock) ListObjectsInv(*meta.Bck, *apc.LsoMsg, *cmn.LsoResult, *core.LsoInventoryCtx) (int, error) {
+	return 0, nil
+}
+
+func (*BackendMock) ListBuckets(cmn.QueryBcks) (cmn.Bcks, int, error) { return nil, 0, nil }
+
+func (*BackendMock) HeadBucket(context.Context, *meta.Bck) (cos.StrKVs, int, error) {
+	return cos.StrKVs{}, 0, nil
+}
+
+func (bp *BackendMock) HeadObj(_ context.Context, lom *core.LOM) (*cmn.ObjAttrs, int, error) {
+	b, ok := bp.Get(lom.Uname())
+	if !ok {
+		return nil, http.StatusNotFound, cos.NewErrNotFound(nil, lom.Cname())
+	}
+	return &cmn.ObjAttrs{Size: int64(len(b))}, 0, nil
+}
+
+func (*BackendMock) GetObj(context.Context, *core.LOM, cmn.OWT) (int, error) {
+	return http.StatusNotImplemented, errBackendMock
+}
+
+// (same as actual backends, sets remote attributes of the `lom`)
+func (bp *BackendMock) GetObjReader(_ context.Context, lom *core.LOM, _, _ int64) core.GetReaderResult {
+	b, ok := bp.Get(lom.Uname())
+	if !ok {
+		return core.GetReaderResult{Err: cos.NewErrNotFound(nil, lom.Cname()), ErrCode: http.StatusNotFound}
+	}
+	lom.SetCustomKey(cmn.SourceObjMD, bp.Provider())
+	lom.SetSize(int64(len(b)))
+	return core.GetReaderResult{R: io.NopCloser(bytes.NewReader(b)), Size: int64(len(b))}
+}
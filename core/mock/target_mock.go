@@ -23,6 +23,7 @@ const mockID = "mock-id"
 type TargetMock struct {
 	BO meta.Bowner
 	SO meta.Sowner
+	BP core.BackendProvider // (optional) remote backend - see BackendMock
 }
 
 // interface guard
@@ -52,12 +53,13 @@ func (*TargetMock) FinalizeObj(*core.LOM, string, core.Xact, cmn.OWT) (int, erro
 func (*TargetMock) EvictObject(*core.LOM) (int, error)                             { return 0, nil }
 func (*TargetMock) DeleteObject(*core.LOM, bool) (int, error)                      { return 0, nil }
 func (*TargetMock) Promote(*core.PromoteParams) (int, error)                       { return 0, nil }
-func (*TargetMock) Backend(*meta.Bck) core.BackendProvider                         { return nil }
 func (*TargetMock) HeadObjT2T(*core.LOM, *meta.Snode) bool                         { return false }
 func (*TargetMock) BMDVersionFixup(*http.Request, ...cmn.Bck)                      {}
 func (*TargetMock) FSHC(error, string)                                             {}
 func (*TargetMock) OOS(*fs.CapStatus) fs.CapStatus                                 { return fs.CapStatus{} }
 
+func (t *TargetMock) Backend(*meta.Bck) core.BackendProvider { return t.BP }
+
 func (*TargetMock) CopyObject(*core.LOM, core.DM, *core.CopyParams) (int64, error) {
 	return 0, nil
 }
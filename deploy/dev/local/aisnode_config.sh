@@ -132,9 +132,11 @@ cat > $AIS_CONF_FILE <<EOL
 		}
 	},
 	"fshc": {
-		"enabled":     true,
-		"test_files":  4,
-		"error_limit": 2
+		"enabled":         true,
+		"test_files":      4,
+		"error_limit":     2,
+		"outlier_factor":  4,
+		"outlier_samples": 3
 	},
 	"auth": {
 		"secret":      "$AIS_SECRET_KEY",
@@ -175,6 +177,9 @@ cat > $AIS_CONF_FILE <<EOL
 		"data": "${WRITE_POLICY_DATA:-}",
 		"md": "${WRITE_POLICY_MD:-}"
 	},
+	"read_ahead": {
+		"enabled": false
+	},
 	"features": "0"
 }
 EOL
@@ -353,6 +353,10 @@ func freeObject(r any) {
 		if handle != nil {
 			cos.Close(handle)
 		}
+	case core.LomReader: // (compressed at rest)
+		if handle != nil {
+			_ = handle.Close()
+		}
 	default:
 		debug.FailTypeCast(r)
 	}
@@ -452,7 +456,7 @@ func WriteSliceAndMeta(hdr *transport.ObjHdr, args *WriteArgs) error {
 		}
 	}
 	tmpFQN := ct.Make(fs.WorkfileType)
-	if err := ct.Write(args.Reader, hdr.ObjAttrs.Size, tmpFQN); err != nil {
+	if err := ct.WriteSlice(args.Reader, hdr.ObjAttrs.Size, tmpFQN); err != nil {
 		return err
 	}
 	if err := ctMeta.Write(bytes.NewReader(args.MD), -1); err != nil {
@@ -177,8 +177,8 @@ func (c *getJogger) copyMissingReplicas(ctx *restoreCtx, reader cos.ReadOpenClos
 	switch r := reader.(type) {
 	case *memsys.SGL:
 		srcReader = memsys.NewReader(r)
-	case *cos.FileHandle:
-		srcReader, err = cos.NewFileHandle(ctx.lom.FQN)
+	case core.LomReader:
+		srcReader, err = ctx.lom.Open()
 	default:
 		debug.FailTypeCast(reader)
 		err = fmt.Errorf("unsupported reader type: %T", reader)
@@ -302,6 +302,13 @@ func (c *getJogger) restoreReplicatedFromDisk(ctx *restoreCtx) error {
 	if writer == nil {
 		return errors.New("failed to read a replica from any target")
 	}
+	// (replica is received decoded) compress and/or encrypt, if configured
+	buf, slab := g.pmm.Alloc()
+	err := ctx.lom.EncodeFile(tmpFQN, buf)
+	slab.Free(buf)
+	if err != nil {
+		return err
+	}
 	if err := ctx.lom.RenameFrom(tmpFQN); err != nil {
 		return err
 	}
@@ -322,7 +329,7 @@ func (c *getJogger) restoreReplicatedFromDisk(ctx *restoreCtx) error {
 		return fmt.Errorf("%s metafile saved while bucket %s was being destroyed", ctMeta.ObjectName(), ctMeta.Bucket())
 	}
 
-	reader, err := cos.NewFileHandle(ctx.lom.FQN)
+	reader, err := ctx.lom.Open()
 	if err != nil {
 		return err
 	}
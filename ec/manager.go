@@ -39,7 +39,7 @@ type Manager struct {
 
 var (
 	ECM        *Manager
-	errSkipped = errors.New("skipped") // CT is skipped due to EC unsupported for the content type
+	errSkipped = errors.New("skipped") // CT is skipped due to EC unsupported for the content type (or tiered object)
 )
 
 func initManager() (err error) {
@@ -233,6 +233,9 @@ func (mgr *Manager) EncodeObject(lom *core.LOM, cb core.OnFinishObj) error {
 	if spec != nil && !spec.PermToProcess() {
 		return errSkipped
 	}
+	if lom.IsTiered() {
+		return errSkipped // metadata-only stub (see core/ltier.go)
+	}
 
 	req := allocateReq(ActSplit, lom.LIF())
 	req.IsCopy = IsECCopy(lom.SizeBytes(), &lom.Bprops().EC)
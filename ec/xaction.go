@@ -7,7 +7,6 @@ package ec
 import (
 	"fmt"
 	"io"
-	"os"
 	"sync"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -108,16 +107,13 @@ func (r *xactReqBase) ecRequestsEnabled() bool {
 // xactECBase //
 ////////////////
 
-func newSliceResponse(md *Metadata, attrs *cmn.ObjAttrs, fqn string) (reader cos.ReadOpenCloser, err error) {
+func newSliceResponse(md *Metadata, attrs *cmn.ObjAttrs, bck *meta.Bck, fqn string) (reader cos.ReadOpenCloser, err error) {
 	attrs.Ver = md.ObjVersion
 	attrs.Cksum = cos.NewCksum(md.CksumType, md.CksumValue)
 
-	stat, err := os.Stat(fqn)
-	if err != nil {
-		return nil, err
-	}
-	attrs.Size = stat.Size()
-	reader, err = cos.NewFileHandle(fqn)
+	// (decrypting, if need be)
+	attrs.Size = SliceSize(md.Size, md.Data)
+	reader, err = core.OpenSlice(bck, fqn, attrs.Size)
 	if err != nil {
 		nlog.Warningf("Failed to read file stats: %s", err)
 		return nil, err
@@ -136,7 +132,7 @@ func newReplicaResponse(attrs *cmn.ObjAttrs, bck *meta.Bck, objName string) (rea
 		nlog.Warningln(err)
 		return nil, err
 	}
-	reader, err = cos.NewFileHandle(lom.FQN)
+	reader, err = lom.Open()
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +159,7 @@ func (r *xactECBase) dataResponse(act intraReqType, hdr *transport.ObjHdr, fqn s
 	ireq := newIntraReq(act, nil, bck)
 	if md != nil && md.SliceID != 0 {
 		// slice request
-		reader, err = newSliceResponse(md, &objAttrs, fqn)
+		reader, err = newSliceResponse(md, &objAttrs, bck, fqn)
 		ireq.exists = err == nil
 	} else {
 		// replica/full object request
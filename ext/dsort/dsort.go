@@ -13,7 +13,6 @@ import (
 	"math"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -370,7 +369,7 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 			goto exit
 		}
 
-		file, err := cos.NewFileHandle(lom.FQN)
+		file, err := lom.Open()
 		if err != nil {
 			return err
 		}
@@ -955,7 +954,7 @@ func (es *extractShard) _do(lom *core.LOM) error {
 	}
 
 	lom.Lock(false)
-	fh, err := os.Open(lom.FQN)
+	fh, err := lom.Open()
 	if err != nil {
 		phaseInfo.adjuster.releaseSema(lom.Mountpath())
 		lom.Unlock(false)
@@ -27,6 +27,7 @@ type rcbCtx struct {
 	extractedSize  int64
 	extractedCount int
 	toDisk         bool
+	noOffset       bool
 }
 
 // handles .tar, .targz, and .tarlz4 - anything and everything that has tar headers
@@ -60,6 +61,7 @@ func (c *rcbCtx) xtar(_ string, reader cos.ReadCloseSizer, hdr any) (bool /*stop
 		metadata:   bmeta,
 		offset:     c.offset,
 		buf:        c.buf,
+		noOffset:   c.noOffset,
 	}
 	args.extractMethod = ExtractToMem
 	if c.toDisk {
@@ -47,6 +47,7 @@ type (
 		extractMethod cos.Bits      // method which needs to be used to extract a record
 		offset        int64         // offset of the body in the shard
 		buf           []byte        // helper buffer for `CopyBuffer` methods
+		noOffset      bool          // shard cannot be read at offset (e.g., compressed at rest)
 	}
 
 	// loads content from local or remote target
@@ -138,7 +139,7 @@ func (recm *RecordManager) RecordWithBuffer(args *extractRecordArgs) (size int64
 			return size, errors.WithStack(err)
 		}
 		recm.contents.Store(fullContentPath, sgl)
-	case args.extractMethod.Has(ExtractToDisk) && recm.extractCreator.SupportsOffset():
+	case args.extractMethod.Has(ExtractToDisk) && recm.extractCreator.SupportsOffset() && !args.noOffset:
 		mdSize, size = recm.extractCreator.MetadataSize(), r.Size()
 		storeType = OffsetStoreType
 		contentPath, _ = recm.encodeRecordName(storeType, args.shardName, args.recordName)
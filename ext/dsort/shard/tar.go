@@ -108,6 +108,7 @@ func (trw *tarRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExt
 		return 0, 0, err
 	}
 	c := &rcbCtx{parent: trw, tw: nil, extractor: extractor, shardName: lom.ObjName, toDisk: toDisk}
+	c.noOffset = !lom.IsRaw() // (offsets are logical - see core/lcodec.go)
 	buf, slab := core.T.PageMM().AllocSize(lom.SizeBytes())
 	c.buf = buf
 
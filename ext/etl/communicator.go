@@ -241,7 +241,7 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 		debug.Assertf(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname("")+" - bucket with namespace")
 		u = pc.boot.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
 
-		fh, err := cos.NewFileHandle(lom.FQN)
+		fh, err := lom.Open()
 		if err != nil {
 			return nil, 0, err
 		}
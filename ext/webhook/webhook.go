@@ -0,0 +1,378 @@
+// Package webhook delivers object event notifications to HTTP endpoints (see cmn.EventsConf)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// - prior to being queued for delivery, each (event, endpoint) pair is written into
+//   the spool directory of the object's mountpath, and is removed once delivered (2xx)
+// - spooling is asynchronous (off the datapath): the spooler writes and fsyncs a batch of
+//   events (and their directories) and only then hands them over for delivery
+// - delivery is at-least-once for spooled events: failed deliveries are retried with exponential backoff
+//   for as long as the endpoint remains configured; upon restart, spooled events are re-delivered
+// - events that are not yet spooled when the node crashes (or gets killed) are lost
+// - events are delivered to a given endpoint sequentially, in the order they were generated
+// - the number of spooled events is bounded: when full, new events are dropped (and counted)
+
+const (
+	MaxSpooled = 64 * 1024
+
+	spoolqSize = 1024
+	spoolBatch = 256
+
+	sleepMin = time.Second
+	sleepMax = time.Minute
+)
+
+type (
+	// spooled (persistent) record
+	record struct {
+		Endpoint string       `json:"endpoint"`
+		Bck      cmn.Bck      `json:"bck"`
+		Event    apc.ObjEvent `json:"event"`
+	}
+	entry struct {
+		fqn  string
+		bck  cmn.Bck
+		body []byte // event JSON
+	}
+	// to be spooled
+	spoolReq struct {
+		rec  *record
+		dir  string
+		body []byte
+		idx  int
+	}
+	// one per endpoint
+	sender struct {
+		cond     *sync.Cond
+		endpoint string
+		queue    []*entry
+	}
+	global struct {
+		tstats    stats.Tracker
+		clientH   *http.Client
+		clientTLS *http.Client
+		senders   map[string]*sender
+		spoolq    chan *spoolReq
+		spooled   atomic.Int64
+		seq       atomic.Uint64
+		mu        sync.Mutex
+	}
+)
+
+var g global
+
+// initialize and re-deliver events that remain spooled (upon restart)
+func Init(tstats stats.Tracker, clientConf *cmn.ClientConf) {
+	g.tstats = tstats
+	g.clientH, g.clientTLS = cmn.NewDefaultClients(clientConf.Timeout.D())
+	g.senders = make(map[string]*sender, 4)
+	replay()
+	g.spoolq = make(chan *spoolReq, spoolqSize)
+	go spooler()
+}
+
+// generate event of a given type, and queue it for delivery to all matching endpoints
+func Notify(lom *core.LOM, evtype string) {
+	bprops := lom.Bprops()
+	if bprops == nil || !bprops.Events.Enabled {
+		return
+	}
+	var (
+		ev   *apc.ObjEvent
+		body []byte
+	)
+	for i := range bprops.Events.Rules {
+		rule := &bprops.Events.Rules[i]
+		if !rule.Match(evtype, lom.ObjName) {
+			continue
+		}
+		if ev == nil {
+			ev = newEvent(lom, evtype)
+			body = cos.MustMarshal(ev)
+		}
+		if g.spooled.Inc() > MaxSpooled {
+			g.spooled.Dec()
+			g.tstats.Inc(stats.WebhookDroppedCount)
+			continue
+		}
+		rec := &record{Endpoint: rule.Endpoint, Bck: *lom.Bucket(), Event: *ev}
+		g.spoolq <- &spoolReq{rec: rec, dir: filepath.Join(lom.Mountpath().Path, fname.WebhookSpool), body: body, idx: i}
+	}
+}
+
+func newEvent(lom *core.LOM, evtype string) *apc.ObjEvent {
+	now := time.Now().UnixNano()
+	ev := &apc.ObjEvent{
+		ID:      strconv.FormatInt(now, 36) + "-" + strconv.FormatUint(g.seq.Inc(), 36) + "-" + core.T.SID(),
+		Type:    evtype,
+		Bucket:  lom.Bck().Cname(""),
+		Name:    lom.ObjName,
+		Version: lom.Version(),
+		Node:    core.T.SID(),
+		Size:    lom.SizeBytes(),
+		Time:    now,
+	}
+	if cksum := lom.Checksum(); !cksum.IsEmpty() {
+		ev.Cksum = cksum.Ty() + ":" + cksum.Val()
+	}
+	return ev
+}
+
+/////////////
+// spooler //
+/////////////
+
+// write and fsync in batches, in the order of generation
+func spooler() {
+	var (
+		batch = make([]*spoolReq, 0, spoolBatch)
+		fqns  = make([]string, 0, spoolBatch)
+		dirs  = make(map[string]struct{}, 4)
+	)
+	for req := range g.spoolq {
+		batch = append(batch, req)
+	drain:
+		for len(batch) < spoolBatch {
+			select {
+			case req = <-g.spoolq:
+				batch = append(batch, req)
+			default:
+				break drain
+			}
+		}
+		for _, req := range batch {
+			fqn, err := spool(req)
+			if err == nil {
+				dirs[req.dir] = struct{}{}
+			} else {
+				g.spooled.Dec()
+				g.tstats.Inc(stats.WebhookDroppedCount)
+				nlog.Errorln("failed to spool", req.rec.Event.Type, "event for", req.rec.Endpoint, "err:", err)
+			}
+			fqns = append(fqns, fqn)
+		}
+		for dir := range dirs {
+			if err := fsyncDir(dir); err != nil {
+				nlog.Errorln("failed to fsync", dir, "err:", err) // (proceeding anyway)
+			}
+			delete(dirs, dir)
+		}
+		for i, req := range batch {
+			if fqns[i] != "" {
+				g.tstats.Add(stats.WebhookPendingCount, 1)
+				push(req.rec.Endpoint, &entry{fqn: fqns[i], bck: req.rec.Bck, body: req.body})
+			}
+			batch[i] = nil
+		}
+		batch, fqns = batch[:0], fqns[:0]
+	}
+}
+
+func spool(req *spoolReq) (string, error) {
+	if err := cos.CreateDir(req.dir); err != nil {
+		return "", err
+	}
+	fqn := filepath.Join(req.dir, req.rec.Event.ID+"-"+strconv.Itoa(req.idx))
+	fh, err := os.OpenFile(fqn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, cos.PermRWR)
+	if err != nil {
+		return "", err
+	}
+	if _, err = fh.Write(cos.MustMarshal(req.rec)); err == nil {
+		err = fh.Sync()
+	}
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		cos.RemoveFile(fqn)
+		return "", err
+	}
+	return fqn, nil
+}
+
+func fsyncDir(dir string) error {
+	fh, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = fh.Sync()
+	fh.Close()
+	return err
+}
+
+func push(endpoint string, e *entry) {
+	g.mu.Lock()
+	s, ok := g.senders[endpoint]
+	if !ok {
+		s = &sender{cond: sync.NewCond(&g.mu), endpoint: endpoint}
+		g.senders[endpoint] = s
+		go s.run()
+	}
+	s.queue = append(s.queue, e)
+	s.cond.Signal()
+	g.mu.Unlock()
+}
+
+func replay() {
+	var (
+		avail, _ = fs.Get()
+		recs     = make(map[string]*record)
+		fqns     []string
+	)
+	for _, mi := range avail {
+		dir := filepath.Join(mi.Path, fname.WebhookSpool)
+		dentries, err := os.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Errorln("failed to read webhook spool:", err)
+			}
+			continue
+		}
+		for _, dent := range dentries {
+			fqn := filepath.Join(dir, dent.Name())
+			rec := &record{}
+			if err := _load(fqn, rec); err != nil {
+				nlog.Errorln("failed to load spooled event", fqn, "err:", err)
+				cos.RemoveFile(fqn)
+				continue
+			}
+			recs[fqn] = rec
+			fqns = append(fqns, fqn)
+		}
+	}
+	if len(fqns) == 0 {
+		return
+	}
+	// in the order of generation (see newEvent)
+	sort.Slice(fqns, func(i, j int) bool { return filepath.Base(fqns[i]) < filepath.Base(fqns[j]) })
+	for _, fqn := range fqns {
+		rec := recs[fqn]
+		g.spooled.Inc()
+		g.tstats.Add(stats.WebhookPendingCount, 1)
+		push(rec.Endpoint, &entry{fqn: fqn, bck: rec.Bck, body: cos.MustMarshal(&rec.Event)})
+	}
+	nlog.Infoln("webhook spool:", len(fqns), "pending event(s)")
+}
+
+func _load(fqn string, rec *record) error {
+	b, err := os.ReadFile(fqn)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(b, rec)
+}
+
+////////////
+// sender //
+////////////
+
+func (s *sender) run() {
+	for {
+		g.mu.Lock()
+		for len(s.queue) == 0 {
+			s.cond.Wait()
+		}
+		e := s.queue[0]
+		g.mu.Unlock()
+
+		s.deliver(e)
+
+		g.mu.Lock()
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		g.mu.Unlock()
+
+		if err := cos.RemoveFile(e.fqn); err != nil {
+			nlog.Errorln("failed to remove spooled event", e.fqn, "err:", err)
+		}
+		g.spooled.Dec()
+		g.tstats.Add(stats.WebhookPendingCount, -1)
+	}
+}
+
+// returns when either delivered or the endpoint is no longer configured
+func (s *sender) deliver(e *entry) {
+	sleep := sleepMin
+	for {
+		err := s.post(e.body)
+		if err == nil {
+			g.tstats.Inc(stats.WebhookCount)
+			return
+		}
+		g.tstats.Inc(stats.ErrWebhookCount)
+		if !s.configured(&e.bck) {
+			nlog.Warningln("endpoint", s.endpoint, "is no longer configured for", e.bck.Cname(""), "- dropping event")
+			return
+		}
+		if sleep == sleepMax || cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Warningln("failed to deliver event to", s.endpoint, "err:", err, "- retrying in", sleep)
+		}
+		time.Sleep(sleep)
+		sleep = min(sleep*2, sleepMax)
+	}
+}
+
+func (s *sender) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	client := g.clientH
+	if cos.IsHTTPS(s.endpoint) {
+		client = g.clientTLS
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	cos.Close(resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s: %s", s.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// (the bucket's rules may have changed)
+func (s *sender) configured(bck *cmn.Bck) bool {
+	b := meta.CloneBck(bck)
+	if err := b.Init(core.T.Bowner()); err != nil {
+		return false
+	}
+	if !b.Props.Events.Enabled {
+		return false
+	}
+	for i := range b.Props.Events.Rules {
+		if b.Props.Events.Rules[i].Endpoint == s.endpoint {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+// Package webhook delivers object event notifications to HTTP endpoints (see cmn.EventsConf)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type listener struct {
+	srv    *httptest.Server
+	events chan apc.ObjEvent
+	nfail  atomic.Int32 // fail so many requests first
+}
+
+func newListener(nfail int32) *listener {
+	l := &listener{events: make(chan apc.ObjEvent, 16)}
+	l.nfail.Store(nfail)
+	l.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.nfail.Dec() >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var ev apc.ObjEvent
+		if err := jsoniter.NewDecoder(r.Body).Decode(&ev); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		l.events <- ev
+	}))
+	return l
+}
+
+func (l *listener) next(t *testing.T) apc.ObjEvent {
+	select {
+	case ev := <-l.events:
+		return ev
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return apc.ObjEvent{}
+}
+
+func setup(t *testing.T, rules []cmn.EventRule) (*meta.Bck, string) {
+	mpath := t.TempDir()
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+
+	props := &cmn.Bprops{
+		Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+		Events: cmn.EventsConf{Rules: rules, Enabled: true},
+		BID:    1,
+	}
+	bck := &meta.Bck{Name: "webhook-test", Provider: apc.AIS, Ns: cmn.NsGlobal, Props: props}
+	_ = mock.NewTarget(mock.NewBaseBownerMock(bck))
+	return bck, mpath
+}
+
+func waitEmpty(t *testing.T, mpath string) {
+	for range 100 {
+		dentries, _ := os.ReadDir(filepath.Join(mpath, fname.WebhookSpool))
+		if g.spooled.Load() == 0 && len(dentries) == 0 {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("spool is not empty: %d", g.spooled.Load())
+}
+
+func TestNotify(t *testing.T) {
+	l := newListener(1)
+	defer l.srv.Close()
+	rules := []cmn.EventRule{{Endpoint: l.srv.URL, Events: []string{apc.EvtObjCreated}, Prefix: "a/", Suffix: ".txt"}}
+	bck, mpath := setup(t, rules)
+	Init(mock.NewStatsTracker(), &cmn.ClientConf{Timeout: cos.Duration(time.Second)})
+
+	for _, tc := range []struct{ name, evtype string }{
+		{"a/skip.bin", apc.EvtObjCreated},
+		{"b/skip.txt", apc.EvtObjCreated},
+		{"a/skip.txt", apc.EvtObjDeleted},
+		{"a/obj.txt", apc.EvtObjCreated},
+	} {
+		lom := core.AllocLOM(tc.name)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		Notify(lom, tc.evtype)
+		core.FreeLOM(lom)
+	}
+
+	// delivered after retrying
+	ev := l.next(t)
+	tassert.Errorf(t, ev.Type == apc.EvtObjCreated && ev.Name == "a/obj.txt", "unexpected event %+v", ev)
+	tassert.Errorf(t, ev.Bucket == bck.Cname("") && ev.ID != "", "unexpected event %+v", ev)
+	waitEmpty(t, mpath)
+	select {
+	case ev := <-l.events:
+		t.Fatalf("unexpected (filtered out) event %+v", ev)
+	default:
+	}
+}
+
+// rule without filters matches all events
+func TestNotifyAll(t *testing.T) {
+	l := newListener(0)
+	defer l.srv.Close()
+	bck, mpath := setup(t, []cmn.EventRule{{Endpoint: l.srv.URL}})
+	Init(mock.NewStatsTracker(), &cmn.ClientConf{Timeout: cos.Duration(time.Second)})
+
+	lom := core.AllocLOM("any")
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	for _, evtype := range apc.SupportedEvents {
+		Notify(lom, evtype)
+	}
+	core.FreeLOM(lom)
+
+	got := make(map[string]bool, len(apc.SupportedEvents))
+	for range apc.SupportedEvents {
+		ev := l.next(t)
+		got[ev.Type] = true
+	}
+	for _, evtype := range apc.SupportedEvents {
+		tassert.Errorf(t, got[evtype], "missing %q event", evtype)
+	}
+	waitEmpty(t, mpath)
+}
+
+func TestReplay(t *testing.T) {
+	l := newListener(0)
+	defer l.srv.Close()
+	bck, mpath := setup(t, []cmn.EventRule{{Endpoint: l.srv.URL}})
+
+	// spooled prior to restart
+	dir := filepath.Join(mpath, fname.WebhookSpool)
+	tassert.CheckFatal(t, cos.CreateDir(dir))
+	for _, id := range []string{"2", "1"} {
+		rec := &record{
+			Endpoint: l.srv.URL,
+			Bck:      *bck.Bucket(),
+			Event:    apc.ObjEvent{ID: id, Type: apc.EvtObjDeleted, Bucket: bck.Cname(""), Name: "obj" + id},
+		}
+		tassert.CheckFatal(t, os.WriteFile(filepath.Join(dir, id+"-0"), cos.MustMarshal(rec), cos.PermRWR))
+	}
+	Init(mock.NewStatsTracker(), &cmn.ClientConf{Timeout: cos.Duration(time.Second)})
+
+	for _, id := range []string{"1", "2"} {
+		ev := l.next(t)
+		tassert.Errorf(t, ev.ID == id && ev.Name == "obj"+id, "expected event %q in order, got %+v", id, ev)
+	}
+	waitEmpty(t, mpath)
+}
+
+// spooled (and fsync-ed) prior to delivery
+func TestSpool(t *testing.T) {
+	l := newListener(1 << 20)
+	defer l.srv.Close()
+	bck, mpath := setup(t, []cmn.EventRule{{Endpoint: l.srv.URL}})
+	Init(mock.NewStatsTracker(), &cmn.ClientConf{Timeout: cos.Duration(time.Second)})
+
+	names := []string{"obj1", "obj2", "obj3"}
+	for _, name := range names {
+		lom := core.AllocLOM(name)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		Notify(lom, apc.EvtObjCreated)
+		core.FreeLOM(lom)
+	}
+
+	dir := filepath.Join(mpath, fname.WebhookSpool)
+	var dentries []os.DirEntry
+	for range 100 {
+		if dentries, _ = os.ReadDir(dir); len(dentries) == len(names) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	tassert.Fatalf(t, len(dentries) == len(names), "expected %d spooled events, got %d", len(names), len(dentries))
+	for i, dent := range dentries { // (sorted by filename, i.e., in the order of generation)
+		rec := &record{}
+		tassert.CheckFatal(t, _load(filepath.Join(dir, dent.Name()), rec))
+		tassert.Errorf(t, rec.Endpoint == l.srv.URL && rec.Event.Name == names[i], "unexpected spooled record %+v", rec)
+	}
+
+	l.nfail.Store(0)
+	for _, name := range names {
+		ev := l.next(t)
+		tassert.Errorf(t, ev.Name == name, "expected event for %q in order, got %+v", name, ev)
+	}
+	waitEmpty(t, mpath)
+}
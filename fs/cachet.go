@@ -0,0 +1,93 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/xoshiro256"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Cache tier: fast (e.g., NVMe) mountpaths configured via local config `cache_fspaths`
+// to keep copies of hot objects (see core/lhot.go). Unlike regular (capacity tier) mountpaths,
+// cache-tier mountpaths:
+// - do not participate in HRW placement, resilvering, rebalancing, and space cleanup;
+// - contain object content only (no metadata) - under the CacheType directory of a given bucket;
+// - are not persistent: all cached content gets removed upon target startup.
+
+const CacheType = "hc" // (not registered with CSM - not to be visited by joggers)
+
+// (read-only once initialized)
+var cacheTier MPI
+
+func AddCacheTier(mpath string) (*Mountpath, error) {
+	mi, err := NewMountpath(mpath)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cacheTier[mi.Path]; ok {
+		return nil, fmt.Errorf("cache-tier %s: duplicated", mi)
+	}
+	mfs.mu.RLock()
+	path, ok := mfs.fsIDs[mi.FsID]
+	mfs.mu.RUnlock()
+	if ok && !mfs.allowSharedDisksAndNoDisks {
+		return nil, fmt.Errorf("cache-tier %s: filesystem is shared with capacity-tier mountpath %q", mi, path)
+	}
+	if err := mi.wipeCache(); err != nil {
+		return nil, err
+	}
+	if cacheTier == nil {
+		cacheTier = make(MPI, 2)
+	}
+	cacheTier[mi.Path] = mi
+	return mi, nil
+}
+
+func CacheTier() MPI { return cacheTier }
+
+// (compare with fs.Hrw)
+func HrwCacheTier(uname string) (mi *Mountpath) {
+	var (
+		max    uint64
+		digest = xxhash.Checksum64S(cos.UnsafeB(uname), cos.MLCG32)
+	)
+	for _, mpathInfo := range cacheTier {
+		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
+		if cs >= max {
+			max = cs
+			mi = mpathInfo
+		}
+	}
+	return
+}
+
+func CacheTierCap(mi *Mountpath, config *cmn.Config) (Capacity, error) {
+	return mi.getCapacity(config, true /*refresh*/)
+}
+
+// remove all (bucket) directories left over from the previous run
+func (mi *Mountpath) wipeCache() error {
+	entries, err := os.ReadDir(mi.Path)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if name := e.Name(); e.IsDir() && name[0] == prefProvider {
+			if err := os.RemoveAll(filepath.Join(mi.Path, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NOTE: used only in tests
+func TestResetCacheTier() { cacheTier = nil }
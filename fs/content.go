@@ -38,6 +38,11 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	PackType     = "pk" // packed small objects (see pack.go)
+	ChunkType    = "ch" // chunks of large objects (see core/lchunk.go)
+	ShardIdxType = "ix" // indexes of archived files (shards) - see core/lshard.go
+	NameIdxType  = "ni" // sorted names of the bucket's objects - see nameidx.go
+	SnapMetaType = "sm" // metadata of snapshot objects - see core/lsnap.go
 )
 
 type (
@@ -177,6 +182,11 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	PackContentResolver     struct{}
+	ChunkContentResolver    struct{}
+	ShardIdxContentResolver struct{}
+	NameIdxContentResolver  struct{}
+	SnapMetaContentResolver struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -251,3 +261,77 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+func (*PackContentResolver) PermToMove() bool    { return false }
+func (*PackContentResolver) PermToEvict() bool   { return false }
+func (*PackContentResolver) PermToProcess() bool { return false }
+
+func (*PackContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*PackContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// chunk name: <object name>.<chunk tag>.<pid> where the latter identifies the writer
+// (chunks that are not referenced by their object's manifest are subject to space cleanup
+// iff "old", that is, written prior to the process restart)
+func (*ChunkContentResolver) PermToMove() bool    { return true }
+func (*ChunkContentResolver) PermToEvict() bool   { return false }
+func (*ChunkContentResolver) PermToProcess() bool { return false }
+
+func (*ChunkContentResolver) GenUniqueFQN(base, prefix string) string {
+	const contentSepa = "."
+	return base + contentSepa + prefix + contentSepa + spid
+}
+
+func (*ChunkContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	const contentSepa = '.'
+	pidIndex := strings.LastIndexByte(base, contentSepa)
+	if pidIndex < 0 {
+		return "", false, false
+	}
+	tagIndex := strings.LastIndexByte(base[:pidIndex], contentSepa)
+	if tagIndex <= 0 {
+		return "", false, false
+	}
+	filePID, err := strconv.ParseInt(base[pidIndex+1:], 16, 64)
+	if err != nil {
+		return "", false, false
+	}
+	return base[:tagIndex], filePID != pid, true
+}
+
+// shard index is a derivative of its shard: never moved or evicted on its own
+// (stale and orphaned indexes are subject to space cleanup)
+func (*ShardIdxContentResolver) PermToMove() bool    { return false }
+func (*ShardIdxContentResolver) PermToEvict() bool   { return false }
+func (*ShardIdxContentResolver) PermToProcess() bool { return false }
+
+func (*ShardIdxContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*ShardIdxContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// name index is maintained (and rebuilt) by its own rules - see nameidx.go
+func (*NameIdxContentResolver) PermToMove() bool    { return false }
+func (*NameIdxContentResolver) PermToEvict() bool   { return false }
+func (*NameIdxContentResolver) PermToProcess() bool { return false }
+
+func (*NameIdxContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*NameIdxContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// snapshot object's metadata goes wherever the object goes (see core/lsnap.go);
+// orphaned metadata is subject to space cleanup
+func (*SnapMetaContentResolver) PermToMove() bool    { return false }
+func (*SnapMetaContentResolver) PermToEvict() bool   { return false }
+func (*SnapMetaContentResolver) PermToProcess() bool { return false }
+
+func (*SnapMetaContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*SnapMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
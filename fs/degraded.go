@@ -0,0 +1,75 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Degraded mountpaths (see fs/health):
+// - a mountpath whose disk(s) keep running much slower than the peers gets marked as degraded;
+// - degraded mountpath remains available (and readable) but is excluded from HRW placement,
+//   so that new objects (and their replicas) get stored elsewhere;
+// - objects stored on a degraded mountpath become temporarily misplaced: GET restores them
+//   to their current HRW location (from mirror copies, if available), and resilver puts
+//   everything back in place once the mountpath recovers;
+// - at most half of the (available) mountpaths can be degraded at any given time.
+
+func (mi *Mountpath) IsDegraded() bool { return mi.IsAnySet(FlagDegraded) }
+
+func Degrade(mpath string) (*Mountpath, error) {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+	avail := GetAvail()
+	mi, ok := avail[mpath]
+	if !ok {
+		return nil, cmn.NewErrMountpathNotFound(mpath, "" /*fqn*/, false /*disabled*/)
+	}
+	if mi.IsDegraded() {
+		return nil, nil // nothing to do
+	}
+	var num, n int
+	for _, m := range avail {
+		if m.IsAnySet(FlagWaitingDD) {
+			continue
+		}
+		num++
+		if m.IsDegraded() {
+			n++
+		}
+	}
+	if (n+1)*2 > num {
+		return nil, fmt.Errorf("cannot degrade %s: %d of %d mountpaths are degraded", mi, n, num)
+	}
+	mi.setFlags(FlagDegraded)
+	return mi, nil
+}
+
+func Undegrade(mpath string) (*Mountpath, error) {
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+	avail := GetAvail()
+	mi, ok := avail[mpath]
+	if !ok {
+		return nil, cmn.NewErrMountpathNotFound(mpath, "" /*fqn*/, false /*disabled*/)
+	}
+	if !mi.IsDegraded() {
+		return nil, nil
+	}
+	mi.clearFlags(FlagDegraded)
+	return mi, nil
+}
+
+func NumDegraded() (n int) {
+	avail := GetAvail()
+	for _, mi := range avail {
+		if mi.IsDegraded() {
+			n++
+		}
+	}
+	return
+}
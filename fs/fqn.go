@@ -32,6 +32,10 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileTier         = "tier"           // metadata-only stub of a tiered object
+	WorkfileScrub        = "scrub"          // repair corrupted replica
+	WorkfileEncode       = "encode"         // compress and/or encrypt content at rest
+	WorkfileCompose      = "compose"        // compose (concatenate) objects and byte ranges
 )
 
 type ParsedFQN struct {
@@ -35,6 +35,7 @@ const nodeXattrID = "user.ais.daemon_id"
 const (
 	FlagBeingDisabled uint64 = 1 << iota
 	FlagBeingDetached
+	FlagDegraded // (see degraded.go)
 )
 
 const FlagWaitingDD = FlagBeingDisabled | FlagBeingDetached
@@ -49,6 +50,7 @@ const FlagWaitingDD = FlagBeingDisabled | FlagBeingDetached
 type (
 	Mountpath struct {
 		lomCaches  cos.MultiSyncMap // LOM caches
+		packs      sync.Map         // bucket => *Pack (see pack.go)
 		info       string
 		Path       string   // clean path
 		cos.FS              // underlying filesystem
@@ -56,6 +58,7 @@ type (
 		flags      uint64   // bit flags (set/get atomic)
 		PathDigest uint64   // (HRW logic)
 		capacity   Capacity
+		packMu     sync.Mutex
 	}
 	MPI map[string]*Mountpath
 
@@ -130,6 +133,10 @@ func (mi *Mountpath) setFlags(flags uint64) (ok bool) {
 	return cos.SetfAtomic(&mi.flags, flags)
 }
 
+func (mi *Mountpath) clearFlags(flags uint64) (ok bool) {
+	return cos.ClearfAtomic(&mi.flags, flags)
+}
+
 func (mi *Mountpath) IsAnySet(flags uint64) bool {
 	return cos.IsAnySetfAtomic(&mi.flags, flags)
 }
@@ -145,11 +152,16 @@ func (mi *Mountpath) String() string {
 			mi.info = fmt.Sprintf("mp[%s, %v]", mi.Path, mi.Disks)
 		}
 	}
-	if !mi.IsAnySet(FlagWaitingDD) {
+	switch {
+	case mi.IsAnySet(FlagWaitingDD):
+		l := len(mi.info)
+		return mi.info[:l-1] + ", waiting-dd]"
+	case mi.IsAnySet(FlagDegraded):
+		l := len(mi.info)
+		return mi.info[:l-1] + ", degraded]"
+	default:
 		return mi.info
 	}
-	l := len(mi.info)
-	return mi.info[:l-1] + ", waiting-dd]"
 }
 
 func (mi *Mountpath) LomCache(idx int) *sync.Map { return mi.lomCaches.Get(idx) }
@@ -529,8 +541,11 @@ func MountpathsToLists() (mpl *apc.MountpathList) {
 	for _, mi := range avail {
 		if mi.IsAnySet(FlagWaitingDD) {
 			mpl.WaitingDD = append(mpl.WaitingDD, mi.Path)
-		} else {
-			mpl.Available = append(mpl.Available, mi.Path)
+			continue
+		}
+		mpl.Available = append(mpl.Available, mi.Path)
+		if mi.IsAnySet(FlagDegraded) {
+			mpl.Degraded = append(mpl.Degraded, mi.Path)
 		}
 	}
 	for mpath := range disabled {
@@ -539,6 +554,7 @@ func MountpathsToLists() (mpl *apc.MountpathList) {
 	sort.Strings(mpl.Available)
 	sort.Strings(mpl.WaitingDD)
 	sort.Strings(mpl.Disabled)
+	sort.Strings(mpl.Degraded)
 	return
 }
 
@@ -805,7 +821,7 @@ func Disable(mpath string, cb ...func()) (disabledMpath *Mountpath, err error) {
 			return
 		}
 		availableCopy, disabledCopy := cloneMPI()
-		cos.ClearfAtomic(&mi.flags, FlagWaitingDD)
+		cos.ClearfAtomic(&mi.flags, FlagWaitingDD|FlagDegraded)
 		disabledCopy[cleanMpath] = mi
 
 		config := cmn.GCO.Get()
@@ -879,6 +895,7 @@ func DestroyBucket(op string, bck *cmn.Bck, bid uint64) (err error) {
 		count = len(avail)
 		now   time.Time
 	)
+	evictNameIdx(bck)
 	for _, mi := range avail {
 		// normally, unique bucket ID (aka BID) must be known
 		// - i.e., non-zero (and unique);
@@ -898,6 +915,7 @@ func DestroyBucket(op string, bck *cmn.Bck, bid uint64) (err error) {
 			}
 		}
 
+		mi.evictPack(bck)
 		dir := mi.makeDelPathBck(bck)
 		if errMv := mi.MoveToDeleted(dir); errMv != nil {
 			nlog.Errorf("%s %q: failed to rm dir %q: %v", op, bck, dir, errMv)
@@ -915,9 +933,13 @@ func DestroyBucket(op string, bck *cmn.Bck, bid uint64) (err error) {
 func RenameBucketDirs(bckFrom, bckTo *cmn.Bck) (err error) {
 	avail := GetAvail()
 	renamed := make([]*Mountpath, 0, len(avail))
+	evictNameIdx(bckFrom)
+	evictNameIdx(bckTo)
 	for _, mi := range avail {
 		fromPath := mi.makeDelPathBck(bckFrom)
 		toPath := mi.MakePathBck(bckTo)
+		mi.evictPack(bckFrom)
+		mi.evictPack(bckTo)
 
 		// remove destination bucket directory before renaming
 		// (the operation will fail otherwise)
@@ -11,11 +11,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
 )
 
 const (
@@ -28,15 +30,20 @@ const (
 // When an IO error is triggered, it runs a few tests to make sure that the
 // failed mountpath is healthy. Once the mountpath is considered faulty the
 // mountpath is disabled and removed from the list.
+// In addition, FSHC periodically looks for latency outliers (see outlier.go).
 //
 // for mountpath definition, see fs/mountfs.go
 type (
 	fspathDispatcher interface {
 		DisableMpath(mpath, reason string) (err error)
+		DegradeMpath(mpath, reason string) (err error)
+		UndegradeMpath(mpath string) (err error)
 	}
 	FSHC struct {
 		dispatcher fspathDispatcher // listener is notified upon mountpath events (disabled, etc.)
 		fileListCh chan string
+		disks      ios.AllDiskStats
+		mpaths     map[string]*mpathHealth
 		stopCh     cos.StopCh
 	}
 )
@@ -49,7 +56,12 @@ type (
 var _ cos.Runner = (*FSHC)(nil)
 
 func NewFSHC(dispatcher fspathDispatcher) (f *FSHC) {
-	f = &FSHC{dispatcher: dispatcher, fileListCh: make(chan string, 100)}
+	f = &FSHC{
+		dispatcher: dispatcher,
+		fileListCh: make(chan string, 100),
+		disks:      make(ios.AllDiskStats, 16),
+		mpaths:     make(map[string]*mpathHealth, 16),
+	}
 	f.stopCh.Init()
 	return
 }
@@ -59,6 +71,8 @@ func (*FSHC) Name() string { return "fshc" }
 func (f *FSHC) Run() error {
 	nlog.Infof("Starting %s", f.Name())
 
+	ticker := time.NewTicker(cmn.GCO.Get().Periodic.StatsTime.D())
+	defer ticker.Stop()
 	for {
 		select {
 		case filePath := <-f.fileListCh:
@@ -67,8 +81,12 @@ func (f *FSHC) Run() error {
 				nlog.Errorln(err)
 				break
 			}
-
+			f.health(mi.Path).ioErrs++
 			f.runMpathTest(mi.Path, filePath)
+		case <-ticker.C:
+			if config := cmn.GCO.Get(); config.FSHC.Enabled {
+				f.sample(config)
+			}
 		case <-f.stopCh.Listen():
 			return nil
 		}
@@ -74,6 +74,16 @@ func (d *MockFSDispatcher) DisableMpath(mpath, reason string) (err error) {
 	return
 }
 
+func (*MockFSDispatcher) DegradeMpath(mpath, _ string) (err error) {
+	_, err = fs.Degrade(mpath)
+	return
+}
+
+func (*MockFSDispatcher) UndegradeMpath(mpath string) (err error) {
+	_, err = fs.Undegrade(mpath)
+	return
+}
+
 func setupTests(t *testing.T) {
 	updateTestConfig()
 	initMountpaths(t)
@@ -157,3 +167,74 @@ func TestFSCheckerTryWriteFile(t *testing.T) {
 	err := tryWriteFile(mpath, cos.KiB)
 	tassert.CheckFatal(t, err)
 }
+
+func TestFSCheckerIsOutlier(t *testing.T) {
+	tests := []struct {
+		awaits map[string]int64
+		yes    bool
+	}{
+		{map[string]int64{"a": 100, "b": 5, "c": 6, "d": 4}, true},
+		{map[string]int64{"a": 100, "b": 50, "c": 60, "d": 40}, false},
+		{map[string]int64{"a": 8, "b": 0, "c": 0, "d": 1}, false}, // below min. await
+		{map[string]int64{"a": 12, "b": 0, "c": 0, "d": 1}, true},
+		{map[string]int64{"a": 100, "b": 5}, false}, // not enough peers
+		{map[string]int64{"a": 100, "b": 5, "c": 90, "d": 80}, false},
+	}
+	for i, tst := range tests {
+		median, yes := isOutlier("a", tst.awaits, cmn.DefaultOutlierFactor)
+		tassert.Errorf(t, yes == tst.yes, "%d: expected outlier=%t, got %t (median %d)", i, tst.yes, yes, median)
+	}
+}
+
+func TestFSCheckerDegrade(t *testing.T) {
+	setupTests(t)
+
+	var (
+		config  = cmn.GCO.Get()
+		avail   = fs.GetAvail()
+		slow    = fsCheckerTmpDir + "/1"
+		fshc    = NewFSHC(newMockFSDispatcher())
+		samples = config.FSHC.Samples()
+		awaits  = make(map[string]int64, len(avail))
+	)
+	for mpath := range avail {
+		awaits[mpath] = 2
+	}
+	awaits[slow] = 200
+	// (3 mountpaths, one of which is missing - still available, though)
+	tassert.Fatalf(t, len(avail) == 3, "expecting 3 available mountpaths, got %d", len(avail))
+
+	for i := 1; i < samples; i++ {
+		fshc.onSample(config, avail, awaits)
+		tassert.Fatalf(t, !avail[slow].IsDegraded(), "%s degraded after %d samples", slow, i)
+	}
+	fshc.onSample(config, avail, awaits)
+	tassert.Fatalf(t, avail[slow].IsDegraded(), "%s not degraded after %d samples", slow, samples)
+	tassert.Errorf(t, fs.NumDegraded() == 1, "expecting 1 degraded mountpath, got %d", fs.NumDegraded())
+
+	// new placement avoids degraded mountpath
+	for i := range 100 {
+		mi, _, err := fs.Hrw(fmt.Sprintf("uname-%d", i))
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, mi.Path != slow, "HRW selected degraded %s", mi)
+	}
+	// at most half can be degraded
+	for mpath := range avail {
+		if mpath != slow {
+			_, err := fs.Degrade(mpath)
+			tassert.Errorf(t, err != nil, "expecting failure to degrade %s", mpath)
+			break
+		}
+	}
+
+	// recover
+	awaits[slow] = 3
+	fshc.health(slow).ioErrs = 1
+	fshc.onSample(config, avail, awaits) // (IO errors count as an outlier sample)
+	for i := 0; i < samples-1; i++ {
+		fshc.onSample(config, avail, awaits)
+		tassert.Fatalf(t, avail[slow].IsDegraded(), "%s recovered after %d samples", slow, i+1)
+	}
+	fshc.onSample(config, avail, awaits)
+	tassert.Fatalf(t, !avail[slow].IsDegraded(), "%s still degraded after %d samples", slow, samples)
+}
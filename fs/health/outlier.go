@@ -0,0 +1,136 @@
+// Package health provides a basic mountpath health monitor.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package health
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+)
+
+// Latency outliers: in addition to testing mountpaths upon IO errors, FSHC periodically
+// compares disk latencies (ios await) of all available mountpaths. A mountpath that is
+// an outlier (or keeps producing IO errors) for `fshc.outlier_samples` consecutive samples
+// gets degraded (see fs/degraded.go), and recovers after the same number of good samples.
+
+const (
+	minOutlierAwait = 10 // ms
+	minOutlierPeers = 2
+)
+
+type mpathHealth struct {
+	bad    int // consecutive outlier samples (when not degraded)
+	good   int // consecutive non-outlier samples (when degraded)
+	ioErrs int // since the previous sample
+}
+
+func (f *FSHC) health(mpath string) (h *mpathHealth) {
+	if h = f.mpaths[mpath]; h == nil {
+		h = &mpathHealth{}
+		f.mpaths[mpath] = h
+	}
+	return
+}
+
+func (f *FSHC) sample(config *cmn.Config) {
+	avail := fs.GetAvail()
+	fs.FillDiskStats(f.disks)
+	f.onSample(config, avail, mpathAwaits(avail, f.disks))
+}
+
+func (f *FSHC) onSample(config *cmn.Config, avail fs.MPI, awaits map[string]int64) {
+	var (
+		factor  = config.FSHC.Factor()
+		samples = config.FSHC.Samples()
+	)
+	for mpath, mi := range avail {
+		if mi.IsAnySet(fs.FlagWaitingDD) {
+			continue
+		}
+		var (
+			h            = f.health(mpath)
+			median, slow = isOutlier(mpath, awaits, factor)
+			bad          = slow || h.ioErrs > 0
+			ioErrs       = h.ioErrs
+		)
+		h.ioErrs = 0
+		if !mi.IsDegraded() {
+			h.good = 0
+			if !bad {
+				h.bad = 0
+				continue
+			}
+			if h.bad++; h.bad < samples {
+				continue
+			}
+			h.bad = 0
+			reason := fmt.Sprintf("%d consecutive samples: await %dms vs peers' median %dms, %d IO error(s)",
+				samples, awaits[mpath], median, ioErrs)
+			if err := f.dispatcher.DegradeMpath(mpath, reason); err != nil {
+				nlog.Errorln("failed to degrade mountpath:", err)
+			}
+			continue
+		}
+		h.bad = 0
+		if bad {
+			h.good = 0
+			continue
+		}
+		if h.good++; h.good >= samples {
+			h.good = 0
+			if err := f.dispatcher.UndegradeMpath(mpath); err != nil {
+				nlog.Errorln("failed to recover degraded mountpath:", err)
+			}
+		}
+	}
+	for mpath := range f.mpaths {
+		if _, ok := avail[mpath]; !ok {
+			delete(f.mpaths, mpath)
+		}
+	}
+}
+
+// mountpath => its slowest disk's await
+// (mountpaths without disks are skipped)
+func mpathAwaits(avail fs.MPI, disks ios.AllDiskStats) map[string]int64 {
+	awaits := make(map[string]int64, len(avail))
+	for mpath, mi := range avail {
+		if mi.IsAnySet(fs.FlagWaitingDD) || len(mi.Disks) == 0 {
+			continue
+		}
+		var await int64
+		for _, disk := range mi.Disks {
+			await = max(await, disks[disk].Await)
+		}
+		awaits[mpath] = await
+	}
+	return awaits
+}
+
+// whether a given mountpath's await exceeds `factor` times the median of its peers
+func isOutlier(mpath string, awaits map[string]int64, factor int64) (median int64, yes bool) {
+	await, ok := awaits[mpath]
+	if !ok || len(awaits) <= minOutlierPeers {
+		return 0, false
+	}
+	peers := make([]int64, 0, len(awaits)-1)
+	for mp, a := range awaits {
+		if mp != mpath {
+			peers = append(peers, a)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	l := len(peers)
+	median = peers[l/2]
+	if l%2 == 0 {
+		median = (peers[l/2-1] + peers[l/2]) / 2
+	}
+	yes = await >= minOutlierAwait && await > factor*max(median, 1)
+	return median, yes
+}
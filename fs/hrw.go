@@ -16,13 +16,21 @@ import (
 // See also: core/meta/hrw.go
 
 func Hrw(uname string) (mi *Mountpath, digest uint64, err error) {
-	var (
-		max   uint64
-		avail = GetAvail()
-	)
+	avail := GetAvail()
 	digest = xxhash.Checksum64S(cos.UnsafeB(uname), cos.MLCG32)
+	if mi = _hrw(avail, digest, FlagWaitingDD|FlagDegraded); mi == nil {
+		mi = _hrw(avail, digest, FlagWaitingDD) // all degraded (shouldn't happen - see Degrade)
+	}
+	if mi == nil {
+		err = cmn.ErrNoMountpaths
+	}
+	return
+}
+
+func _hrw(avail MPI, digest, skip uint64) (mi *Mountpath) {
+	var max uint64
 	for _, mpathInfo := range avail {
-		if mpathInfo.IsAnySet(FlagWaitingDD) {
+		if mpathInfo.IsAnySet(skip) {
 			continue
 		}
 		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
@@ -31,8 +39,5 @@ func Hrw(uname string) (mi *Mountpath, digest uint64, err error) {
 			mi = mpathInfo
 		}
 	}
-	if mi == nil {
-		err = cmn.ErrNoMountpaths
-	}
 	return
 }
@@ -0,0 +1,936 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Name index: sorted names of the objects stored on a given target in a given ais:// bucket
+// (see cmn.NameIdxConf, core/lnidx.go, and xs/nidx.go)
+// - resides on a single mountpath (selected by HRW at build time), content type NameIdxType:
+//   * base file: length-prefixed sorted names followed by the fence table (every
+//     nidxFenceStep-th name and its offset) and the trailer
+//   * journal files: names added and deleted since the base was written
+//   * "dirty" marker that exists for as long as the index is open - the index found with
+//     the marker upon loading (e.g., after a crash) gets discarded, to be rebuilt
+// - in memory: the fence table and the delta (ie., journaled names)
+// - when the delta grows beyond nidxMaxDelta it gets frozen and merged with the base into
+//   the next base file - in the background, while updates keep coming into the next journal
+// - iterators seek within the base (via fence table) and merge it with the delta
+
+const (
+	nidxBaseExt = ".nib"
+	nidxJrnlExt = ".nij"
+	nidxRunExt  = ".nir" // sorted run (when building)
+	nidxDirty   = "dirty"
+
+	nidxMagic     = uint64(0x6169736e69647831) // "aisnidx1"
+	nidxTrailer   = 32                         // records end(8) | count(8) | number of fences(8) | magic(8)
+	nidxFenceStep = 1024
+	nidxMaxDelta  = 64 * 1024
+	nidxRunSize   = 256 * 1024 // names
+	nidxMaxName   = 64 * cos.KiB
+)
+
+// journal record ops
+const (
+	nidxOpAdd = byte('+')
+	nidxOpDel = byte('-')
+)
+
+type (
+	NameIdx struct {
+		mi     *Mountpath
+		jfh    *os.File        // active journal
+		delta  map[string]bool // name => added (true) or deleted (false)
+		frozen map[string]bool // being merged into the next base (nil when not compacting)
+		fences []nidxFence     // of the current base
+		dir    string
+		uname  string
+		end    int64  // end of the base records
+		count  int64  // number of names in the base
+		base   uint32 // current base (seq)
+		seq    uint32 // active journal
+		wg     sync.WaitGroup
+		mu     sync.RWMutex
+		ready  bool // built (and not closed)
+		closed bool
+	}
+	nidxFence struct {
+		name string
+		off  int64
+	}
+	nidxDelta struct {
+		name    string
+		present bool
+	}
+	// writes base file
+	nidxWriter struct {
+		fh     *os.File
+		bw     *bufio.Writer
+		fences []nidxFence
+		last   string
+		fqn    string
+		off    int64
+		cnt    int64
+	}
+	// (re)builds the index from scratch (see NewNameIdx); names get sorted in memory
+	// or, when there are too many, written as sorted runs and merged (external sort)
+	NameIdxBuilder struct {
+		ni    *NameIdx
+		names []string
+		runs  []*nidxWriter
+	}
+	nidxHeap []*NameIter
+	// iterates names in ascending order: base merged with a snapshot of the delta
+	NameIter struct {
+		fh      *os.File
+		br      *bufio.Reader
+		fences  []nidxFence
+		delta   []nidxDelta
+		buf     []byte
+		head    string // the current base name (iff hasHead)
+		off     int64  // offset of the next base record
+		end     int64
+		di      int
+		hasHead bool
+	}
+)
+
+// loaded indexes (and known absence thereof)
+var nidxs struct {
+	m  sync.Map // bucket uname => *NameIdx (nil when there's none)
+	mu sync.Mutex
+}
+
+// returns the bucket's name index or nil if there's none (not built yet or discarded);
+// the index that is still being built is not ready to be iterated
+func NameIdxOf(bck *cmn.Bck) *NameIdx {
+	uname := bck.MakeUname("")
+	if v, ok := nidxs.m.Load(uname); ok {
+		if ni := v.(*NameIdx); ni == nil || ni.avail() {
+			return ni
+		}
+	}
+	nidxs.mu.Lock()
+	defer nidxs.mu.Unlock()
+	if v, ok := nidxs.m.Load(uname); ok {
+		ni := v.(*NameIdx)
+		if ni == nil || ni.avail() {
+			return ni
+		}
+		nlog.Warningln(ni.String(), "mountpath is not available - discarding")
+		ni.mu.Lock()
+		ni.close(false)
+		ni.mu.Unlock()
+	}
+	ni := loadNameIdx(bck, uname)
+	nidxs.m.Store(uname, ni)
+	return ni
+}
+
+// NewNameIdx discards the bucket's name index, if exists, and starts building the new one;
+// updates (Add, Del) that happen while building get journaled and, upon Commit, merged
+// with the names added via NameIdxBuilder.Add
+func NewNameIdx(bck *cmn.Bck) (*NameIdxBuilder, error) {
+	uname := bck.MakeUname("")
+	mi, _, err := Hrw(uname)
+	if err != nil {
+		return nil, err
+	}
+	nidxs.mu.Lock()
+	defer nidxs.mu.Unlock()
+	if v, ok := nidxs.m.LoadAndDelete(uname); ok {
+		if ni := v.(*NameIdx); ni != nil {
+			ni.mu.Lock()
+			ni.close(false)
+			ni.mu.Unlock()
+		}
+	}
+	rmNameIdx(bck)
+
+	ni := &NameIdx{mi: mi, dir: mi.MakePathCT(bck, NameIdxType), uname: uname, delta: make(map[string]bool), base: 1}
+	if err := cos.CreateDir(ni.dir); err != nil {
+		return nil, err
+	}
+	if err := ni.open(ni.base + 1); err != nil {
+		rmNameIdx(bck)
+		return nil, err
+	}
+	nidxs.m.Store(uname, ni)
+	return &NameIdxBuilder{ni: ni}, nil
+}
+
+// remove the index (e.g., upon disabling it via bucket props)
+func RemoveNameIdx(bck *cmn.Bck) {
+	nidxs.mu.Lock()
+	evictNameIdx(bck)
+	rmNameIdx(bck)
+	nidxs.mu.Unlock()
+}
+
+// (upon destroying or renaming the bucket)
+func evictNameIdx(bck *cmn.Bck) {
+	v, ok := nidxs.m.LoadAndDelete(bck.MakeUname(""))
+	if !ok {
+		return
+	}
+	if ni := v.(*NameIdx); ni != nil {
+		ni.mu.Lock()
+		ni.close(false)
+		ni.mu.Unlock()
+	}
+}
+
+// close all (upon graceful shutdown)
+func TermNameIdx() {
+	nidxs.m.Range(func(k, v any) bool {
+		if ni := v.(*NameIdx); ni != nil {
+			ni.mu.Lock()
+			ni.close(true)
+			ni.mu.Unlock()
+		}
+		nidxs.m.Delete(k)
+		return true
+	})
+}
+
+// NOTE: used only in tests
+func TestEvictNameIdx(bck *cmn.Bck) { evictNameIdx(bck) }
+func TestWaitNameIdx(ni *NameIdx)   { ni.wg.Wait() }
+
+func rmNameIdx(bck *cmn.Bck) {
+	for _, mi := range GetAvail() {
+		dir := mi.MakePathCT(bck, NameIdxType)
+		if err := RemoveAll(dir); err != nil && !os.IsNotExist(err) {
+			nlog.Errorln("failed to remove name index", dir, "err:", err)
+		}
+	}
+}
+
+func loadNameIdx(bck *cmn.Bck, uname string) *NameIdx {
+	var (
+		found *Mountpath
+		n     int
+	)
+	for _, mi := range GetAvail() {
+		if err := cos.Stat(mi.MakePathCT(bck, NameIdxType)); err == nil {
+			found = mi
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	if n > 1 {
+		nlog.Warningln("found", n, "name indexes of the bucket", bck.Cname(""), "- discarding all")
+		rmNameIdx(bck)
+		return nil
+	}
+	ni := &NameIdx{mi: found, dir: found.MakePathCT(bck, NameIdxType), uname: uname, delta: make(map[string]bool)}
+	if err := ni.load(); err != nil {
+		nlog.Warningln(ni.String(), "failed to load:", err, "- discarding")
+		ni.close(false)
+		rmNameIdx(bck)
+		return nil
+	}
+	return ni
+}
+
+/////////////
+// NameIdx //
+/////////////
+
+func (ni *NameIdx) String() string { return "name-index[" + ni.dir + "]" }
+
+func (ni *NameIdx) fname(seq uint32, ext string) string {
+	return filepath.Join(ni.dir, fmt.Sprintf("%08x", seq)+ext)
+}
+
+func (ni *NameIdx) avail() bool {
+	mi, ok := GetAvail()[ni.mi.Path]
+	return ok && mi == ni.mi
+}
+
+// built and ready to be iterated
+func (ni *NameIdx) Ready() bool {
+	ni.mu.RLock()
+	ready := ni.ready
+	ni.mu.RUnlock()
+	return ready
+}
+
+func (ni *NameIdx) Add(name string) { ni.update(nidxOpAdd, name) }
+func (ni *NameIdx) Del(name string) { ni.update(nidxOpDel, name) }
+
+func (ni *NameIdx) update(op byte, name string) {
+	buf := make([]byte, 1+binary.MaxVarintLen64+len(name))
+	buf[0] = op
+	n := 1 + binary.PutUvarint(buf[1:], uint64(len(name)))
+	n += copy(buf[n:], name)
+
+	ni.mu.Lock()
+	if ni.closed {
+		ni.mu.Unlock()
+		return
+	}
+	if _, err := ni.jfh.Write(buf[:n]); err != nil {
+		ni.mu.Unlock()
+		nlog.Errorln(ni.String(), "failed to journal:", err, "- discarding")
+		ni.discard()
+		return
+	}
+	ni.delta[name] = op == nidxOpAdd
+	if len(ni.delta) < nidxMaxDelta || ni.frozen != nil || !ni.ready {
+		ni.mu.Unlock()
+		return
+	}
+
+	// freeze the delta and start the next journal
+	var (
+		jseq   = ni.seq
+		jfh    = ni.jfh
+		frozen = ni.delta
+	)
+	if err := ni.open(jseq + 1); err != nil {
+		ni.mu.Unlock()
+		nlog.Errorln(ni.String(), "failed to rotate journal:", err, "- discarding")
+		ni.discard()
+		return
+	}
+	cos.Close(jfh)
+	ni.frozen, ni.delta = frozen, make(map[string]bool, len(frozen))
+	ni.wg.Add(1)
+	go ni.compact(jseq, ni.base, ni.fences, ni.end, frozen)
+	ni.mu.Unlock()
+}
+
+// Iter returns iterator positioned at the first name that is greater or equal `start`
+func (ni *NameIdx) Iter(start string) (*NameIter, error) {
+	ni.mu.RLock()
+	defer ni.mu.RUnlock()
+	if !ni.ready {
+		return nil, errors.New(ni.String() + ": not ready")
+	}
+	delta := sortDelta(start, ni.frozen, ni.delta)
+	it, err := newNameIter(ni.fname(ni.base, nidxBaseExt), ni.fences, ni.end, delta)
+	if err != nil {
+		return nil, err
+	}
+	if err := it.Seek(start); err != nil {
+		it.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// (under lock)
+func (ni *NameIdx) open(seq uint32) error {
+	fh, err := os.OpenFile(ni.fname(seq, nidxJrnlExt), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	if ni.jfh == nil {
+		marker, err := cos.CreateFile(filepath.Join(ni.dir, nidxDirty))
+		if err != nil {
+			cos.Close(fh)
+			return err
+		}
+		cos.Close(marker)
+	}
+	ni.jfh, ni.seq = fh, seq
+	return nil
+}
+
+// (under lock)
+func (ni *NameIdx) close(graceful bool) {
+	if ni.closed {
+		return
+	}
+	ready := ni.ready
+	ni.closed, ni.ready = true, false
+	if ni.jfh == nil {
+		return
+	}
+	var err error
+	if graceful {
+		err = ni.jfh.Sync()
+	}
+	cos.Close(ni.jfh)
+	ni.jfh = nil
+	// keep the marker unless there's a base and all updates are persisted
+	if graceful && ready && err == nil {
+		if err := cos.RemoveFile(filepath.Join(ni.dir, nidxDirty)); err != nil {
+			nlog.Errorln(ni.String(), "failed to remove marker:", err)
+		}
+	}
+}
+
+// close and remove the index (e.g., upon write error) - to be rebuilt
+func (ni *NameIdx) discard() {
+	ni.mu.Lock()
+	ni.close(false)
+	ni.mu.Unlock()
+	nidxs.mu.Lock()
+	if nidxs.m.CompareAndSwap(ni.uname, ni, (*NameIdx)(nil)) {
+		if err := RemoveAll(ni.dir); err != nil {
+			nlog.Errorln(ni.String(), "failed to remove:", err)
+		}
+	}
+	nidxs.mu.Unlock()
+}
+
+// merge the frozen delta (journals up to and including `jseq`) and the current base
+// into the next base
+func (ni *NameIdx) compact(jseq, base uint32, fences []nidxFence, end int64, frozen map[string]bool) {
+	defer ni.wg.Done()
+	w, err := ni.merge(jseq, base, fences, end, frozen)
+	if err != nil {
+		nlog.Errorln(ni.String(), "failed to compact:", err, "- discarding")
+		ni.discard()
+		return
+	}
+	ni.mu.Lock()
+	if ni.closed {
+		ni.mu.Unlock()
+		return
+	}
+	ni.base, ni.fences, ni.end, ni.count = jseq, w.fences, w.off, w.cnt
+	ni.frozen = nil
+	ni.mu.Unlock()
+
+	ni.cleanup(jseq)
+}
+
+func (ni *NameIdx) merge(jseq, base uint32, fences []nidxFence, end int64, frozen map[string]bool) (*nidxWriter, error) {
+	it, err := newNameIter(ni.fname(base, nidxBaseExt), fences, end, sortDelta("", frozen))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	w, err := newNidxWriter(ni.fname(jseq, nidxBaseExt))
+	if err != nil {
+		return nil, err
+	}
+	for {
+		name, ok, err := it.Next()
+		if err == nil && ok {
+			err = w.add(name)
+		}
+		if err != nil {
+			w.abort()
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+	}
+	return w, w.finish()
+}
+
+// remove older bases, merged journals, and leftovers (if any)
+func (ni *NameIdx) cleanup(base uint32) {
+	des, err := os.ReadDir(ni.dir)
+	if err != nil {
+		return
+	}
+	for _, de := range des {
+		name := de.Name()
+		if name == nidxDirty {
+			continue
+		}
+		ext := filepath.Ext(name)
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 16, 32)
+		if err == nil && ((ext == nidxBaseExt && uint32(seq) >= base) || (ext == nidxJrnlExt && uint32(seq) > base)) {
+			continue
+		}
+		if err := cos.RemoveFile(filepath.Join(ni.dir, name)); err != nil {
+			nlog.Errorln(ni.String(), "failed to cleanup:", err)
+		}
+	}
+}
+
+//
+// load
+//
+
+func (ni *NameIdx) load() error {
+	if err := cos.Stat(filepath.Join(ni.dir, nidxDirty)); err == nil {
+		return errors.New("was not closed properly")
+	}
+	des, err := os.ReadDir(ni.dir)
+	if err != nil {
+		return err
+	}
+	var jrnls []uint32
+	for _, de := range des {
+		var (
+			name = de.Name()
+			ext  = filepath.Ext(name)
+		)
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 16, 32)
+		if err != nil {
+			continue
+		}
+		switch ext {
+		case nidxBaseExt:
+			ni.base = max(ni.base, uint32(seq))
+		case nidxJrnlExt:
+			jrnls = append(jrnls, uint32(seq))
+		}
+	}
+	if ni.base == 0 {
+		return errors.New("no base")
+	}
+	if ni.fences, ni.end, ni.count, err = readFences(ni.fname(ni.base, nidxBaseExt)); err != nil {
+		return err
+	}
+	sort.Slice(jrnls, func(i, j int) bool { return jrnls[i] < jrnls[j] })
+	seq := ni.base
+	for _, jseq := range jrnls {
+		if jseq <= ni.base {
+			continue
+		}
+		if err := ni.replay(jseq); err != nil {
+			return err
+		}
+		seq = jseq
+	}
+	ni.cleanup(ni.base)
+	if err := ni.open(seq + 1); err != nil {
+		return err
+	}
+	ni.ready = true
+	return nil
+}
+
+// apply journaled updates; a torn record at the end (if any) is ignored
+func (ni *NameIdx) replay(seq uint32) error {
+	b, err := os.ReadFile(ni.fname(seq, nidxJrnlExt))
+	if err != nil {
+		return err
+	}
+	for off := 0; off < len(b); {
+		op := b[off]
+		l, n := binary.Uvarint(b[off+1:])
+		if (op != nidxOpAdd && op != nidxOpDel) || n <= 0 || l == 0 || l > nidxMaxName {
+			break
+		}
+		off += 1 + n
+		if off+int(l) > len(b) {
+			break
+		}
+		ni.delta[string(b[off:off+int(l)])] = op == nidxOpAdd
+		off += int(l)
+	}
+	return nil
+}
+
+func readFences(fqn string) (fences []nidxFence, end, count int64, _ error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer cos.Close(fh)
+	finfo, err := fh.Stat()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	size := finfo.Size()
+	if size < nidxTrailer {
+		return nil, 0, 0, fmt.Errorf("%s: invalid size %d", fqn, size)
+	}
+	var tr [nidxTrailer]byte
+	if _, err := fh.ReadAt(tr[:], size-nidxTrailer); err != nil {
+		return nil, 0, 0, err
+	}
+	end = int64(binary.LittleEndian.Uint64(tr[:]))
+	count = int64(binary.LittleEndian.Uint64(tr[8:]))
+	nf := int64(binary.LittleEndian.Uint64(tr[16:]))
+	if binary.LittleEndian.Uint64(tr[24:]) != nidxMagic || end < 0 || end > size-nidxTrailer ||
+		count < 0 || nf != (count+nidxFenceStep-1)/nidxFenceStep {
+		return nil, 0, 0, fmt.Errorf("%s: invalid trailer", fqn)
+	}
+	var (
+		b8 [8]byte
+		br = bufio.NewReader(io.NewSectionReader(fh, end, size-nidxTrailer-end))
+	)
+	fences = make([]nidxFence, 0, nf)
+	for range nf {
+		l, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if l == 0 || l > nidxMaxName {
+			return nil, 0, 0, fmt.Errorf("%s: invalid fence", fqn)
+		}
+		name := make([]byte, l)
+		if _, err := io.ReadFull(br, name); err != nil {
+			return nil, 0, 0, err
+		}
+		if _, err := io.ReadFull(br, b8[:]); err != nil {
+			return nil, 0, 0, err
+		}
+		fences = append(fences, nidxFence{name: string(name), off: int64(binary.LittleEndian.Uint64(b8[:]))})
+	}
+	return fences, end, count, nil
+}
+
+// merge delta maps (the latter wins) and sort the names that are greater or equal `start`
+func sortDelta(start string, maps ...map[string]bool) []nidxDelta {
+	merged := make(map[string]bool)
+	for _, m := range maps {
+		for name, present := range m {
+			if name >= start {
+				merged[name] = present
+			}
+		}
+	}
+	delta := make([]nidxDelta, 0, len(merged))
+	for name, present := range merged {
+		delta = append(delta, nidxDelta{name: name, present: present})
+	}
+	sort.Slice(delta, func(i, j int) bool { return delta[i].name < delta[j].name })
+	return delta
+}
+
+////////////////////
+// NameIdxBuilder //
+////////////////////
+
+// names can be added in any order (and may repeat)
+func (b *NameIdxBuilder) Add(name string) error {
+	b.names = append(b.names, name)
+	if len(b.names) < nidxRunSize {
+		return nil
+	}
+	return b.spill()
+}
+
+// Commit installs the built base - from this point on the index is ready to be iterated
+func (b *NameIdxBuilder) Commit() error {
+	ni := b.ni
+	w, err := b.build()
+	if err != nil {
+		ni.discard()
+		return err
+	}
+	ni.mu.Lock()
+	defer ni.mu.Unlock()
+	if ni.closed {
+		return errors.New(ni.String() + ": closed")
+	}
+	ni.fences, ni.end, ni.count = w.fences, w.off, w.cnt
+	ni.ready = true
+	return nil
+}
+
+func (b *NameIdxBuilder) Abort() { b.ni.discard() }
+
+func (b *NameIdxBuilder) build() (w *nidxWriter, err error) {
+	if len(b.runs) > 0 && len(b.names) > 0 {
+		if err = b.spill(); err != nil {
+			return nil, err
+		}
+	}
+	if w, err = newNidxWriter(b.ni.fname(b.ni.base, nidxBaseExt)); err != nil {
+		return nil, err
+	}
+	if len(b.runs) == 0 {
+		err = b.addSorted(w)
+	} else {
+		err = b.merge(w)
+		for _, run := range b.runs {
+			if errRm := cos.RemoveFile(run.fqn); errRm != nil {
+				nlog.Errorln("nested err:", errRm)
+			}
+		}
+	}
+	if err != nil {
+		w.abort()
+		return nil, err
+	}
+	return w, w.finish()
+}
+
+// sort in memory and add to a given writer
+func (b *NameIdxBuilder) addSorted(w *nidxWriter) (err error) {
+	sort.Strings(b.names)
+	for _, name := range b.names {
+		if err = w.add(name); err != nil {
+			break
+		}
+	}
+	b.names = b.names[:0]
+	return err
+}
+
+// write sorted run
+func (b *NameIdxBuilder) spill() error {
+	w, err := newNidxWriter(filepath.Join(b.ni.dir, fmt.Sprintf("%08x", len(b.runs))+nidxRunExt))
+	if err != nil {
+		return err
+	}
+	if err := b.addSorted(w); err != nil {
+		w.abort()
+		return err
+	}
+	if err := w.finish(); err != nil {
+		return err
+	}
+	b.runs = append(b.runs, w)
+	return nil
+}
+
+// k-way merge sorted runs
+func (b *NameIdxBuilder) merge(w *nidxWriter) error {
+	var (
+		its = make([]*NameIter, 0, len(b.runs))
+		h   = make(nidxHeap, 0, len(b.runs))
+	)
+	defer func() {
+		for _, it := range its {
+			it.Close()
+		}
+	}()
+	for _, run := range b.runs {
+		it, err := newNameIter(run.fqn, nil, run.off, nil)
+		if err != nil {
+			return err
+		}
+		its = append(its, it)
+		if it.hasHead {
+			h = append(h, it)
+		}
+	}
+	heap.Init(&h)
+	for len(h) > 0 {
+		it := h[0]
+		if err := w.add(it.head); err != nil {
+			return err
+		}
+		if err := it.advance(); err != nil {
+			return err
+		}
+		if it.hasHead {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return nil
+}
+
+func (h nidxHeap) Len() int           { return len(h) }
+func (h nidxHeap) Less(i, j int) bool { return h[i].head < h[j].head }
+func (h nidxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nidxHeap) Push(x any)        { *h = append(*h, x.(*NameIter)) }
+
+func (h *nidxHeap) Pop() any {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
+
+////////////////
+// nidxWriter //
+////////////////
+
+func newNidxWriter(fqn string) (*nidxWriter, error) {
+	fh, err := cos.CreateFile(fqn + ".tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &nidxWriter{fh: fh, bw: bufio.NewWriterSize(fh, 64*cos.KiB), fqn: fqn}, nil
+}
+
+func (w *nidxWriter) add(name string) error {
+	if w.cnt > 0 && name <= w.last {
+		if name == w.last {
+			return nil
+		}
+		return fmt.Errorf("name index: %q is out of order (follows %q)", name, w.last)
+	}
+	if name == "" || len(name) > nidxMaxName {
+		return fmt.Errorf("name index: invalid name length %d", len(name))
+	}
+	if w.cnt%nidxFenceStep == 0 {
+		w.fences = append(w.fences, nidxFence{name: name, off: w.off})
+	}
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], uint64(len(name)))
+	w.bw.Write(b[:n])
+	w.bw.WriteString(name)
+	w.off += int64(n + len(name))
+	w.cnt++
+	w.last = name
+	return nil
+}
+
+// write fence table and trailer, and rename
+func (w *nidxWriter) finish() error {
+	var b [binary.MaxVarintLen64]byte
+	for _, f := range w.fences {
+		n := binary.PutUvarint(b[:], uint64(len(f.name)))
+		w.bw.Write(b[:n])
+		w.bw.WriteString(f.name)
+		binary.LittleEndian.PutUint64(b[:], uint64(f.off))
+		w.bw.Write(b[:8])
+	}
+	var tr [nidxTrailer]byte
+	binary.LittleEndian.PutUint64(tr[:], uint64(w.off))
+	binary.LittleEndian.PutUint64(tr[8:], uint64(w.cnt))
+	binary.LittleEndian.PutUint64(tr[16:], uint64(len(w.fences)))
+	binary.LittleEndian.PutUint64(tr[24:], nidxMagic)
+	w.bw.Write(tr[:])
+
+	err := w.bw.Flush()
+	if err == nil {
+		err = cos.FlushClose(w.fh)
+	} else {
+		cos.Close(w.fh)
+	}
+	if err == nil {
+		err = cos.Rename(w.fqn+".tmp", w.fqn)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(w.fqn + ".tmp"); errRm != nil && !os.IsNotExist(errRm) {
+			nlog.Errorln("nested err:", errRm)
+		}
+	}
+	return err
+}
+
+func (w *nidxWriter) abort() {
+	cos.Close(w.fh)
+	if err := cos.RemoveFile(w.fqn + ".tmp"); err != nil && !os.IsNotExist(err) {
+		nlog.Errorln("nested err:", err)
+	}
+}
+
+//////////////
+// NameIter //
+//////////////
+
+func newNameIter(fqn string, fences []nidxFence, end int64, delta []nidxDelta) (*NameIter, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	it := &NameIter{fh: fh, br: bufio.NewReaderSize(fh, 64*cos.KiB), fences: fences, end: end, delta: delta}
+	if err := it.advance(); err != nil {
+		cos.Close(fh)
+		return nil, err
+	}
+	return it, nil
+}
+
+// read the next base record
+func (it *NameIter) advance() error {
+	it.hasHead = false
+	if it.off >= it.end {
+		return nil
+	}
+	l, err := binary.ReadUvarint(it.br)
+	if err != nil {
+		return err
+	}
+	if l == 0 || l > nidxMaxName {
+		return fmt.Errorf("%s: invalid record at offset %d", it.fh.Name(), it.off)
+	}
+	if cap(it.buf) < int(l) {
+		it.buf = make([]byte, l)
+	}
+	b := it.buf[:l]
+	if _, err := io.ReadFull(it.br, b); err != nil {
+		return err
+	}
+	it.head, it.hasHead = string(b), true
+	it.off += int64(uvarintLen(l)) + int64(l)
+	return nil
+}
+
+// Seek moves the iterator forward to the first name that is greater or equal `key`
+func (it *NameIter) Seek(key string) error {
+	it.di += sort.Search(len(it.delta)-it.di, func(i int) bool { return it.delta[it.di+i].name >= key })
+	if !it.hasHead || it.head >= key {
+		return nil
+	}
+	// jump to the closest preceding fence, if ahead
+	i := sort.Search(len(it.fences), func(i int) bool { return it.fences[i].name > key }) - 1
+	if i >= 0 && it.fences[i].off >= it.off {
+		if _, err := it.fh.Seek(it.fences[i].off, io.SeekStart); err != nil {
+			return err
+		}
+		it.br.Reset(it.fh)
+		it.off = it.fences[i].off
+		if err := it.advance(); err != nil {
+			return err
+		}
+	}
+	for it.hasHead && it.head < key {
+		if err := it.advance(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Next returns the next name, if any
+func (it *NameIter) Next() (string, bool, error) {
+	for {
+		var d *nidxDelta
+		if it.di < len(it.delta) {
+			d = &it.delta[it.di]
+		}
+		switch {
+		case d == nil && !it.hasHead:
+			return "", false, nil
+		case d != nil && (!it.hasHead || d.name <= it.head):
+			if it.hasHead && d.name == it.head {
+				if err := it.advance(); err != nil {
+					return "", false, err
+				}
+			}
+			it.di++
+			if d.present {
+				return d.name, true, nil
+			}
+		default:
+			name := it.head
+			if err := it.advance(); err != nil {
+				return "", false, err
+			}
+			return name, true, nil
+		}
+	}
+}
+
+func (it *NameIter) Close() { cos.Close(it.fh) }
+
+func uvarintLen(x uint64) (n int) {
+	for n = 1; x >= 0x80; n++ {
+		x >>= 7
+	}
+	return n
+}
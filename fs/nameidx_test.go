@@ -0,0 +1,172 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestNameIdx(t *testing.T) {
+	const num = 5000
+	var (
+		bck   = cmn.Bck{Name: "indexed", Provider: apc.AIS}
+		names = make(map[string]struct{}, num)
+	)
+	initNameIdxMpath(t)
+	tassert.Fatalf(t, fs.NameIdxOf(&bck) == nil, "expected no name index")
+
+	b, err := fs.NewNameIdx(&bck)
+	tassert.CheckFatal(t, err)
+	ni := fs.NameIdxOf(&bck)
+	tassert.Fatalf(t, ni != nil && !ni.Ready(), "expected name index that is being built")
+	for i := 0; i < num; i += 2 {
+		name := fmt.Sprintf("dir-%d/obj-%05d", i%3, i)
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		tassert.CheckFatal(t, b.Add(name))
+	}
+	// updates that happen while building
+	ni.Add("dir-0/obj-00001")
+	ni.Del("dir-1/obj-00004")
+	names["dir-0/obj-00001"] = struct{}{}
+	delete(names, "dir-1/obj-00004")
+	tassert.CheckFatal(t, b.Commit())
+	tassert.Fatalf(t, ni.Ready(), "expected name index to be ready")
+	checkNameIdx(t, ni, names)
+
+	for i := 1; i < num; i += 4 {
+		name := fmt.Sprintf("dir-%d/obj-%05d", i%3, i)
+		ni.Add(name)
+		names[name] = struct{}{}
+	}
+	for i := 0; i < num; i += 10 {
+		name := fmt.Sprintf("dir-%d/obj-%05d", i%3, i)
+		ni.Del(name)
+		delete(names, name)
+	}
+	checkNameIdx(t, ni, names)
+
+	// graceful restart
+	fs.TermNameIdx()
+	ni = fs.NameIdxOf(&bck)
+	tassert.Fatalf(t, ni != nil && ni.Ready(), "failed to reload name index")
+	checkNameIdx(t, ni, names)
+
+	// compaction
+	for i := range 70000 {
+		name := fmt.Sprintf("new/%06d", i)
+		ni.Add(name)
+		names[name] = struct{}{}
+	}
+	fs.TestWaitNameIdx(ni)
+	checkNameIdx(t, ni, names)
+	fs.TermNameIdx()
+	ni = fs.NameIdxOf(&bck)
+	tassert.Fatalf(t, ni != nil && ni.Ready(), "failed to reload name index")
+	checkNameIdx(t, ni, names)
+
+	// not closed properly - discarded
+	fs.TestEvictNameIdx(&bck)
+	tassert.Fatalf(t, fs.NameIdxOf(&bck) == nil, "expected name index to be discarded")
+}
+
+// external sort (sorted runs) with duplicates
+func TestNameIdxBuild(t *testing.T) {
+	const num = 600_000
+	var (
+		bck   = cmn.Bck{Name: "indexed-large", Provider: apc.AIS}
+		names = make(map[string]struct{}, num)
+	)
+	initNameIdxMpath(t)
+	b, err := fs.NewNameIdx(&bck)
+	tassert.CheckFatal(t, err)
+	for _, i := range rand.Perm(num) {
+		name := fmt.Sprintf("%x/%d", i%256, i)
+		names[name] = struct{}{}
+		tassert.CheckFatal(t, b.Add(name))
+		if i%7 == 0 {
+			tassert.CheckFatal(t, b.Add(name)) // (e.g., mirror copy)
+		}
+	}
+	tassert.CheckFatal(t, b.Commit())
+	ni := fs.NameIdxOf(&bck)
+	tassert.Fatalf(t, ni != nil && ni.Ready(), "expected name index to be ready")
+	checkNameIdx(t, ni, names)
+	fs.TermNameIdx()
+}
+
+func initNameIdxMpath(t *testing.T) {
+	fs.TestNew(mock.NewIOS())
+	fs.TestDisableValidation()
+	fs.CSM.Reg(fs.NameIdxType, &fs.NameIdxContentResolver{}, true)
+	for range 3 {
+		_, err := fs.Add(t.TempDir(), "daeID")
+		tassert.CheckFatal(t, err)
+	}
+}
+
+func checkNameIdx(t *testing.T, ni *fs.NameIdx, names map[string]struct{}) {
+	expected := sortedNames(names)
+	tassert.Fatalf(t, reflect.DeepEqual(iterNameIdx(t, ni, ""), expected), "name index: unexpected names")
+
+	// seek
+	for _, start := range []string{"dir-1/", "dir-2/obj-03", "new/", "zzz"} {
+		var exp []string
+		for _, name := range expected {
+			if name >= start {
+				exp = append(exp, name)
+			}
+		}
+		got := iterNameIdx(t, ni, start)
+		tassert.Errorf(t, reflect.DeepEqual(got, exp), "start %q: expected %d names, got %d", start, len(exp), len(got))
+	}
+	// seek forward while iterating
+	it, err := ni.Iter("")
+	tassert.CheckFatal(t, err)
+	defer it.Close()
+	next := 0
+	for _, key := range []string{"dir-0/obj-0100", "dir-1/obj-00007", "dir-2/", "new/05"} {
+		tassert.CheckFatal(t, it.Seek(key))
+		name, ok, err := it.Next()
+		tassert.CheckFatal(t, err)
+		i := max(sort.SearchStrings(expected, key), next)
+		tassert.Errorf(t, ok == (i < len(expected)) && (!ok || name == expected[i]), "seek %q: got %q", key, name)
+		next = i + 1
+	}
+}
+
+func iterNameIdx(t *testing.T, ni *fs.NameIdx, start string) (names []string) {
+	it, err := ni.Iter(start)
+	tassert.CheckFatal(t, err)
+	defer it.Close()
+	for {
+		name, ok, err := it.Next()
+		tassert.CheckFatal(t, err)
+		if !ok {
+			return names
+		}
+		names = append(names, name)
+	}
+}
+
+func sortedNames(names map[string]struct{}) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
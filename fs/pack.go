@@ -0,0 +1,708 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Packing small objects (see cmn.PackConf and core/lpack.go)
+// - objects get appended to per-mountpath, per-bucket pack files (content type PackType)
+//   as self-describing records: header | name | metadata | content
+// - updated metadata is appended as a separate (metadata-only) record, deletion - as a tombstone;
+//   the most recent record wins
+// - upon reaching configured size the (active) pack file gets sealed - that is, accompanied by its
+//   index (all record headers and names) - and the next one gets started
+// - loading (ie., the first access after restart) reads the indexes and scans the active file, if any
+// - compaction rewrites live records of the pack files with too much deleted content and then
+//   removes those files (see space/cleanup.go and space/lru.go)
+
+const (
+	packExt    = ".pack"
+	packIdxExt = ".pidx"
+
+	packMagic    = uint32(0x61697370) // "aisp"
+	packIdxMagic = uint64(0x6169737069647831)
+	packHdrLen   = 32
+	packIdxHdr   = 25 // per-record: kind(1) | name-len(4) | md-len(4) | data-len(8) | offset(8)
+
+	packMaxName = 64 * cos.KiB
+	packMaxMD   = cos.MiB
+	packMaxData = cos.GiB
+)
+
+// record kinds
+const (
+	packRecFull = byte(iota + 1)
+	packRecMD
+	packRecDel
+)
+
+type (
+	Pack struct {
+		mi     *Mountpath
+		index  map[string]*packEntry
+		files  map[uint32]*packFile
+		active *packFile // being appended (nil when not yet started)
+		dir    string
+		seq    uint32 // the most recent pack file
+		mu     sync.RWMutex
+	}
+	packFile struct {
+		fh   *os.File
+		recs []packRec // active only (to write its index when sealing)
+		size int64
+		live int64
+		seq  uint32
+	}
+	packRec struct {
+		name  string
+		off   int64
+		dlen  int64
+		mdlen uint32
+		kind  byte
+	}
+	packLoc struct {
+		off  int64
+		rlen int64
+		seq  uint32
+	}
+	packEntry struct {
+		data  packLoc // record that contains the content
+		md    packLoc // the most recent metadata (same as `data` unless updated)
+		size  int64
+		mdlen uint32
+	}
+	packDirent struct{}
+)
+
+// interface guard
+var _ DirEntry = packDirent{}
+
+func (packDirent) IsDir() bool { return false }
+
+func (r *packRec) rlen() int64 { return packHdrLen + int64(len(r.name)) + int64(r.mdlen) + r.dlen }
+
+////////////////
+// Mountpath //
+////////////////
+
+// returns nil when there's nothing packed (and `create` is false)
+func (mi *Mountpath) Pack(bck *cmn.Bck, create bool) (*Pack, error) {
+	uname := bck.MakeUname("")
+	if v, ok := mi.packs.Load(uname); ok {
+		pk := v.(*Pack)
+		if pk != nil || !create {
+			return pk, nil
+		}
+	}
+	mi.packMu.Lock()
+	defer mi.packMu.Unlock()
+	if v, ok := mi.packs.Load(uname); ok {
+		if pk := v.(*Pack); pk != nil || !create {
+			return pk, nil
+		}
+	}
+	dir := mi.MakePathCT(bck, PackType)
+	if !create {
+		if err := cos.Stat(dir); err != nil {
+			if !os.IsNotExist(err) {
+				return nil, err
+			}
+			mi.packs.Store(uname, (*Pack)(nil))
+			return nil, nil
+		}
+	} else if err := cos.CreateDir(dir); err != nil {
+		return nil, err
+	}
+	pk := &Pack{mi: mi, dir: dir, index: make(map[string]*packEntry), files: make(map[uint32]*packFile)}
+	if err := pk.load(); err != nil {
+		pk.close()
+		return nil, err
+	}
+	if len(pk.files) == 0 && !create {
+		mi.packs.Store(uname, (*Pack)(nil))
+		return nil, nil
+	}
+	mi.packs.Store(uname, pk)
+	return pk, nil
+}
+
+// given object's FQN, returns its mountpath's pack (see above) and the object name
+func PackOf(fqn string, create bool) (*Pack, string, error) {
+	parsed, err := ParseFQN(fqn)
+	if err != nil {
+		return nil, "", err
+	}
+	pk, err := parsed.Mountpath.Pack(&parsed.Bck, create)
+	return pk, parsed.ObjName, err
+}
+
+// (upon destroying or renaming the bucket)
+func (mi *Mountpath) evictPack(bck *cmn.Bck) {
+	v, ok := mi.packs.LoadAndDelete(bck.MakeUname(""))
+	if !ok {
+		return
+	}
+	if pk := v.(*Pack); pk != nil {
+		pk.mu.Lock()
+		pk.close()
+		pk.mu.Unlock()
+	}
+}
+
+// NOTE: used only in tests
+func TestEvictPack(mi *Mountpath, bck *cmn.Bck) { mi.evictPack(bck) }
+
+//////////
+// Pack //
+//////////
+
+func (pk *Pack) String() string { return "pack[" + pk.dir + "]" }
+
+func (pk *Pack) fname(seq uint32, ext string) string {
+	return filepath.Join(pk.dir, fmt.Sprintf("%08x", seq)+ext)
+}
+
+func (pk *Pack) Has(name string) bool {
+	pk.mu.RLock()
+	_, ok := pk.index[name]
+	pk.mu.RUnlock()
+	return ok
+}
+
+func (pk *Pack) Len() int {
+	pk.mu.RLock()
+	l := len(pk.index)
+	pk.mu.RUnlock()
+	return l
+}
+
+// names of all packed objects that have a given prefix
+func (pk *Pack) Names(prefix string, sorted bool) []string {
+	pk.mu.RLock()
+	names := make([]string, 0, len(pk.index))
+	for name := range pk.index {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	pk.mu.RUnlock()
+	if sorted {
+		sort.Strings(names)
+	}
+	return names
+}
+
+// returns metadata, atime, and content size
+func (pk *Pack) MD(name string) (md []byte, atime, size int64, err error) {
+	pk.mu.RLock()
+	defer pk.mu.RUnlock()
+	e, ok := pk.index[name]
+	if !ok {
+		return nil, 0, 0, pk.errNotExist(name)
+	}
+	n := packHdrLen + len(name) + int(e.mdlen)
+	buf := make([]byte, n)
+	if _, err = pk.files[e.md.seq].fh.ReadAt(buf, e.md.off); err != nil {
+		return nil, 0, 0, pk.errRead(name, err)
+	}
+	atime = int64(binary.LittleEndian.Uint64(buf[24:]))
+	return buf[packHdrLen+len(name):], atime, e.size, nil
+}
+
+// returns (packed) content
+func (pk *Pack) Read(name string) ([]byte, error) {
+	pk.mu.RLock()
+	defer pk.mu.RUnlock()
+	e, ok := pk.index[name]
+	if !ok {
+		return nil, pk.errNotExist(name)
+	}
+	data := make([]byte, e.size)
+	off := e.data.rlen - e.size
+	if _, err := pk.files[e.data.seq].fh.ReadAt(data, e.data.off+off); err != nil {
+		return nil, pk.errRead(name, err)
+	}
+	return data, nil
+}
+
+// add new or overwrite existing
+func (pk *Pack) Put(name string, md []byte, atime int64, data []byte, maxSize int64) error {
+	pk.mu.Lock()
+	err := pk.append(packRecFull, name, md, atime, data, maxSize)
+	pk.mu.Unlock()
+	return err
+}
+
+func (pk *Pack) SetMD(name string, md []byte, atime int64) error {
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	if _, ok := pk.index[name]; !ok {
+		return pk.errNotExist(name)
+	}
+	return pk.append(packRecMD, name, md, atime, nil, 0)
+}
+
+func (pk *Pack) Delete(name string) (bool, error) {
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	if _, ok := pk.index[name]; !ok {
+		return false, nil
+	}
+	return true, pk.append(packRecDel, name, nil, 0, nil, 0)
+}
+
+// garbage (deleted and overwritten content) vs total
+func (pk *Pack) Usage() (live, size int64) {
+	pk.mu.RLock()
+	for _, pf := range pk.files {
+		live += pf.live
+		size += pf.size
+	}
+	pk.mu.RUnlock()
+	return
+}
+
+func (pk *Pack) errNotExist(name string) error {
+	return &os.PathError{Op: "packed", Path: filepath.Join(pk.dir, name), Err: os.ErrNotExist}
+}
+
+func (pk *Pack) errRead(name string, err error) error {
+	return fmt.Errorf("%s: failed to read %q: %w", pk, name, err)
+}
+
+// (under lock)
+func (pk *Pack) append(kind byte, name string, md []byte, atime int64, data []byte, maxSize int64) error {
+	rec := packRec{name: name, kind: kind, mdlen: uint32(len(md)), dlen: int64(len(data))}
+	if pk.active != nil && maxSize > 0 && pk.active.size > 0 && pk.active.size+rec.rlen() > maxSize {
+		if err := pk.seal(); err != nil {
+			return err
+		}
+	}
+	if pk.active == nil {
+		if err := pk.startNext(); err != nil {
+			return err
+		}
+	}
+	pf := pk.active
+	rec.off = pf.size
+
+	buf := make([]byte, rec.rlen())
+	binary.LittleEndian.PutUint32(buf, packMagic)
+	buf[4] = kind
+	binary.LittleEndian.PutUint32(buf[8:], uint32(len(name)))
+	binary.LittleEndian.PutUint32(buf[12:], rec.mdlen)
+	binary.LittleEndian.PutUint64(buf[16:], uint64(rec.dlen))
+	binary.LittleEndian.PutUint64(buf[24:], uint64(atime))
+	n := copy(buf[packHdrLen:], name)
+	n += copy(buf[packHdrLen+n:], md)
+	copy(buf[packHdrLen+n:], data)
+
+	if _, err := pf.fh.WriteAt(buf, rec.off); err != nil {
+		// (may leave a torn record at the end - to be truncated upon loading)
+		return fmt.Errorf("%s: failed to append %q: %w", pk, name, err)
+	}
+	pf.recs = append(pf.recs, rec)
+	pk.apply(pf, &rec)
+	return nil
+}
+
+// update index and accounting; same logic applies when appending and loading
+func (pk *Pack) apply(pf *packFile, rec *packRec) {
+	var (
+		rlen = rec.rlen()
+		loc  = packLoc{off: rec.off, rlen: rlen, seq: pf.seq}
+		e    = pk.index[rec.name]
+	)
+	pf.size = max(pf.size, rec.off+rlen)
+	switch rec.kind {
+	case packRecFull:
+		if e != nil {
+			pk.release(e)
+		}
+		pk.index[rec.name] = &packEntry{data: loc, md: loc, size: rec.dlen, mdlen: rec.mdlen}
+		pf.live += rlen
+	case packRecMD:
+		if e == nil {
+			return // (garbage)
+		}
+		if e.md != e.data {
+			pk.release(&packEntry{data: e.md, md: e.md})
+		}
+		e.md, e.mdlen = loc, rec.mdlen
+		pf.live += rlen
+	case packRecDel:
+		if e != nil {
+			pk.release(e)
+			delete(pk.index, rec.name)
+		}
+	}
+}
+
+func (pk *Pack) release(e *packEntry) {
+	if pf, ok := pk.files[e.data.seq]; ok {
+		pf.live -= e.data.rlen
+	}
+	if e.md != e.data {
+		if pf, ok := pk.files[e.md.seq]; ok {
+			pf.live -= e.md.rlen
+		}
+	}
+}
+
+func (pk *Pack) startNext() error {
+	seq := pk.seq + 1
+	fh, err := os.OpenFile(pk.fname(seq, packExt), os.O_CREATE|os.O_RDWR|os.O_TRUNC, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	pk.seq = seq
+	pk.active = &packFile{fh: fh, seq: seq}
+	pk.files[seq] = pk.active
+	return nil
+}
+
+// write the index of the active file (that from now on becomes read-only)
+func (pk *Pack) seal() error {
+	pf := pk.active
+	if err := pf.fh.Sync(); err != nil {
+		return err
+	}
+	if err := pk.writeIdx(pf); err != nil {
+		return err
+	}
+	pf.recs = nil
+	pk.active = nil
+	return nil
+}
+
+func (pk *Pack) writeIdx(pf *packFile) error {
+	var (
+		b8      [8]byte
+		h       = xxhash.New64()
+		idxFQN  = pk.fname(pf.seq, packIdxExt)
+		workFQN = idxFQN + ".tmp"
+	)
+	fh, err := cos.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(io.MultiWriter(fh, h))
+	binary.LittleEndian.PutUint64(b8[:], packIdxMagic)
+	w.Write(b8[:])
+	for i := range pf.recs {
+		rec := &pf.recs[i]
+		var hdr [packIdxHdr]byte
+		hdr[0] = rec.kind
+		binary.LittleEndian.PutUint32(hdr[1:], uint32(len(rec.name)))
+		binary.LittleEndian.PutUint32(hdr[5:], rec.mdlen)
+		binary.LittleEndian.PutUint64(hdr[9:], uint64(rec.dlen))
+		binary.LittleEndian.PutUint64(hdr[17:], uint64(rec.off))
+		w.Write(hdr[:])
+		w.WriteString(rec.name)
+	}
+	if err = w.Flush(); err == nil {
+		binary.LittleEndian.PutUint64(b8[:], h.Sum64())
+		_, err = fh.Write(b8[:])
+	}
+	if err == nil {
+		err = cos.FlushClose(fh)
+	} else {
+		cos.Close(fh)
+	}
+	if err == nil {
+		err = cos.Rename(workFQN, idxFQN)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil && !os.IsNotExist(errRm) {
+			nlog.Errorln("nested err:", errRm)
+		}
+	}
+	return err
+}
+
+func (pk *Pack) close() {
+	for _, pf := range pk.files {
+		cos.Close(pf.fh)
+	}
+	pk.files = map[uint32]*packFile{}
+	pk.index = map[string]*packEntry{}
+	pk.active = nil
+}
+
+//
+// load
+//
+
+func (pk *Pack) load() error {
+	des, err := os.ReadDir(pk.dir)
+	if err != nil {
+		return err
+	}
+	seqs := make([]uint32, 0, len(des))
+	for _, de := range des {
+		name := de.Name()
+		if !strings.HasSuffix(name, packExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, packExt), 16, 32)
+		if err != nil {
+			nlog.Warningln(pk.String(), "unexpected file", name)
+			continue
+		}
+		seqs = append(seqs, uint32(seq))
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	for i, seq := range seqs {
+		last := i == len(seqs)-1
+		fh, err := os.OpenFile(pk.fname(seq, packExt), os.O_RDWR, cos.PermRWR)
+		if err != nil {
+			return err
+		}
+		pf := &packFile{fh: fh, seq: seq}
+		pk.files[seq] = pf
+		pk.seq = seq
+
+		recs, errIdx := pk.readIdx(seq)
+		if errIdx == nil {
+			for i := range recs {
+				pk.apply(pf, &recs[i])
+			}
+			continue
+		}
+		if !os.IsNotExist(errIdx) {
+			nlog.Warningln(pk.String(), "failed to read index:", errIdx, "- scanning", seq)
+		}
+		if pf.recs, err = pk.scan(pf, last); err != nil {
+			return err
+		}
+		if last {
+			pk.active = pf // keep appending
+			continue
+		}
+		// (e.g., crashed before having sealed)
+		if err := pk.writeIdx(pf); err != nil {
+			nlog.Errorln(pk.String(), "failed to write index:", err)
+		}
+		pf.recs = nil
+	}
+	return nil
+}
+
+func (pk *Pack) readIdx(seq uint32) ([]packRec, error) {
+	b, err := os.ReadFile(pk.fname(seq, packIdxExt))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 16 || binary.LittleEndian.Uint64(b) != packIdxMagic {
+		return nil, errors.New("invalid index")
+	}
+	l := len(b) - 8
+	if xxhash.Checksum64(b[:l]) != binary.LittleEndian.Uint64(b[l:]) {
+		return nil, errors.New("index checksum mismatch")
+	}
+	var recs []packRec
+	for off := 8; off < l; {
+		if off+packIdxHdr > l {
+			return nil, errors.New("truncated index")
+		}
+		hdr := b[off : off+packIdxHdr]
+		rec := packRec{
+			kind:  hdr[0],
+			mdlen: binary.LittleEndian.Uint32(hdr[5:]),
+			dlen:  int64(binary.LittleEndian.Uint64(hdr[9:])),
+			off:   int64(binary.LittleEndian.Uint64(hdr[17:])),
+		}
+		nlen := int(binary.LittleEndian.Uint32(hdr[1:]))
+		off += packIdxHdr
+		if off+nlen > l {
+			return nil, errors.New("truncated index")
+		}
+		rec.name = string(b[off : off+nlen])
+		off += nlen
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+// read all record headers and names; truncate the torn tail of the active file
+func (pk *Pack) scan(pf *packFile, last bool) (recs []packRec, _ error) {
+	finfo, err := pf.fh.Stat()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		hdr   [packHdrLen]byte
+		fsize = finfo.Size()
+		r     = bufio.NewReaderSize(io.NewSectionReader(pf.fh, 0, fsize), 64*cos.KiB)
+		off   int64
+	)
+	for off < fsize {
+		if _, err = io.ReadFull(r, hdr[:]); err != nil {
+			break
+		}
+		rec := packRec{
+			kind:  hdr[4],
+			mdlen: binary.LittleEndian.Uint32(hdr[12:]),
+			dlen:  int64(binary.LittleEndian.Uint64(hdr[16:])),
+			off:   off,
+		}
+		nlen := binary.LittleEndian.Uint32(hdr[8:])
+		if binary.LittleEndian.Uint32(hdr[:]) != packMagic || rec.kind < packRecFull || rec.kind > packRecDel ||
+			nlen == 0 || nlen > packMaxName || rec.mdlen > packMaxMD || rec.dlen < 0 || rec.dlen > packMaxData {
+			err = fmt.Errorf("invalid record header at offset %d", off)
+			break
+		}
+		name := make([]byte, nlen)
+		if _, err = io.ReadFull(r, name); err != nil {
+			break
+		}
+		rec.name = string(name)
+		if off+rec.rlen() > fsize {
+			err = io.ErrUnexpectedEOF
+			break
+		}
+		if _, err = r.Discard(int(rec.mdlen) + int(rec.dlen)); err != nil {
+			break
+		}
+		recs = append(recs, rec)
+		pk.apply(pf, &recs[len(recs)-1])
+		off += rec.rlen()
+	}
+	pf.size = off
+	if off == fsize {
+		return recs, nil
+	}
+	nlog.Warningf("%s: pack file %08x: %v at offset %d (size %d)", pk, pf.seq, err, off, fsize)
+	if last {
+		if err := pf.fh.Truncate(off); err != nil {
+			return nil, err
+		}
+	}
+	return recs, nil
+}
+
+//
+// compaction
+//
+
+// rewrite live records of the pack files that have at least `pct` percent of garbage,
+// and remove those files; returns the number of reclaimed bytes
+func (pk *Pack) Compact(pct, maxSize int64) (reclaimed int64, err error) {
+	debug.Assert(pct > 0 && pct <= 100)
+	pk.mu.RLock()
+	seqs := make([]uint32, 0, len(pk.files))
+	for seq, pf := range pk.files {
+		if pf.size > 0 && (pf.size-pf.live)*100 >= pf.size*pct {
+			seqs = append(seqs, seq)
+		}
+	}
+	pk.mu.RUnlock()
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	for _, seq := range seqs {
+		var n int64
+		n, err = pk.compact(seq, maxSize)
+		reclaimed += n
+		if err != nil {
+			break
+		}
+	}
+	return
+}
+
+func (pk *Pack) compact(seq uint32, maxSize int64) (int64, error) {
+	pk.mu.Lock()
+	pf, ok := pk.files[seq]
+	if !ok {
+		pk.mu.Unlock()
+		return 0, nil
+	}
+	if pf == pk.active {
+		if err := pk.seal(); err != nil {
+			pk.mu.Unlock()
+			return 0, err
+		}
+	}
+	oldest := true
+	for s := range pk.files {
+		if s < seq {
+			oldest = false
+			break
+		}
+	}
+	pk.mu.Unlock()
+
+	recs, err := pk.readIdx(pf.seq)
+	if err != nil {
+		return 0, err
+	}
+	for i := range recs {
+		if err := pk.move(pf, &recs[i], oldest, maxSize); err != nil {
+			return 0, err
+		}
+	}
+
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	debug.Assert(pf.live == 0, pk.String(), " ", pf.seq, " ", pf.live)
+	cos.Close(pf.fh)
+	delete(pk.files, seq)
+	if err := cos.RemoveFile(pk.fname(seq, packIdxExt)); err != nil {
+		return 0, err
+	}
+	if err := cos.RemoveFile(pk.fname(seq, packExt)); err != nil {
+		return 0, err
+	}
+	return pf.size, nil
+}
+
+// (one record at a time)
+func (pk *Pack) move(pf *packFile, rec *packRec, oldest bool, maxSize int64) error {
+	pk.mu.Lock()
+	defer pk.mu.Unlock()
+	e, ok := pk.index[rec.name]
+	if rec.kind == packRecDel {
+		// tombstone must keep shadowing older records (if any)
+		if ok || oldest {
+			return nil
+		}
+		return pk.append(packRecDel, rec.name, nil, 0, nil, maxSize)
+	}
+	if !ok || (e.data.seq != pf.seq && e.md.seq != pf.seq) {
+		return nil // deleted, overwritten, or already moved
+	}
+	// re-append the most recent metadata along with the content
+	var (
+		hdr  = make([]byte, packHdrLen+len(rec.name)+int(e.mdlen))
+		data = make([]byte, e.size)
+	)
+	if _, err := pk.files[e.md.seq].fh.ReadAt(hdr, e.md.off); err != nil {
+		return pk.errRead(rec.name, err)
+	}
+	if _, err := pk.files[e.data.seq].fh.ReadAt(data, e.data.off+e.data.rlen-e.size); err != nil {
+		return pk.errRead(rec.name, err)
+	}
+	atime := int64(binary.LittleEndian.Uint64(hdr[24:]))
+	return pk.append(packRecFull, rec.name, hdr[packHdrLen+len(rec.name):], atime, data, maxSize)
+}
@@ -0,0 +1,149 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestPack(t *testing.T) {
+	const (
+		num      = 100
+		packSize = 4 * cos.KiB // (=> multiple pack files)
+	)
+	var (
+		bck  = cmn.Bck{Name: "packed", Provider: apc.AIS}
+		mi   = initPackMpath(t, &bck)
+		data = func(i int) []byte { return []byte(fmt.Sprintf("content-%d-%s", i, cos.CryptoRandS(i%64))) }
+	)
+	pk, err := mi.Pack(&bck, false)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, pk == nil, "expected no pack")
+
+	pk, err = mi.Pack(&bck, true)
+	tassert.CheckFatal(t, err)
+	contents := make(map[string][]byte, num)
+	for i := range num {
+		name := fmt.Sprintf("dir/obj-%03d", i)
+		contents[name] = data(i)
+		tassert.CheckFatal(t, pk.Put(name, []byte("md"), int64(i), contents[name], packSize))
+	}
+	// overwrite, update metadata, and delete
+	for i := 0; i < num; i += 3 {
+		name := fmt.Sprintf("dir/obj-%03d", i)
+		contents[name] = data(i + 1)
+		tassert.CheckFatal(t, pk.Put(name, []byte("md2"), int64(i), contents[name], packSize))
+	}
+	for i := 1; i < num; i += 3 {
+		tassert.CheckFatal(t, pk.SetMD(fmt.Sprintf("dir/obj-%03d", i), []byte("md3"), 1000))
+	}
+	for i := 2; i < num; i += 3 {
+		name := fmt.Sprintf("dir/obj-%03d", i)
+		deleted, err := pk.Delete(name)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, deleted, "%q: expected deleted", name)
+		delete(contents, name)
+	}
+	checkPack(t, pk, contents)
+
+	// reload (sealed files via their indexes, the active one by scanning)
+	pk = reloadPack(t, mi, &bck)
+	checkPack(t, pk, contents)
+	md, atime, _, err := pk.MD("dir/obj-001")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(md) == "md3" && atime == 1000, "unexpected metadata %q, atime %d", md, atime)
+
+	// compact
+	live, size := pk.Usage()
+	reclaimed, err := pk.Compact(10, packSize)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, reclaimed > 0, "expected to reclaim (live %d, size %d)", live, size)
+	checkPack(t, pk, contents)
+
+	pk = reloadPack(t, mi, &bck)
+	checkPack(t, pk, contents)
+	live2, size2 := pk.Usage()
+	tassert.Errorf(t, size2 < size && live2 <= live, "expected smaller size: (%d, %d) vs (%d, %d)", live2, size2, live, size)
+}
+
+func TestPackWalk(t *testing.T) {
+	var (
+		bck = cmn.Bck{Name: "walk-packed", Provider: apc.AIS}
+		mi  = initPackMpath(t, &bck)
+	)
+	pk, err := mi.Pack(&bck, true)
+	tassert.CheckFatal(t, err)
+	for _, name := range []string{"b", "d", "f"} {
+		tassert.CheckFatal(t, pk.Put(name, nil, 0, []byte(name), 0))
+	}
+	for _, name := range []string{"a", "c", "e"} {
+		fqn := mi.MakePathFQN(&bck, fs.ObjectType, name)
+		tassert.CheckFatal(t, os.WriteFile(fqn, []byte(name), cos.PermRWR))
+	}
+	var names []string
+	opts := &fs.WalkOpts{
+		Mi:  mi,
+		Bck: bck,
+		CTs: []string{fs.ObjectType},
+		Callback: func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			parsed, err := fs.ParseFQN(fqn)
+			tassert.CheckFatal(t, err)
+			names = append(names, parsed.ObjName)
+			return nil
+		},
+		Sorted: true,
+	}
+	tassert.CheckFatal(t, fs.Walk(opts))
+	expected := []string{"a", "b", "c", "d", "e", "f"}
+	tassert.Errorf(t, reflect.DeepEqual(names, expected), "expected %v, got %v", expected, names)
+}
+
+func initPackMpath(t *testing.T, bck *cmn.Bck) *fs.Mountpath {
+	fs.TestNew(mock.NewIOS())
+	fs.TestDisableValidation()
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.PackType, &fs.PackContentResolver{}, true)
+
+	mpath := t.TempDir()
+	mi, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, cos.CreateDir(mi.MakePathCT(bck, fs.ObjectType)))
+	return mi
+}
+
+// drop in-memory state and load from disk
+func reloadPack(t *testing.T, mi *fs.Mountpath, bck *cmn.Bck) *fs.Pack {
+	fs.TestEvictPack(mi, bck)
+	pk, err := mi.Pack(bck, false)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, pk != nil, "failed to reload pack")
+	return pk
+}
+
+func checkPack(t *testing.T, pk *fs.Pack, contents map[string][]byte) {
+	tassert.Fatalf(t, pk.Len() == len(contents), "expected %d packed objects, got %d", len(contents), pk.Len())
+	for name, content := range contents {
+		data, err := pk.Read(name)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, string(data) == string(content), "%q: content mismatch", name)
+		_, _, size, err := pk.MD(name)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, size == int64(len(content)), "%q: size %d != %d", name, size, len(content))
+	}
+	tassert.Errorf(t, len(pk.Names("", true)) == len(contents), "names vs contents")
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"syscall"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 )
@@ -85,3 +86,13 @@ func ValidateNCopies(tname string, copies int) (err error) {
 	}
 	return nil
 }
+
+// returns true if the file has other hard links (e.g., bucket snapshots - see cmn/snap.go)
+// and cannot, therefore, be modified in place
+func IsHardLinked(fqn string) bool {
+	var st syscall.Stat_t
+	if err := syscall.Stat(fqn, &st); err != nil {
+		return false
+	}
+	return st.Nlink > 1
+}
@@ -7,6 +7,7 @@ package fs_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/NVIDIA/aistore/fs"
@@ -49,6 +50,23 @@ func TestIsDirEmptyNonExist(t *testing.T) {
 	tassert.Fatalf(t, err != nil, "expected error")
 }
 
+func TestIsHardLinked(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		fqn  = filepath.Join(dir, "obj")
+		link = filepath.Join(dir, "snap")
+	)
+	tassert.CheckFatal(t, os.WriteFile(fqn, []byte("data"), 0o644))
+	tassert.Fatalf(t, !fs.IsHardLinked(fqn), "%q: not expecting hard links", fqn)
+
+	tassert.CheckFatal(t, os.Link(fqn, link))
+	tassert.Fatalf(t, fs.IsHardLinked(fqn) && fs.IsHardLinked(link), "%q: expecting hard link", fqn)
+
+	tassert.CheckFatal(t, os.Remove(link))
+	tassert.Fatalf(t, !fs.IsHardLinked(fqn), "%q: not expecting hard links (removed)", fqn)
+	tassert.Fatalf(t, !fs.IsHardLinked(link), "%q: does not exist", link)
+}
+
 func BenchmarkIsDirEmpty(b *testing.B) {
 	benches := []tools.DirTreeDesc{
 		{Dirs: 0, Depth: 1, Empty: true},
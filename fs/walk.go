@@ -56,6 +56,13 @@ type (
 		dir string                       // root pathname
 		errCallbackWrapper
 	}
+
+	// visits packed objects (see pack.go)
+	walkPacked struct {
+		opts  *WalkOpts
+		bck   *cmn.Bck
+		names []string // remaining (not yet visited)
+	}
 )
 
 // PathErrToAction is a default error callback for fast godirwalk.Walk.
@@ -104,9 +111,10 @@ func (opts *WalkOpts) callback(fqn string, de *godirwalk.Dirent) error {
 
 func Walk(opts *WalkOpts) error {
 	var (
-		fqns []string
-		err  error
-		ew   = &errCallbackWrapper{}
+		fqns   []string
+		packed []*walkPacked
+		err    error
+		ew     = &errCallbackWrapper{}
 	)
 	if opts.Dir != "" {
 		debug.Assert(opts.Prefix == "")
@@ -122,10 +130,13 @@ func Walk(opts *WalkOpts) error {
 				fqns = append(fqns, bdir)
 			}
 		}
+		if wp := opts.newWalkPacked(&opts.Bck); wp != nil {
+			packed = append(packed, wp)
+		}
 	} else {
 		// all buckets
 		debug.Assert(len(opts.CTs) > 0)
-		fqns, err = allMpathCTpaths(opts)
+		fqns, packed, err = allMpathCTpaths(opts)
 		if len(fqns) == 0 || err != nil {
 			return err
 		}
@@ -137,6 +148,18 @@ func Walk(opts *WalkOpts) error {
 		Unsorted:      !opts.Sorted,
 		ScratchBuffer: scratch,
 	}
+	// one bucket, sorted: merge packed objects into the (sorted) walk
+	if len(packed) == 1 && opts.Sorted && len(opts.CTs) == 1 {
+		wp := packed[0]
+		gOpts.Callback = func(fqn string, de *godirwalk.Dirent) error {
+			if !de.IsDir() {
+				if err := wp.visit(fqn); err != nil {
+					return err
+				}
+			}
+			return opts.Callback(fqn, de)
+		}
+	}
 	for _, fqn := range fqns {
 		err1 := godirwalk.Walk(fqn, gOpts)
 		if err1 == nil || os.IsNotExist(err1) {
@@ -161,6 +184,14 @@ func Walk(opts *WalkOpts) error {
 		err = err1
 	}
 	slab.Free(scratch)
+	if err != nil {
+		return err
+	}
+	for _, wp := range packed {
+		if err = wp.visit(""); err != nil {
+			break
+		}
+	}
 	return err
 }
 
@@ -177,10 +208,10 @@ func _join(bdir, prefix string) string {
 	return bdir
 }
 
-func allMpathCTpaths(opts *WalkOpts) (fqns []string, err error) {
+func allMpathCTpaths(opts *WalkOpts) (fqns []string, packed []*walkPacked, err error) {
 	children, erc := mpathChildren(opts)
 	if erc != nil {
-		return nil, erc
+		return nil, nil, erc
 	}
 	if len(opts.CTs) > 1 {
 		fqns = make([]string, 0, len(children)*len(opts.CTs))
@@ -201,6 +232,9 @@ func allMpathCTpaths(opts *WalkOpts) (fqns []string, err error) {
 				fqns = append(fqns, bdir)
 			}
 		}
+		if wp := opts.newWalkPacked(&bck); wp != nil {
+			packed = append(packed, wp)
+		}
 	}
 	return
 }
@@ -240,6 +274,53 @@ func mpathChildren(opts *WalkOpts) (children []string, err error) {
 	return
 }
 
+////////////////
+// walkPacked //
+////////////////
+
+// returns nil if the bucket has no packed objects (or the walk doesn't include objects)
+func (opts *WalkOpts) newWalkPacked(bck *cmn.Bck) *walkPacked {
+	if !cos.StringInSlice(ObjectType, opts.CTs) {
+		return nil
+	}
+	pk, err := opts.Mi.Pack(bck, false)
+	if err != nil {
+		nlog.Errorln(err)
+		return nil
+	}
+	if pk == nil {
+		return nil
+	}
+	names := pk.Names(opts.Prefix, opts.Sorted)
+	if len(names) == 0 {
+		return nil
+	}
+	b := *bck
+	return &walkPacked{opts: opts, bck: &b, names: names}
+}
+
+// visit packed objects that precede a given (regular) object; all remaining when `fqn` is empty
+func (wp *walkPacked) visit(fqn string) error {
+	var i int
+	if fqn == "" {
+		i = len(wp.names)
+	} else {
+		parsed, err := ParseFQN(fqn)
+		if err != nil || parsed.ContentType != ObjectType {
+			return nil
+		}
+		i = sort.SearchStrings(wp.names, parsed.ObjName)
+	}
+	for _, name := range wp.names[:i] {
+		err := wp.opts.Callback(wp.opts.Mi.MakePathFQN(wp.bck, ObjectType, name), packDirent{})
+		if err != nil && err != filepath.SkipDir {
+			return err
+		}
+	}
+	wp.names = wp.names[i:]
+	return nil
+}
+
 ////////////////////
 // WalkDir & walkDirWrapper - non-recursive walk
 ////////////////////
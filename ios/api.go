@@ -6,6 +6,6 @@
 package ios
 
 type (
-	DiskStats    struct{ RBps, Ravg, WBps, Wavg, Util int64 }
+	DiskStats    struct{ RBps, Ravg, WBps, Wavg, Util, Await int64 } // (await in milliseconds)
 	AllDiskStats map[string]DiskStats
 )
@@ -50,6 +50,7 @@ type (
 		writes map[string]int64 // completed write requests
 		wbps   map[string]int64 // write B/s
 		wavg   map[string]int64 // average write size
+		await  map[string]int64 // average IO latency (ms), reads and writes combined
 
 		mpathUtil   map[string]int64 // Average utilization of the disks, range [0, 100].
 		mpathUtilRO MpathUtil        // Read-only copy of `mpathUtil`.
@@ -135,6 +136,7 @@ func newCache(num int) *cache {
 		writes:    make(map[string]int64, num),
 		wbps:      make(map[string]int64, num),
 		wavg:      make(map[string]int64, num),
+		await:     make(map[string]int64, num),
 		mpathUtil: make(map[string]int64, num),
 	}
 }
@@ -280,11 +282,12 @@ func (ios *ios) FillDiskStats(m AllDiskStats) {
 	cache := ios.refresh()
 	for disk := range cache.ioms {
 		m[disk] = DiskStats{
-			RBps: cache.rbps[disk],
-			Ravg: cache.ravg[disk],
-			WBps: cache.wbps[disk],
-			Wavg: cache.wavg[disk],
-			Util: cache.util[disk],
+			RBps:  cache.rbps[disk],
+			Ravg:  cache.ravg[disk],
+			WBps:  cache.wbps[disk],
+			Wavg:  cache.wavg[disk],
+			Util:  cache.util[disk],
+			Await: cache.await[disk],
 		}
 	}
 	for disk := range m {
@@ -368,6 +371,7 @@ func (ios *ios) _ref(config *cmn.Config) (ncache *cache, maxUtil int64, missingI
 		ncache.util[disk] = 0
 		ncache.ravg[disk] = 0
 		ncache.wavg[disk] = 0
+		ncache.await[disk] = 0
 		ds := ios.blockStats[disk]
 		ncache.ioms[disk] = ds.IOMs()
 		ncache.rms[disk] = ds.ReadMs()
@@ -384,6 +388,7 @@ func (ios *ios) _ref(config *cmn.Config) (ncache *cache, maxUtil int64, missingI
 		// deltas
 		var (
 			ioMs       = ncache.ioms[disk] - statsCache.ioms[disk]
+			rwMs       = ncache.rms[disk] - statsCache.rms[disk] + ncache.wms[disk] - statsCache.wms[disk]
 			reads      = ncache.reads[disk] - statsCache.reads[disk]
 			writes     = ncache.writes[disk] - statsCache.writes[disk]
 			readBytes  = ncache.rbytes[disk] - statsCache.rbytes[disk]
@@ -424,6 +429,11 @@ func (ios *ios) _ref(config *cmn.Config) (ncache *cache, maxUtil int64, missingI
 		} else {
 			ncache.wavg[disk] = 0
 		}
+		if n := reads + writes; n > 0 {
+			ncache.await[disk] = cos.DivRound(rwMs, n)
+		} else if elapsedSeconds == 0 {
+			ncache.await[disk] = statsCache.await[disk]
+		}
 	}
 
 	// average and max
@@ -54,8 +54,8 @@ func addCopies(lom *core.LOM, copies int, buf []byte) (size int64, err error) {
 		return 0, err
 	}
 
-	// Recheck if we still need to create the copy.
-	if lom.NumCopies() >= copies {
+	// Recheck if we still need to create the copy (stubs are never mirrored - see core/ltier.go).
+	if lom.NumCopies() >= copies || lom.IsTiered() {
 		return 0, nil
 	}
 
@@ -120,6 +120,11 @@ func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode,
 			core.FreeLOM(lom)
 			return
 		}
+		if lom.IsTiered() { // metadata-only stub is not EC-encoded (see ec.ECM.EncodeObject)
+			lom.Unlock(false)
+			core.FreeLOM(lom)
+			return
+		}
 	} else {
 		lom = nil // sending slice; TODO: rlock
 	}
@@ -129,7 +134,7 @@ func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode,
 		defer core.FreeLOM(lom)
 		roc, err = lom.NewDeferROC()
 	} else {
-		roc, err = cos.NewFileHandle(fqn)
+		roc, err = core.OpenSlice(ct.Bck(), fqn, ec.SliceSize(meta.Size, meta.Data))
 	}
 	if err != nil {
 		return
@@ -858,26 +858,30 @@ func _getReader(lom *core.LOM) (roc cos.ReadOpenCloser, err error) {
 		err = cmn.ErrSkip
 		return
 	}
-	if lom.Checksum() == nil {
+	if lom.Checksum() == nil && !lom.IsTiered() {
 		if _, err = lom.ComputeSetCksum(); err != nil {
 			lom.Unlock(false)
 			return
 		}
 	}
-	debug.Assert(lom.Checksum() != nil, lom.String())
+	debug.Assert(lom.Checksum() != nil || lom.IsTiered(), lom.String())
 	return lom.NewDeferROC()
 }
 
 func (rj *rebJogger) doSend(lom *core.LOM, tsi *meta.Snode, roc cos.ReadOpenCloser) error {
 	var (
-		ack    = regularAck{rebID: rj.m.RebID(), daemonID: core.T.SID()}
-		o      = transport.AllocSend()
-		opaque = ack.NewPack()
+		ack = regularAck{rebID: rj.m.RebID(), daemonID: core.T.SID()}
+		o   = transport.AllocSend()
 	)
 	o.Hdr.Bck.Copy(lom.Bucket())
 	o.Hdr.ObjName = lom.ObjName
-	o.Hdr.Opaque = opaque
 	o.Hdr.ObjAttrs.CopyFrom(lom.ObjAttrs(), false /*skip cksum*/)
+	if lom.IsTiered() {
+		// metadata only (the receiver rebuilds the stub - see core/ltier.go)
+		ack.stubSize = lom.SizeBytes()
+		o.Hdr.ObjAttrs.Size = 0
+	}
+	o.Hdr.Opaque = ack.NewPack()
 	o.Callback, o.CmplArg = rj.objSentCallback, lom
 	rj.m.inQueue.Inc()
 	return rj.m.dm.Send(o, roc, tsi)
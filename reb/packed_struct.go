@@ -28,6 +28,7 @@ type (
 	regularAck struct {
 		daemonID string // sender's DaemonID
 		rebID    int64
+		stubSize int64 // tiered object's size - sending metadata-only stub (see core/ltier.go)
 	}
 	ecAck struct {
 		daemonID string // sender's DaemonID
@@ -59,12 +60,16 @@ func (rack *regularAck) Unpack(unpacker *cos.ByteUnpack) (err error) {
 	if rack.rebID, err = unpacker.ReadInt64(); err != nil {
 		return
 	}
+	if rack.stubSize, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
 	rack.daemonID, err = unpacker.ReadString()
 	return
 }
 
 func (rack *regularAck) Pack(packer *cos.BytePack) {
 	packer.WriteInt64(rack.rebID)
+	packer.WriteInt64(rack.stubSize)
 	packer.WriteString(rack.daemonID)
 }
 
@@ -76,9 +81,9 @@ func (rack *regularAck) NewPack() []byte { // TODO: consider adding as another c
 	return packer.Bytes()
 }
 
-// rebID + length of DaemonID + Daemon
+// rebID + stub size + length of DaemonID + Daemon
 func (rack *regularAck) PackedSize() int {
-	return cos.SizeofI64 + cos.SizeofLen + len(rack.daemonID)
+	return cos.SizeofI64*2 + cos.SizeofLen + len(rack.daemonID)
 }
 
 func (eack *ecAck) Unpack(unpacker *cos.ByteUnpack) (err error) {
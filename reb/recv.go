@@ -165,17 +165,23 @@ func (reb *Reb) recvObjRegular(hdr *transport.ObjHdr, smap *meta.Smap, unpacker
 	if xreb.IsAborted() {
 		return nil
 	}
-	params := core.AllocPutParams()
-	{
-		params.WorkTag = fs.WorkfilePut
-		params.Reader = io.NopCloser(objReader)
-		params.OWT = cmn.OwtRebalance
-		params.Cksum = hdr.ObjAttrs.Cksum
-		params.Atime = lom.Atime()
-		params.Xact = xreb
-	}
-	erp := core.T.PutObject(lom, params)
-	core.FreePutParams(params)
+	var erp error
+	if lom.IsTiered() {
+		erp = lom.StubFrom(&hdr.ObjAttrs, ack.stubSize) // metadata only (see doSend)
+	} else {
+		params := core.AllocPutParams()
+		{
+			params.WorkTag = fs.WorkfilePut
+			params.Reader = io.NopCloser(objReader)
+			params.OWT = cmn.OwtRebalance
+			params.Cksum = hdr.ObjAttrs.Cksum
+			params.Size = hdr.ObjAttrs.Size // (e.g., to store in chunks - see lom.NewChunkWriter)
+			params.Atime = lom.Atime()
+			params.Xact = xreb
+		}
+		erp = core.T.PutObject(lom, params)
+		core.FreePutParams(params)
+	}
 	if erp != nil {
 		nlog.Errorln(erp)
 		return erp
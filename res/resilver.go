@@ -103,7 +103,7 @@ func (res *Res) RunResilver(args Args) {
 		jctx      = &joggerCtx{xres: xres, config: config}
 
 		opts = &mpather.JgroupOpts{
-			CTs:                   []string{fs.ObjectType, fs.ECSliceType},
+			CTs:                   []string{fs.ObjectType, fs.ECSliceType, fs.ChunkType},
 			VisitObj:              jctx.visitObj,
 			VisitCT:               jctx.visitCT,
 			Slab:                  slab,
@@ -203,6 +203,44 @@ func (jg *joggerCtx) _mvSlice(ct *core.CT, buf []byte) {
 	}
 }
 
+// Moves a chunk of a large object to its HRW mountpath (see core/lchunk.go) - as is,
+// via work file on the destination
+func (jg *joggerCtx) _mvChunk(ct *core.CT, buf []byte) {
+	destFQN, _, err := core.HrwFQN(ct.Bucket(), fs.ChunkType, ct.ObjectName())
+	if err != nil {
+		jg.xres.AddErr(err)
+		return
+	}
+	if destFQN == ct.FQN() {
+		return
+	}
+	destMpath, _, err := fs.FQN2Mpath(destFQN)
+	if err != nil {
+		jg.xres.AddErr(err)
+		return
+	}
+	workFQN := destMpath.MakePathFQN(ct.Bucket(), fs.WorkfileType, fs.WorkfileCopy+"."+ct.ObjectName())
+	if cmn.Rom.FastV(4, cos.SmoduleReb) {
+		nlog.Infof("%s: moving %q -> %q", core.T, ct.FQN(), destFQN)
+	}
+	if _, _, err = cos.CopyFile(ct.FQN(), workFQN, buf, cos.ChecksumNone); err == nil {
+		err = cos.Rename(workFQN, destFQN)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return // removed in the meantime
+		}
+		jg.xres.AddErr(fmt.Errorf("failed to move %q -> %q: %v", ct.FQN(), destFQN, err), 0)
+		if errRm := cos.RemoveFile(workFQN); errRm != nil && !os.IsNotExist(errRm) {
+			nlog.Warningln("nested err:", errRm)
+		}
+		return
+	}
+	if err := cos.RemoveFile(ct.FQN()); err != nil {
+		nlog.Warningf("failed to cleanup %q: %v", ct.FQN(), err)
+	}
+}
+
 // Copies EC metafile to correct mpath. It returns FQNs of the source and
 // destination for a caller to do proper cleanup. Empty values means: either
 // the source FQN does not exist(err==nil), or copying failed
@@ -273,6 +311,10 @@ func (jg *joggerCtx) visitObj(lom *core.LOM, buf []byte) (errHrw error) {
 		return nil
 	}
 	size = lom.SizeBytes()
+	if lom.Bck().IsSnap() {
+		copied, errHrw = jg.mvSnap(lom, buf)
+		return errHrw
+	}
 	// 2. fix hrw location; fail and subsequently abort if unsuccessful
 	var (
 		retries   int
@@ -304,6 +346,9 @@ redo:
 		}
 		lom = hlom
 		copied = true
+		if lom.Bck().IsAIS() {
+			lom.Reshare() // (with snapshot objects, if any - see core/lsnap.go)
+		}
 	}
 
 	// 3. fix copies
@@ -372,7 +417,37 @@ func (*joggerCtx) fixHrw(lom *core.LOM, mi *fs.Mountpath, buf []byte) (hlom *cor
 	return
 }
 
+// snapshot objects are hard links that share inode with their origin and keep
+// their metadata separately (see core/lsnap.go) - the two are moved together
+func (jg *joggerCtx) mvSnap(lom *core.LOM, buf []byte) (bool, error) {
+	mi, isHrw := lom.ToMpath()
+	if mi == nil || !isHrw {
+		return false, nil
+	}
+	if err := lom.MoveSnap(mi, buf); err != nil {
+		if cos.IsErrOOS(err) {
+			errV := fmt.Errorf("%s: %s OOS, err: %w", core.T, mi, err)
+			jg.xres.AddErr(errV, 0)
+			return false, cmn.NewErrAborted(jg.xres.Name(), "", errV)
+		}
+		jg.xres.AddErr(fmt.Errorf("%s: failed to move %s to %s, err: %w", jg.xres.Name(), lom, mi, err), 0)
+		return false, nil
+	}
+	// (cross-mountpath move copies content - share it again with the origin, if possible)
+	slom := core.AllocLOM(lom.ObjName)
+	if slom.InitFQN(mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName), lom.Bucket()) == nil &&
+		slom.Load(false /*cache it*/, true /*locked*/) == nil {
+		slom.Reshare()
+	}
+	core.FreeLOM(slom)
+	return true, nil
+}
+
 func (jg *joggerCtx) visitCT(ct *core.CT, buf []byte) (err error) {
+	if ct.ContentType() == fs.ChunkType {
+		jg._mvChunk(ct, buf)
+		return nil
+	}
 	debug.Assert(ct.ContentType() == fs.ECSliceType)
 	if !ct.Bck().Props.EC.Enabled {
 		// Since `%ec` directory is inside a bucket, it is safe to skip
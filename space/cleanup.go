@@ -348,7 +348,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ChunkType, fs.ShardIdxType, fs.SnapMetaType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -356,7 +356,15 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	if err = fs.Walk(opts); err != nil {
 		return
 	}
-	size, err = j.rmLeftovers()
+	if size, err = j.rmLeftovers(); err != nil {
+		return
+	}
+	// reclaim deleted packed content (see fs/pack.go)
+	reclaimed, errC := core.CompactPack(j.mi, &j.bck)
+	if errC != nil {
+		j.ini.Xaction.AddErr(errC)
+	}
+	size += reclaimed
 	return
 }
 
@@ -411,6 +419,26 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ChunkType:
+		// chunks of large objects (see core/lchunk.go):
+		// remove old ones that are not referenced by their objects' manifests
+		// (e.g., left behind by failed or interrupted writes)
+		_, old, ok := fs.CSM.Resolver(fs.ChunkType).ParseUniqueFQN(parsedFQN.ObjName)
+		if ok && old && !core.ChunkInUse(&parsedFQN.Bck, parsedFQN.ObjName) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.ShardIdxType, fs.SnapMetaType:
+		// shard indexes (see core/lshard.go) and snapshot objects' metadata (core/lsnap.go):
+		// remove those that outlived their objects
+		// (stale indexes of existing shards get removed upon loading)
+		finfo, err := os.Stat(fqn)
+		if err != nil || finfo.ModTime().UnixNano()+int64(j.config.LRU.DontEvictTime) > j.now {
+			return
+		}
+		objFQN := parsedFQN.Mountpath.MakePathFQN(&parsedFQN.Bck, fs.ObjectType, parsedFQN.ObjName)
+		if cos.Stat(objFQN) != nil {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
@@ -562,9 +590,9 @@ func (j *clnJ) rmLeftovers() (size int64, err error) {
 			)
 			lom := core.AllocLOM(mlom.ObjName) // yes placed
 			if lom.InitBck(&j.bck) != nil {
-				removed = os.Remove(fqn) == nil
+				removed = core.RemoveFQN(fqn) == nil
 			} else if lom.FromFS() != nil {
-				removed = os.Remove(fqn) == nil
+				removed = core.RemoveFQN(fqn) == nil
 			} else {
 				removed, _ = lom.DelExtraCopies(fqn)
 			}
@@ -9,6 +9,7 @@ package space
 import (
 	"container/heap"
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/webhook"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/mpather"
 	"github.com/NVIDIA/aistore/ios"
@@ -32,7 +34,16 @@ import (
 // config.Space.HighWM (section "space" in the cluster config).
 //
 // When and if exceeded, AIS target will start gradually evicting objects from its
-// stable storage: oldest first access-time wise.
+// stable storage in the order defined by the bucket's eviction policy (lru.policy):
+//   - "lru" (default): oldest first access-time wise
+//   - "lfu":           least frequently accessed first (see cmn.AccessCntObjMD), oldest first within
+//   - "size":          large and cold first (size weighted by time since last access)
+//
+// Buckets are visited in the order of their lru.priority (lowest first) and then size (largest first),
+// so that datasets with higher priority are evicted last.
+//
+// In dry-run mode LRU does not remove anything - instead, it reports objects that would be evicted
+// (see ExtLRUStats).
 //
 // LRU is implemented as eXtended Action (xaction, see xact/README.md) that gets
 // triggered when/if a used local capacity exceeds high watermark (config.Space.HighWM). LRU then
@@ -47,6 +58,7 @@ import (
 const (
 	minEvictThresh = 10 * cos.MiB  // to run or not to run
 	capCheckThresh = 256 * cos.MiB // capacity checking threshold (in re: periodic throttle)
+	maxDryRunCands = 1000          // max number of dry-run candidates reported by a given target
 )
 
 type (
@@ -59,16 +71,40 @@ type (
 		GetFSStats          func(path string) (blocks, bavail uint64, bsize int64, err error)
 		WG                  *sync.WaitGroup
 		Force               bool // Ignore LRU prop when set to be true.
+		DryRun              bool // report (but do not evict) eviction candidates
 	}
 	XactLRU struct {
 		xact.Base
+		dryRun struct {
+			cands []EvictCand
+			mu    sync.Mutex
+			on    bool
+		}
+	}
+
+	// dry-run report
+	EvictCand struct {
+		Bck       cmn.Bck `json:"bck"`
+		ObjName   string  `json:"name"`
+		Policy    string  `json:"policy"`
+		Size      int64   `json:"size,string"`
+		Atime     int64   `json:"atime,string"`
+		AccessCnt int64   `json:"access_cnt,string,omitempty"`
+	}
+	ExtLRUStats struct {
+		Cands []EvictCand `json:"lru.dry_run.candidates"` // in eviction order (per mountpath)
 	}
 )
 
 // private
 type (
-	// minHeap keeps fileInfo sorted by access time with oldest on top of the heap.
-	minHeap []*core.LOM
+	// eviction candidate and its policy-specific keys (the smallest goes first)
+	evictItem struct {
+		lom    *core.LOM
+		k1, k2 int64
+	}
+	// minHeap keeps candidates sorted by (k1, k2) with the first to evict on top of the heap.
+	minHeap []evictItem
 
 	// parent (contains mpath joggers)
 	lruP struct {
@@ -82,10 +118,11 @@ type (
 	lruJ struct {
 		// runtime
 		curSize   int64
-		totalSize int64 // difference between lowWM size and used size
-		newest    int64
+		totalSize int64     // difference between lowWM size and used size
+		last      evictItem // the last to evict (max keys) among those in the heap
 		heap      *minHeap
 		bck       cmn.Bck
+		policy    string // bucket's eviction policy (see cmn.LRUConf)
 		now       int64
 		// init-time
 		p       *lruP
@@ -170,7 +207,12 @@ func RunLRU(ini *IniLRU) {
 		go j.run(providers)
 	}
 	cs := fs.Cap()
-	nlog.Infof("%s started, dont-evict-time %v, %s", xlru, config.LRU.DontEvictTime, cs.String())
+	if ini.DryRun {
+		xlru.dryRun.on = true
+		nlog.Infof("%s started (dry-run), dont-evict-time %v, %s", xlru, config.LRU.DontEvictTime, cs.String())
+	} else {
+		nlog.Infof("%s started, dont-evict-time %v, %s", xlru, config.LRU.DontEvictTime, cs.String())
+	}
 	if ini.WG != nil {
 		ini.WG.Done()
 		ini.WG = nil
@@ -191,10 +233,30 @@ func (r *XactLRU) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
 
+	if r.dryRun.on {
+		r.dryRun.mu.Lock()
+		snap.Ext = &ExtLRUStats{Cands: append([]EvictCand{}, r.dryRun.cands...)}
+		r.dryRun.mu.Unlock()
+	}
 	snap.IdleX = r.IsIdle()
 	return
 }
 
+func (r *XactLRU) addCand(lom *core.LOM, policy string) {
+	r.dryRun.mu.Lock()
+	if len(r.dryRun.cands) < maxDryRunCands {
+		r.dryRun.cands = append(r.dryRun.cands, EvictCand{
+			Bck:       *lom.Bucket(),
+			ObjName:   lom.ObjName,
+			Policy:    policy,
+			Size:      lom.SizeBytes(),
+			Atime:     lom.AtimeUnix(),
+			AccessCnt: lom.AccessCnt(),
+		})
+	}
+	r.dryRun.mu.Unlock()
+}
+
 //////////////////////
 // mountpath jogger //
 //////////////////////
@@ -254,7 +316,7 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 		return
 	}
 	if len(bcks) > 1 {
-		j.sortBsize(bcks)
+		j.sortBcks(bcks)
 	}
 	for _, bck := range bcks { // for each bucket under a given provider
 		var size int64
@@ -271,9 +333,11 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 		if size < cos.KiB {
 			continue
 		}
-		// recompute size-to-evict
-		if err = j.evictSize(); err != nil {
-			return
+		// recompute size-to-evict (dry-run: keep decrementing - see postRemove)
+		if !j.ini.DryRun {
+			if err = j.evictSize(); err != nil {
+				return
+			}
 		}
 		if j.totalSize < cos.KiB {
 			return
@@ -287,6 +351,7 @@ func (j *lruJ) jogBck() (size int64, err error) {
 	h := (*j.heap)[:0]
 	j.heap = &h
 	heap.Init(j.heap)
+	j.curSize, j.last = 0, evictItem{}
 
 	// 2. collect
 	opts := &fs.WalkOpts{
@@ -301,7 +366,16 @@ func (j *lruJ) jogBck() (size int64, err error) {
 		return
 	}
 	// 3. evict
-	size, err = j.evict()
+	if size, err = j.evict(); err != nil || size == 0 {
+		return
+	}
+	// 4. reclaim evicted packed content (see fs/pack.go)
+	if j.ini.DryRun {
+		return
+	}
+	if _, errC := core.CompactPack(j.mi, &j.bck); errC != nil {
+		j.ini.Xaction.AddErr(errC)
+	}
 	return
 }
 
@@ -328,19 +402,44 @@ func (j *lruJ) _visit(lom *core.LOM) (pushed bool) {
 	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
+	if lom.IsWbackPending() { // not yet written back
+		return
+	}
 	// do nothing if the heap's curSize >= totalSize and
-	// the file is more recent then the the heap's newest.
-	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
+	// the object goes after the heap's last (policy-wise)
+	item := j.keys(lom)
+	if j.curSize >= j.totalSize && j.last.less(&item) {
 		return
 	}
-	heap.Push(j.heap, lom)
+	heap.Push(j.heap, item)
 	j.curSize += lom.SizeBytes()
-	if lom.AtimeUnix() > j.newest {
-		j.newest = lom.AtimeUnix()
+	if j.last.lom == nil || j.last.less(&item) {
+		j.last = item
 	}
 	return true
 }
 
+// policy-specific keys (see cmn.LRUConf.Policy)
+func (j *lruJ) keys(lom *core.LOM) evictItem {
+	atime := lom.AtimeUnix()
+	switch j.policy {
+	case cmn.EvictLFU:
+		return evictItem{lom: lom, k1: lom.AccessCnt(), k2: atime}
+	case cmn.EvictSize:
+		var (
+			idle  = max((j.now-atime)/int64(time.Second), 1)
+			kib   = lom.SizeBytes()>>10 + 1
+			score = int64(math.MaxInt64)
+		)
+		if kib <= math.MaxInt64/idle {
+			score = kib * idle
+		}
+		return evictItem{lom: lom, k1: -score, k2: atime}
+	default:
+		return evictItem{lom: lom, k1: atime}
+	}
+}
+
 func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if de.IsDir() {
 		return nil
@@ -369,8 +468,10 @@ func (j *lruJ) evict() (size int64, err error) {
 
 	// evict(sic!) and house-keep
 	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*core.LOM)
-		if !j.evictObj(lom) {
+		lom := heap.Pop(h).(evictItem).lom
+		if j.ini.DryRun {
+			xlru.addCand(lom, j.policy)
+		} else if !j.evictObj(lom) {
 			core.FreeLOM(lom)
 			continue
 		}
@@ -383,8 +484,14 @@ func (j *lruJ) evict() (size int64, err error) {
 			return
 		}
 	}
-	j.ini.StatsT.Add(stats.LruEvictSize, bevicted)
-	j.ini.StatsT.Add(stats.LruEvictCount, fevicted)
+	// free the remaining (not evicted) candidates
+	for h.Len() > 0 {
+		core.FreeLOM(heap.Pop(h).(evictItem).lom)
+	}
+	if !j.ini.DryRun {
+		j.ini.StatsT.Add(stats.LruEvictSize, bevicted)
+		j.ini.StatsT.Add(stats.LruEvictCount, fevicted)
+	}
 	xlru.ObjsAdd(int(fevicted), bevicted)
 	return
 }
@@ -441,6 +548,7 @@ func (j *lruJ) evictObj(lom *core.LOM) bool {
 	if cmn.Rom.FastV(5, cos.SmoduleSpace) {
 		nlog.Infof("%s: evicted %s, size=%d", j, lom, lom.SizeBytes(true /*not loaded*/))
 	}
+	webhook.Notify(lom, apc.EvtObjEvicted)
 	return true
 }
 
@@ -479,18 +587,28 @@ func (j *lruJ) yieldTerm() error {
 	return nil
 }
 
-// sort buckets by size
-func (j *lruJ) sortBsize(bcks []cmn.Bck) {
-	sized := make([]struct {
-		b cmn.Bck
-		v uint64
-	}, len(bcks))
+// sort buckets by eviction priority (lowest first) and size (largest first)
+func (j *lruJ) sortBcks(bcks []cmn.Bck) {
+	var (
+		bowner = core.T.Bowner()
+		sized  = make([]struct {
+			b cmn.Bck
+			v uint64
+			p int
+		}, len(bcks))
+	)
 	for i := range bcks {
 		path := j.mi.MakePathCT(&bcks[i], fs.ObjectType)
 		sized[i].b = bcks[i]
 		sized[i].v, _ = ios.DirSizeOnDisk(path, false /*withNonDirPrefix*/)
+		if b := meta.CloneBck(&bcks[i]); b.Init(bowner) == nil {
+			sized[i].p = b.Props.LRU.Priority
+		}
 	}
 	sort.Slice(sized, func(i, j int) bool {
+		if sized[i].p != sized[j].p {
+			return sized[i].p < sized[j].p
+		}
 		return sized[i].v > sized[j].v
 	})
 	for i := range bcks {
@@ -507,6 +625,7 @@ func (j *lruJ) allow() (ok bool, err error) {
 		return
 	}
 	ok = b.Props.LRU.Enabled && b.Allow(apc.AceObjDELETE) == nil
+	j.policy = b.Props.LRU.EvictPolicy()
 	return
 }
 
@@ -514,10 +633,17 @@ func (j *lruJ) allow() (ok bool, err error) {
 // min-heap //
 //////////////
 
+func (a *evictItem) less(b *evictItem) bool {
+	if a.k1 != b.k1 {
+		return a.k1 < b.k1
+	}
+	return a.k2 < b.k2
+}
+
 func (h minHeap) Len() int           { return len(h) }
-func (h minHeap) Less(i, j int) bool { return h[i].Atime().Before(h[j].Atime()) }
+func (h minHeap) Less(i, j int) bool { return h[i].less(&h[j]) }
 func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-func (h *minHeap) Push(x any)        { *h = append(*h, x.(*core.LOM)) }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(evictItem)) }
 func (h *minHeap) Pop() any {
 	old := *h
 	n := len(old)
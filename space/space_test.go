@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"testing"
 	"time"
 
@@ -37,6 +38,8 @@ const (
 	basePath             = "/tmp/space-tests"
 	bucketName           = "space-bck"
 	bucketNameAnother    = bucketName + "-another"
+	bucketNameLFU        = bucketName + "-lfu"
+	bucketNameSize       = bucketName + "-size"
 )
 
 type fileMetadata struct {
@@ -61,7 +64,11 @@ var _ = Describe("space evict/cleanup tests", func() {
 		var (
 			filesPath  string
 			fpAnother  string
+			fpLFU      string
+			fpSize     string
+			bck        cmn.Bck
 			bckAnother cmn.Bck
+			bckSize    cmn.Bck
 		)
 
 		BeforeEach(func() {
@@ -69,12 +76,18 @@ var _ = Describe("space evict/cleanup tests", func() {
 			createAndAddMountpath(basePath)
 			core.T = newTargetLRUMock()
 			availablePaths := fs.GetAvail()
-			bck := cmn.Bck{Name: bucketName, Provider: apc.AIS, Ns: cmn.NsGlobal}
+			bck = cmn.Bck{Name: bucketName, Provider: apc.AIS, Ns: cmn.NsGlobal}
 			bckAnother = cmn.Bck{Name: bucketNameAnother, Provider: apc.AIS, Ns: cmn.NsGlobal}
+			bckLFU := cmn.Bck{Name: bucketNameLFU, Provider: apc.AIS, Ns: cmn.NsGlobal}
+			bckSize = cmn.Bck{Name: bucketNameSize, Provider: apc.AIS, Ns: cmn.NsGlobal}
 			filesPath = availablePaths[basePath].MakePathCT(&bck, fs.ObjectType)
 			fpAnother = availablePaths[basePath].MakePathCT(&bckAnother, fs.ObjectType)
+			fpLFU = availablePaths[basePath].MakePathCT(&bckLFU, fs.ObjectType)
+			fpSize = availablePaths[basePath].MakePathCT(&bckSize, fs.ObjectType)
 			cos.CreateDir(filesPath)
 			cos.CreateDir(fpAnother)
+			cos.CreateDir(fpLFU)
+			cos.CreateDir(fpSize)
 		})
 
 		AfterEach(func() {
@@ -200,6 +213,118 @@ var _ = Describe("space evict/cleanup tests", func() {
 			})
 		})
 
+		Describe("eviction policies", func() {
+			var ini *space.IniLRU
+			BeforeEach(func() {
+				ini = newIniLRU()
+			})
+			It("should evict least frequently used files [lfu]", func() {
+				var (
+					now      = time.Now()
+					frequent = []fileMetadata{
+						{getRandomFileName(0), fileSize},
+						{getRandomFileName(1), fileSize},
+						{getRandomFileName(2), fileSize},
+					}
+					rare = []fileMetadata{
+						{getRandomFileName(3), fileSize},
+						{getRandomFileName(4), fileSize},
+						{getRandomFileName(5), fileSize},
+					}
+				)
+				ini.GetFSStats = getMockGetFSStats(len(frequent) + len(rare))
+				// frequently accessed files are also the oldest (LRU would've evicted them)
+				for _, file := range frequent {
+					saveRandomFileMD(path.Join(fpLFU, file.name), file.size, now.Add(-time.Hour), 10)
+				}
+				for _, file := range rare {
+					saveRandomFileMD(path.Join(fpLFU, file.name), file.size, now.Add(-time.Minute), 1)
+				}
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(fpLFU)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(len(frequent)))
+				names := namesFromFilesMetadatas(frequent)
+				for _, name := range files {
+					Expect(cos.StringInSlice(name.Name(), names)).To(BeTrue())
+				}
+			})
+
+			It("should evict large cold files first [size]", func() {
+				const totalSize = 32 * cos.MiB
+				ini.GetFSStats = func(string) (blocks, bavail uint64, bsize int64, err error) {
+					bsize = blockSize
+					btaken := uint64(totalSize / blockSize)
+					blocks = uint64(float64(btaken) / initialDiskUsagePct)
+					bavail = blocks - btaken
+					return
+				}
+				files := []fileMetadata{
+					{getRandomFileName(0), int64(4 * cos.MiB)},
+					{getRandomFileName(1), int64(8 * cos.MiB)},
+					{getRandomFileName(2), int64(16 * cos.MiB)},
+					{getRandomFileName(3), int64(4 * cos.MiB)},
+				}
+				// same atime: the largest goes first and suffices to get below lwm
+				atime := time.Now().Add(-time.Hour)
+				for _, file := range files {
+					saveRandomFileMD(path.Join(fpSize, file.name), file.size, atime, 0)
+				}
+
+				space.RunLRU(ini)
+
+				filesLeft, err := os.ReadDir(fpSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesLeft)).To(Equal(3))
+				for _, name := range filesLeft {
+					Expect(name.Name()).NotTo(Equal(files[2].name))
+				}
+			})
+
+			It("should evict higher priority buckets last", func() {
+				const numberOfFiles = 3
+				saveRandomFiles(filesPath, numberOfFiles)
+				saveRandomFiles(fpSize, numberOfFiles) // lru.priority = 10
+
+				ini.GetFSStats = getDirsFSStats(2*numberOfFiles, filesPath, fpSize)
+				ini.Buckets = []cmn.Bck{bckSize, bck}
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(BeZero())
+				files, err = os.ReadDir(fpSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfFiles))
+			})
+
+			It("should report but not evict when dry-run", func() {
+				const numberOfFiles = 6
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+				ini.DryRun = true
+				saveRandomFiles(filesPath, numberOfFiles)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfFiles))
+
+				snap := ini.Xaction.Snap()
+				ext, ok := snap.Ext.(*space.ExtLRUStats)
+				Expect(ok).To(BeTrue())
+				Expect(len(ext.Cands)).To(Equal(3))
+				Expect(snap.Stats.Objs).To(BeEquivalentTo(3))
+				for _, cand := range ext.Cands {
+					Expect(cand.Bck.Name).To(Equal(bucketName))
+					Expect(cand.Policy).To(Equal(cmn.EvictLRU))
+					Expect(cand.Size).To(BeEquivalentTo(fileSize))
+				}
+			})
+		})
+
 		Describe("not evict files", func() {
 			var ini *space.IniLRU
 			BeforeEach(func() {
@@ -313,6 +438,26 @@ func getMockGetFSStats(currentFilesNum int) func(string) (uint64, uint64, int64,
 	}
 }
 
+// (capacity is fixed while the used size is computed from the actual content of the given dirs)
+func getDirsFSStats(capFilesNum int, dirs ...string) func(string) (uint64, uint64, int64, error) {
+	blocks := uint64(float64(capFilesNum*fileSize/blockSize) / initialDiskUsagePct)
+	return func(string) (_, bavail uint64, bsize int64, err error) {
+		var size int64
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			for _, e := range entries {
+				finfo, err := e.Info()
+				Expect(err).NotTo(HaveOccurred())
+				size += finfo.Size()
+			}
+		}
+		bsize = blockSize
+		bavail = blocks - uint64(size/blockSize)
+		return blocks, bavail, bsize, nil
+	}
+}
+
 func newTargetLRUMock() *mock.TargetMock {
 	// Bucket owner mock, required for LOM
 	var (
@@ -335,6 +480,24 @@ func newTargetLRUMock() *mock.TargetMock {
 					BID:    0xf4e3d2c1,
 				},
 			),
+			meta.NewBck(
+				bucketNameLFU, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Policy: cmn.EvictLFU},
+					Access: apc.AccessAll,
+					BID:    0xb1c2d3e4,
+				},
+			),
+			meta.NewBck(
+				bucketNameSize, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Policy: cmn.EvictSize, Priority: 10},
+					Access: apc.AccessAll,
+					BID:    0xc1d2e3f4,
+				},
+			),
 		)
 		tMock = mock.NewTarget(bmdMock)
 	)
@@ -399,6 +562,20 @@ func saveRandomFile(filename string, size int64) {
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
+func saveRandomFileMD(filename string, size int64, atime time.Time, accessCnt int64) {
+	saveRandomFile(filename, size)
+	lom := &core.LOM{}
+	err := lom.InitFQN(filename, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+	lom.SetAtimeUnix(atime.UnixNano())
+	if accessCnt > 0 {
+		lom.SetCustomKey(cmn.AccessCntObjMD, strconv.FormatInt(accessCnt, 10))
+	}
+	Expect(lom.Persist()).NotTo(HaveOccurred())
+	lom.Uncache()
+}
+
 func saveRandomFilesWithMetadata(filesPath string, files []fileMetadata) {
 	for _, file := range files {
 		saveRandomFile(path.Join(filesPath, file.name), file.size)
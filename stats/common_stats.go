@@ -328,6 +328,8 @@ func (s *coreStats) update(nv cos.NamedVal64) {
 	case KindThroughput:
 		ratomic.AddInt64(&v.Value, nv.Value)
 		ratomic.AddInt64(&v.cumulative, nv.Value)
+	case KindGauge:
+		ratomic.AddInt64(&v.Value, nv.Value) // (+/- delta)
 	case KindCounter, KindSize:
 		ratomic.AddInt64(&v.Value, nv.Value)
 		// - non-empty suffix forces an immediate Tx with no aggregation (see below);
@@ -476,9 +478,9 @@ func (s *coreStats) reset(errorsOnly bool) {
 		case KindThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
 			ratomic.StoreInt64(&v.cumulative, 0)
-		case KindCounter, KindSize, KindComputedThroughput, KindGauge:
+		case KindCounter, KindSize, KindComputedThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
-		default: // KindSpecial - do nothing
+		default: // KindSpecial and KindGauge - do nothing
 		}
 	}
 }
@@ -63,6 +63,11 @@ const (
 	ErrMetadataCount = "err.md.n"
 	ErrIOCount       = "err.io.n"
 
+	// degraded mountpaths: number of transitions to degraded state (counter),
+	// and the current number of degraded mountpaths (gauge) - see fs/health
+	MpathDegradeCount  = "mpath.degrade.n"
+	MpathDegradedCount = "mpath.degraded"
+
 	// target restarted (effectively, boolean)
 	RestartCount = "restart.n"
 
@@ -88,6 +93,18 @@ const (
 	// Downloader
 	DownloadSize = "dl.size"
 
+	// batch GET (multiple objects => single archive; see cmn.GetBatchMsg)
+	GetBatchCount    = "getbatch.n"
+	GetBatchObjCount = "getbatch.obj.n" // number of entries (objects and archived files)
+	GetBatchSize     = "getbatch.size"
+	ErrGetBatchCount = errPrefix + "getbatch.n"
+
+	// object event notifications (webhooks)
+	WebhookCount        = "webhook.n"
+	ErrWebhookCount     = errPrefix + "webhook.n"
+	WebhookDroppedCount = "webhook.dropped.n" // (when the on-disk queue is full)
+	WebhookPendingCount = "webhook.pending"   // KindGauge
+
 	// KindThroughput
 	GetThroughput = "get.bps" // bytes per second
 	PutThroughput = "put.bps" // ditto
@@ -103,6 +120,31 @@ const (
 	LcacheEvictedCount   = core.LcacheEvictedCount
 	LcacheFlushColdCount = core.LcacheFlushColdCount
 
+	WbackPendingCount = core.WbackPendingCount // KindGauge
+
+	ReplPendingCount = core.ReplPendingCount // KindGauge
+	ReplLagLatency   = core.ReplLagLatency
+	ReplCount        = core.ReplCount
+	ReplErrCount     = core.ReplErrCount
+
+	ScrubCorruptedCount     = core.ScrubCorruptedCount
+	ScrubRepairedCount      = core.ScrubRepairedCount
+	ScrubUnrecoverableCount = core.ScrubUnrecoverableCount
+
+	HotGetCount     = core.HotGetCount
+	HotPromoteCount = core.HotPromoteCount
+	HotPromoteSize  = core.HotPromoteSize
+	HotDemoteCount  = core.HotDemoteCount
+
+	RaHitCount   = core.RaHitCount
+	RaHitSize    = core.RaHitSize
+	RaMissCount  = core.RaMissCount
+	RaReadSize   = core.RaReadSize
+	RaEvictCount = core.RaEvictCount
+
+	ShardIdxGetCount   = core.ShardIdxGetCount
+	ShardIdxBuildCount = core.ShardIdxBuildCount
+
 	// variable label used for prometheus disk metrics
 	diskMetricLabel = "disk"
 )
@@ -183,11 +225,12 @@ func (r *Trunner) InitCDF() error {
 func diskMetricName(disk, metric string) string {
 	return fmt.Sprintf("%s.%s.%s", diskMetricLabel, disk, metric)
 }
-func nameRbps(disk string) string { return diskMetricName(disk, "read.bps") }
-func nameRavg(disk string) string { return diskMetricName(disk, "avg.rsize") }
-func nameWbps(disk string) string { return diskMetricName(disk, "write.bps") }
-func nameWavg(disk string) string { return diskMetricName(disk, "avg.wsize") }
-func nameUtil(disk string) string { return diskMetricName(disk, ".util") }
+func nameRbps(disk string) string  { return diskMetricName(disk, "read.bps") }
+func nameRavg(disk string) string  { return diskMetricName(disk, "avg.rsize") }
+func nameWbps(disk string) string  { return diskMetricName(disk, "write.bps") }
+func nameWavg(disk string) string  { return diskMetricName(disk, "avg.wsize") }
+func nameUtil(disk string) string  { return diskMetricName(disk, ".util") }
+func nameAwait(disk string) string { return diskMetricName(disk, "await") }
 
 // log vs idle logic
 func isDiskMetric(name string) bool {
@@ -241,6 +284,9 @@ func (r *Trunner) RegMetrics(node *meta.Snode) {
 	r.reg(node, ErrMetadataCount, KindCounter)
 	r.reg(node, ErrIOCount, KindCounter)
 
+	r.reg(node, MpathDegradeCount, KindCounter)
+	r.reg(node, MpathDegradedCount, KindGauge)
+
 	// streams
 	r.reg(node, StreamsOutObjCount, KindCounter)
 	r.reg(node, StreamsOutObjSize, KindSize)
@@ -254,6 +300,18 @@ func (r *Trunner) RegMetrics(node *meta.Snode) {
 	r.reg(node, DownloadSize, KindSize)
 	r.reg(node, DownloadLatency, KindLatency)
 
+	// batch GET
+	r.reg(node, GetBatchCount, KindCounter)
+	r.reg(node, GetBatchObjCount, KindCounter)
+	r.reg(node, GetBatchSize, KindSize)
+	r.reg(node, ErrGetBatchCount, KindCounter)
+
+	// webhooks
+	r.reg(node, WebhookCount, KindCounter)
+	r.reg(node, ErrWebhookCount, KindCounter)
+	r.reg(node, WebhookDroppedCount, KindCounter)
+	r.reg(node, WebhookPendingCount, KindGauge)
+
 	// dsort
 	r.reg(node, DsortCreationReqCount, KindCounter)
 	r.reg(node, DsortCreationRespCount, KindCounter)
@@ -267,6 +325,25 @@ func (r *Trunner) RegMetrics(node *meta.Snode) {
 	r.reg(node, LcacheCollisionCount, KindCounter)
 	r.reg(node, LcacheEvictedCount, KindCounter)
 	r.reg(node, LcacheFlushColdCount, KindCounter)
+	r.reg(node, WbackPendingCount, KindGauge)
+	r.reg(node, ReplPendingCount, KindGauge)
+	r.reg(node, ReplLagLatency, KindLatency)
+	r.reg(node, ReplCount, KindCounter)
+	r.reg(node, ReplErrCount, KindCounter)
+	r.reg(node, ScrubCorruptedCount, KindCounter)
+	r.reg(node, ScrubRepairedCount, KindCounter)
+	r.reg(node, ScrubUnrecoverableCount, KindCounter)
+	r.reg(node, HotGetCount, KindCounter)
+	r.reg(node, HotPromoteCount, KindCounter)
+	r.reg(node, HotPromoteSize, KindSize)
+	r.reg(node, HotDemoteCount, KindCounter)
+	r.reg(node, RaHitCount, KindCounter)
+	r.reg(node, RaHitSize, KindSize)
+	r.reg(node, RaMissCount, KindCounter)
+	r.reg(node, RaReadSize, KindSize)
+	r.reg(node, RaEvictCount, KindCounter)
+	r.reg(node, ShardIdxGetCount, KindCounter)
+	r.reg(node, ShardIdxBuildCount, KindCounter)
 
 	// Prometheus
 	r.core.initProm(node)
@@ -283,6 +360,7 @@ func (r *Trunner) RegDiskMetrics(node *meta.Snode, disk string) {
 	r.reg(node, nameRavg(disk), KindGauge)
 	r.reg(node, nameWavg(disk), KindGauge)
 	r.reg(node, nameUtil(disk), KindGauge)
+	r.reg(node, nameAwait(disk), KindGauge)
 }
 
 func (r *Trunner) GetStats() (ds *Node) {
@@ -310,6 +388,8 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 		v.Value = stats.Wavg
 		v = s.Tracker[nameUtil(disk)]
 		v.Value = stats.Util
+		v = s.Tracker[nameAwait(disk)]
+		v.Value = stats.Await
 	}
 
 	// 2 copy stats, reset latencies, send via StatsD if configured
@@ -151,6 +151,11 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{}, true)
 	fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{}, true)
+	fs.CSM.Reg(fs.PackType, &fs.PackContentResolver{}, true)
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{}, true)
+	fs.CSM.Reg(fs.ShardIdxType, &fs.ShardIdxContentResolver{}, true)
+	fs.CSM.Reg(fs.NameIdxType, &fs.NameIdxContentResolver{}, true)
+	fs.CSM.Reg(fs.SnapMetaType, &fs.SnapMetaContentResolver{}, true)
 
 	dir := t.TempDir()
 
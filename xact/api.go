@@ -62,6 +62,7 @@ type (
 		Buckets     []cmn.Bck     // list of buckets (e.g., copy-bucket, lru-evict, etc.)
 		Timeout     time.Duration // max time to wait
 		Force       bool          // force
+		DryRun      bool          // e.g., lru-evict: report but do not evict
 		OnlyRunning bool          // only for running xactions
 	}
 
@@ -140,6 +141,8 @@ var Table = map[string]Descriptor{
 	apc.ActECPut:     {Scope: ScopeB, Startable: false, RefreshCap: true, Idles: true, ExtendedStats: true},
 	apc.ActECRespond: {Scope: ScopeB, Startable: false, Idles: true},
 	apc.ActPutCopies: {Scope: ScopeB, Startable: false, RefreshCap: true, Idles: true},
+	apc.ActWriteBack: {Scope: ScopeB, Startable: false, Idles: true},
+	apc.ActReplicate: {Scope: ScopeB, Startable: false, Idles: true},
 
 	//
 	// on-demand multi-object (consider setting ConflictRebRes = true)
@@ -227,6 +230,23 @@ var Table = map[string]Descriptor{
 		Metasync:    true,
 		RefreshCap:  true,
 	},
+	apc.ActCreateSnap: {
+		DisplayName:    "snapshot-bucket",
+		Scope:          ScopeB,
+		Access:         apc.AccessRW,
+		Startable:      false, // via `api.CreateSnapshot`
+		Metasync:       true,
+		RefreshCap:     true,
+		ConflictRebRes: true,
+	},
+	apc.ActRestoreSnap: {
+		DisplayName:    "restore-snapshot",
+		Scope:          ScopeB,
+		Access:         apc.AccessRW,
+		Startable:      false, // via `api.RestoreSnapshot`
+		RefreshCap:     true,
+		ConflictRebRes: true,
+	},
 	apc.ActMoveBck: {
 		DisplayName:    "rename-bucket",
 		Scope:          ScopeB,
@@ -254,9 +274,36 @@ var Table = map[string]Descriptor{
 		RefreshCap:  true,
 		AbortRebRes: true,
 	},
+	apc.ActRenamePrefix: {
+		DisplayName:    "rename-prefix",
+		Scope:          ScopeB,
+		Access:         apc.AceObjMOVE,
+		Startable:      false, // via `api.RenamePrefix`
+		Metasync:       true,
+		RefreshCap:     true,
+		ConflictRebRes: true,
+	},
 
 	apc.ActList: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false, Metasync: false, Idles: true},
 
+	// move cold objects to the bucket's (remote) tier
+	apc.ActTier: {DisplayName: "tier", Scope: ScopeB, Access: apc.AccessRW, Startable: true, RefreshCap: true},
+
+	// replicate (async) objects that are missing or differ in the remote AIS destination
+	apc.ActReplResync: {DisplayName: "resync-replica", Scope: ScopeB, Access: apc.AccessRW, Startable: true},
+
+	// verify checksums and self-heal corrupted objects (from mirror copies or EC slices)
+	apc.ActScrub: {DisplayName: "scrub", Scope: ScopeB, Access: apc.AccessRW, Startable: true},
+
+	// encryption at rest: rotate data key and re-encrypt (or decrypt) existing objects
+	apc.ActReencrypt: {DisplayName: "re-encrypt", Scope: ScopeB, Access: apc.AccessRW, Startable: true},
+
+	// (re)build persistent name index used to list objects (see cmn.NameIdxConf)
+	apc.ActNameIdx: {DisplayName: "name-index", Scope: ScopeB, Access: apc.AccessRW, Startable: true},
+
+	// write bucket listing as CSV, JSONL, or Parquet object(s) (see cmn.ExportListMsg)
+	apc.ActExportList: {DisplayName: "export-list", Scope: ScopeB, Access: apc.AceObjLIST | apc.AcePUT, Startable: true},
+
 	// cache management, internal usage
 	apc.ActLoadLomCache:   {DisplayName: "warm-up-metadata", Scope: ScopeB, Startable: true},
 	apc.ActInvalListCache: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false},
@@ -43,6 +43,9 @@ type (
 		Tag    string
 		Copies int
 	}
+	SnapArgs struct {
+		Snap *meta.Bck // virtual (read-only) snapshot bucket, e.g. ais://data@snap1
+	}
 )
 
 //////////////
@@ -102,6 +105,11 @@ func RenewBckMakeNCopies(bck *meta.Bck, uuid, tag string, copies int) (res Renew
 	return dreg.renew(e, bck)
 }
 
+// create or restore bucket snapshot (see cmn/snap.go)
+func RenewSnap(kind, uuid string, bck, snap *meta.Bck) RenewRes {
+	return RenewBucketXact(kind, bck, Args{Custom: &SnapArgs{Snap: snap}, UUID: uuid})
+}
+
 func RenewPromote(uuid string, bck *meta.Bck, args *apc.PromoteArgs) RenewRes {
 	return RenewBucketXact(apc.ActPromote, bck, Args{Custom: args, UUID: uuid})
 }
@@ -110,10 +118,46 @@ func RenewBckLoadLomCache(uuid string, bck *meta.Bck) RenewRes {
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{UUID: uuid})
 }
 
+func RenewTier(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActTier, bck, Args{UUID: uuid})
+}
+
+func RenewScrub(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActScrub, bck, Args{UUID: uuid})
+}
+
+func RenewReencrypt(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActReencrypt, bck, Args{UUID: uuid})
+}
+
+func RenewNameIdx(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActNameIdx, bck, Args{UUID: uuid})
+}
+
+func RenewExportList(uuid string, bck *meta.Bck, msg *cmn.ExportListMsg) RenewRes {
+	return RenewBucketXact(apc.ActExportList, bck, Args{UUID: uuid, Custom: msg})
+}
+
+func RenewRenamePrefix(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActRenamePrefix, bck, Args{UUID: uuid})
+}
+
 func RenewPutMirror(lom *core.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{Custom: lom})
 }
 
+func RenewWback(lom *core.LOM) RenewRes {
+	return RenewBucketXact(apc.ActWriteBack, lom.Bck(), Args{})
+}
+
+func RenewRepl(lom *core.LOM) RenewRes {
+	return RenewBucketXact(apc.ActReplicate, lom.Bck(), Args{})
+}
+
+func RenewReplResync(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActReplResync, bck, Args{UUID: uuid})
+}
+
 func RenewTCB(uuid, kind string, custom *TCBArgs) RenewRes {
 	return RenewBucketXact(
 		kind,
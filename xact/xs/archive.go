@@ -146,13 +146,14 @@ func (r *XactArch) Begin(msg *cmn.ArchiveBckMsg, archlom *core.LOM) (err error)
 	if core.T.SID() == wi.tsi.ID() {
 		var (
 			s           string
-			lmfh        *os.File
+			size        int64
+			lmfh        io.ReadCloser
 			finfo, errX = os.Stat(wi.archlom.FQN)
 			exists      = errX == nil
 		)
 		if exists && wi.msg.AppendIfExists {
 			s = " append"
-			lmfh, err = wi.beginAppend()
+			lmfh, size, err = wi.beginAppend(finfo)
 		} else {
 			wi.wfh, err = wi.archlom.CreateFile(wi.fqn)
 		}
@@ -169,7 +170,7 @@ func (r *XactArch) Begin(msg *cmn.ArchiveBckMsg, archlom *core.LOM) (err error)
 
 		// append case (above)
 		if lmfh != nil {
-			err = wi.writer.Copy(lmfh, finfo.Size())
+			err = wi.writer.Copy(lmfh, size)
 			if err != nil {
 				wi.writer.Fini()
 				wi.cleanup()
@@ -429,18 +430,33 @@ func (r *XactArch) Snap() (snap *core.Snap) {
 // archwi //
 ////////////
 
-func (wi *archwi) beginAppend() (lmfh *os.File, err error) {
-	msg := wi.msg
-	if msg.Mime == archive.ExtTar {
+func (wi *archwi) beginAppend(finfo os.FileInfo) (lmfh io.ReadCloser, size int64, err error) {
+	var (
+		msg     = wi.msg
+		clom    = core.AllocLOM(wi.archlom.ObjName) // (not to modify archlom md)
+		encoded bool
+	)
+	defer core.FreeLOM(clom)
+	// compressed, encrypted, packed, or chunked at rest (see core/lcodec.go)
+	if clom.InitBck(wi.archlom.Bucket()) == nil && clom.Load(false /*cache it*/, false /*locked*/) == nil {
+		encoded = !clom.IsRaw()
+	}
+	if msg.Mime == archive.ExtTar && !encoded && !fs.IsHardLinked(wi.archlom.FQN) {
 		if err = wi.openTarForAppend(); err == nil || err != archive.ErrTarIsEmpty {
 			return
 		}
 	}
 	// msg.Mime has been already validated (see ais/* for apc.ActArchive)
 	// prep to copy `lmfh` --> `wi.fh` with subsequent APPEND-ing
-	lmfh, err = os.Open(wi.archlom.FQN)
+	if encoded {
+		size = clom.SizeBytes()
+		lmfh, err = clom.Open()
+	} else {
+		size = finfo.Size()
+		lmfh, err = os.Open(wi.archlom.FQN)
+	}
 	if err != nil {
-		return
+		return nil, 0, err
 	}
 	if wi.wfh, err = wi.archlom.CreateFile(wi.fqn); err != nil {
 		cos.Close(lmfh)
@@ -501,9 +517,17 @@ func (wi *archwi) do(lom *core.LOM, lrit *lriterator) {
 			wi.r.AddErr(err, 5, cos.SmoduleXs)
 			return
 		}
+	} else if lom.IsTiered() {
+		lom.Lock(true)
+		_, err := lom.Rehydrate(context.Background())
+		lom.Unlock(true)
+		if err != nil {
+			wi.r.AddErr(err, 5, cos.SmoduleXs)
+			return
+		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open()
 	if err != nil {
 		wi.r.AddErr(err, 5, cos.SmoduleXs)
 		return
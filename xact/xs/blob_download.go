@@ -50,6 +50,7 @@ type (
 		lom        *core.LOM
 		expCksum   *cos.Cksum
 		lmfh       *os.File
+		cw         *core.ChunkWriter // instead of `lmfh` when storing in chunks (see core/lchunk.go)
 		wfqn       string
 		chunkSize  int64
 		fullSize   int64
@@ -94,11 +95,10 @@ var (
 	_ xreg.Renewable = (*blobFactory)(nil)
 )
 
-func RenewBlobDl(xid string, lom *core.LOM, oa *cmn.ObjAttrs, wfqn string, lmfh *os.File, msg *apc.BlobMsg, w http.ResponseWriter) xreg.RenewRes {
+func RenewBlobDl(xid string, lom *core.LOM, oa *cmn.ObjAttrs, wfqn string, msg *apc.BlobMsg, w http.ResponseWriter) xreg.RenewRes {
 	args := &blobArgs{
 		w:          w,
 		lom:        lom,
-		lmfh:       lmfh,
 		wfqn:       wfqn,
 		chunkSize:  msg.ChunkSize,
 		numWorkers: msg.NumWorkers,
@@ -209,6 +209,15 @@ func (p *blobFactory) Start() error {
 		p.args.numWorkers++
 	}
 
+	// work file or, when storing large objects in chunks, chunk writer
+	if p.args.cw = p.args.lom.NewChunkWriter(p.args.wfqn, p.args.fullSize); p.args.cw == nil {
+		lmfh, err := p.args.lom.CreateFile(p.args.wfqn)
+		if err != nil {
+			return err
+		}
+		p.args.lmfh = lmfh
+	}
+
 	// init and allocate
 	r.readers = make([]*blobReader, p.args.numWorkers)
 	r.sgls = make([]*memsys.SGL, p.args.numWorkers)
@@ -225,7 +234,11 @@ func (p *blobFactory) Start() error {
 		r.cksum.Init(ty)
 		ws = append(ws, r.cksum.H)
 	}
-	ws = append(ws, p.args.lmfh)
+	if p.args.cw != nil {
+		ws = append(ws, p.args.cw)
+	} else {
+		ws = append(ws, p.args.lmfh)
+	}
 	if p.args.w != nil {
 		// and transmit concurrently (alternatively,
 		// could keep writing locally even after GET client goes away)
@@ -348,10 +361,20 @@ outer:
 	}
 fin:
 	close(r.workCh)
-	if err == nil && cmn.Rom.Features().IsSet(feat.FsyncPUT) {
-		err = r.p.args.lmfh.Sync()
+	if cw := r.p.args.cw; cw != nil {
+		if err == nil {
+			if err = cw.Finish(); err != nil {
+				cw.Abort()
+			}
+		} else {
+			cw.Abort()
+		}
+	} else {
+		if err == nil && cmn.Rom.Features().IsSet(feat.FsyncPUT) {
+			err = r.p.args.lmfh.Sync()
+		}
+		cos.Close(r.p.args.lmfh)
 	}
-	cos.Close(r.p.args.lmfh)
 
 	if err == nil {
 		if r.p.args.fullSize != r.woff {
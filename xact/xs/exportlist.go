@@ -0,0 +1,261 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/tabular"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// export bucket listing (see cmn.ExportListMsg): each target walks the bucket (all mountpaths
+// in parallel, merge-sorted by name), writes the objects it is responsible for into a local
+// workfile in the requested format, and then promotes the latter as an object in the
+// destination bucket - one object per target unless the max number of rows is exceeded;
+// (for remote buckets, only the objects present in the cluster get listed)
+
+const expWorkPrefix = "export-list-"
+
+type (
+	expFactory struct {
+		xreg.RenewBase
+		xctn *XactExportList
+		msg  *cmn.ExportListMsg
+	}
+	XactExportList struct {
+		msg    *cmn.ExportListMsg
+		bckTo  *meta.Bck
+		wi     *walkInfo
+		config *cmn.Config
+		cols   []tabular.Column
+		row    []string
+		// current output
+		w   tabular.Writer
+		bw  *bufio.Writer
+		fh  *os.File
+		fqn string
+		n   int   // sequence number
+		num int64 // rows written
+		xact.Base
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactExportList)(nil)
+	_ xreg.Renewable = (*expFactory)(nil)
+)
+
+////////////////
+// expFactory //
+////////////////
+
+func (*expFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	msg := args.Custom.(*cmn.ExportListMsg)
+	p := &expFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, msg: msg}
+	return p
+}
+
+func (p *expFactory) Start() error {
+	if err := p.msg.Validate(p.Bck.Bucket()); err != nil {
+		return err
+	}
+	bckTo := meta.CloneBck(&p.msg.ToBck)
+	if err := bckTo.Init(core.T.Bowner()); err != nil {
+		return err
+	}
+	r := &XactExportList{msg: p.msg, bckTo: bckTo, config: cmn.GCO.Get(), cols: p.msg.Columns()}
+	r.row = make([]string, len(r.cols))
+	lsmsg := &apc.LsoMsg{Props: p.msg.Props, Prefix: p.msg.Prefix, TimeFormat: time.RFC3339Nano}
+	r.wi = newWalkInfo(lsmsg, noopCb)
+	r.InitBase(p.UUID(), apc.ActExportList, p.Bck)
+	p.xctn = r
+	return nil
+}
+
+func (*expFactory) Kind() string     { return apc.ActExportList }
+func (p *expFactory) Get() core.Xact { return p.xctn }
+
+func (*expFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprKeepAndStartNew, nil
+}
+
+////////////////////
+// XactExportList //
+////////////////////
+
+func (r *XactExportList) Run(wg *sync.WaitGroup) {
+	wg.Done()
+	nlog.Infoln(r.Name(), "=>", r.bckTo.Cname(r.msg.ObjName+"/"))
+
+	opts := &fs.WalkBckOpts{
+		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: r.msg.Prefix, Sorted: true},
+	}
+	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
+	opts.ValidateCallback = r.validateCb
+	err := fs.WalkBck(opts)
+	if err == nil || err == filepath.SkipDir {
+		err = r.flush()
+	}
+	if err != nil {
+		r.AddErr(err)
+		r.cleanup()
+	}
+	r.Finish()
+}
+
+func (r *XactExportList) validateCb(fqn string, de fs.DirEntry) error {
+	if !de.IsDir() {
+		return nil
+	}
+	return r.wi.processDir(fqn)
+}
+
+func (r *XactExportList) cb(fqn string, de fs.DirEntry) error {
+	if r.IsAborted() {
+		return cmn.NewErrAborted(r.Name(), "", r.AbortErr())
+	}
+	entry, err := r.wi.callback(fqn, de)
+	if err != nil || entry == nil {
+		return err
+	}
+	for i, col := range r.cols {
+		switch col.Name {
+		case apc.GetPropsName:
+			r.row[i] = entry.Name
+		case apc.GetPropsSize:
+			r.row[i] = strconv.FormatInt(entry.Size, 10)
+		case apc.GetPropsChecksum:
+			r.row[i] = entry.Checksum
+		case apc.GetPropsVersion:
+			r.row[i] = entry.Version
+		case apc.GetPropsAtime:
+			r.row[i] = entry.Atime
+		case apc.GetPropsCustom:
+			r.row[i] = entry.Custom
+		case apc.GetPropsLocation:
+			r.row[i] = entry.Location
+		}
+	}
+	if r.w == nil {
+		if err := r.open(); err != nil {
+			return err
+		}
+	}
+	if err := r.w.Write(r.row); err != nil {
+		return err
+	}
+	r.ObjsAdd(1, entry.Size)
+	r.num++
+	if r.msg.MaxRows > 0 && r.num >= r.msg.MaxRows {
+		return r.flush()
+	}
+	return nil
+}
+
+// new output workfile
+func (r *XactExportList) open() (err error) {
+	mi, _, err := fs.Hrw(r.ID() + strconv.Itoa(r.n))
+	if err != nil {
+		return err
+	}
+	r.fqn = mi.MakePathFQN(r.bckTo.Bucket(), fs.WorkfileType, expWorkPrefix+r.ID()+"-"+strconv.Itoa(r.n))
+	if err = cos.CreateDir(filepath.Dir(r.fqn)); err != nil {
+		return err
+	}
+	if r.fh, err = os.OpenFile(r.fqn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, cos.PermRWR); err != nil {
+		return err
+	}
+	if r.bw == nil {
+		r.bw = bufio.NewWriterSize(r.fh, 64*cos.KiB)
+	} else {
+		r.bw.Reset(r.fh)
+	}
+	if r.w, err = tabular.NewWriter(r.bw, r.msg.Format, r.cols); err != nil {
+		r.cleanup()
+	}
+	return err
+}
+
+// finalize the current output (if any) and promote it into the destination bucket
+func (r *XactExportList) flush() error {
+	if r.w == nil {
+		return nil
+	}
+	err := r.w.Close()
+	if err == nil {
+		err = r.bw.Flush()
+	}
+	if errC := r.fh.Close(); err == nil {
+		err = errC
+	}
+	r.w, r.fh = nil, nil
+	if err != nil {
+		return err
+	}
+	var (
+		objName = r.msg.OutName(core.T.SID(), r.n)
+		params  = core.PromoteParams{
+			Bck:    r.bckTo,
+			Config: r.config,
+			PromoteArgs: apc.PromoteArgs{
+				SrcFQN:         r.fqn,
+				ObjName:        objName,
+				OverwriteDst:   true,
+				DeleteSrc:      true,
+				SrcIsNotFshare: true,
+			},
+		}
+	)
+	fi, err := os.Stat(r.fqn)
+	if err != nil {
+		return err
+	}
+	if _, err := core.T.Promote(&params); err != nil {
+		return fmt.Errorf("%s: failed to write %s: %w", r, r.bckTo.Cname(objName), err)
+	}
+	r.OutObjsAdd(1, fi.Size())
+	r.fqn = ""
+	r.n++
+	r.num = 0
+	return nil
+}
+
+func (r *XactExportList) cleanup() {
+	if r.fh != nil {
+		r.fh.Close()
+		r.fh, r.w = nil, nil
+	}
+	if r.fqn != "" {
+		if err := os.Remove(r.fqn); err != nil && !os.IsNotExist(err) {
+			nlog.Errorln(r.Name()+":", err)
+		}
+		r.fqn = ""
+	}
+}
+
+func (r *XactExportList) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -30,6 +30,18 @@ func Xreg(xeleOnly bool) {
 
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
+	xreg.RegBckXact(&tierFactory{})
+	xreg.RegBckXact(&wbFactory{})
+	xreg.RegBckXact(&replFactory{})
+	xreg.RegBckXact(&resyncFactory{})
+	xreg.RegBckXact(&scrubFactory{})
+	xreg.RegBckXact(&reencFactory{})
+	xreg.RegBckXact(&renpfxFactory{})
+	xreg.RegBckXact(&nidxFactory{})
+	xreg.RegBckXact(&expFactory{})
+
+	xreg.RegBckXact(&snapFactory{kind: apc.ActCreateSnap})
+	xreg.RegBckXact(&snapFactory{kind: apc.ActRestoreSnap})
 
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActCopyBck})
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActETLBck})
@@ -0,0 +1,259 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+// bucket inventory: list remote bucket by paging through its manifest - a tabular listing
+// (CSV, JSONL, or Parquet) stored as an object in any accessible bucket (see cmn.InvConf)
+//
+// - the manifest is fetched (via regular intra-cluster GET, including cold GET) and stored
+//   locally as a workfile - once per listing;
+// - continuation token is the (opaque) position of the next row in the manifest;
+// - used by list-objects (the designated target pages and broadcasts), and
+//   by multi-object prefetch and copy (each target pages on its own - see lrit.go)
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/tabular"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+const (
+	invWorkPrefix = "inventory-"
+	invMaxErrBody = 4 * cos.KiB
+)
+
+// column indices (see cmn.InvColumns.Cols)
+const (
+	invColName = iota
+	invColSize
+	invColCksum
+	invColVersion
+	invColETag
+	invColMtime
+)
+
+func invEnabled(bck *meta.Bck) bool { return bck.Props != nil && bck.Props.Inventory.Enabled }
+
+// next page from the bucket's manifest
+func (npg *npgCtx) nextPageInv(lst *cmn.LsoResult) error {
+	var (
+		inv = &npg.bck.Props.Inventory
+		ctx = npg.ctx
+		msg = npg.wi.msg
+		pos int64
+	)
+	if msg.ContinuationToken != "" {
+		var err error
+		if pos, err = strconv.ParseInt(msg.ContinuationToken, 10, 64); err != nil || pos < 0 {
+			return fmt.Errorf("%s: invalid inventory continuation token %q", npg.bck.Cname(""), msg.ContinuationToken)
+		}
+	}
+	if ctx.FQN == "" {
+		if err := getManifest(npg.bck, inv, msg.UUID, ctx); err != nil {
+			return err
+		}
+	}
+	fh, err := os.Open(ctx.FQN)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	format, _ := inv.Fmt()
+	r, err := tabular.NewReader(fh, ctx.Size, format, inv.Columns.Cols(), pos)
+	if err != nil {
+		return fmt.Errorf("%s: inventory %s: %w", npg.bck.Cname(""), inv, err)
+	}
+	pageSize := msg.PageSize
+	if pageSize == 0 || pageSize > apc.MaxPageSizeAIS {
+		pageSize = apc.MaxPageSizeAIS
+	}
+	var idx int
+	for uint(idx) < pageSize {
+		row, err := r.Next()
+		if err == io.EOF {
+			lst.Entries = lst.Entries[:idx]
+			lst.ContinuationToken = ""
+			ctx.Offset = r.Pos()
+			return nil
+		}
+		if err != nil {
+			lst.Entries = lst.Entries[:idx]
+			return fmt.Errorf("%s: inventory %s: %w", npg.bck.Cname(""), inv, err)
+		}
+		name := row[invColName]
+		if name == "" || !strings.HasPrefix(name, msg.Prefix) {
+			continue
+		}
+		var entry *cmn.LsoEntry
+		if idx < len(lst.Entries) {
+			entry = lst.Entries[idx]
+			*entry = cmn.LsoEntry{Name: name}
+		} else {
+			entry = &cmn.LsoEntry{Name: name}
+			lst.Entries = append(lst.Entries, entry)
+		}
+		idx++
+		if err := npg.invEntry(entry, row); err != nil {
+			lst.Entries = lst.Entries[:idx]
+			return fmt.Errorf("%s: inventory %s: %q: %w", npg.bck.Cname(""), inv, name, err)
+		}
+	}
+	lst.Entries = lst.Entries[:idx]
+	ctx.Offset = r.Pos()
+	lst.ContinuationToken = strconv.FormatInt(ctx.Offset, 10)
+	return nil
+}
+
+func (npg *npgCtx) invEntry(entry *cmn.LsoEntry, row []string) (err error) {
+	msg := npg.wi.msg
+	if v := row[invColSize]; v != "" && !msg.IsFlagSet(apc.LsNameOnly) {
+		if entry.Size, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("invalid size %q", v)
+		}
+	}
+	if msg.IsFlagSet(apc.LsNameOnly) || msg.IsFlagSet(apc.LsNameSize) {
+		return nil
+	}
+	if msg.WantProp(apc.GetPropsChecksum) {
+		entry.Checksum = row[invColCksum]
+	}
+	if msg.WantProp(apc.GetPropsVersion) {
+		entry.Version = row[invColVersion]
+	}
+	if msg.WantProp(apc.GetPropsCustom) {
+		var custom cos.StrKVs
+		if v := row[invColETag]; v != "" {
+			custom = cos.StrKVs{cmn.ETag: v}
+		}
+		if v := row[invColMtime]; v != "" {
+			if custom == nil {
+				custom = make(cos.StrKVs, 1)
+			}
+			custom[cmn.LastModified] = v
+		}
+		if custom != nil {
+			entry.Custom = cmn.CustomMD2S(custom)
+		}
+	}
+	return nil
+}
+
+// fetch the manifest and store it locally (decompressed, if need be)
+func getManifest(bck *meta.Bck, inv *cmn.InvConf, uuid string, ctx *core.LsoInventoryCtx) error {
+	mbck := meta.CloneBck(inv.ManifestBck(bck.Bucket()))
+	if err := mbck.Init(core.T.Bowner()); err != nil {
+		return fmt.Errorf("%s: inventory bucket: %w", bck.Cname(""), err)
+	}
+	var (
+		uname    = mbck.MakeUname(inv.Name)
+		smap     = core.T.Sowner().Get()
+		tsi, err = smap.HrwName2T(uname)
+	)
+	if err != nil {
+		return err
+	}
+	mi, _, err := fs.Hrw(uuid)
+	if err != nil {
+		return err
+	}
+	fqn := mi.MakePathFQN(bck.Bucket(), fs.WorkfileType, invWorkPrefix+uuid)
+
+	// intra-cluster GET from the manifest's (HRW) target, possibly this one
+	config := cmn.GCO.Get()
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodGet
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{core.T.SID()},
+			apc.HdrCallerName: []string{core.T.String()},
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(mbck.Name, inv.Name)
+		reqArgs.Query = mbck.NewQuery()
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(config.Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	resp, err := core.T.DataClient().Do(req) //nolint:bodyclose // closed below
+	if err != nil {
+		return err
+	}
+	defer cos.Close(resp.Body)
+	if code := resp.StatusCode; code != http.StatusOK {
+		if code == http.StatusNotFound {
+			return cos.NewErrNotFound(core.T, "inventory "+mbck.Cname(inv.Name))
+		}
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, invMaxErrBody))
+		if herr := cmn.Str2HTTPErr(string(b)); herr != nil {
+			return herr
+		}
+		return fmt.Errorf("%s: failed to get inventory %s: %s responded with status %d",
+			core.T, mbck.Cname(inv.Name), tsi.StringEx(), code)
+	}
+
+	var r io.Reader = resp.Body
+	if _, gz := inv.Fmt(); gz {
+		gzr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("%s: inventory %s: %w", core.T, mbck.Cname(inv.Name), err)
+		}
+		defer gzr.Close()
+		r = gzr
+	}
+	if err := cos.CreateDir(filepath.Dir(fqn)); err != nil {
+		return err
+	}
+	wfh, err := os.OpenFile(fqn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	buf, slab := core.T.PageMM().Alloc()
+	size, err := cos.CopyBuffer(wfh, r, buf)
+	slab.Free(buf)
+	if errC := wfh.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		if errR := os.Remove(fqn); errR != nil && !os.IsNotExist(errR) {
+			nlog.Errorln(core.T.String()+":", errR)
+		}
+		return fmt.Errorf("%s: inventory %s: %w", core.T, mbck.Cname(inv.Name), err)
+	}
+	ctx.FQN, ctx.Size, ctx.Offset = fqn, size, 0
+	if cmn.Rom.FastV(4, cos.SmoduleXs) {
+		nlog.Infoln(core.T.String()+": inventory", mbck.Cname(inv.Name), "=>", fqn, "size", size)
+	}
+	return nil
+}
+
+// remove the local copy of the manifest
+func freeInvCtx(ctx *core.LsoInventoryCtx) {
+	if ctx == nil || ctx.FQN == "" {
+		return
+	}
+	if err := os.Remove(ctx.FQN); err != nil && !os.IsNotExist(err) {
+		nlog.Errorln(core.T.String()+":", err)
+	}
+	ctx.FQN = ""
+}
@@ -160,8 +160,9 @@ func (r *lriterator) _prefix(wi lrwi, smap *meta.Smap) error {
 		errCode int
 		lst     *cmn.LsoResult
 		msg     = &apc.LsoMsg{Prefix: r.prefix, Props: apc.GetPropsStatus}
-		npg     = newNpgCtx(r.bck, msg, noopCb, nil) // TODO -- FIXME: inventory offset
+		npg     *npgCtx
 		bremote = r.bck.IsRemote()
+		binv    bool
 	)
 	if err := r.bck.Init(core.T.Bowner()); err != nil {
 		return err
@@ -169,14 +170,31 @@ func (r *lriterator) _prefix(wi lrwi, smap *meta.Smap) error {
 	if !bremote {
 		smap = nil // not needed
 	}
+	// page through the bucket's manifest, if configured (see linv.go)
+	if binv = bremote && invEnabled(r.bck); binv {
+		msg.UUID = cos.GenUUID()
+		msg.SetFlag(apc.LsInventory)
+		npg = newNpgCtx(r.bck, msg, noopCb, &core.LsoInventoryCtx{})
+		defer freeInvCtx(npg.ctx)
+	} else {
+		npg = newNpgCtx(r.bck, msg, noopCb, nil)
+	}
 	for {
 		if r.done() {
 			break
 		}
-		if bremote {
+		switch {
+		case binv:
+			npg.wi.msg.ContinuationToken = msg.ContinuationToken
+			lst, err = npg.nextPageR(allocLsoEntries(), false /*load LOMs to include status and local MD*/)
+			if err != nil {
+				nlog.Errorln(core.T.String()+":", err)
+				return err
+			}
+		case bremote:
 			lst = &cmn.LsoResult{Entries: allocLsoEntries()}
-			errCode, err = core.T.Backend(r.bck).ListObjects(r.bck, msg, lst) // (TODO comment above)
-		} else {
+			errCode, err = core.T.Backend(r.bck).ListObjects(r.bck, msg, lst)
+		default:
 			npg.page.Entries = allocLsoEntries()
 			err = npg.nextPageA()
 			lst = &npg.page
@@ -58,6 +58,7 @@ type (
 			done         bool               // done walking (indication)
 			wor          bool               // wantOnlyRemote
 			dontPopulate bool               // when listing remote obj-s: don't include local MD (in re: LsDonAddRemote)
+			renpfx       *cmn.PrefixRename  // virtual directory rename in progress, if any (see cmn.PrefixRename)
 			this         bool               // r.msg.SID == core.T.SID(): true when this target does remote paging
 		}
 		streamingX
@@ -69,6 +70,7 @@ type (
 		Lst    *cmn.LsoResult
 		Status int
 	}
+	nidxDirent struct{} // (names from the name index are never directories)
 )
 
 const (
@@ -85,8 +87,11 @@ var (
 var (
 	_ core.Xact      = (*LsoXact)(nil)
 	_ xreg.Renewable = (*lsoFactory)(nil)
+	_ fs.DirEntry    = nidxDirent{}
 )
 
+func (nidxDirent) IsDir() bool { return false }
+
 func (*lsoFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
 	p := &lsoFactory{
 		streamingF: streamingF{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, kind: apc.ActList},
@@ -242,6 +247,7 @@ func (r *LsoXact) stop() {
 		freeLsoEntries(r.lastPage)
 		r.lastPage = nil
 	}
+	freeInvCtx(r.ctx)
 }
 
 func (r *LsoXact) lastmsg() {
@@ -412,6 +418,7 @@ ex:
 		r.walk.done = true
 		r.resetIdle()
 	}
+	npg.filter(page) // (after having broadcast the entire page)
 	freeLsoEntries(r.lastPage)
 	r.lastPage = page.Entries
 	r.nextToken = page.ContinuationToken
@@ -533,21 +540,89 @@ func (r *LsoXact) shiftLastPage(token string) {
 }
 
 func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
+	var it *fs.NameIter
 	r.walk.wi = newWalkInfo(msg, r.LomAdd)
-	opts := &fs.WalkBckOpts{
-		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: msg.Prefix, Sorted: true},
+	r.walk.renpfx = nil
+	if bprops, ok := core.T.Bowner().Get().Get(r.Bck()); ok {
+		r.walk.renpfx = bprops.RenPrefix
+		it = r.nidxIter(bprops, msg)
 	}
-	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
-	opts.ValidateCallback = r.validateCb
-	if err := fs.WalkBck(opts); err != nil {
-		if err != filepath.SkipDir && err != errStopped {
+	if it != nil {
+		if err := r.walkIdx(it, msg); err != nil && err != errStopped {
 			r.AddErr(err, 0)
 		}
+		it.Close()
+	} else {
+		opts := &fs.WalkBckOpts{
+			WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: msg.Prefix, Sorted: true},
+		}
+		opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
+		opts.ValidateCallback = r.validateCb
+		if err := fs.WalkBck(opts); err != nil {
+			if err != filepath.SkipDir && err != errStopped {
+				r.AddErr(err, 0)
+			}
+		}
 	}
 	close(r.walk.pageCh)
 	r.walk.wg.Done()
 }
 
+// returns name index iterator positioned at the prefix or continuation token, whichever
+// is greater, or nil when there's no index yet (in which case the index gets built
+// in the background); non-recursive listing (that doesn't go deeper than one level)
+// and listing misplaced objects (that are not indexed) always walk the filesystem
+func (r *LsoXact) nidxIter(bprops *cmn.Bprops, msg *apc.LsoMsg) *fs.NameIter {
+	if !bprops.NameIdx.Enabled || msg.IsFlagSet(apc.LsNoRecursion) || msg.IsFlagSet(apc.LsMissing) {
+		return nil
+	}
+	ni := fs.NameIdxOf(r.Bck().Bucket())
+	if ni == nil {
+		if rns := xreg.RenewNameIdx(cos.GenUUID(), r.Bck()); rns.Err != nil {
+			nlog.Warningln(r.Name(), "failed to start building name index:", rns.Err)
+		}
+		return nil
+	}
+	if !ni.Ready() {
+		return nil // being built
+	}
+	it, err := ni.Iter(max(msg.Prefix, msg.ContinuationToken))
+	if err != nil {
+		nlog.Warningln(r.Name(), err)
+		return nil
+	}
+	return it
+}
+
+// page from the name index (see fs/nameidx.go)
+func (r *LsoXact) walkIdx(it *fs.NameIter, msg *apc.LsoMsg) error {
+	bck := r.Bck().Bucket()
+	for {
+		name, ok, err := it.Next()
+		if err != nil || !ok {
+			return err
+		}
+		if !cmn.ObjHasPrefix(name, msg.Prefix) {
+			return nil // (sorted)
+		}
+		select {
+		case <-r.walk.stopCh.Listen():
+			return errStopped
+		default:
+		}
+		lom := core.AllocLOM(name)
+		err = lom.InitBck(bck)
+		fqn := lom.FQN
+		core.FreeLOM(lom)
+		if err != nil {
+			return err
+		}
+		if err := r.cb(fqn, nidxDirent{}); err != nil {
+			return err
+		}
+	}
+}
+
 func (r *LsoXact) validateCb(fqn string, de fs.DirEntry) error {
 	if !de.IsDir() {
 		return nil
@@ -587,6 +662,9 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	if entry.Name <= msg.StartAfter {
 		return nil
 	}
+	if r.walk.renpfx != nil && r.walk.renpfx.Hidden(entry.Name) {
+		return nil
+	}
 	if r.walk.wi.msg.IsFlagSet(apc.LsNoRecursion) {
 		// Check if the object is nested deeper than requested.
 		// Note that it'd be incorrect to return `SkipDir` in this case.
@@ -609,7 +687,7 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 
 	// ls arch
 	// looking only at the file extension - not reading ("detecting") file magic (TODO: add lsmsg flag)
-	archList, err := archive.List(fqn)
+	archList, err := lsArch(fqn)
 	if err != nil {
 		if archive.IsErrUnknownFileExt(err) {
 			// skip and keep going
@@ -688,3 +766,20 @@ func (r *LsoXact) _recv(hdr *transport.ObjHdr, objReader io.Reader, buf []byte)
 	}
 	return
 }
+
+// (compare w/ archive.List)
+func lsArch(fqn string) ([]*archive.Entry, error) {
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if lom.InitFQN(fqn, nil) != nil || lom.Load(true /*cache it*/, false /*locked*/) != nil || lom.IsRaw() {
+		return archive.List(fqn)
+	}
+	// compressed, encrypted, or chunked at rest (see core/lcodec.go)
+	fh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	lst, err := archive.ListReader(fh, fqn, lom.SizeBytes())
+	cos.Close(fh)
+	return lst, err
+}
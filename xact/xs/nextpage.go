@@ -30,6 +30,7 @@ func newNpgCtx(bck *meta.Bck, msg *apc.LsoMsg, cb lomVisitedCb, ctx *core.LsoInv
 		wi: walkInfo{
 			msg:          msg.Clone(),
 			lomVisitedCb: cb,
+			filter:       lsoFilter(msg),
 			wanted:       wanted(msg),
 			smap:         core.T.Sowner().Get(),
 		},
@@ -83,10 +84,14 @@ func (npg *npgCtx) cb(fqn string, de fs.DirEntry) error {
 func (npg *npgCtx) nextPageR(nentries cmn.LsoEntries, inclStatusLocalMD bool) (lst *cmn.LsoResult, err error) {
 	debug.Assert(!npg.wi.msg.IsFlagSet(apc.LsObjCached))
 	lst = &cmn.LsoResult{Entries: nentries}
-	if npg.wi.msg.IsFlagSet(apc.LsInventory) {
+	switch {
+	case npg.wi.msg.IsFlagSet(apc.LsInventory) && invEnabled(npg.bck):
+		debug.Assert(npg.ctx != nil)
+		err = npg.nextPageInv(lst) // (any backend - see linv.go)
+	case npg.wi.msg.IsFlagSet(apc.LsInventory):
 		debug.Assert(npg.ctx != nil)
 		_, err = core.T.Backend(npg.bck).ListObjectsInv(npg.bck, npg.wi.msg, lst, npg.ctx)
-	} else {
+	default:
 		_, err = core.T.Backend(npg.bck).ListObjects(npg.bck, npg.wi.msg, lst)
 	}
 	if err != nil {
@@ -105,6 +110,9 @@ func (npg *npgCtx) nextPageR(nentries cmn.LsoEntries, inclStatusLocalMD bool) (l
 func (npg *npgCtx) populate(lst *cmn.LsoResult) error {
 	post := npg.wi.lomVisitedCb
 	for _, obj := range lst.Entries {
+		if obj.IsDir() {
+			continue // (apc.LsNoRecursion)
+		}
 		si, err := npg.wi.smap.HrwName2T(npg.bck.MakeUname(obj.Name))
 		if err != nil {
 			return err
@@ -135,3 +143,36 @@ func (npg *npgCtx) populate(lst *cmn.LsoResult) error {
 	}
 	return nil
 }
+
+// (remote) filter the page: objects present locally are evaluated given their metadata,
+// all the rest - given their listed (remote) properties (see also cmn.MergeLso);
+// directories (apc.LsNoRecursion) are always included, same as when walking ais buckets
+func (npg *npgCtx) filter(lst *cmn.LsoResult) {
+	if npg.wi.filter == nil {
+		return
+	}
+	var j int
+	for _, e := range lst.Entries {
+		if e.IsDir() || npg.match(e) {
+			lst.Entries[j] = e
+			j++
+		}
+	}
+	clear(lst.Entries[j:])
+	lst.Entries = lst.Entries[:j]
+}
+
+func (npg *npgCtx) match(e *cmn.LsoEntry) bool {
+	if !e.IsPresent() {
+		return npg.wi.filter.MatchEntry(e)
+	}
+	lom := core.AllocLOM(e.Name)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(npg.bck.Bucket()); err != nil {
+		return npg.wi.filter.MatchEntry(e)
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return npg.wi.filter.MatchEntry(e) // (present elsewhere)
+	}
+	return npg.wi.filter.Match(e.Name, lom.ObjAttrs(), lom.FQN)
+}
@@ -0,0 +1,124 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// (re)build the bucket's name index (see fs/nameidx.go): walk the bucket and add the names
+// of the objects this target is responsible for; objects written or deleted in the meantime
+// get journaled and merged upon completion; started on demand - by list-objects that finds
+// no index (see LsoXact.doWalk) - or via x-start API
+
+type (
+	nidxFactory struct {
+		xreg.RenewBase
+		xctn *XactNameIdx
+	}
+	XactNameIdx struct {
+		b    *fs.NameIdxBuilder
+		smap *meta.Smap
+		xact.Base
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactNameIdx)(nil)
+	_ xreg.Renewable = (*nidxFactory)(nil)
+)
+
+/////////////////
+// nidxFactory //
+/////////////////
+
+func (*nidxFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &nidxFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *nidxFactory) Start() error {
+	if !p.Bck.Props.NameIdx.Enabled {
+		return fmt.Errorf("%s: name index is not enabled", p.Bck.Cname(""))
+	}
+	r := &XactNameIdx{smap: core.T.Sowner().Get()}
+	r.InitBase(p.UUID(), apc.ActNameIdx, p.Bck)
+	p.xctn = r
+	go r.Run(nil)
+	return nil
+}
+
+func (*nidxFactory) Kind() string     { return apc.ActNameIdx }
+func (p *nidxFactory) Get() core.Xact { return p.xctn }
+
+func (*nidxFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+/////////////////
+// XactNameIdx //
+/////////////////
+
+func (r *XactNameIdx) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name())
+	b, err := fs.NewNameIdx(r.Bck().Bucket())
+	if err == nil {
+		r.b = b
+		opts := &fs.WalkBckOpts{
+			WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Sorted: true},
+		}
+		opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
+		if err = fs.WalkBck(opts); err == nil {
+			err = b.Commit()
+		} else {
+			b.Abort()
+		}
+	}
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactNameIdx) cb(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if r.IsAborted() {
+		return cmn.NewErrAborted(r.Name(), "", r.AbortErr())
+	}
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return nil
+	}
+	// (mountpath-misplaced objects included - they stay with this target)
+	if _, local, err := lom.HrwTarget(r.smap); err != nil || !local {
+		return nil
+	}
+	if err := r.b.Add(lom.ObjName); err != nil {
+		return err
+	}
+	r.ObjsAdd(1, 0)
+	return nil
+}
+
+func (r *XactNameIdx) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
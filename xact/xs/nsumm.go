@@ -254,6 +254,7 @@ func (r *XactNsumm) cloneRes(dst, src *cmn.BsummResult) {
 
 	dst.ObjCount.Present = ratomic.LoadUint64(&src.ObjCount.Present)
 	dst.TotalSize.PresentObjs = ratomic.LoadUint64(&src.TotalSize.PresentObjs)
+	dst.TotalSize.PhysObjs = ratomic.LoadUint64(&src.TotalSize.PhysObjs)
 
 	if r.listRemote {
 		dst.ObjCount.Remote = ratomic.LoadUint64(&src.ObjCount.Remote)
@@ -295,6 +296,7 @@ func (r *XactNsumm) visitObj(lom *core.LOM, _ []byte) error {
 		ratomic.CompareAndSwapInt64(&res.ObjSize.Max, cmax, size)
 	}
 	ratomic.AddUint64(&res.TotalSize.PresentObjs, uint64(size))
+	ratomic.AddUint64(&res.TotalSize.PhysObjs, uint64(lom.PhysSize())) // (compression at rest)
 
 	// generic stats (same as base.LomAdd())
 	r.ObjsAdd(1, size)
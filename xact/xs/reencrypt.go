@@ -0,0 +1,144 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// re-encrypt: traverse the bucket and rewrite the objects that are not encrypted with
+// the bucket's current data key (see core/lcrypt.go)
+// - the primary generates the new data key prior to starting the xaction (key rotation)
+// - objects written prior to enabling encryption get encrypted; after disabling - decrypted
+// - EC slices are not rewritten and remain encrypted with their respective (older) keys
+// - objects that are currently locked (being read or written) are skipped
+
+type (
+	reencFactory struct {
+		xreg.RenewBase
+		xctn *XactReencrypt
+	}
+	XactReencrypt struct {
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactReencrypt)(nil)
+	_ xreg.Renewable = (*reencFactory)(nil)
+)
+
+//////////////////
+// reencFactory //
+//////////////////
+
+func (*reencFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &reencFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *reencFactory) Start() error {
+	slab, err := core.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	if err != nil {
+		return err
+	}
+	xctn := newXactReencrypt(p.UUID(), p.Bck, slab)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*reencFactory) Kind() string     { return apc.ActReencrypt }
+func (p *reencFactory) Get() core.Xact { return p.xctn }
+
+func (*reencFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////////
+// XactReencrypt //
+///////////////////
+
+func newXactReencrypt(uuid string, bck *meta.Bck, slab *memsys.Slab) (r *XactReencrypt) {
+	r = &XactReencrypt{}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		Slab:     slab,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActReencrypt, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactReencrypt) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactReencrypt) visitObj(lom *core.LOM, buf []byte) error {
+	if !lom.TryLock(true) {
+		return nil // busy, skipping
+	}
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return r.err(err)
+	}
+	if lom.IsCopy() || !r.stale(lom) {
+		return nil // (copies are rewritten along with the main replica)
+	}
+	if err := lom.Reencode(buf); err != nil {
+		return r.err(err)
+	}
+	r.ObjsAdd(1, lom.SizeBytes())
+	return nil
+}
+
+// not encrypted as per the current bucket configuration
+func (*XactReencrypt) stale(lom *core.LOM) bool {
+	bprops := lom.Bprops()
+	if !bprops.Encrypt.Enabled {
+		return lom.IsEncrypted()
+	}
+	dk := bprops.Encrypt.Current()
+	return !lom.IsEncrypted() || dk == nil || lom.KeyID() != dk.ID
+}
+
+func (r *XactReencrypt) err(err error) error {
+	if cos.IsNotExist(err, 0) {
+		return nil
+	}
+	if cos.IsErrOOS(err) {
+		r.Abort(err)
+		return err
+	}
+	r.AddErr(err, 4, cos.SmoduleXs)
+	return nil
+}
+
+func (r *XactReencrypt) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -0,0 +1,173 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// rename virtual directory (prefix): traverse the objects under the prefix and execute
+// the current phase of the rename as per the bucket's props (cmn.PrefixRename):
+// - copy:     copy From => To (locally or to the respective HRW target); new names are hidden
+// - commit:   remove the old (From) names that are already hidden at this point
+// - rollback: remove the copies (To) upon abort
+// Phase transitions (and resulting visibility) are driven by the primary upon completion
+// of each phase - see ais/prxtxn.go
+
+type (
+	renpfxFactory struct {
+		xreg.RenewBase
+		xctn *XactRenPrefix
+	}
+	XactRenPrefix struct {
+		rp cmn.PrefixRename
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactRenPrefix)(nil)
+	_ xreg.Renewable = (*renpfxFactory)(nil)
+)
+
+///////////////////
+// renpfxFactory //
+///////////////////
+
+func (*renpfxFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &renpfxFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *renpfxFactory) Start() error {
+	rp := p.Bck.Props.RenPrefix
+	if rp == nil {
+		return fmt.Errorf("%s: no %s in progress", p.Bck.Cname(""), apc.ActRenamePrefix)
+	}
+	slab, err := core.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	if err != nil {
+		return err
+	}
+	p.xctn = newXactRenPrefix(p.UUID(), p.Bck, rp, slab) // (to run upon commit, see ais/tgttxn.go)
+	return nil
+}
+
+func (*renpfxFactory) Kind() string     { return apc.ActRenamePrefix }
+func (p *renpfxFactory) Get() core.Xact { return p.xctn }
+
+func (*renpfxFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////////
+// XactRenPrefix //
+///////////////////
+
+func newXactRenPrefix(uuid string, bck *meta.Bck, rp *cmn.PrefixRename, slab *memsys.Slab) (r *XactRenPrefix) {
+	r = &XactRenPrefix{rp: *rp}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		Slab:     slab,
+		DoLoad:   mpather.Load,
+		Prefix:   rp.From,
+		Throttle: true,
+	}
+	if rp.Phase == cmn.RenPrefixRollback {
+		mpopts.Prefix = rp.To
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActRenamePrefix, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactRenPrefix) Run(wg *sync.WaitGroup) {
+	wg.Done()
+	r.BckJog.Run()
+	nlog.Infoln(r.Name(), r.rp.String())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactRenPrefix) visitObj(lom *core.LOM, buf []byte) error {
+	if r.rp.Phase == cmn.RenPrefixCopy {
+		return r.copyObj(lom, buf)
+	}
+	return r.rmObj(lom)
+}
+
+func (r *XactRenPrefix) copyObj(lom *core.LOM, buf []byte) error {
+	if !strings.HasPrefix(lom.ObjName, r.rp.From) {
+		return nil
+	}
+	coiParams := core.AllocCOI()
+	{
+		coiParams.Xact = r
+		coiParams.Config = r.Config
+		coiParams.BckTo = lom.Bck()
+		coiParams.ObjnameTo = r.rp.To + strings.TrimPrefix(lom.ObjName, r.rp.From)
+		coiParams.Buf = buf
+		coiParams.OWT = cmn.OwtCopy
+		coiParams.Finalize = true // (mirror)
+	}
+	_, err := core.T.CopyObject(lom, nil /*DM*/, coiParams)
+	core.FreeCOI(coiParams)
+	return r.err(err)
+}
+
+func (r *XactRenPrefix) rmObj(lom *core.LOM) error {
+	prefix := r.rp.From
+	if r.rp.Phase == cmn.RenPrefixRollback {
+		prefix = r.rp.To
+	}
+	if !strings.HasPrefix(lom.ObjName, prefix) {
+		return nil
+	}
+	size := lom.SizeBytes()
+	lom.Lock(true)
+	err := lom.Remove()
+	lom.Unlock(true)
+	if err == nil {
+		r.ObjsAdd(1, size)
+	}
+	return r.err(err)
+}
+
+func (r *XactRenPrefix) err(err error) error {
+	if err == nil || cos.IsNotExist(err, 0) {
+		return nil
+	}
+	if cos.IsErrOOS(err) {
+		r.Abort(err)
+		return err
+	}
+	// strict: any failure to copy (or remove) fails the phase (that can then be resumed)
+	r.AddErr(err, 4, cos.SmoduleXs)
+	return nil
+}
+
+func (r *XactRenPrefix) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+	snap.IdleX = r.IsIdle()
+	return
+}
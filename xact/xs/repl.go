@@ -0,0 +1,426 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/OneOfOne/xxhash"
+)
+
+// replication: on-demand xaction that ships logged changes to the bucket's
+// remote AIS destination (see cmn.ReplConf and core/lrepl.go)
+// - changes are sharded by object name, so that all changes of a given object
+//   are shipped by the same worker and in the order they were logged
+// - failed shipments are retried with exponential backoff until successful or aborted
+//   (e.g., when the remote cluster is unreachable); changes that remain queued when
+//   aborted stay in the change log and will be shipped upon target restart
+//
+// resync: traverse the bucket and replicate objects that are missing or differ
+// in the destination; remove destination objects that do not exist locally
+// - use to catch up after long outages (or when enabling replication on a non-empty bucket)
+
+const (
+	replSleepMin = time.Second
+	replSleepMax = 30 * time.Second
+)
+
+type (
+	replFactory struct {
+		xreg.RenewBase
+		xctn *XactRepl
+	}
+	replChange struct {
+		uname  string
+		logged int64 // mono time
+		op     core.ReplOp
+	}
+	replWorker struct {
+		cond  *sync.Cond
+		queue []replChange
+	}
+	XactRepl struct {
+		xact.DemandBase
+		workers []*replWorker
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		stopped bool
+	}
+)
+
+type (
+	resyncFactory struct {
+		xreg.RenewBase
+		xctn *XactReplResync
+	}
+	XactReplResync struct {
+		dst *meta.Bck
+		xact.BckJog
+		nrm int64
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactRepl)(nil)
+	_ xreg.Renewable = (*replFactory)(nil)
+	_ core.Xact      = (*XactReplResync)(nil)
+	_ xreg.Renewable = (*resyncFactory)(nil)
+)
+
+/////////////////
+// replFactory //
+/////////////////
+
+func (*replFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &replFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *replFactory) Start() error {
+	num := max(fs.NumAvail(), 1)
+	r := &XactRepl{workers: make([]*replWorker, num)}
+	for i := range num {
+		r.workers[i] = &replWorker{cond: sync.NewCond(&r.mu)}
+	}
+
+	// target-local generation of a global UUID (compare w/ x-put-copies)
+	div := uint64(xact.IdleDefault)
+	beid, _, _ := xreg.GenBEID(div, p.Kind()+"|"+p.Bck.MakeUname(""))
+	if beid == "" {
+		beid = cos.GenUUID()
+	}
+	r.DemandBase.Init(beid, p.Kind(), p.Bck, xact.IdleDefault)
+	p.xctn = r
+
+	go r.Run(nil)
+	return nil
+}
+
+func (*replFactory) Kind() string     { return apc.ActReplicate }
+func (p *replFactory) Get() core.Xact { return p.xctn }
+
+func (p *replFactory) WhenPrevIsRunning(xprev xreg.Renewable) (xreg.WPR, error) {
+	debug.Assertf(false, "%s vs %s", p.Str(p.Kind()), xprev) // xreg.usePrev() must've returned true
+	return xreg.WprUse, nil
+}
+
+//////////////
+// XactRepl //
+//////////////
+
+func (r *XactRepl) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name())
+	r.wg.Add(len(r.workers))
+	for _, w := range r.workers {
+		go r.work(w)
+	}
+loop:
+	for {
+		select {
+		case <-r.IdleTimer():
+			break loop
+		case <-r.ChanAbort():
+			break loop
+		}
+	}
+	if n := r.stop(); n > 0 {
+		r.AddErr(fmt.Errorf("%s: %d change%s remain%s in the change log", r, n, cos.Plural(n), cos.Plural(n)))
+	}
+	r.Finish()
+}
+
+// main method
+func (r *XactRepl) Enqueue(uname string, op core.ReplOp) {
+	r.IncPending() // (decremented when done - see below)
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		r.DecPending()
+		nlog.Warningln(r.String(), "stopped, change", uname, "remains pending")
+		return
+	}
+	w := r.workers[xxhash.Checksum64S(cos.UnsafeB(uname), cos.MLCG32)%uint64(len(r.workers))]
+	w.queue = append(w.queue, replChange{uname: uname, logged: mono.NanoTime(), op: op})
+	w.cond.Signal()
+	r.mu.Unlock()
+}
+
+func (r *XactRepl) next(w *replWorker) (replChange, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for len(w.queue) == 0 && !r.stopped {
+		w.cond.Wait()
+	}
+	if r.stopped {
+		return replChange{}, false
+	}
+	change := w.queue[0]
+	w.queue[0] = replChange{}
+	w.queue = w.queue[1:]
+	return change, true
+}
+
+func (r *XactRepl) work(w *replWorker) {
+	defer r.wg.Done()
+	for {
+		change, ok := r.next(w)
+		if !ok {
+			return
+		}
+		r.do(&change)
+		r.DecPending()
+	}
+}
+
+func (r *XactRepl) do(change *replChange) {
+	b, objName := cmn.ParseUname(change.uname)
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&b); err != nil {
+		nlog.Warningln(r.Name(), "object", change.uname, "err:", err)
+		core.ReplDone(change.uname, change.op, 0) // (e.g., bucket does not exist)
+		return
+	}
+	dst := lom.ReplBck()
+	if dst == nil {
+		core.ReplDone(change.uname, change.op, 0) // replication disabled in the meantime
+		return
+	}
+	sleep := replSleepMin
+	for {
+		size, errCode, err := lom.Replicate(dst, change.op)
+		if err == nil {
+			core.ReplDone(change.uname, change.op, mono.Since(change.logged))
+			if size > 0 {
+				r.ObjsAdd(1, size)
+			}
+			return
+		}
+		core.ReplErr()
+		if r.IsAborted() {
+			r.AddErr(fmt.Errorf("%s: failed to replicate %s => %s: %w(%d)", r, lom.Cname(), dst.Cname(""), err, errCode))
+			return
+		}
+		if sleep == replSleepMax || cmn.Rom.FastV(4, cos.SmoduleXs) {
+			nlog.Warningln(r.Name(), lom.Cname(), "=>", dst.Cname(""), "err:", err, errCode, "- retrying in", sleep)
+		}
+		select {
+		case <-time.After(sleep):
+			sleep = min(sleep*2, replSleepMax)
+		case <-r.ChanAbort():
+		}
+	}
+}
+
+// returns the number of changes that were queued but not shipped
+func (r *XactRepl) stop() (n int) {
+	r.DemandBase.Stop()
+	r.mu.Lock()
+	r.stopped = true
+	for _, w := range r.workers {
+		n += len(w.queue)
+		w.queue = nil
+		w.cond.Broadcast()
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+	if n > 0 {
+		r.SubPending(n)
+	}
+	return n
+}
+
+func (r *XactRepl) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+///////////////////
+// resyncFactory //
+///////////////////
+
+func (*resyncFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &resyncFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *resyncFactory) Start() error {
+	conf := p.Bck.Props.Replication
+	if !conf.Enabled {
+		return fmt.Errorf("%s: replication is not enabled (see bucket property %q)", p.Bck.Cname(""), "replication")
+	}
+	dst := meta.CloneBck(&conf.Bck)
+	if err := dst.Init(core.T.Bowner()); err != nil {
+		return err
+	}
+	xctn := newXactReplResync(p.UUID(), p.Bck, dst)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*resyncFactory) Kind() string     { return apc.ActReplResync }
+func (p *resyncFactory) Get() core.Xact { return p.xctn }
+
+func (*resyncFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+////////////////////
+// XactReplResync //
+////////////////////
+
+func newXactReplResync(uuid string, bck, dst *meta.Bck) (r *XactReplResync) {
+	r = &XactReplResync{dst: dst}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActReplResync, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactReplResync) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name(), "=>", r.dst.Cname(""))
+	err := r.BckJog.Wait()
+	if err == nil && !r.IsAborted() {
+		err = r.rmExtra()
+	}
+	if err != nil {
+		r.AddErr(err)
+	}
+	if r.nrm > 0 {
+		nlog.Infoln(r.Name(), "removed", r.nrm, "object(s) from", r.dst.Cname(""))
+	}
+	r.Finish()
+}
+
+func (r *XactReplResync) visitObj(lom *core.LOM, _ []byte) error {
+	tlom := core.AllocLOM(lom.ObjName)
+	err := tlom.InitBck(r.dst.Bucket())
+	if err == nil {
+		var (
+			oa      *cmn.ObjAttrs
+			errCode int
+		)
+		oa, errCode, err = core.T.Backend(tlom.Bck()).HeadObj(context.Background(), tlom)
+		switch {
+		case err == nil:
+			if _replEq(lom.ObjAttrs(), oa) {
+				core.FreeLOM(tlom)
+				return nil
+			}
+		case cos.IsNotExist(err, errCode):
+			err = nil
+		}
+	}
+	core.FreeLOM(tlom)
+
+	var size int64
+	if err == nil {
+		size, _, err = lom.Replicate(r.dst, core.ReplPut)
+	}
+	if err != nil {
+		if cos.IsNotExist(err, 0) {
+			return nil
+		}
+		r.AddErr(err, 4, cos.SmoduleXs)
+		return nil
+	}
+	if size > 0 {
+		if cmn.Rom.FastV(5, cos.SmoduleXs) {
+			nlog.Infoln(r.Name(), lom.Cname(), "=>", r.dst.Cname(""))
+		}
+		r.ObjsAdd(1, size)
+	}
+	return nil
+}
+
+// versions are local to each cluster and are, therefore, not compared
+func _replEq(loc, rem *cmn.ObjAttrs) bool {
+	if loc.Size != rem.Size {
+		return false
+	}
+	a, b := loc.Cksum, rem.Cksum
+	if a.IsEmpty() || b.IsEmpty() || a.Ty() != b.Ty() {
+		return true
+	}
+	return a.Equal(b)
+}
+
+// remove destination objects that do not exist locally (and that this target is responsible for)
+func (r *XactReplResync) rmExtra() error {
+	var (
+		msg     = &apc.LsoMsg{Props: apc.GetPropsName}
+		backend = core.T.Backend(r.dst)
+		smap    = core.T.Sowner().Get()
+	)
+	for !r.IsAborted() {
+		lst := &cmn.LsoResult{}
+		if _, err := backend.ListObjects(r.dst, msg, lst); err != nil {
+			return err
+		}
+		for _, en := range lst.Entries {
+			if err := r.rmIfNotLocal(en.Name, smap); err != nil {
+				r.AddErr(err, 4, cos.SmoduleXs)
+			}
+		}
+		if lst.ContinuationToken == "" {
+			break
+		}
+		msg.ContinuationToken = lst.ContinuationToken
+	}
+	return nil
+}
+
+func (r *XactReplResync) rmIfNotLocal(objName string, smap *meta.Smap) error {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(r.Bck().Bucket()); err != nil {
+		return err
+	}
+	if _, local, err := lom.HrwTarget(smap); err != nil || !local {
+		return err
+	}
+	err := lom.Load(false /*cache it*/, false /*locked*/)
+	if err == nil || !cos.IsNotExist(err, 0) {
+		return err
+	}
+	if _, _, err := lom.Replicate(r.dst, core.ReplDel); err != nil {
+		return err
+	}
+	r.nrm++
+	return nil
+}
+
+func (r *XactReplResync) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -0,0 +1,239 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// scrub: traverse the bucket, read every object, and verify its content against
+// the stored checksum (see core/lscrub.go)
+// - corrupted replicas are repaired from healthy mirror copies, if any
+// - otherwise (all replicas corrupted), erasure coded objects get reconstructed from EC slices
+// - objects that cannot be repaired are left in place and counted as unrecoverable
+// - objects that are currently being written are skipped (and counted as such)
+
+type (
+	scrubFactory struct {
+		xreg.RenewBase
+		xctn *XactScrub
+	}
+	XactScrub struct {
+		xact.BckJog
+		corrupted     atomic.Int64
+		repaired      atomic.Int64
+		unrecoverable atomic.Int64
+		skipped       atomic.Int64
+	}
+	ExtScrubStats struct {
+		Corrupted     int64 `json:"scrub.corrupted.n,string"`
+		Repaired      int64 `json:"scrub.repaired.n,string"`
+		Unrecoverable int64 `json:"scrub.unrecoverable.n,string"`
+		Skipped       int64 `json:"scrub.skipped.n,string"` // busy
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactScrub)(nil)
+	_ xreg.Renewable = (*scrubFactory)(nil)
+)
+
+//////////////////
+// scrubFactory //
+//////////////////
+
+func (*scrubFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &scrubFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *scrubFactory) Start() error {
+	slab, err := core.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	if err != nil {
+		return err
+	}
+	xctn := newXactScrub(p.UUID(), p.Bck, slab)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*scrubFactory) Kind() string     { return apc.ActScrub }
+func (p *scrubFactory) Get() core.Xact { return p.xctn }
+
+func (*scrubFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////
+// XactScrub //
+///////////////
+
+func newXactScrub(uuid string, bck *meta.Bck, slab *memsys.Slab) (r *XactScrub) {
+	r = &XactScrub{}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		Slab:     slab,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActScrub, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactScrub) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactScrub) visitObj(lom *core.LOM, buf []byte) error {
+	if !lom.TryLock(false) {
+		r.skipped.Inc() // busy
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		return r.err(err)
+	}
+	if lom.IsCopy() {
+		lom.Unlock(false)
+		return nil // (verified along with the main replica)
+	}
+	size := lom.SizeBytes()
+	nbad, err := lom.Scrub(buf) // (upgrades to w-lock iff nbad > 0)
+	switch {
+	case nbad == 0:
+		lom.Unlock(false)
+		if err != nil {
+			return r.err(err)
+		}
+	case err == nil: // repaired from a mirror copy
+		lom.Unlock(true)
+		r.done(lom, true)
+	case err == core.ErrNoHealthyReplica:
+		r.done(lom, r.restoreEC(lom)) // (unlocks)
+	default:
+		lom.Unlock(true)
+		r.done(lom, false)
+		return r.err(err)
+	}
+	r.ObjsAdd(1, size)
+	return nil
+}
+
+func (r *XactScrub) done(lom *core.LOM, repaired bool) {
+	r.corrupted.Inc()
+	if repaired {
+		r.repaired.Inc()
+		nlog.Infoln(r.Name(), "repaired", lom.Cname())
+	} else {
+		r.unrecoverable.Inc()
+		nlog.Errorln(r.Name(), "failed to repair", lom.Cname())
+	}
+	core.ScrubDone(repaired)
+}
+
+func (r *XactScrub) err(err error) error {
+	if cos.IsNotExist(err, 0) {
+		return nil
+	}
+	if cos.IsErrOOS(err) {
+		r.Abort(err)
+		return err
+	}
+	r.AddErr(err, 4, cos.SmoduleXs)
+	return nil
+}
+
+// all replicas are corrupted: reconstruct the object from EC slices
+// (compare with ais/tgtobj.go restoreFromAny)
+// - the corrupted main replica is moved aside for the duration, and moved back upon failure
+// - called under wlock; returns unlocked
+func (*XactScrub) restoreEC(lom *core.LOM) bool {
+	if !lom.ECEnabled() {
+		lom.Unlock(true)
+		return false
+	}
+	var (
+		copies  = make([]string, 0, lom.NumCopies())
+		workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileScrub)
+	)
+	for fqn := range lom.GetCopies() {
+		if fqn != lom.FQN {
+			copies = append(copies, fqn)
+		}
+	}
+	if err := cos.Rename(lom.FQN, workFQN); err != nil {
+		lom.Unlock(true)
+		nlog.Errorln(err)
+		return false
+	}
+	lom.Uncache()
+	lom.Unlock(true)
+
+	err := ec.ECM.RestoreObject(lom)
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err == nil {
+		if err = lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+			err = lom.ValidateContentChecksum()
+		}
+	}
+	if err != nil {
+		nlog.Warningln("failed to EC-restore", lom.Cname(), "err:", err)
+		if errS := cos.Stat(lom.FQN); os.IsNotExist(errS) {
+			if errR := cos.Rename(workFQN, lom.FQN); errR == nil {
+				return false
+			}
+		}
+		cos.RemoveFile(workFQN)
+		return false
+	}
+	cos.RemoveFile(workFQN)
+	// remove corrupted copies that are no longer referenced
+	cur := lom.GetCopies()
+	for _, fqn := range copies {
+		if _, ok := cur[fqn]; !ok {
+			cos.RemoveFile(fqn)
+		}
+	}
+	return true
+}
+
+func (r *XactScrub) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.Ext = &ExtScrubStats{
+		Corrupted:     r.corrupted.Load(),
+		Repaired:      r.repaired.Load(),
+		Unrecoverable: r.unrecoverable.Load(),
+		Skipped:       r.skipped.Load(),
+	}
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -0,0 +1,244 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Bucket snapshots (see cmn/snap.go for the big picture)
+//
+// create:  hard-link every object of a given ais:// bucket into the (virtual, read-only)
+//          snapshot bucket, e.g. ais://data => ais://data@snap1; both are located
+//          on the same mountpath (see Bck.HrwUname), and no data is copied
+// restore: the reverse - i.e., re-link the snapshot into the bucket, drop the bucket's
+//          current replicas (if any), and remove objects that did not exist at
+//          the time the snapshot was taken
+//
+// Both operations are atomic bucket-wide: the bucket is write-fenced on all targets
+// from the transaction's begin and until the respective xaction is done - objects cannot
+// be written, deleted, or renamed in the meantime (see core.FenceWrites)
+
+const wfnameSnapRestore = "snap-restore"
+
+type (
+	snapFactory struct {
+		xreg.RenewBase
+		xctn *xactSnap
+		kind string
+	}
+	xactSnap struct {
+		snap   *meta.Bck
+		config *cmn.Config
+		xact.Base
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*xactSnap)(nil)
+	_ xreg.Renewable = (*snapFactory)(nil)
+)
+
+/////////////////
+// snapFactory //
+/////////////////
+
+func (p *snapFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	np := &snapFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, kind: p.kind}
+	return np
+}
+
+func (p *snapFactory) Start() error {
+	custom := p.Args.Custom.(*xreg.SnapArgs)
+	debug.Assert(custom.Snap.IsSnap(), custom.Snap.String())
+	r := &xactSnap{snap: custom.Snap, config: cmn.GCO.Get()}
+	r.InitBase(p.UUID(), p.kind, p.Bck)
+	p.xctn = r
+	return nil
+}
+
+func (p *snapFactory) Kind() string   { return p.kind }
+func (p *snapFactory) Get() core.Xact { return p.xctn }
+
+func (*snapFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprKeepAndStartNew, nil
+}
+
+//////////////
+// xactSnap //
+//////////////
+
+func (r *xactSnap) Run(wg *sync.WaitGroup) {
+	var err error
+	if wg != nil {
+		wg.Done()
+	}
+	nlog.Infoln(r.Name(), r.Bck().Cname(""), "=>", r.snap.Cname(""))
+
+	switch r.Kind() {
+	case apc.ActCreateSnap:
+		err = r.jog(r.Bck(), r.link)
+	case apc.ActRestoreSnap:
+		if err = r.jog(r.snap, r.restore); err == nil {
+			// (prune must not run if the snapshot got destroyed in the meantime)
+			if _, present := core.T.Bowner().Get().Get(r.snap); !present {
+				err = cmn.NewErrBckNotFound(r.snap.Bucket())
+				break
+			}
+			err = r.jog(r.Bck(), r.prune)
+		}
+	default:
+		debug.Assert(false, r.Kind())
+	}
+	if err != nil {
+		r.AddErr(err)
+	}
+	core.UnfenceWrites(r.Bck().Bucket(), r.ID())
+	r.Finish()
+}
+
+// walk a given bucket (in parallel, one jogger per mountpath)
+func (r *xactSnap) jog(bck *meta.Bck, visit func(*core.LOM, []byte) error) error {
+	opts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: visit,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	}
+	opts.Bck.Copy(bck.Bucket())
+	jg := mpather.NewJoggerGroup(opts, r.config, "")
+	jg.Run()
+	select {
+	case errCause := <-r.ChanAbort():
+		jg.Stop()
+		return cmn.NewErrAborted(r.Name(), "x-snap", errCause)
+	case <-jg.ListenFinished():
+		return jg.Stop()
+	}
+}
+
+// create: bucket => snapshot
+func (r *xactSnap) link(lom *core.LOM, buf []byte) error {
+	lom.Lock(false)
+	err := core.LinkSnap(lom, r.snap.Bucket(), buf) // (see core/lsnap.go)
+	lom.Unlock(false)
+	if err != nil {
+		return err
+	}
+	r.ObjsAdd(1, lom.SizeBytes(true))
+	return nil
+}
+
+// restore #1: snapshot => bucket
+func (r *xactSnap) restore(snaplom *core.LOM, buf []byte) error {
+	lom := core.AllocLOM(snaplom.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(r.Bck().Bucket()); err != nil {
+		return err
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		if lom.HasCopies() {
+			if err := lom.DelAllCopies(); err != nil {
+				return err
+			}
+		}
+		if _sameFile(lom.FQN, snaplom.FQN) {
+			// content unchanged since the snapshot was taken, metadata may have
+			return r._restoreMD(lom, snaplom)
+		}
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, wfnameSnapRestore)
+	if err := core.LinkFile(snaplom.FQN, workFQN, buf); err != nil {
+		return err
+	}
+	if snaplom.IsChunked() {
+		if err := core.LinkChunks(snaplom.Bucket(), lom.Bucket(), lom.ObjName, workFQN, buf); err != nil {
+			if errRm := cos.RemoveFile(workFQN); errRm != nil {
+				nlog.Errorln("nested error:", err, errRm)
+			}
+			return err
+		}
+	}
+	if err := lom.RenameFrom(workFQN); err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			nlog.Errorln("nested error:", err, errRm)
+		}
+		return err
+	}
+	lom.Uncache()
+
+	// replicas that existed at snapshot time are stale by now
+	// (note that the snapshot itself ignores replicas - see core/lsnap.go)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+	if lom.HasCopies() {
+		if err := lom.DelAllCopies(); err != nil {
+			return err
+		}
+	}
+	return r._restoreMD(lom, snaplom)
+}
+
+func (r *xactSnap) _restoreMD(lom, snaplom *core.LOM) error {
+	if err := lom.RestoreSnapMD(snaplom); err != nil {
+		return err
+	}
+	r.ObjsAdd(1, lom.SizeBytes(true))
+	return nil
+}
+
+// restore #2: remove objects that did not exist at snapshot time
+func (r *xactSnap) prune(lom *core.LOM, _ []byte) error {
+	snapFQN := lom.Mountpath().MakePathFQN(r.snap.Bucket(), fs.ObjectType, lom.ObjName)
+	if err := cos.Stat(snapFQN); err == nil || !os.IsNotExist(err) {
+		return nil
+	}
+	// paranoid: snapshot destroyed while pruning
+	if err := cos.Stat(lom.Mountpath().MakePathCT(r.snap.Bucket(), fs.ObjectType)); err != nil {
+		return cmn.NewErrBckNotFound(r.snap.Bucket())
+	}
+	lom.Lock(true)
+	err := lom.Remove()
+	lom.Unlock(true)
+	return err
+}
+
+func (r *xactSnap) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+//
+// misc
+//
+
+func _sameFile(a, b string) bool {
+	fa, erra := os.Stat(a)
+	fb, errb := os.Stat(b)
+	return erra == nil && errb == nil && os.SameFile(fa, fb)
+}
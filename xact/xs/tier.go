@@ -0,0 +1,176 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// tiering: traverse the bucket and move cold objects to the bucket's tier
+// (see cmn.TierConf and core/ltier.go)
+// - cold object: not accessed in the last `tier.age` and not smaller than `tier.min_size`
+// - cold object is uploaded to the tier bucket under shared lock (readers proceed, writers wait),
+//   and only then the lock gets upgraded to locally replace the object with a stub
+// - objects that are currently write-locked are skipped
+// - runs periodically for all buckets with tiering enabled (see tierHK in ais/tgtspace.go), or on demand
+
+type (
+	tierFactory struct {
+		xreg.RenewBase
+		xctn *XactTier
+	}
+	XactTier struct {
+		tier *meta.Bck
+		conf cmn.TierConf
+		xact.BckJog
+		now int64
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactTier)(nil)
+	_ xreg.Renewable = (*tierFactory)(nil)
+)
+
+/////////////////
+// tierFactory //
+/////////////////
+
+func (*tierFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &tierFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *tierFactory) Start() error {
+	conf := p.Bck.Props.Tier
+	if !conf.Enabled {
+		return fmt.Errorf("%s: tiering is not enabled (see bucket property %q)", p.Bck.Cname(""), "tier")
+	}
+	tier := meta.CloneBck(&conf.Bck)
+	if err := tier.Init(core.T.Bowner()); err != nil {
+		return err
+	}
+	xctn := newXactTier(p.UUID(), p.Bck, tier, &conf)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*tierFactory) Kind() string     { return apc.ActTier }
+func (p *tierFactory) Get() core.Xact { return p.xctn }
+
+func (*tierFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+//////////////
+// XactTier //
+//////////////
+
+func newXactTier(uuid string, bck, tier *meta.Bck, conf *cmn.TierConf) (r *XactTier) {
+	r = &XactTier{tier: tier, conf: *conf, now: time.Now().UnixNano()}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActTier, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *XactTier) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name(), "=>", r.tier.Cname(""))
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *XactTier) isCold(lom *core.LOM) bool {
+	if lom.IsTiered() || lom.SizeBytes() < int64(r.conf.MinSize) {
+		return false
+	}
+	return r.now-lom.AtimeUnix() >= int64(r.conf.Age)
+}
+
+func (r *XactTier) visitObj(lom *core.LOM, _ []byte) error {
+	if !r.isCold(lom) {
+		return nil
+	}
+	if !lom.TryLock(false) {
+		return nil // busy, skipping
+	}
+	size, err := r.do(lom)
+	if err != nil {
+		if cos.IsNotExist(err, 0) {
+			return nil
+		}
+		if cos.IsErrOOS(err) {
+			r.Abort(err)
+			return err
+		}
+		r.AddErr(err, 4, cos.SmoduleXs)
+		return nil
+	}
+	if size > 0 {
+		if cmn.Rom.FastV(5, cos.SmoduleXs) {
+			nlog.Infoln(r.Name(), lom.Cname(), "=>", r.tier.Cname(""))
+		}
+		r.ObjsAdd(1, size)
+	}
+	return nil
+}
+
+// upload under rlock, and upgrade the latter to replace the object with its stub
+// - the object cannot change in between (writers wait)
+// - unlocks in all cases
+func (r *XactTier) do(lom *core.LOM) (int64, error) {
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		return 0, err
+	}
+	if !r.isCold(lom) { // re-check
+		lom.Unlock(false)
+		return 0, nil
+	}
+	size := lom.SizeBytes()
+	if _, err := lom.TierPut(r.tier); err != nil {
+		lom.Unlock(false)
+		return 0, err
+	}
+	if lom.UpgradeLock() {
+		// upgraded and possibly modified by another goroutine (e.g., cold GET) - next time
+		lom.Unlock(false)
+		return 0, nil
+	}
+	err := lom.Stub(r.tier)
+	lom.Unlock(true)
+	return size, err
+}
+
+func (r *XactTier) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
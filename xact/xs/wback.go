@@ -0,0 +1,242 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// write-back: on-demand xaction that uploads pending objects to their remote bucket
+// (see apc.WriteDelayed and core/lwback.go)
+// - failed uploads are retried with exponential backoff
+// - objects that keep failing get requeued (at the tail, after a delay) and remain pending
+// - objects that remain queued when the xaction stops stay pending in the journal
+//   and will be retried upon target restart
+
+const (
+	wbackRetries  = 5
+	wbackSleepMin = time.Second
+	wbackSleepMax = 30 * time.Second
+	wbackRequeue  = time.Minute
+)
+
+type (
+	wbFactory struct {
+		xreg.RenewBase
+		xctn *XactWback
+	}
+	XactWback struct {
+		xact.DemandBase
+		cond    *sync.Cond
+		queue   []string // unames
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		delayed int // requeued, waiting to be added back
+		stopped bool
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactWback)(nil)
+	_ xreg.Renewable = (*wbFactory)(nil)
+)
+
+///////////////
+// wbFactory //
+///////////////
+
+func (*wbFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &wbFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *wbFactory) Start() error {
+	r := &XactWback{}
+	r.cond = sync.NewCond(&r.mu)
+
+	// target-local generation of a global UUID (compare w/ x-put-copies)
+	div := uint64(xact.IdleDefault)
+	beid, _, _ := xreg.GenBEID(div, p.Kind()+"|"+p.Bck.MakeUname(""))
+	if beid == "" {
+		beid = cos.GenUUID()
+	}
+	r.DemandBase.Init(beid, p.Kind(), p.Bck, xact.IdleDefault)
+	p.xctn = r
+
+	go r.Run(nil)
+	return nil
+}
+
+func (*wbFactory) Kind() string     { return apc.ActWriteBack }
+func (p *wbFactory) Get() core.Xact { return p.xctn }
+
+func (p *wbFactory) WhenPrevIsRunning(xprev xreg.Renewable) (xreg.WPR, error) {
+	debug.Assertf(false, "%s vs %s", p.Str(p.Kind()), xprev) // xreg.usePrev() must've returned true
+	return xreg.WprUse, nil
+}
+
+///////////////
+// XactWback //
+///////////////
+
+func (r *XactWback) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name())
+	num := max(fs.NumAvail(), 1)
+	r.wg.Add(num)
+	for range num {
+		go r.work()
+	}
+loop:
+	for {
+		select {
+		case <-r.IdleTimer():
+			break loop
+		case <-r.ChanAbort():
+			break loop
+		}
+	}
+	if n := r.stop(); n > 0 {
+		r.AddErr(fmt.Errorf("%s: %d pending object%s remain%s in the journal", r, n, cos.Plural(n), cos.Plural(n)))
+	}
+	r.Finish()
+}
+
+// main method
+func (r *XactWback) Enqueue(uname string) {
+	r.IncPending() // (decremented when done - see below)
+	r.add(uname, false)
+}
+
+// requeue object that keeps failing: add it back (at the tail) after a delay
+// while still accounting for it as pending
+func (r *XactWback) requeue(uname string) {
+	r.IncPending()
+	r.mu.Lock()
+	r.delayed++
+	r.mu.Unlock()
+	time.AfterFunc(wbackRequeue, func() { r.add(uname, true) })
+}
+
+func (r *XactWback) add(uname string, requeued bool) {
+	r.mu.Lock()
+	if requeued {
+		r.delayed--
+	}
+	if r.stopped {
+		r.mu.Unlock()
+		r.DecPending()
+		nlog.Warningln(r.String(), "stopped, object", uname, "remains pending")
+		return
+	}
+	r.queue = append(r.queue, uname)
+	r.cond.Signal()
+	r.mu.Unlock()
+}
+
+func (r *XactWback) next() (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for len(r.queue) == 0 && !r.stopped {
+		r.cond.Wait()
+	}
+	if r.stopped {
+		return "", false
+	}
+	uname := r.queue[0]
+	r.queue[0] = ""
+	r.queue = r.queue[1:]
+	return uname, true
+}
+
+func (r *XactWback) work() {
+	defer r.wg.Done()
+	for {
+		uname, ok := r.next()
+		if !ok {
+			return
+		}
+		r.do(uname)
+		r.DecPending()
+	}
+}
+
+func (r *XactWback) do(uname string) {
+	b, objName := cmn.ParseUname(uname)
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&b); err != nil {
+		nlog.Warningln(r.Name(), "object", uname, "err:", err)
+		core.WbackDone(uname) // (e.g., bucket does not exist)
+		return
+	}
+	sleep := wbackSleepMin
+	for i := 0; ; i++ {
+		size, errCode, err := lom.Wback()
+		if err == nil {
+			core.WbackDone(uname)
+			if size > 0 {
+				r.ObjsAdd(1, size)
+			}
+			return
+		}
+		if r.IsAborted() {
+			r.AddErr(fmt.Errorf("%s: failed to write back %s: %w(%d)", r, lom.Cname(), err, errCode))
+			return
+		}
+		if i >= wbackRetries {
+			nlog.Errorln(r.Name(), "failed to write back", lom.Cname(), "err:", err, errCode, "- requeuing")
+			r.requeue(uname)
+			return
+		}
+		if cmn.Rom.FastV(4, cos.SmoduleXs) {
+			nlog.Warningln(r.Name(), lom.Cname(), "err:", err, errCode, "- retrying in", sleep)
+		}
+		select {
+		case <-time.After(sleep):
+			sleep = min(sleep*2, wbackSleepMax)
+		case <-r.ChanAbort():
+		}
+	}
+}
+
+// returns the number of objects that were queued but not written back
+func (r *XactWback) stop() (n int) {
+	r.DemandBase.Stop()
+	r.mu.Lock()
+	r.stopped = true
+	n = len(r.queue)
+	r.queue = nil
+	delayed := r.delayed
+	r.cond.Broadcast()
+	r.mu.Unlock()
+	r.wg.Wait()
+	if n > 0 {
+		r.SubPending(n)
+	}
+	return n + delayed // (delayed ones get dropped when added back - see add)
+}
+
+func (r *XactWback) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
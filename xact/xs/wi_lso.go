@@ -28,6 +28,7 @@ type (
 		smap         *meta.Smap
 		msg          *apc.LsoMsg
 		lomVisitedCb lomVisitedCb
+		filter       *cmn.LsoFilter // (see apc.LsoMsg.Filter)
 		markerDir    string
 		wanted       cos.BitFlags
 	}
@@ -43,6 +44,7 @@ func newWalkInfo(msg *apc.LsoMsg, lomVisitedCb lomVisitedCb) (wi *walkInfo) {
 		smap:         core.T.Sowner().Get(),
 		lomVisitedCb: lomVisitedCb,
 		msg:          msg,
+		filter:       lsoFilter(msg),
 		wanted:       wanted(msg),
 	}
 	if msg.ContinuationToken != "" { // marker is always a filename
@@ -56,6 +58,13 @@ func newWalkInfo(msg *apc.LsoMsg, lomVisitedCb lomVisitedCb) (wi *walkInfo) {
 
 func (wi *walkInfo) lsmsg() *apc.LsoMsg { return wi.msg }
 
+// (filter expression is validated by the proxy)
+func lsoFilter(msg *apc.LsoMsg) *cmn.LsoFilter {
+	f, err := cmn.NewLsoFilter(msg.Filter)
+	debug.AssertNoErr(err)
+	return f
+}
+
 // Checks if the directory should be processed by cache list call
 // Does checks:
 //   - Object name must start with prefix (if it is set)
@@ -86,6 +95,9 @@ func (wi *walkInfo) match(lom *core.LOM) bool {
 	if !cmn.ObjHasPrefix(lom.ObjName, wi.msg.Prefix) {
 		return false
 	}
+	if wi.filter != nil && !wi.filter.MatchName(lom.ObjName) {
+		return false
+	}
 	return wi.msg.ContinuationToken == "" || !cmn.TokenGreaterEQ(wi.msg.ContinuationToken, lom.ObjName)
 }
 
@@ -153,7 +165,7 @@ func (wi *walkInfo) cb(lom *core.LOM, fqn string) (*cmn.LsoEntry, error) {
 	}
 
 	// shortcut #1: name-only optimizes-out loading md (NOTE: won't show misplaced and copies)
-	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && (wi.filter == nil || wi.filter.NameOnly()) {
 		if !isOK(status) {
 			return nil, nil
 		}
@@ -166,6 +178,9 @@ func (wi *walkInfo) cb(lom *core.LOM, fqn string) (*cmn.LsoEntry, error) {
 		}
 		return nil, err
 	}
+	if wi.filter != nil && !wi.filter.Match(lom.ObjName, lom.ObjAttrs(), lom.FQN) {
+		return nil, nil
+	}
 	if local && lom.IsCopy() {
 		// still may change below
 		status = apc.LocIsCopy